@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/branchcut"
+	"k8s.io/release/pkg/mail"
+	"k8s.io/release/pkg/release"
+	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-utils/env"
+)
+
+// branchCutCmd represents the subcommand for `krel branch-cut`
+var branchCutCmd = &cobra.Command{
+	Use:   "branch-cut --branch release-x.y",
+	Short: "Create a new Kubernetes release branch",
+	Long: fmt.Sprintf(`krel branch-cut
+
+Creates a new release branch by cutting its first release candidate
+(vX.Y.0-rc.0), which also tags the next alpha (vX.Y+1.0-alpha.0) on the main
+branch. The stage and release jobs use the release type rc, which can be set
+to beta via --release-type. The following steps are run one after another:
+
+1. %s: Verify that the branch does not exist yet and resolve the
+   build version from the latest CI build of the branch. The announcement
+   requires %s, even for mock runs.
+
+2. %s: Submit the stage job to Google Cloud Build and wait for it.
+
+3. %s: Submit the release job to Google Cloud Build and wait for it.
+
+4. %s: Open an issue for updating the publishing-bot
+   configuration, requires %s.
+
+5. %s: Create the branch announcement and mail it to the
+   %q and %q Google Groups, or to %q in
+   mock mode.
+
+The progress is tracked in a state file inside of the working directory. If a
+step fails, running the same command again resumes with the failed step.
+Without --nomock every step runs in mock mode.
+
+The manual follow-up tasks are printed after the branch has been created.
+`,
+		branchcut.StepPreconditions, sendgridAPIKeyEnvKey,
+		branchcut.StepStage,
+		branchcut.StepRelease,
+		branchcut.StepPubBotIssue, github.TokenEnvKey,
+		branchcut.StepAnnouncement,
+		mail.KubernetesAnnounceGoogleGroup,
+		mail.KubernetesDevGoogleGroup,
+		mail.KubernetesAnnounceTestGoogleGroup,
+	),
+	Example:       "krel branch-cut --branch release-1.23",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBranchCut(branchCutOpts)
+	},
+}
+
+var branchCutOpts = branchcut.DefaultOptions()
+
+func init() {
+	branchCutOpts.SendgridAPIKey = env.Default(sendgridAPIKeyEnvKey, "")
+
+	branchCutCmd.PersistentFlags().StringVar(
+		&branchCutOpts.Branch,
+		"branch",
+		"",
+		"release branch to be created, for example release-1.23",
+	)
+
+	branchCutCmd.PersistentFlags().StringVar(
+		&branchCutOpts.BuildVersion,
+		buildVersionFlag,
+		"",
+		"build version to be released, defaults to the latest CI build of the branch",
+	)
+
+	branchCutCmd.PersistentFlags().StringVar(
+		&branchCutOpts.ReleaseType,
+		"release-type",
+		branchCutOpts.ReleaseType,
+		fmt.Sprintf(
+			"type of the first release of the branch, either %s or %s",
+			release.ReleaseTypeBeta, release.ReleaseTypeRC,
+		),
+	)
+
+	branchCutCmd.PersistentFlags().StringVar(
+		&branchCutOpts.WorkDir,
+		"workdir",
+		branchCutOpts.WorkDir,
+		"working directory for the announcement and the state file",
+	)
+
+	branchCutCmd.PersistentFlags().StringVar(
+		&branchCutOpts.StateFile,
+		"state-file",
+		"",
+		fmt.Sprintf("path to the state file, defaults to %s in the working directory", branchcut.StateFileName),
+	)
+
+	branchCutCmd.PersistentFlags().StringVarP(
+		&branchCutOpts.MailSenderName,
+		nameFlag,
+		"n",
+		"",
+		"mail sender name",
+	)
+
+	branchCutCmd.PersistentFlags().StringVarP(
+		&branchCutOpts.MailSenderEmail,
+		emailFlag,
+		"e",
+		"",
+		"email address",
+	)
+
+	if err := branchCutCmd.MarkPersistentFlagRequired("branch"); err != nil {
+		logrus.Fatal(err)
+	}
+
+	rootCmd.AddCommand(branchCutCmd)
+}
+
+func runBranchCut(opts *branchcut.Options) error {
+	opts.NoMock = rootOpts.nomock
+	return branchcut.New(opts).Run()
+}
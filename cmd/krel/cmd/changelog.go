@@ -78,6 +78,7 @@ func init() {
 	changelogCmd.PersistentFlags().StringVar(&changelogOptions.HTMLFile, "html-file", "", "The target html file to be written. If empty, then it will be CHANGELOG-x.y.html in the current path.")
 	changelogCmd.PersistentFlags().StringVar(&changelogOptions.RecordDir, "record", "", "Record the API into a directory")
 	changelogCmd.PersistentFlags().StringVar(&changelogOptions.ReplayDir, "replay", "", "Replay a previously recorded API from a directory")
+	changelogCmd.PersistentFlags().StringVar(&changelogOptions.CacheDir, "cache-dir", "", "Cache the API responses in a directory to speed up subsequent runs")
 	changelogCmd.PersistentFlags().BoolVar(&changelogOptions.Dependencies, "dependencies", true, "Add dependency report")
 
 	if err := changelogCmd.MarkPersistentFlagRequired("tag"); err != nil {
@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/cherrypick"
+	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-utils/env"
+)
+
+// cherryPicksCmd represents the subcommand for `krel cherry-picks`
+var cherryPicksCmd = &cobra.Command{
+	Use:   "cherry-picks --branch release-x.y",
+	Short: "Report the cherry pick queue of a release branch",
+	Long: fmt.Sprintf(`krel cherry-picks
+
+Lists all open pull requests against a release branch and classifies them as:
+
+- %s: all required labels are set and the tests pass
+- %s: approvals or release notes are missing, or the tests are
+  still running
+- %s: the tests fail, or the pull request is a draft, on hold, needs
+  a rebase or has another do-not-merge label
+
+The report is printed as markdown, which can be pasted into the patch release
+issue. If a patch release schedule from the schedule-builder is provided via
+--schedule, the next patch release and its cherry pick deadline are part of
+the report.
+
+Setting %s is recommended to avoid running into the GitHub API rate
+limit.
+`,
+		cherrypick.StatusReady,
+		cherrypick.StatusNeedsAttention,
+		cherrypick.StatusBlocked,
+		github.TokenEnvKey,
+	),
+	Example:       "krel cherry-picks --branch release-1.22 --schedule sig-release/releases/schedule.yaml",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCherryPicks(cherryPicksOpts)
+	},
+}
+
+type cherryPicksOptions struct {
+	*cherrypick.Options
+	json bool
+}
+
+var cherryPicksOpts = &cherryPicksOptions{Options: cherrypick.DefaultOptions()}
+
+func init() {
+	cherryPicksCmd.PersistentFlags().StringVar(
+		&cherryPicksOpts.Branch,
+		"branch",
+		"",
+		"release branch of the cherry picks, for example release-1.22",
+	)
+
+	cherryPicksCmd.PersistentFlags().StringVar(
+		&cherryPicksOpts.Org,
+		"org",
+		cherryPicksOpts.Org,
+		"GitHub organization of the repository",
+	)
+
+	cherryPicksCmd.PersistentFlags().StringVar(
+		&cherryPicksOpts.Repo,
+		"repo",
+		cherryPicksOpts.Repo,
+		"GitHub repository of the pull requests",
+	)
+
+	cherryPicksCmd.PersistentFlags().StringVar(
+		&cherryPicksOpts.ScheduleFile,
+		"schedule",
+		"",
+		"path to the patch release schedule of the schedule-builder",
+	)
+
+	cherryPicksCmd.PersistentFlags().StringVar(
+		&cherryPicksOpts.Deadline,
+		"deadline",
+		"",
+		"cherry pick deadline in the format YYYY-MM-DD, overrides the schedule",
+	)
+
+	cherryPicksCmd.PersistentFlags().StringVar(
+		&cherryPicksOpts.RecordDir,
+		"record",
+		env.Default("RECORD", ""),
+		"Record the API into a directory",
+	)
+
+	cherryPicksCmd.PersistentFlags().StringVar(
+		&cherryPicksOpts.ReplayDir,
+		"replay",
+		env.Default("REPLAY", ""),
+		"Replay a previously recorded API from a directory",
+	)
+
+	cherryPicksCmd.PersistentFlags().BoolVar(
+		&cherryPicksOpts.json,
+		"json",
+		false,
+		"print the report as JSON",
+	)
+
+	if err := cherryPicksCmd.MarkPersistentFlagRequired("branch"); err != nil {
+		logrus.Fatal(err)
+	}
+
+	rootCmd.AddCommand(cherryPicksCmd)
+}
+
+func runCherryPicks(opts *cherryPicksOptions) error {
+	client, err := cherrypick.NewClient(opts.Options)
+	if err != nil {
+		return errors.Wrap(err, "creating GitHub client")
+	}
+
+	cherryPicks := cherrypick.New(opts.Options)
+	cherryPicks.SetClient(client)
+	report, err := cherryPicks.Report()
+	if err != nil {
+		return errors.Wrap(err, "creating cherry pick report")
+	}
+
+	if opts.json {
+		content, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "marshal cherry pick report")
+		}
+		fmt.Println(string(content))
+		return nil
+	}
+
+	fmt.Print(report.Markdown())
+	return nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/binary"
+)
+
+// inspectBinariesCmd represents the subcommand for `krel inspect-binaries`
+var inspectBinariesCmd = &cobra.Command{
+	Use:   "inspect-binaries PATH...",
+	Short: "Report linking, symbol and hardening details of binaries",
+	Long: `krel inspect-binaries
+
+Inspects the provided binaries, or all binaries found in the provided
+directories, and reports for each of them:
+
+- the shared libraries it requires (ELF DT_NEEDED, Mach-O load commands or
+  PE imports), "static" if none
+- if the symbol table got stripped
+- the hardening features: PIE, RELRO and NX for ELF binaries, PIE and NX for
+  Mach-O binaries as well as ASLR and DEP for PE binaries
+`,
+	Example:       "krel inspect-binaries _output/release-stage/server --json",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Args:          cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runInspectBinaries(inspectBinariesOpts, args)
+	},
+}
+
+type inspectBinariesOptions struct {
+	json bool
+}
+
+var inspectBinariesOpts = &inspectBinariesOptions{}
+
+func init() {
+	inspectBinariesCmd.PersistentFlags().BoolVar(
+		&inspectBinariesOpts.json,
+		"json",
+		false,
+		"print the report as JSON",
+	)
+
+	rootCmd.AddCommand(inspectBinariesCmd)
+}
+
+func runInspectBinaries(opts *inspectBinariesOptions, paths []string) error {
+	inspections := []*binary.Inspection{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return errors.Wrapf(err, "checking path %s", path)
+		}
+
+		if !info.IsDir() {
+			inspection, err := inspectBinary(path)
+			if err != nil {
+				return err
+			}
+			inspections = append(inspections, inspection)
+			continue
+		}
+
+		if err := filepath.Walk(path,
+			func(filePath string, info os.FileInfo, err error) error {
+				if err != nil {
+					return err
+				}
+				if info.IsDir() {
+					return nil
+				}
+				inspection, err := inspectBinary(filePath)
+				if err != nil {
+					logrus.Debugf("Skipping %s: %v", filePath, err)
+					return nil
+				}
+				inspections = append(inspections, inspection)
+				return nil
+			},
+		); err != nil {
+			return errors.Wrapf(err, "traversing %s", path)
+		}
+	}
+
+	if opts.json {
+		content, err := json.MarshalIndent(inspections, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "marshal inspection report")
+		}
+		fmt.Println(string(content))
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{
+		"Binary", "Platform", "Linking", "Stripped",
+		"PIE", "RELRO", "NX", "ASLR", "DEP",
+	})
+	for _, inspection := range inspections {
+		linking := "static"
+		if !inspection.Static() {
+			dynamic := inspection.Libraries
+			if inspection.Interpreter != "" {
+				dynamic = append([]string{inspection.Interpreter}, dynamic...)
+			}
+			linking = strings.Join(dynamic, ", ")
+		}
+		hardening := inspection.Hardening
+		table.Append([]string{
+			inspection.Path,
+			inspection.OS + "/" + inspection.Arch,
+			linking,
+			strconv.FormatBool(inspection.Stripped),
+			strconv.FormatBool(hardening.PIE),
+			hardening.RELRO,
+			strconv.FormatBool(hardening.NX),
+			strconv.FormatBool(hardening.ASLR),
+			strconv.FormatBool(hardening.DEP),
+		})
+	}
+	table.Render()
+	return nil
+}
+
+func inspectBinary(path string) (*binary.Inspection, error) {
+	bin, err := binary.New(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening binary %s", path)
+	}
+	inspection, err := bin.Inspect()
+	if err != nil {
+		return nil, errors.Wrapf(err, "inspecting binary %s", path)
+	}
+	return inspection, nil
+}
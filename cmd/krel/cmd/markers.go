@@ -0,0 +1,215 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/objectstore"
+	"k8s.io/release/pkg/release"
+)
+
+// markersCmd represents the subcommand for `krel markers`
+var markersCmd = &cobra.Command{
+	Use:   "markers",
+	Short: "Inspect and modify version markers",
+	Long: `krel markers
+
+Subcommand to work with the version markers of a release bucket, like
+stable.txt or latest-1.22.txt. Markers can be listed, shown, set and audited
+for pointing to builds which do not exist. See each subcommand for more
+information.
+`,
+	SilenceUsage:  false,
+	SilenceErrors: false,
+}
+
+var markersListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "List all version markers of the bucket",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Args:          cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMarkersList(markersOpts)
+	},
+}
+
+var markersShowCmd = &cobra.Command{
+	Use:           "show MARKER",
+	Short:         "Show the version a marker points to",
+	Example:       "krel markers show stable-1.22",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Args:          cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMarkersShow(markersOpts, args[0])
+	},
+}
+
+var markersSetCmd = &cobra.Command{
+	Use:   "set MARKER VERSION",
+	Short: "Point a version marker to a version",
+	Long: `krel markers set
+
+Updates the version marker to point to the provided version. The build for
+the version has to exist in the bucket and the version has to be newer than
+the currently published one. Use --force to downgrade a marker.
+
+Without --nomock, the command runs all checks but does not update the
+marker.
+`,
+	Example:       "krel markers set stable-1.22 v1.22.2 --nomock",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Args:          cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMarkersSet(markersOpts, args[0], args[1])
+	},
+}
+
+var markersAuditCmd = &cobra.Command{
+	Use:           "audit",
+	Short:         "Find version markers pointing to builds which do not exist",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Args:          cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMarkersAudit(markersOpts)
+	},
+}
+
+type markersOptions struct {
+	bucket        string
+	gcsRoot       string
+	privateBucket bool
+	force         bool
+}
+
+var markersOpts = &markersOptions{}
+
+func init() {
+	markersCmd.PersistentFlags().StringVar(
+		&markersOpts.bucket,
+		"bucket",
+		release.ProductionBucket,
+		"bucket containing the version markers, can be prefixed with "+
+			"gs://, s3:// or file://",
+	)
+
+	markersCmd.PersistentFlags().StringVar(
+		&markersOpts.gcsRoot,
+		"gcs-root",
+		"release",
+		"root directory of the builds and version markers inside the bucket",
+	)
+
+	markersSetCmd.PersistentFlags().BoolVar(
+		&markersOpts.privateBucket,
+		"private-bucket",
+		false,
+		"do not make the updated version marker public",
+	)
+
+	markersSetCmd.PersistentFlags().BoolVar(
+		&markersOpts.force,
+		"force",
+		false,
+		"update the version marker even if this downgrades its version",
+	)
+
+	markersCmd.AddCommand(
+		markersListCmd, markersShowCmd, markersSetCmd, markersAuditCmd,
+	)
+	rootCmd.AddCommand(markersCmd)
+}
+
+func newVersionMarkers(opts *markersOptions) *release.VersionMarkers {
+	return release.NewVersionMarkers(objectstore.ForBucket(opts.bucket))
+}
+
+func runMarkersList(opts *markersOptions) error {
+	markers, err := newVersionMarkers(opts).List(opts.bucket, opts.gcsRoot)
+	if err != nil {
+		return errors.Wrap(err, "list version markers")
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{"Marker", "Version"})
+	for _, marker := range markers {
+		table.Append([]string{marker.Name, marker.Version})
+	}
+	table.Render()
+	return nil
+}
+
+func runMarkersShow(opts *markersOptions, name string) error {
+	marker, err := newVersionMarkers(opts).Show(opts.bucket, opts.gcsRoot, name)
+	if err != nil {
+		return errors.Wrapf(err, "show version marker %s", name)
+	}
+	fmt.Println(marker.Version)
+	return nil
+}
+
+func runMarkersSet(opts *markersOptions, name, version string) error {
+	markers := newVersionMarkers(opts)
+	markers.SetDryRun(!rootOpts.nomock)
+
+	if err := markers.Set(
+		opts.bucket, opts.gcsRoot, name, version, opts.force, opts.privateBucket,
+	); err != nil {
+		return errors.Wrapf(err, "set version marker %s", name)
+	}
+	if !rootOpts.nomock {
+		logrus.Infof(
+			"Mock mode: version marker %s can be updated to %s, "+
+				"run with --nomock to apply the change",
+			name, version,
+		)
+		return nil
+	}
+	logrus.Infof("Version marker %s now points to %s", name, version)
+	return nil
+}
+
+func runMarkersAudit(opts *markersOptions) error {
+	problems, err := newVersionMarkers(opts).Audit(opts.bucket, opts.gcsRoot)
+	if err != nil {
+		return errors.Wrap(err, "audit version markers")
+	}
+	if len(problems) == 0 {
+		logrus.Info("All version markers point to existing builds")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{"Marker", "Version", "Problem"})
+	for _, problem := range problems {
+		table.Append([]string{problem.Name, problem.Version, problem.Reason})
+	}
+	table.Render()
+	return errors.Errorf("found %d broken version markers", len(problems))
+}
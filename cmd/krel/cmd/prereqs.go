@@ -0,0 +1,187 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/release"
+)
+
+// prereqsCmd represents the subcommand for `krel prereqs`
+var prereqsCmd = &cobra.Command{
+	Use:   "prereqs",
+	Short: "Check the prerequisites for cutting a release",
+	Long: fmt.Sprintf(`krel prereqs
+
+Runs the release prerequisite checks on the local machine, which should be
+done before submitting a stage or release job to Google Cloud Build.
+
+All checks run, even if some of them fail, and the result is printed as a
+report including hints how to fix the failures. The available checks are:
+
+- %s: required commands exist in $PATH
+- %s: minimum Docker version
+- %s: minimum Go version (--min-go-version)
+- %s: gcloud has an authorized account and project
+- %s: GitHub token is set
+- %s: available disk space of the working directory
+- %s: kube-cross image is pullable (--kube-cross-image)
+- %s: git signing key is available (--check-signing-key)
+- %s: write access to the buckets (--bucket)
+
+Single checks can be disabled using --skip.
+`,
+		release.PrerequisiteCommands,
+		release.PrerequisiteDockerVersion,
+		release.PrerequisiteGoVersion,
+		release.PrerequisiteGCloud,
+		release.PrerequisiteGitHubToken,
+		release.PrerequisiteDiskSpace,
+		release.PrerequisiteKubeCross,
+		release.PrerequisiteSigningKey,
+		release.PrerequisiteBucketAccess,
+	),
+	Example:       "krel prereqs --bucket gs://kubernetes-release-gcb --skip disk-space",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPrereqs(prereqsOpts)
+	},
+}
+
+type prereqsOptions struct {
+	release.PrerequisitesCheckerOptions
+	workdir string
+	json    bool
+}
+
+var prereqsOpts = &prereqsOptions{
+	PrerequisitesCheckerOptions: *release.DefaultPrerequisitesCheckerOptions,
+}
+
+func init() {
+	prereqsCmd.PersistentFlags().StringVar(
+		&prereqsOpts.workdir,
+		"workdir",
+		".",
+		"working directory used for checking the available disk space",
+	)
+
+	prereqsCmd.PersistentFlags().StringSliceVar(
+		&prereqsOpts.Commands,
+		"commands",
+		prereqsOpts.Commands,
+		"commands which have to be available in $PATH",
+	)
+
+	prereqsCmd.PersistentFlags().StringVar(
+		&prereqsOpts.MinDockerVersion,
+		"min-docker-version",
+		prereqsOpts.MinDockerVersion,
+		"minimum required Docker version, empty to skip the check",
+	)
+
+	prereqsCmd.PersistentFlags().StringVar(
+		&prereqsOpts.MinGoVersion,
+		"min-go-version",
+		"",
+		"minimum required Go version, for example 1.17",
+	)
+
+	prereqsCmd.PersistentFlags().Uint64Var(
+		&prereqsOpts.MinDiskSpaceGiB,
+		"min-disk-space",
+		prereqsOpts.MinDiskSpaceGiB,
+		"minimum free disk space in GiB, 0 to skip the check",
+	)
+
+	prereqsCmd.PersistentFlags().BoolVar(
+		&prereqsOpts.CheckGitHubToken,
+		"github-token",
+		prereqsOpts.CheckGitHubToken,
+		"check that the GitHub token is set",
+	)
+
+	prereqsCmd.PersistentFlags().StringVar(
+		&prereqsOpts.KubeCrossImage,
+		"kube-cross-image",
+		"",
+		"kube-cross image which has to be pullable, "+
+			"for example k8s.gcr.io/build-image/kube-cross:v1.17.3-1",
+	)
+
+	prereqsCmd.PersistentFlags().BoolVar(
+		&prereqsOpts.CheckSigningKey,
+		"check-signing-key",
+		false,
+		"check that the tag signing key from the TAG_SIGNING_* environment "+
+			"variables or the git signing key is usable",
+	)
+
+	prereqsCmd.PersistentFlags().StringSliceVar(
+		&prereqsOpts.Buckets,
+		"bucket",
+		[]string{},
+		"GCS bucket which has to be writable, can be set multiple times",
+	)
+
+	prereqsCmd.PersistentFlags().StringSliceVar(
+		&prereqsOpts.Skip,
+		"skip",
+		[]string{},
+		"names of the checks to skip, can be set multiple times",
+	)
+
+	prereqsCmd.PersistentFlags().BoolVar(
+		&prereqsOpts.json,
+		"json",
+		false,
+		"print the report as JSON",
+	)
+
+	rootCmd.AddCommand(prereqsCmd)
+}
+
+func runPrereqs(opts *prereqsOptions) error {
+	checker := release.NewPrerequisitesChecker()
+	*checker.Options() = opts.PrerequisitesCheckerOptions
+
+	// Never touch the git configuration of a release manager
+	checker.Options().ConfigureGitUser = false
+	checker.Options().TagSigning = release.TagSignerOptionsFromEnv()
+
+	report := checker.Check(opts.workdir)
+	if opts.json {
+		content, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "marshal prerequisites report")
+		}
+		fmt.Println(string(content))
+	} else {
+		fmt.Print(report.String())
+	}
+
+	if !report.Passed() {
+		return errors.New("not all prerequisites are met")
+	}
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 
 	"k8s.io/release/pkg/build"
 	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/sign"
 )
 
 const pushCmdDescription = `
@@ -147,6 +148,23 @@ func init() {
 		"Validate that the remote image digests exists",
 	)
 
+	pushBuildCmd.PersistentFlags().StringVar(
+		&pushBuildOpts.ImageSigningKey,
+		"image-signing-key",
+		"",
+		fmt.Sprintf(
+			"Path to the private key for signing the pushed images, encrypted cosign keys are decrypted using $%s",
+			sign.PasswordEnvKey,
+		),
+	)
+
+	pushBuildCmd.PersistentFlags().StringVar(
+		&pushBuildOpts.ImageVerificationKey,
+		"image-verification-key",
+		"",
+		"Path to the public key for verifying the image signatures when validating images (--validate-images)",
+	)
+
 	rootCmd.AddCommand(pushBuildCmd)
 }
 
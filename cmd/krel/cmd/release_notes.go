@@ -127,6 +127,7 @@ type releaseNotesOptions struct {
 	createWebsitePR    bool
 	fixNotes           bool
 	listReleaseNotesV2 bool
+	source             string
 	websiteRepo        string
 	mapProviders       []string
 	githubOrg          string
@@ -188,7 +189,7 @@ func init() {
 		"maps-from",
 		"m",
 		[]string{},
-		"specify a location to recursively look for release notes *.y[a]ml file mappings",
+		"specify a location to look for release notes *.y[a]ml file mappings, like a directory, gs://bucket/path/ or git+https://github.com/org/repo//path@ref",
 	)
 
 	releaseNotesCmd.PersistentFlags().BoolVar(
@@ -212,6 +213,18 @@ func init() {
 		"enable experimental implementation to list commits (ListReleaseNotesV2)",
 	)
 
+	releaseNotesCmd.PersistentFlags().StringVar(
+		&releaseNotesOpts.source,
+		"source",
+		options.SourcePullRequests,
+		fmt.Sprintf("source of the release notes (options: %s)",
+			strings.Join([]string{
+				options.SourcePullRequests,
+				options.SourceConventionalCommits,
+			}, ", "),
+		),
+	)
+
 	releaseNotesCmd.PersistentFlags().BoolVar(
 		&releaseNotesOpts.interactiveMode,
 		"interactiveMode",
@@ -842,6 +855,7 @@ func releaseNotesJSON(repoPath, tag string) (jsonString string, err error) {
 	notesOptions.EndRev = tag
 	notesOptions.Debug = logrus.StandardLogger().Level >= logrus.DebugLevel
 	notesOptions.MapProviderStrings = releaseNotesOpts.mapProviders
+	notesOptions.Source = releaseNotesOpts.source
 	notesOptions.AddMarkdownLinks = true
 
 	// If the the release for the tag we are using has a mapping directory,
@@ -896,6 +910,7 @@ func gatherNotesFrom(repoPath, startTag string) (*notes.ReleaseNotes, error) {
 	notesOptions.Debug = logrus.StandardLogger().Level >= logrus.DebugLevel
 	notesOptions.MapProviderStrings = releaseNotesOpts.mapProviders
 	notesOptions.ListReleaseNotesV2 = releaseNotesOpts.listReleaseNotesV2
+	notesOptions.Source = releaseNotesOpts.source
 	notesOptions.AddMarkdownLinks = true
 
 	if err := notesOptions.ValidateAndFinish(); err != nil {
@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/release"
+)
+
+// reproDiffCmd represents the subcommand for `krel repro-diff`
+var reproDiffCmd = &cobra.Command{
+	Use:   "repro-diff DIR_A DIR_B",
+	Short: "Compare the artifacts of two builds for reproducibility",
+	Long: `krel repro-diff
+
+Compares the artifacts of two builds of the same release, for example two
+local _output directories or two staged builds on GCS (gs://...). Files are
+paired by their relative path, tarballs and image tarballs are compared
+recursively down to the files inside of their layers.
+
+Every differing file is reported, grouped by the likely cause:
+
+- timestamps: file modification times, build dates
+- build paths: absolute paths of the build environment
+- embedded versions: version strings and git commits
+- archive metadata: file modes, owners and order of tarball entries
+- derived digests: checksums of other differing files
+- content: everything else, including files only present in one build
+
+The command fails if the builds are not identical.
+`,
+	Example:       "krel repro-diff _output-a/release-tars _output-b/release-tars",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Args:          cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runReproDiff(reproDiffOpts, args[0], args[1])
+	},
+}
+
+type reproDiffOptions struct {
+	json bool
+}
+
+var reproDiffOpts = &reproDiffOptions{}
+
+func init() {
+	reproDiffCmd.PersistentFlags().BoolVar(
+		&reproDiffOpts.json,
+		"json",
+		false,
+		"print the report as JSON",
+	)
+
+	rootCmd.AddCommand(reproDiffCmd)
+}
+
+func runReproDiff(opts *reproDiffOptions, dirA, dirB string) error {
+	report, err := release.NewReproDiffer(&release.ReproDiffOptions{
+		A: dirA, B: dirB,
+	}).Diff()
+	if err != nil {
+		return errors.Wrapf(err, "comparing %s and %s", dirA, dirB)
+	}
+
+	if opts.json {
+		content, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "marshal repro diff report")
+		}
+		fmt.Println(string(content))
+	} else {
+		fmt.Print(report.String())
+	}
+
+	if !report.Passed() {
+		return errors.Errorf(
+			"found %d differences between the builds", len(report.Differences),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/release"
+	"sigs.k8s.io/release-sdk/git"
+)
+
+// verifyReleaseCmd represents the subcommand for `krel verify-release`
+var verifyReleaseCmd = &cobra.Command{
+	Use:   "verify-release VERSION",
+	Short: "Verify that a published release is complete and intact",
+	Long: `krel verify-release
+
+Verifies the artifacts of a published release. The release artifacts are read
+from the bucket, or from a local mirror of the release directory, and every
+file gets checked against the SHA256SUMS and SHA512SUMS of the release.
+
+Afterwards the subjects of the provenance attestation and the files of the
+release SBOM are compared with the verified artifacts, the image manifests are
+checked for every architecture and the assets on the GitHub release page have
+to match the artifacts as well.
+
+The result is printed as a pass/fail report per check. The command fails if
+any check fails.
+`,
+	Example:       "krel verify-release v1.22.3",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Args:          cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		verifyReleaseOpts.Version = args[0]
+		return runVerifyRelease(verifyReleaseOpts)
+	},
+}
+
+var verifyReleaseOpts = &release.ReleaseVerifierOptions{}
+
+func init() {
+	verifyReleaseCmd.PersistentFlags().StringVar(
+		&verifyReleaseOpts.Bucket,
+		"bucket",
+		release.ProductionBucket,
+		"bucket containing the release artifacts, can be prefixed with "+
+			"gs://, s3:// or file://",
+	)
+
+	verifyReleaseCmd.PersistentFlags().StringVar(
+		&verifyReleaseOpts.GCSRoot,
+		"gcs-root",
+		"release",
+		"root directory of the releases inside the bucket",
+	)
+
+	verifyReleaseCmd.PersistentFlags().StringVar(
+		&verifyReleaseOpts.Mirror,
+		"mirror",
+		"",
+		"local directory containing the release artifacts, "+
+			"takes precedence over --bucket",
+	)
+
+	verifyReleaseCmd.PersistentFlags().StringVar(
+		&verifyReleaseOpts.Registry,
+		"registry",
+		release.GCRIOPathProd,
+		"registry containing the release images, empty to skip the images",
+	)
+
+	verifyReleaseCmd.PersistentFlags().StringVar(
+		&verifyReleaseOpts.GitHubOrg,
+		"github-org",
+		git.DefaultGithubOrg,
+		"GitHub organization of the release page, empty to skip the page",
+	)
+
+	verifyReleaseCmd.PersistentFlags().StringVar(
+		&verifyReleaseOpts.GitHubRepo,
+		"github-repo",
+		git.DefaultGithubRepo,
+		"GitHub repository of the release page, empty to skip the page",
+	)
+
+	rootCmd.AddCommand(verifyReleaseCmd)
+}
+
+func runVerifyRelease(opts *release.ReleaseVerifierOptions) error {
+	report, err := release.NewReleaseVerifier(opts).Verify()
+	if err != nil {
+		return errors.Wrapf(err, "verifying release %s", opts.Version)
+	}
+
+	fmt.Print(report.String())
+	if !report.Passed() {
+		return errors.Errorf("release %s failed verification", opts.Version)
+	}
+	return nil
+}
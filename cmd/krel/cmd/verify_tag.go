@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/release"
+)
+
+// verifyTagCmd represents the subcommand for `krel verify-tag`
+var verifyTagCmd = &cobra.Command{
+	Use:   "verify-tag TAG",
+	Short: "Verify the signature of a release tag",
+	Long: fmt.Sprintf(`krel verify-tag
+
+Verifies the signature of a git tag in a local repository. Both OpenPGP and
+SSH signatures are supported, the format is detected from the tag object if
+--format is not set.
+
+The trusted keys can be provided via --keyring, which is either an armored
+OpenPGP public keyring (%s) or an SSH allowed signers file (%s). Without a
+keyring, the default git and GnuPG configuration of the user is used.
+`,
+		release.TagSigningFormatOpenPGP,
+		release.TagSigningFormatSSH,
+	),
+	Example:       "krel verify-tag v1.23.0 --repo ~/go/src/k8s.io/kubernetes --keyring release-keys.asc",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Args:          cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runVerifyTag(verifyTagOpts, args[0])
+	},
+}
+
+type verifyTagOptions struct {
+	repo    string
+	keyring string
+	format  string
+}
+
+var verifyTagOpts = &verifyTagOptions{}
+
+func init() {
+	verifyTagCmd.PersistentFlags().StringVar(
+		&verifyTagOpts.repo,
+		"repo",
+		".",
+		"path to the local git repository containing the tag",
+	)
+
+	verifyTagCmd.PersistentFlags().StringVar(
+		&verifyTagOpts.keyring,
+		"keyring",
+		"",
+		"OpenPGP public keyring or SSH allowed signers file with the trusted keys",
+	)
+
+	verifyTagCmd.PersistentFlags().StringVar(
+		&verifyTagOpts.format,
+		"format",
+		"",
+		fmt.Sprintf(
+			"signature format (%s or %s), detected from the tag if empty",
+			release.TagSigningFormatOpenPGP, release.TagSigningFormatSSH,
+		),
+	)
+
+	rootCmd.AddCommand(verifyTagCmd)
+}
+
+func runVerifyTag(opts *verifyTagOptions, tag string) error {
+	if opts.format != "" &&
+		opts.format != release.TagSigningFormatOpenPGP &&
+		opts.format != release.TagSigningFormatSSH {
+		return errors.Errorf("unsupported signature format %q", opts.format)
+	}
+
+	verifier := release.NewTagSigner(&release.TagSignerOptions{
+		Format:  opts.format,
+		Keyring: opts.keyring,
+	})
+	defer verifier.Cleanup()
+
+	if err := verifier.Verify(opts.repo, tag); err != nil {
+		return errors.Wrapf(err, "verifying tag %s", tag)
+	}
+	logrus.Infof("Tag %s has a valid signature", tag)
+	return nil
+}
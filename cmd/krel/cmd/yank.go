@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/release"
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-utils/util"
+)
+
+// yankCmd represents the subcommand for `krel yank`
+var yankCmd = &cobra.Command{
+	Use:   "yank",
+	Short: "Revert a broken release",
+	Long: `krel yank
+
+Reverts a partially or fully published release. All version markers pointing
+to the yanked version get reverted to the previous version, which is derived
+from the yanked one if not specified. The GitHub release page gets marked as
+yanked (or deleted) and the release tag can optionally be removed from the
+remote repository.
+
+All planned actions are shown and have to be confirmed before anything runs.
+Without --nomock, no changes will be made. The executed actions are recorded
+in the release archive of the bucket.
+`,
+	Example:       "krel yank --version v1.22.3 --previous-version v1.22.2 --nomock",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runYank(yankOpts)
+	},
+}
+
+var yankOpts = &release.YankOptions{}
+
+func init() {
+	yankCmd.PersistentFlags().StringVar(
+		&yankOpts.Version,
+		"version",
+		"",
+		"release version to be yanked",
+	)
+
+	yankCmd.PersistentFlags().StringVar(
+		&yankOpts.PreviousVersion,
+		"previous-version",
+		"",
+		"last good version the version markers get reverted to, "+
+			"derived from --version if empty",
+	)
+
+	yankCmd.PersistentFlags().StringVar(
+		&yankOpts.Bucket,
+		"bucket",
+		release.ProductionBucket,
+		"bucket containing the version markers, can be prefixed with "+
+			"gs://, s3:// or file://",
+	)
+
+	yankCmd.PersistentFlags().StringVar(
+		&yankOpts.GCSRoot,
+		"gcs-root",
+		"release",
+		"root directory of the builds and version markers inside the bucket",
+	)
+
+	yankCmd.PersistentFlags().BoolVar(
+		&yankOpts.PrivateBucket,
+		"private-bucket",
+		false,
+		"do not make the reverted version markers public",
+	)
+
+	yankCmd.PersistentFlags().StringVar(
+		&yankOpts.GitHubOrg,
+		"github-org",
+		git.DefaultGithubOrg,
+		"GitHub organization of the release page, empty to skip the page",
+	)
+
+	yankCmd.PersistentFlags().StringVar(
+		&yankOpts.GitHubRepo,
+		"github-repo",
+		git.DefaultGithubRepo,
+		"GitHub repository of the release page, empty to skip the page",
+	)
+
+	yankCmd.PersistentFlags().BoolVar(
+		&yankOpts.DeleteGitHubRelease,
+		"delete-github-release",
+		false,
+		"delete the GitHub release page instead of marking it as yanked",
+	)
+
+	yankCmd.PersistentFlags().BoolVar(
+		&yankOpts.DeleteTags,
+		"delete-tags",
+		false,
+		"delete the release tag from the remote of the repository",
+	)
+
+	yankCmd.PersistentFlags().StringVar(
+		&yankOpts.RepoPath,
+		"repo",
+		"",
+		"path to the kubernetes/kubernetes repository used to delete tags",
+	)
+
+	if err := yankCmd.MarkPersistentFlagRequired("version"); err != nil {
+		logrus.Fatal(err)
+	}
+
+	rootCmd.AddCommand(yankCmd)
+}
+
+func runYank(opts *release.YankOptions) error {
+	opts.NoMock = rootOpts.nomock
+	yanker := release.NewYanker(opts)
+
+	actions, err := yanker.Plan()
+	if err != nil {
+		return errors.Wrap(err, "planning the yank")
+	}
+	if len(actions) == 0 {
+		logrus.Infof("Nothing to do for yanking %s", opts.Version)
+		return nil
+	}
+
+	fmt.Printf("The following actions will be run to yank %s:\n", opts.Version)
+	for i, action := range actions {
+		fmt.Printf("  %d. %s\n", i+1, action)
+	}
+
+	if opts.NoMock {
+		_, success, err := util.Ask(
+			"Do you want to continue? (y/N)", "y:Y:yes|n:N:no|n", 10,
+		)
+		if err != nil {
+			return errors.Wrap(err, "asking for confirmation")
+		}
+		if !success {
+			logrus.Info("Aborting yank")
+			return nil
+		}
+	}
+
+	if err := yanker.Run(actions); err != nil {
+		return errors.Wrapf(err, "yanking %s", opts.Version)
+	}
+	logrus.Infof("Finished yanking %s", opts.Version)
+	return nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/notes"
+)
+
+type diffOptions struct {
+	markdown bool
+	json     bool
+}
+
+var (
+	diffOpts = &diffOptions{}
+	diffCmd  = &cobra.Command{
+		Use:   "diff OLD.json NEW.json",
+		Short: "Compare two sets of release notes in JSON format",
+		Long: `release-notes diff
+
+Compares two sets of release notes as written by --format=json, for example
+before and after editing a notes map or between a release candidate and the
+final release. Added and removed PRs are reported as well as the changed
+text, kinds, SIGs, areas and flags of a note.
+`,
+		Example:       "release-notes diff v1.23.0-rc.0.json v1.23.0.json",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(diffOpts, args[0], args[1])
+		},
+	}
+)
+
+func init() {
+	diffCmd.PersistentFlags().BoolVar(
+		&diffOpts.markdown,
+		"markdown",
+		false,
+		"print the diff as markdown, for example to be used as review comment",
+	)
+
+	diffCmd.PersistentFlags().BoolVar(
+		&diffOpts.json,
+		"json",
+		false,
+		"print the diff as JSON",
+	)
+
+	cmd.AddCommand(diffCmd)
+}
+
+func runDiff(opts *diffOptions, oldPath, newPath string) error {
+	if opts.markdown && opts.json {
+		return errors.New("only one of --markdown and --json can be set")
+	}
+
+	oldNotes, err := notes.ReadReleaseNotesByPR(oldPath)
+	if err != nil {
+		return err
+	}
+	newNotes, err := notes.ReadReleaseNotesByPR(newPath)
+	if err != nil {
+		return err
+	}
+	diff := notes.DiffReleaseNotes(oldNotes, newNotes)
+
+	switch {
+	case opts.json:
+		content, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "marshal release notes diff")
+		}
+		fmt.Println(string(content))
+	case opts.markdown:
+		fmt.Print(diff.Markdown())
+	default:
+		fmt.Print(diff.String())
+	}
+	return nil
+}
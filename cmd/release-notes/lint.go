@@ -0,0 +1,245 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/lint"
+)
+
+type lintOptions struct {
+	prs       []int
+	notes     []string
+	disable   []string
+	maxLength int
+	online    bool
+	strict    bool
+	json      bool
+}
+
+var (
+	lintOpts = &lintOptions{}
+	lintCmd  = &cobra.Command{
+		Use:   "lint",
+		Short: "Check the quality of release notes",
+		Long: `release-notes lint
+
+Checks release notes of notes maps (--maps-from), JSON files written by
+--format=json (--notes) or pull requests (--pr) for common problems:
+
+- missing-labels: notes without SIG or kind labels
+- repeats-title: notes which just repeat the PR title
+- past-tense: notes not starting with a verb in past tense
+- first-person: notes written in first person
+- formatting: trailing whitespace, leading bullets and code fences
+- length: notes longer than --max-length characters
+- docs-url: invalid documentation URLs, or unresolvable ones if --online is set
+- action-required-guidance: action required notes without upgrade guidance
+
+Notes maps usually contain only parts of a note, so unset fields like the
+labels are not checked for them. The command fails if an error has been
+found, or any problem if --strict is set.
+`,
+		Example: `release-notes lint --maps-from maps/
+release-notes lint --org kubernetes --repo kubernetes --pr 106000 --online`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		RunE: func(*cobra.Command, []string) error {
+			return runLint(lintOpts)
+		},
+	}
+)
+
+func init() {
+	lintCmd.PersistentFlags().IntSliceVar(
+		&lintOpts.prs,
+		"pr",
+		[]int{},
+		"pull requests of --org and --repo to be checked",
+	)
+
+	lintCmd.PersistentFlags().StringSliceVar(
+		&lintOpts.notes,
+		"notes",
+		[]string{},
+		"JSON files with release notes as written by --format=json to be checked",
+	)
+
+	lintCmd.PersistentFlags().StringSliceVar(
+		&lintOpts.disable,
+		"disable",
+		[]string{},
+		"rules to be disabled",
+	)
+
+	lintCmd.PersistentFlags().IntVar(
+		&lintOpts.maxLength,
+		"max-length",
+		lint.DefaultMaxLength,
+		"maximum number of characters of a note",
+	)
+
+	lintCmd.PersistentFlags().BoolVar(
+		&lintOpts.online,
+		"online",
+		false,
+		"check if the documentation URLs resolve",
+	)
+
+	lintCmd.PersistentFlags().BoolVar(
+		&lintOpts.strict,
+		"strict",
+		false,
+		"fail on warnings, too",
+	)
+
+	lintCmd.PersistentFlags().BoolVar(
+		&lintOpts.json,
+		"json",
+		false,
+		"print the problems as JSON",
+	)
+
+	cmd.AddCommand(lintCmd)
+}
+
+func runLint(lintOpts *lintOptions) error {
+	linter := lint.New()
+	if err := linter.Disable(lintOpts.disable...); err != nil {
+		return err
+	}
+	for _, rule := range linter.Rules() {
+		switch r := rule.(type) {
+		case *lint.LengthRule:
+			r.MaxLength = lintOpts.maxLength
+		case *lint.DocsURLRule:
+			if lintOpts.online {
+				r.Client = &http.Client{Timeout: 30 * time.Second}
+			}
+		}
+	}
+
+	inputs, err := lintInputs(lintOpts)
+	if err != nil {
+		return err
+	}
+	if len(inputs) == 0 {
+		return errors.New("no release notes found, please specify --maps-from, --notes or --pr")
+	}
+
+	problems := linter.Lint(inputs...)
+	if lintOpts.json {
+		content, err := json.MarshalIndent(problems, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "marshal release notes problems")
+		}
+		fmt.Println(string(content))
+	} else {
+		for _, problem := range problems {
+			fmt.Print(problem.String())
+		}
+		fmt.Printf(
+			"Checked %d release notes, found %d problems (%d errors)\n",
+			len(inputs), len(problems), lint.Errors(problems),
+		)
+	}
+
+	failed := lint.Errors(problems)
+	if lintOpts.strict {
+		failed = len(problems)
+	}
+	if failed > 0 {
+		return errors.Errorf("found %d release notes problems", failed)
+	}
+	return nil
+}
+
+// lintInputs collects the notes from all specified sources.
+func lintInputs(lintOpts *lintOptions) ([]*lint.Input, error) {
+	inputs := []*lint.Input{}
+	for _, path := range opts.MapProviderStrings {
+		if strings.Contains(path, "://") {
+			return nil, errors.Errorf("linting maps from %s is not supported, please use a local directory", path)
+		}
+		files, err := notes.MapFiles(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing notes maps in %s", path)
+		}
+		for _, file := range files {
+			maps, err := notes.ParseReleaseNotesMap(file)
+			if err != nil {
+				return nil, errors.Wrapf(err, "parsing notes map %s", file)
+			}
+			for i := range *maps {
+				noteMap := &(*maps)[i]
+				note := &notes.ReleaseNote{PrNumber: noteMap.PR}
+				if err := note.ApplyMap(noteMap, false); err != nil {
+					return nil, errors.Wrapf(err, "applying notes map %s", file)
+				}
+				inputs = append(inputs, &lint.Input{Note: note, Source: file, Partial: true})
+			}
+		}
+	}
+
+	for _, file := range lintOpts.notes {
+		notesByPR, err := notes.ReadReleaseNotesByPR(file)
+		if err != nil {
+			return nil, err
+		}
+		prs := make([]int, 0, len(notesByPR))
+		for pr := range notesByPR {
+			prs = append(prs, pr)
+		}
+		sort.Ints(prs)
+		for _, pr := range prs {
+			inputs = append(inputs, &lint.Input{Note: notesByPR[pr], Source: file})
+		}
+	}
+
+	if len(lintOpts.prs) == 0 {
+		return inputs, nil
+	}
+	if err := opts.ValidateAndFinishClient(); err != nil {
+		return nil, errors.Wrap(err, "validating options")
+	}
+	gatherer, err := notes.NewGatherer(context.Background(), opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating notes gatherer")
+	}
+	for _, number := range lintOpts.prs {
+		note, pr, err := gatherer.ReleaseNoteForPullRequest(number)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, &lint.Input{
+			Note: note, Source: pr.GetHTMLURL(), PRTitle: pr.GetTitle(),
+		})
+	}
+	return inputs, nil
+}
@@ -30,6 +30,7 @@ import (
 
 	"k8s.io/release/pkg/notes"
 	"k8s.io/release/pkg/notes/document"
+	"k8s.io/release/pkg/notes/forge"
 	"k8s.io/release/pkg/notes/options"
 	"k8s.io/release/pkg/release"
 	"sigs.k8s.io/mdtoc/pkg/mdtoc"
@@ -76,6 +77,29 @@ func init() {
 		"Upload URL of github",
 	)
 
+	// forge contains the platform hosting the repository.
+	cmd.PersistentFlags().StringVar(
+		&opts.Forge,
+		"forge",
+		env.Default("FORGE", forge.GitHub),
+		fmt.Sprintf("The platform hosting the repository (options: %s). The API token is read from $%s, $%s or $%s respectively",
+			strings.Join([]string{forge.GitHub, forge.GitLab, forge.Gitea}, ", "),
+			forge.TokenEnvKey(forge.GitHub),
+			forge.GitLabTokenEnvKey,
+			forge.GiteaTokenEnvKey,
+		),
+	)
+
+	// forgeURL contains the base URL of the GitLab or Gitea instance.
+	cmd.PersistentFlags().StringVar(
+		&opts.ForgeURL,
+		"forge-url",
+		env.Default("FORGE_URL", ""),
+		fmt.Sprintf("Base URL of the GitLab or Gitea instance (defaults to %s or %s)",
+			forge.DefaultGitLabURL, forge.DefaultGiteaURL,
+		),
+	)
+
 	// githubOrg contains name of github organization that holds the repo to scrape.
 	cmd.PersistentFlags().StringVar(
 		&opts.GithubOrg,
@@ -150,7 +174,7 @@ func init() {
 		&opts.RepoPath,
 		"repo-path",
 		env.Default("REPO_PATH", filepath.Join(os.TempDir(), "k8s-repo")),
-		"Path to a local Kubernetes repository, used only for tag discovery.",
+		"Path to a local Kubernetes repository, used for tag discovery and the conventional-commits source.",
 	)
 
 	// format is the output format to produce the notes in.
@@ -162,16 +186,20 @@ func init() {
 			strings.Join([]string{
 				options.FormatJSON,
 				options.FormatMarkdown,
+				options.FormatAsciiDoc,
+				options.FormatRST,
+				options.FormatHTML,
+				options.FormatYAML,
 			}, ", "),
 		),
 	)
 
-	// go-template is the go template to be used when the format is markdown
+	// go-template is the go template to be used when the format is not JSON
 	cmd.PersistentFlags().StringVar(
 		&opts.GoTemplate,
 		"go-template",
 		env.Default("GO_TEMPLATE", options.GoTemplateDefault),
-		fmt.Sprintf("The go template to be used if --format=markdown (options: %s)",
+		fmt.Sprintf("The go template to be used if --format is not json (options: %s)",
 			strings.Join([]string{
 				options.GoTemplateDefault,
 				options.GoTemplateInline + "<template>",
@@ -233,7 +261,7 @@ func init() {
 		&releaseNotesOpts.tableOfContents,
 		"toc",
 		env.IsSet("TOC"),
-		"Enable the rendering of the table of contents",
+		"Enable the rendering of the table of contents (markdown only)",
 	)
 
 	cmd.PersistentFlags().StringVar(
@@ -250,6 +278,13 @@ func init() {
 		"Replay a previously recorded API from a directory",
 	)
 
+	cmd.PersistentFlags().StringVar(
+		&opts.CacheDir,
+		"cache-dir",
+		env.Default("CACHE_DIR", ""),
+		"Cache the API responses in a directory to speed up subsequent runs",
+	)
+
 	cmd.PersistentFlags().BoolVar(
 		&releaseNotesOpts.dependencies,
 		"dependencies",
@@ -262,8 +297,28 @@ func init() {
 		"maps-from",
 		"m",
 		[]string{},
-		"specify a location to recursively look for release notes *.y[a]ml file mappings",
+		"specify a location to look for release notes *.y[a]ml file mappings, like a directory, gs://bucket/path/ or git+https://github.com/org/repo//path@ref",
+	)
+	cmd.PersistentFlags().StringVar(
+		&opts.ConfigFile,
+		"config",
+		env.Default("CONFIG", ""),
+		"Path to a YAML file defining the note extraction patterns, labels and kinds. Uses the Kubernetes conventions if not set",
 	)
+
+	cmd.PersistentFlags().StringVar(
+		&opts.Source,
+		"source",
+		env.Default("SOURCE", options.SourcePullRequests),
+		fmt.Sprintf("The source of the release notes (options: %s). The %s source works without a GitHub token and uses it only for enriching the notes",
+			strings.Join([]string{
+				options.SourcePullRequests,
+				options.SourceConventionalCommits,
+			}, ", "),
+			options.SourceConventionalCommits,
+		),
+	)
+
 	cmd.PersistentFlags().BoolVar(
 		&opts.ListReleaseNotesV2,
 		"list-v2",
@@ -336,29 +391,36 @@ func WriteReleaseNotes(releaseNotes *notes.ReleaseNotes) (err error) {
 			return errors.Wrapf(err, "creating release note document")
 		}
 
-		markdown, err := doc.RenderMarkdownTemplate(opts.ReleaseBucket, opts.ReleaseTars, opts.GoTemplate)
-		if err != nil {
-			return errors.Wrapf(err, "rendering release note document with template")
-		}
-
-		const nl = "\n"
+		var deps string
 		if releaseNotesOpts.dependencies {
 			if opts.StartSHA == opts.EndSHA {
 				logrus.Info("Skipping dependency report because start and end SHA are the same")
 			} else {
 				url := git.GetRepoURL(opts.GithubOrg, opts.GithubRepo, false)
-				deps, err := notes.NewDependencies().ChangesForURL(
+				deps, err = notes.NewDependencies().ChangesForURL(
 					url, opts.StartSHA, opts.EndSHA,
 				)
 				if err != nil {
 					return errors.Wrap(err, "generating dependency report")
 				}
-				markdown += strings.Repeat(nl, 2) + deps
+				if opts.Format != options.FormatMarkdown {
+					doc.Dependencies = document.ParseDependencies(deps)
+				}
 			}
 		}
 
-		if releaseNotesOpts.tableOfContents {
-			toc, err := mdtoc.GenerateTOC([]byte(markdown), mdtoc.Options{
+		rendered, err := doc.Render(opts.Format, opts.ReleaseBucket, opts.ReleaseTars, opts.GoTemplate)
+		if err != nil {
+			return errors.Wrapf(err, "rendering release note document with template")
+		}
+
+		const nl = "\n"
+		if deps != "" && opts.Format == options.FormatMarkdown {
+			rendered += strings.Repeat(nl, 2) + deps
+		}
+
+		if releaseNotesOpts.tableOfContents && opts.Format == options.FormatMarkdown {
+			toc, err := mdtoc.GenerateTOC([]byte(rendered), mdtoc.Options{
 				Dryrun:     false,
 				SkipPrefix: false,
 				MaxDepth:   mdtoc.MaxHeaderDepth,
@@ -366,10 +428,10 @@ func WriteReleaseNotes(releaseNotes *notes.ReleaseNotes) (err error) {
 			if err != nil {
 				return errors.Wrap(err, "generating table of contents")
 			}
-			markdown = toc + nl + markdown
+			rendered = toc + nl + rendered
 		}
 
-		if _, err := output.WriteString(markdown); err != nil {
+		if _, err := output.WriteString(rendered); err != nil {
 			return errors.Wrap(err, "writing output file")
 		}
 	}
@@ -0,0 +1,159 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/maps"
+)
+
+type mapsValidateOptions struct {
+	online bool
+	strict bool
+	json   bool
+}
+
+var (
+	mapsValidateOpts = &mapsValidateOptions{}
+
+	mapsCmd = &cobra.Command{
+		Use:   "maps",
+		Short: "Work with release notes map files",
+	}
+
+	mapsValidateCmd = &cobra.Command{
+		Use:   "validate DIR",
+		Short: "Validate release notes map files",
+		Long: `release-notes maps validate
+
+Checks all *.y[a]ml release notes map files below DIR before they are used to
+generate the release notes:
+
+- the structure and value types of the maps, see 'release-notes maps schema'
+- the embedded CVE data
+- PRs which are mapped in more than one file
+- if --online is set, that every PR is part of the release range given by
+  --start-sha/--end-sha or --start-rev/--end-rev
+
+The command fails if an error has been found, or any problem if --strict is
+set.
+`,
+		Example: `release-notes maps validate maps/
+release-notes maps validate maps/ --online --start-rev v1.23.0 --end-rev v1.23.1 --branch release-1.23`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runMapsValidate(mapsValidateOpts, args[0])
+		},
+	}
+
+	mapsSchemaCmd = &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema of release notes map files",
+		Long: `release-notes maps schema
+
+Prints the JSON Schema of a single YAML document of a release notes map file,
+which can be used to validate maps in editors.
+`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		Run: func(*cobra.Command, []string) {
+			fmt.Print(string(maps.Schema))
+		},
+	}
+)
+
+func init() {
+	mapsValidateCmd.PersistentFlags().BoolVar(
+		&mapsValidateOpts.online,
+		"online",
+		false,
+		"check that the PRs are part of the release range",
+	)
+
+	mapsValidateCmd.PersistentFlags().BoolVar(
+		&mapsValidateOpts.strict,
+		"strict",
+		false,
+		"fail on warnings, too",
+	)
+
+	mapsValidateCmd.PersistentFlags().BoolVar(
+		&mapsValidateOpts.json,
+		"json",
+		false,
+		"print the problems as JSON",
+	)
+
+	mapsCmd.AddCommand(mapsValidateCmd, mapsSchemaCmd)
+	cmd.AddCommand(mapsCmd)
+}
+
+func runMapsValidate(mapsValidateOpts *mapsValidateOptions, path string) error {
+	validateOpts := &maps.Options{}
+	if mapsValidateOpts.online {
+		if err := opts.ValidateAndFinish(); err != nil {
+			return errors.Wrap(err, "validating options")
+		}
+		gatherer, err := notes.NewGatherer(context.Background(), opts)
+		if err != nil {
+			return errors.Wrap(err, "creating notes gatherer")
+		}
+		if validateOpts.PRs, err = gatherer.PullRequestNumbers(); err != nil {
+			return errors.Wrap(err, "listing the pull requests of the release range")
+		}
+	}
+
+	problems, err := maps.Validate(path, validateOpts)
+	if err != nil {
+		return err
+	}
+
+	if mapsValidateOpts.json {
+		content, err := json.MarshalIndent(problems, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "marshal release notes map problems")
+		}
+		fmt.Println(string(content))
+	} else {
+		for _, problem := range problems {
+			fmt.Print(problem.String())
+		}
+		fmt.Printf(
+			"Found %d problems (%d errors) in the release notes maps of %s\n",
+			len(problems), maps.Errors(problems), path,
+		)
+	}
+
+	failed := maps.Errors(problems)
+	if mapsValidateOpts.strict {
+		failed = len(problems)
+	}
+	if failed > 0 {
+		return errors.Errorf("found %d release notes map problems", failed)
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ package anago
 
 import (
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/blang/semver"
@@ -25,8 +26,10 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/sign"
 	"k8s.io/release/pkg/version"
 	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-utils/env"
 	"sigs.k8s.io/release-utils/log"
 	"sigs.k8s.io/release-utils/util"
 )
@@ -70,6 +73,23 @@ type Options struct {
 	// The build version to be released. Has to be specified in the format:
 	// `vX.Y.Z-[alpha|beta|rc].N.C+SHA`
 	BuildVersion string
+
+	// TagSigning configures the signing of the release tags. Tags are
+	// signed during the stage and verified before pushing them on release
+	// if a signing key is configured, usually via the TAG_SIGNING_*
+	// environment variables.
+	TagSigning *release.TagSignerOptions
+
+	// ImageSigningKey is the path to the private key for signing the
+	// container images when pushing them during the stage. Encrypted cosign
+	// keys are decrypted using the password from the `COSIGN_PASSWORD`
+	// environment variable. Images are not signed if empty.
+	ImageSigningKey string
+
+	// ImageVerificationKey is the path to the public key for verifying the
+	// image signatures when validating the images. Defaults to the public
+	// key of `ImageSigningKey` if empty.
+	ImageVerificationKey string
 }
 
 // DefaultOptions returns a new Options instance.
@@ -77,6 +97,10 @@ func DefaultOptions() *Options {
 	return &Options{
 		ReleaseType:   release.ReleaseTypeAlpha,
 		ReleaseBranch: git.DefaultBranch,
+		TagSigning:    release.TagSignerOptionsFromEnv(),
+
+		ImageSigningKey:      env.Default(sign.SigningKeyEnvKey, ""),
+		ImageVerificationKey: env.Default(sign.VerificationKeyEnvKey, ""),
 	}
 }
 
@@ -118,9 +142,28 @@ func (o *Options) Validate(state *State) error {
 	}
 	state.semverBuildVersion = semverBuildVersion
 
+	if o.TagSigning.Enabled() {
+		if err := o.TagSigning.Validate(); err != nil {
+			return errors.Wrap(err, "validating tag signing options")
+		}
+	}
+
 	return nil
 }
 
+// ImageVerifier returns the verifier for the image signatures based on
+// `ImageVerificationKey` and `ImageSigningKey`, or nil if no key is set.
+func (o *Options) ImageVerifier() (*sign.Verifier, error) {
+	verifier, err := sign.NewVerifierFromKeys(
+		o.ImageSigningKey, o.ImageVerificationKey,
+		[]byte(os.Getenv(sign.PasswordEnvKey)),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "load image verification key")
+	}
+	return verifier, nil
+}
+
 // Bucket returns the Google Cloud Bucket for these `Options`.
 func (o *Options) Bucket() string {
 	if o.NoMock {
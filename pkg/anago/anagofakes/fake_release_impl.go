@@ -25,6 +25,7 @@ import (
 	"k8s.io/release/pkg/build"
 	"k8s.io/release/pkg/gcp/gcb"
 	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/sign"
 	"sigs.k8s.io/release-sdk/object"
 )
 
@@ -55,9 +56,10 @@ type FakeReleaseImpl struct {
 		result1 bool
 		result2 error
 	}
-	CheckPrerequisitesStub        func() error
+	CheckPrerequisitesStub        func(*release.TagSignerOptions) error
 	checkPrerequisitesMutex       sync.RWMutex
 	checkPrerequisitesArgsForCall []struct {
+		arg1 *release.TagSignerOptions
 	}
 	checkPrerequisitesReturns struct {
 		result1 error
@@ -223,39 +225,17 @@ type FakeReleaseImpl struct {
 	publishVersionReturnsOnCall map[int]struct {
 		result1 error
 	}
-	PushBranchesStub        func(*release.GitObjectPusher, []string) error
-	pushBranchesMutex       sync.RWMutex
-	pushBranchesArgsForCall []struct {
+	PushRefsStub        func(*release.GitObjectPusher, []string, []string) error
+	pushRefsMutex       sync.RWMutex
+	pushRefsArgsForCall []struct {
 		arg1 *release.GitObjectPusher
 		arg2 []string
+		arg3 []string
 	}
-	pushBranchesReturns struct {
+	pushRefsReturns struct {
 		result1 error
 	}
-	pushBranchesReturnsOnCall map[int]struct {
-		result1 error
-	}
-	PushMainBranchStub        func(*release.GitObjectPusher) error
-	pushMainBranchMutex       sync.RWMutex
-	pushMainBranchArgsForCall []struct {
-		arg1 *release.GitObjectPusher
-	}
-	pushMainBranchReturns struct {
-		result1 error
-	}
-	pushMainBranchReturnsOnCall map[int]struct {
-		result1 error
-	}
-	PushTagsStub        func(*release.GitObjectPusher, []string) error
-	pushTagsMutex       sync.RWMutex
-	pushTagsArgsForCall []struct {
-		arg1 *release.GitObjectPusher
-		arg2 []string
-	}
-	pushTagsReturns struct {
-		result1 error
-	}
-	pushTagsReturnsOnCall map[int]struct {
+	pushRefsReturnsOnCall map[int]struct {
 		result1 error
 	}
 	SubmitStub        func(*gcb.Options) error
@@ -291,12 +271,13 @@ type FakeReleaseImpl struct {
 	updateGitHubPageReturnsOnCall map[int]struct {
 		result1 error
 	}
-	ValidateImagesStub        func(string, string, string) error
+	ValidateImagesStub        func(string, string, string, *sign.Verifier) error
 	validateImagesMutex       sync.RWMutex
 	validateImagesArgsForCall []struct {
 		arg1 string
 		arg2 string
 		arg3 string
+		arg4 *sign.Verifier
 	}
 	validateImagesReturns struct {
 		result1 error
@@ -304,6 +285,19 @@ type FakeReleaseImpl struct {
 	validateImagesReturnsOnCall map[int]struct {
 		result1 error
 	}
+	VerifyTagsStub        func(*release.TagSignerOptions, string, []string) error
+	verifyTagsMutex       sync.RWMutex
+	verifyTagsArgsForCall []struct {
+		arg1 *release.TagSignerOptions
+		arg2 string
+		arg3 []string
+	}
+	verifyTagsReturns struct {
+		result1 error
+	}
+	verifyTagsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -435,17 +429,18 @@ func (fake *FakeReleaseImpl) BranchNeedsCreationReturnsOnCall(i int, result1 boo
 	}{result1, result2}
 }
 
-func (fake *FakeReleaseImpl) CheckPrerequisites() error {
+func (fake *FakeReleaseImpl) CheckPrerequisites(arg1 *release.TagSignerOptions) error {
 	fake.checkPrerequisitesMutex.Lock()
 	ret, specificReturn := fake.checkPrerequisitesReturnsOnCall[len(fake.checkPrerequisitesArgsForCall)]
 	fake.checkPrerequisitesArgsForCall = append(fake.checkPrerequisitesArgsForCall, struct {
-	}{})
+		arg1 *release.TagSignerOptions
+	}{arg1})
 	stub := fake.CheckPrerequisitesStub
 	fakeReturns := fake.checkPrerequisitesReturns
-	fake.recordInvocation("CheckPrerequisites", []interface{}{})
+	fake.recordInvocation("CheckPrerequisites", []interface{}{arg1})
 	fake.checkPrerequisitesMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -459,12 +454,19 @@ func (fake *FakeReleaseImpl) CheckPrerequisitesCallCount() int {
 	return len(fake.checkPrerequisitesArgsForCall)
 }
 
-func (fake *FakeReleaseImpl) CheckPrerequisitesCalls(stub func() error) {
+func (fake *FakeReleaseImpl) CheckPrerequisitesCalls(stub func(*release.TagSignerOptions) error) {
 	fake.checkPrerequisitesMutex.Lock()
 	defer fake.checkPrerequisitesMutex.Unlock()
 	fake.CheckPrerequisitesStub = stub
 }
 
+func (fake *FakeReleaseImpl) CheckPrerequisitesArgsForCall(i int) *release.TagSignerOptions {
+	fake.checkPrerequisitesMutex.RLock()
+	defer fake.checkPrerequisitesMutex.RUnlock()
+	argsForCall := fake.checkPrerequisitesArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeReleaseImpl) CheckPrerequisitesReturns(result1 error) {
 	fake.checkPrerequisitesMutex.Lock()
 	defer fake.checkPrerequisitesMutex.Unlock()
@@ -1254,152 +1256,30 @@ func (fake *FakeReleaseImpl) PublishVersionReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeReleaseImpl) PushBranches(arg1 *release.GitObjectPusher, arg2 []string) error {
+func (fake *FakeReleaseImpl) PushRefs(arg1 *release.GitObjectPusher, arg2 []string, arg3 []string) error {
 	var arg2Copy []string
 	if arg2 != nil {
 		arg2Copy = make([]string, len(arg2))
 		copy(arg2Copy, arg2)
 	}
-	fake.pushBranchesMutex.Lock()
-	ret, specificReturn := fake.pushBranchesReturnsOnCall[len(fake.pushBranchesArgsForCall)]
-	fake.pushBranchesArgsForCall = append(fake.pushBranchesArgsForCall, struct {
-		arg1 *release.GitObjectPusher
-		arg2 []string
-	}{arg1, arg2Copy})
-	stub := fake.PushBranchesStub
-	fakeReturns := fake.pushBranchesReturns
-	fake.recordInvocation("PushBranches", []interface{}{arg1, arg2Copy})
-	fake.pushBranchesMutex.Unlock()
-	if stub != nil {
-		return stub(arg1, arg2)
-	}
-	if specificReturn {
-		return ret.result1
-	}
-	return fakeReturns.result1
-}
-
-func (fake *FakeReleaseImpl) PushBranchesCallCount() int {
-	fake.pushBranchesMutex.RLock()
-	defer fake.pushBranchesMutex.RUnlock()
-	return len(fake.pushBranchesArgsForCall)
-}
-
-func (fake *FakeReleaseImpl) PushBranchesCalls(stub func(*release.GitObjectPusher, []string) error) {
-	fake.pushBranchesMutex.Lock()
-	defer fake.pushBranchesMutex.Unlock()
-	fake.PushBranchesStub = stub
-}
-
-func (fake *FakeReleaseImpl) PushBranchesArgsForCall(i int) (*release.GitObjectPusher, []string) {
-	fake.pushBranchesMutex.RLock()
-	defer fake.pushBranchesMutex.RUnlock()
-	argsForCall := fake.pushBranchesArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
-}
-
-func (fake *FakeReleaseImpl) PushBranchesReturns(result1 error) {
-	fake.pushBranchesMutex.Lock()
-	defer fake.pushBranchesMutex.Unlock()
-	fake.PushBranchesStub = nil
-	fake.pushBranchesReturns = struct {
-		result1 error
-	}{result1}
-}
-
-func (fake *FakeReleaseImpl) PushBranchesReturnsOnCall(i int, result1 error) {
-	fake.pushBranchesMutex.Lock()
-	defer fake.pushBranchesMutex.Unlock()
-	fake.PushBranchesStub = nil
-	if fake.pushBranchesReturnsOnCall == nil {
-		fake.pushBranchesReturnsOnCall = make(map[int]struct {
-			result1 error
-		})
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
 	}
-	fake.pushBranchesReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
-}
-
-func (fake *FakeReleaseImpl) PushMainBranch(arg1 *release.GitObjectPusher) error {
-	fake.pushMainBranchMutex.Lock()
-	ret, specificReturn := fake.pushMainBranchReturnsOnCall[len(fake.pushMainBranchArgsForCall)]
-	fake.pushMainBranchArgsForCall = append(fake.pushMainBranchArgsForCall, struct {
-		arg1 *release.GitObjectPusher
-	}{arg1})
-	stub := fake.PushMainBranchStub
-	fakeReturns := fake.pushMainBranchReturns
-	fake.recordInvocation("PushMainBranch", []interface{}{arg1})
-	fake.pushMainBranchMutex.Unlock()
-	if stub != nil {
-		return stub(arg1)
-	}
-	if specificReturn {
-		return ret.result1
-	}
-	return fakeReturns.result1
-}
-
-func (fake *FakeReleaseImpl) PushMainBranchCallCount() int {
-	fake.pushMainBranchMutex.RLock()
-	defer fake.pushMainBranchMutex.RUnlock()
-	return len(fake.pushMainBranchArgsForCall)
-}
-
-func (fake *FakeReleaseImpl) PushMainBranchCalls(stub func(*release.GitObjectPusher) error) {
-	fake.pushMainBranchMutex.Lock()
-	defer fake.pushMainBranchMutex.Unlock()
-	fake.PushMainBranchStub = stub
-}
-
-func (fake *FakeReleaseImpl) PushMainBranchArgsForCall(i int) *release.GitObjectPusher {
-	fake.pushMainBranchMutex.RLock()
-	defer fake.pushMainBranchMutex.RUnlock()
-	argsForCall := fake.pushMainBranchArgsForCall[i]
-	return argsForCall.arg1
-}
-
-func (fake *FakeReleaseImpl) PushMainBranchReturns(result1 error) {
-	fake.pushMainBranchMutex.Lock()
-	defer fake.pushMainBranchMutex.Unlock()
-	fake.PushMainBranchStub = nil
-	fake.pushMainBranchReturns = struct {
-		result1 error
-	}{result1}
-}
-
-func (fake *FakeReleaseImpl) PushMainBranchReturnsOnCall(i int, result1 error) {
-	fake.pushMainBranchMutex.Lock()
-	defer fake.pushMainBranchMutex.Unlock()
-	fake.PushMainBranchStub = nil
-	if fake.pushMainBranchReturnsOnCall == nil {
-		fake.pushMainBranchReturnsOnCall = make(map[int]struct {
-			result1 error
-		})
-	}
-	fake.pushMainBranchReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
-}
-
-func (fake *FakeReleaseImpl) PushTags(arg1 *release.GitObjectPusher, arg2 []string) error {
-	var arg2Copy []string
-	if arg2 != nil {
-		arg2Copy = make([]string, len(arg2))
-		copy(arg2Copy, arg2)
-	}
-	fake.pushTagsMutex.Lock()
-	ret, specificReturn := fake.pushTagsReturnsOnCall[len(fake.pushTagsArgsForCall)]
-	fake.pushTagsArgsForCall = append(fake.pushTagsArgsForCall, struct {
+	fake.pushRefsMutex.Lock()
+	ret, specificReturn := fake.pushRefsReturnsOnCall[len(fake.pushRefsArgsForCall)]
+	fake.pushRefsArgsForCall = append(fake.pushRefsArgsForCall, struct {
 		arg1 *release.GitObjectPusher
 		arg2 []string
-	}{arg1, arg2Copy})
-	stub := fake.PushTagsStub
-	fakeReturns := fake.pushTagsReturns
-	fake.recordInvocation("PushTags", []interface{}{arg1, arg2Copy})
-	fake.pushTagsMutex.Unlock()
+		arg3 []string
+	}{arg1, arg2Copy, arg3Copy})
+	stub := fake.PushRefsStub
+	fakeReturns := fake.pushRefsReturns
+	fake.recordInvocation("PushRefs", []interface{}{arg1, arg2Copy, arg3Copy})
+	fake.pushRefsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1407,44 +1287,44 @@ func (fake *FakeReleaseImpl) PushTags(arg1 *release.GitObjectPusher, arg2 []stri
 	return fakeReturns.result1
 }
 
-func (fake *FakeReleaseImpl) PushTagsCallCount() int {
-	fake.pushTagsMutex.RLock()
-	defer fake.pushTagsMutex.RUnlock()
-	return len(fake.pushTagsArgsForCall)
+func (fake *FakeReleaseImpl) PushRefsCallCount() int {
+	fake.pushRefsMutex.RLock()
+	defer fake.pushRefsMutex.RUnlock()
+	return len(fake.pushRefsArgsForCall)
 }
 
-func (fake *FakeReleaseImpl) PushTagsCalls(stub func(*release.GitObjectPusher, []string) error) {
-	fake.pushTagsMutex.Lock()
-	defer fake.pushTagsMutex.Unlock()
-	fake.PushTagsStub = stub
+func (fake *FakeReleaseImpl) PushRefsCalls(stub func(*release.GitObjectPusher, []string, []string) error) {
+	fake.pushRefsMutex.Lock()
+	defer fake.pushRefsMutex.Unlock()
+	fake.PushRefsStub = stub
 }
 
-func (fake *FakeReleaseImpl) PushTagsArgsForCall(i int) (*release.GitObjectPusher, []string) {
-	fake.pushTagsMutex.RLock()
-	defer fake.pushTagsMutex.RUnlock()
-	argsForCall := fake.pushTagsArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+func (fake *FakeReleaseImpl) PushRefsArgsForCall(i int) (*release.GitObjectPusher, []string, []string) {
+	fake.pushRefsMutex.RLock()
+	defer fake.pushRefsMutex.RUnlock()
+	argsForCall := fake.pushRefsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeReleaseImpl) PushTagsReturns(result1 error) {
-	fake.pushTagsMutex.Lock()
-	defer fake.pushTagsMutex.Unlock()
-	fake.PushTagsStub = nil
-	fake.pushTagsReturns = struct {
+func (fake *FakeReleaseImpl) PushRefsReturns(result1 error) {
+	fake.pushRefsMutex.Lock()
+	defer fake.pushRefsMutex.Unlock()
+	fake.PushRefsStub = nil
+	fake.pushRefsReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeReleaseImpl) PushTagsReturnsOnCall(i int, result1 error) {
-	fake.pushTagsMutex.Lock()
-	defer fake.pushTagsMutex.Unlock()
-	fake.PushTagsStub = nil
-	if fake.pushTagsReturnsOnCall == nil {
-		fake.pushTagsReturnsOnCall = make(map[int]struct {
+func (fake *FakeReleaseImpl) PushRefsReturnsOnCall(i int, result1 error) {
+	fake.pushRefsMutex.Lock()
+	defer fake.pushRefsMutex.Unlock()
+	fake.PushRefsStub = nil
+	if fake.pushRefsReturnsOnCall == nil {
+		fake.pushRefsReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.pushTagsReturnsOnCall[i] = struct {
+	fake.pushRefsReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
@@ -1632,20 +1512,21 @@ func (fake *FakeReleaseImpl) UpdateGitHubPageReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
-func (fake *FakeReleaseImpl) ValidateImages(arg1 string, arg2 string, arg3 string) error {
+func (fake *FakeReleaseImpl) ValidateImages(arg1 string, arg2 string, arg3 string, arg4 *sign.Verifier) error {
 	fake.validateImagesMutex.Lock()
 	ret, specificReturn := fake.validateImagesReturnsOnCall[len(fake.validateImagesArgsForCall)]
 	fake.validateImagesArgsForCall = append(fake.validateImagesArgsForCall, struct {
 		arg1 string
 		arg2 string
 		arg3 string
-	}{arg1, arg2, arg3})
+		arg4 *sign.Verifier
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.ValidateImagesStub
 	fakeReturns := fake.validateImagesReturns
-	fake.recordInvocation("ValidateImages", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("ValidateImages", []interface{}{arg1, arg2, arg3, arg4})
 	fake.validateImagesMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1659,17 +1540,17 @@ func (fake *FakeReleaseImpl) ValidateImagesCallCount() int {
 	return len(fake.validateImagesArgsForCall)
 }
 
-func (fake *FakeReleaseImpl) ValidateImagesCalls(stub func(string, string, string) error) {
+func (fake *FakeReleaseImpl) ValidateImagesCalls(stub func(string, string, string, *sign.Verifier) error) {
 	fake.validateImagesMutex.Lock()
 	defer fake.validateImagesMutex.Unlock()
 	fake.ValidateImagesStub = stub
 }
 
-func (fake *FakeReleaseImpl) ValidateImagesArgsForCall(i int) (string, string, string) {
+func (fake *FakeReleaseImpl) ValidateImagesArgsForCall(i int) (string, string, string, *sign.Verifier) {
 	fake.validateImagesMutex.RLock()
 	defer fake.validateImagesMutex.RUnlock()
 	argsForCall := fake.validateImagesArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeReleaseImpl) ValidateImagesReturns(result1 error) {
@@ -1695,6 +1576,74 @@ func (fake *FakeReleaseImpl) ValidateImagesReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeReleaseImpl) VerifyTags(arg1 *release.TagSignerOptions, arg2 string, arg3 []string) error {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.verifyTagsMutex.Lock()
+	ret, specificReturn := fake.verifyTagsReturnsOnCall[len(fake.verifyTagsArgsForCall)]
+	fake.verifyTagsArgsForCall = append(fake.verifyTagsArgsForCall, struct {
+		arg1 *release.TagSignerOptions
+		arg2 string
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	stub := fake.VerifyTagsStub
+	fakeReturns := fake.verifyTagsReturns
+	fake.recordInvocation("VerifyTags", []interface{}{arg1, arg2, arg3Copy})
+	fake.verifyTagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseImpl) VerifyTagsCallCount() int {
+	fake.verifyTagsMutex.RLock()
+	defer fake.verifyTagsMutex.RUnlock()
+	return len(fake.verifyTagsArgsForCall)
+}
+
+func (fake *FakeReleaseImpl) VerifyTagsCalls(stub func(*release.TagSignerOptions, string, []string) error) {
+	fake.verifyTagsMutex.Lock()
+	defer fake.verifyTagsMutex.Unlock()
+	fake.VerifyTagsStub = stub
+}
+
+func (fake *FakeReleaseImpl) VerifyTagsArgsForCall(i int) (*release.TagSignerOptions, string, []string) {
+	fake.verifyTagsMutex.RLock()
+	defer fake.verifyTagsMutex.RUnlock()
+	argsForCall := fake.verifyTagsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeReleaseImpl) VerifyTagsReturns(result1 error) {
+	fake.verifyTagsMutex.Lock()
+	defer fake.verifyTagsMutex.Unlock()
+	fake.VerifyTagsStub = nil
+	fake.verifyTagsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseImpl) VerifyTagsReturnsOnCall(i int, result1 error) {
+	fake.verifyTagsMutex.Lock()
+	defer fake.verifyTagsMutex.Unlock()
+	fake.VerifyTagsStub = nil
+	if fake.verifyTagsReturnsOnCall == nil {
+		fake.verifyTagsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.verifyTagsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeReleaseImpl) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1728,12 +1677,8 @@ func (fake *FakeReleaseImpl) Invocations() map[string][][]interface{} {
 	defer fake.publishReleaseNotesIndexMutex.RUnlock()
 	fake.publishVersionMutex.RLock()
 	defer fake.publishVersionMutex.RUnlock()
-	fake.pushBranchesMutex.RLock()
-	defer fake.pushBranchesMutex.RUnlock()
-	fake.pushMainBranchMutex.RLock()
-	defer fake.pushMainBranchMutex.RUnlock()
-	fake.pushTagsMutex.RLock()
-	defer fake.pushTagsMutex.RUnlock()
+	fake.pushRefsMutex.RLock()
+	defer fake.pushRefsMutex.RUnlock()
 	fake.submitMutex.RLock()
 	defer fake.submitMutex.RUnlock()
 	fake.toFileMutex.RLock()
@@ -1742,6 +1687,8 @@ func (fake *FakeReleaseImpl) Invocations() map[string][][]interface{} {
 	defer fake.updateGitHubPageMutex.RUnlock()
 	fake.validateImagesMutex.RLock()
 	defer fake.validateImagesMutex.RUnlock()
+	fake.verifyTagsMutex.RLock()
+	defer fake.verifyTagsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
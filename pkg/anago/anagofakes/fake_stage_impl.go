@@ -85,9 +85,10 @@ type FakeStageImpl struct {
 		result1 *spdx.Document
 		result2 error
 	}
-	CheckPrerequisitesStub        func() error
+	CheckPrerequisitesStub        func(*release.TagSignerOptions) error
 	checkPrerequisitesMutex       sync.RWMutex
 	checkPrerequisitesArgsForCall []struct {
+		arg1 *release.TagSignerOptions
 	}
 	checkPrerequisitesReturns struct {
 		result1 error
@@ -422,6 +423,20 @@ type FakeStageImpl struct {
 		result1 string
 		result2 error
 	}
+	SignTagStub        func(*release.TagSignerOptions, string, string, string) error
+	signTagMutex       sync.RWMutex
+	signTagArgsForCall []struct {
+		arg1 *release.TagSignerOptions
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	signTagReturns struct {
+		result1 error
+	}
+	signTagReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StageLocalArtifactsStub        func(*build.Options) error
 	stageLocalArtifactsMutex       sync.RWMutex
 	stageLocalArtifactsArgsForCall []struct {
@@ -762,17 +777,18 @@ func (fake *FakeStageImpl) BuildBaseArtifactsSBOMReturnsOnCall(i int, result1 *s
 	}{result1, result2}
 }
 
-func (fake *FakeStageImpl) CheckPrerequisites() error {
+func (fake *FakeStageImpl) CheckPrerequisites(arg1 *release.TagSignerOptions) error {
 	fake.checkPrerequisitesMutex.Lock()
 	ret, specificReturn := fake.checkPrerequisitesReturnsOnCall[len(fake.checkPrerequisitesArgsForCall)]
 	fake.checkPrerequisitesArgsForCall = append(fake.checkPrerequisitesArgsForCall, struct {
-	}{})
+		arg1 *release.TagSignerOptions
+	}{arg1})
 	stub := fake.CheckPrerequisitesStub
 	fakeReturns := fake.checkPrerequisitesReturns
-	fake.recordInvocation("CheckPrerequisites", []interface{}{})
+	fake.recordInvocation("CheckPrerequisites", []interface{}{arg1})
 	fake.checkPrerequisitesMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -786,12 +802,19 @@ func (fake *FakeStageImpl) CheckPrerequisitesCallCount() int {
 	return len(fake.checkPrerequisitesArgsForCall)
 }
 
-func (fake *FakeStageImpl) CheckPrerequisitesCalls(stub func() error) {
+func (fake *FakeStageImpl) CheckPrerequisitesCalls(stub func(*release.TagSignerOptions) error) {
 	fake.checkPrerequisitesMutex.Lock()
 	defer fake.checkPrerequisitesMutex.Unlock()
 	fake.CheckPrerequisitesStub = stub
 }
 
+func (fake *FakeStageImpl) CheckPrerequisitesArgsForCall(i int) *release.TagSignerOptions {
+	fake.checkPrerequisitesMutex.RLock()
+	defer fake.checkPrerequisitesMutex.RUnlock()
+	argsForCall := fake.checkPrerequisitesArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeStageImpl) CheckPrerequisitesReturns(result1 error) {
 	fake.checkPrerequisitesMutex.Lock()
 	defer fake.checkPrerequisitesMutex.Unlock()
@@ -2413,6 +2436,70 @@ func (fake *FakeStageImpl) RevParseTagReturnsOnCall(i int, result1 string, resul
 	}{result1, result2}
 }
 
+func (fake *FakeStageImpl) SignTag(arg1 *release.TagSignerOptions, arg2 string, arg3 string, arg4 string) error {
+	fake.signTagMutex.Lock()
+	ret, specificReturn := fake.signTagReturnsOnCall[len(fake.signTagArgsForCall)]
+	fake.signTagArgsForCall = append(fake.signTagArgsForCall, struct {
+		arg1 *release.TagSignerOptions
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SignTagStub
+	fakeReturns := fake.signTagReturns
+	fake.recordInvocation("SignTag", []interface{}{arg1, arg2, arg3, arg4})
+	fake.signTagMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageImpl) SignTagCallCount() int {
+	fake.signTagMutex.RLock()
+	defer fake.signTagMutex.RUnlock()
+	return len(fake.signTagArgsForCall)
+}
+
+func (fake *FakeStageImpl) SignTagCalls(stub func(*release.TagSignerOptions, string, string, string) error) {
+	fake.signTagMutex.Lock()
+	defer fake.signTagMutex.Unlock()
+	fake.SignTagStub = stub
+}
+
+func (fake *FakeStageImpl) SignTagArgsForCall(i int) (*release.TagSignerOptions, string, string, string) {
+	fake.signTagMutex.RLock()
+	defer fake.signTagMutex.RUnlock()
+	argsForCall := fake.signTagArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeStageImpl) SignTagReturns(result1 error) {
+	fake.signTagMutex.Lock()
+	defer fake.signTagMutex.Unlock()
+	fake.SignTagStub = nil
+	fake.signTagReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageImpl) SignTagReturnsOnCall(i int, result1 error) {
+	fake.signTagMutex.Lock()
+	defer fake.signTagMutex.Unlock()
+	fake.SignTagStub = nil
+	if fake.signTagReturnsOnCall == nil {
+		fake.signTagReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.signTagReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStageImpl) StageLocalArtifacts(arg1 *build.Options) error {
 	fake.stageLocalArtifactsMutex.Lock()
 	ret, specificReturn := fake.stageLocalArtifactsReturnsOnCall[len(fake.stageLocalArtifactsArgsForCall)]
@@ -2913,6 +3000,8 @@ func (fake *FakeStageImpl) Invocations() map[string][][]interface{} {
 	defer fake.revParseMutex.RUnlock()
 	fake.revParseTagMutex.RLock()
 	defer fake.revParseTagMutex.RUnlock()
+	fake.signTagMutex.RLock()
+	defer fake.signTagMutex.RUnlock()
 	fake.stageLocalArtifactsMutex.RLock()
 	defer fake.stageLocalArtifactsMutex.RUnlock()
 	fake.stageLocalSourceTreeMutex.RLock()
@@ -29,6 +29,7 @@ import (
 	"k8s.io/release/pkg/build"
 	"k8s.io/release/pkg/gcp/gcb"
 	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/sign"
 	"sigs.k8s.io/release-sdk/git"
 	"sigs.k8s.io/release-sdk/object"
 	"sigs.k8s.io/release-utils/log"
@@ -54,7 +55,7 @@ type releaseClient interface {
 	// variable is set. It also checks for the existence and version of
 	// required packages and if the correct Google Cloud project is set. A
 	// basic hardware check will ensure that enough disk space is available,
-	// too.
+	// too. If tag signing is configured, the signing key has to be usable.
 	CheckPrerequisites() error
 
 	// CheckReleaseBranchState discovers if the provided release branch has to
@@ -66,7 +67,8 @@ type releaseClient interface {
 
 	// PrepareWorkspace verifies that the working directory is in the desired
 	// state. This means that the staged sources will be downloaded from the
-	// bucket which should contain a copy of the repository.
+	// bucket which should contain a copy of the repository. The signatures
+	// of the staged tags get verified if tag signing is configured.
 	PrepareWorkspace() error
 
 	// CheckProvenance downloads the artifacts from the staging bucket
@@ -125,7 +127,7 @@ type defaultReleaseImpl struct{}
 type releaseImpl interface {
 	Submit(options *gcb.Options) error
 	ToFile(fileName string) error
-	CheckPrerequisites() error
+	CheckPrerequisites(tagSigning *release.TagSignerOptions) error
 	BranchNeedsCreation(
 		branch, releaseType string, buildVersion semver.Version,
 	) (bool, error)
@@ -137,7 +139,9 @@ type releaseImpl interface {
 	CopyStagedFromGCS(
 		options *build.Options, stagedBucket, buildVersion string,
 	) error
-	ValidateImages(registry, version, buildPath string) error
+	ValidateImages(
+		registry, version, buildPath string, verifier *sign.Verifier,
+	) error
 	PublishVersion(
 		buildType, version, buildDir, bucket, gcsRoot string,
 		versionMarkers []string,
@@ -147,9 +151,8 @@ type releaseImpl interface {
 		options *announce.Options,
 	) error
 	UpdateGitHubPage(options *announce.GitHubPageOptions) error
-	PushTags(pusher *release.GitObjectPusher, tagList []string) error
-	PushBranches(pusher *release.GitObjectPusher, branchList []string) error
-	PushMainBranch(pusher *release.GitObjectPusher) error
+	PushRefs(pusher *release.GitObjectPusher, tagList, branchList []string) error
+	VerifyTags(opts *release.TagSignerOptions, repoPath string, tags []string) error
 	NewGitPusher(opts *release.GitObjectPusherOptions) (*release.GitObjectPusher, error)
 	ArchiveRelease(options *release.ArchiverOptions) error
 	NormalizePath(store object.Store, pathParts ...string) (string, error)
@@ -169,8 +172,13 @@ func (d *defaultReleaseImpl) ToFile(fileName string) error {
 	return log.ToFile(fileName)
 }
 
-func (d *defaultReleaseImpl) CheckPrerequisites() error {
-	return release.NewPrerequisitesChecker().Run(workspaceDir)
+func (d *defaultReleaseImpl) CheckPrerequisites(
+	tagSigning *release.TagSignerOptions,
+) error {
+	checker := release.NewPrerequisitesChecker()
+	checker.Options().CheckSigningKey = tagSigning.Enabled()
+	checker.Options().TagSigning = tagSigning
+	return checker.Run(workspaceDir)
 }
 
 func (d *defaultReleaseImpl) BranchNeedsCreation(
@@ -214,9 +222,13 @@ func (d *defaultReleaseImpl) CopyStagedFromGCS(
 }
 
 func (d *defaultReleaseImpl) ValidateImages(
-	registry, version, buildPath string,
+	registry, version, buildPath string, verifier *sign.Verifier,
 ) error {
-	return release.NewImages().Validate(registry, version, buildPath)
+	images := release.NewImages()
+	if verifier != nil {
+		images.SetVerifier(verifier)
+	}
+	return images.Validate(registry, version, buildPath)
 }
 
 func (d *defaultReleaseImpl) PublishVersion(
@@ -271,21 +283,22 @@ func (d *defaultReleaseImpl) UpdateGitHubPage(options *announce.GitHubPageOption
 	return announce.UpdateGitHubPage(options)
 }
 
-func (d *defaultReleaseImpl) PushTags(
-	pusher *release.GitObjectPusher, tagList []string,
+func (d *defaultReleaseImpl) PushRefs(
+	pusher *release.GitObjectPusher, tagList, branchList []string,
 ) error {
-	return pusher.PushTags(tagList)
+	return pusher.PushRefs(tagList, branchList)
 }
 
-func (d *defaultReleaseImpl) PushBranches(
-	pusher *release.GitObjectPusher, branchList []string,
+func (d *defaultReleaseImpl) VerifyTags(
+	opts *release.TagSignerOptions, repoPath string, tags []string,
 ) error {
-	return pusher.PushBranches(branchList)
-}
+	signer := release.NewTagSigner(opts)
+	defer signer.Cleanup()
 
-func (d *defaultReleaseImpl) PushMainBranch(pusher *release.GitObjectPusher) error {
-	if err := pusher.PushMain(); err != nil {
-		return errors.Wrap(err, "pushing changes in main branch")
+	for _, tag := range tags {
+		if err := signer.Verify(repoPath, tag); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -333,7 +346,7 @@ func (d *DefaultRelease) ValidateOptions() error {
 }
 
 func (d *DefaultRelease) CheckPrerequisites() error {
-	return d.impl.CheckPrerequisites()
+	return d.impl.CheckPrerequisites(d.options.TagSigning)
 }
 
 func (d *DefaultRelease) CheckReleaseBranchState() error {
@@ -370,12 +383,30 @@ func (d *DefaultRelease) PrepareWorkspace() error {
 	); err != nil {
 		return errors.Wrap(err, "prepare workspace")
 	}
+
+	// The staged tags are available after restoring the repository, verify
+	// them before publishing any artifacts
+	if d.options.TagSigning.Enabled() {
+		if err := d.impl.VerifyTags(
+			d.options.TagSigning, gitRoot, d.state.versions.Ordered(),
+		); err != nil {
+			return errors.Wrap(err, "verifying tag signatures")
+		}
+	}
 	return nil
 }
 
 func (d *DefaultRelease) PushArtifacts() error {
 	const gcsRoot = "release"
 
+	verifier, err := d.options.ImageVerifier()
+	if err != nil {
+		return err
+	}
+	if verifier == nil {
+		logrus.Warn("No image verification key set, skipping image signature verification")
+	}
+
 	for _, version := range d.state.versions.Ordered() {
 		logrus.Infof("Pushing artifacts for version %s", version)
 		buildDir := filepath.Join(
@@ -390,6 +421,8 @@ func (d *DefaultRelease) PushArtifacts() error {
 			Version:                    version,
 			AllowDup:                   true,
 			ValidateRemoteImageDigests: true,
+			ImageSigningKey:            d.options.ImageSigningKey,
+			ImageVerificationKey:       d.options.ImageVerificationKey,
 		}
 		if err := d.impl.CheckReleaseBucket(pushBuildOptions); err != nil {
 			return errors.Wrap(err, "check release bucket access")
@@ -412,7 +445,7 @@ func (d *DefaultRelease) PushArtifacts() error {
 		// Image promotion has been done on nomock stage, verify that the
 		// images are available.
 		if err := d.impl.ValidateImages(
-			targetRegistry, version, buildDir,
+			targetRegistry, version, buildDir, verifier,
 		); err != nil {
 			return errors.Wrap(err, "validate container images")
 		}
@@ -469,8 +502,9 @@ func (d *DefaultRelease) PushArtifacts() error {
 }
 
 // PushGitObjects uploads to the remote repository the release's tags and branches.
-// Internally, this function calls the release implementation's PushTags,
-// PushBranches and PushMainBranch methods
+// Internally, this function calls the release implementation's PushRefs
+// method. The tags, the release branch and the main branch are pushed in a
+// single atomic push, which can be resumed if it fails.
 func (d *DefaultRelease) PushGitObjects() error {
 	// Build the git object pusher
 	pusher, err := d.impl.NewGitPusher(
@@ -483,34 +517,35 @@ func (d *DefaultRelease) PushGitObjects() error {
 		return errors.Wrap(err, "getting git pusher from the release implementation")
 	}
 
-	// The list of tags to be pushed to the remote repository.
-	// These come from the versions object created during
-	// GenerateReleaseVersion()
-	if err := d.impl.PushTags(pusher, d.state.versions.Ordered()); err != nil {
-		return errors.Wrap(err, "pushing release tags")
-	}
-
-	// Determine which branches have to be pushed, except main
-	// which gets pushed at the end by itself
+	// Determine which branches have to be pushed. The main branch contains
+	// the changelog and has to be updated together with the tags.
 	branchList := []string{}
 	if d.options.ReleaseBranch != git.DefaultBranch {
 		branchList = append(branchList, d.options.ReleaseBranch)
 	}
+	branchList = append(branchList, git.DefaultBranch)
 
-	// Call the release imprementation PushBranches() method
-	if err := d.impl.PushBranches(pusher, branchList); err != nil {
-		return errors.Wrap(err, "pushing branches to the remote repository")
+	// Signed tags have to be verified before they get published
+	if d.options.TagSigning.Enabled() {
+		if err := d.impl.VerifyTags(
+			d.options.TagSigning, gitRoot, d.state.versions.Ordered(),
+		); err != nil {
+			return errors.Wrap(err, "verifying tag signatures")
+		}
 	}
 
-	// For files created on master with new branches and
-	// for $CHANGELOG_FILEPATH, update the main branch
-	if err := d.impl.PushMainBranch(pusher); err != nil {
-		return errors.Wrap(err, "pushing changes in main branch")
+	// The list of tags to be pushed to the remote repository.
+	// These come from the versions object created during
+	// GenerateReleaseVersion()
+	if err := d.impl.PushRefs(
+		pusher, d.state.versions.Ordered(), branchList,
+	); err != nil {
+		return errors.Wrap(err, "pushing release tags and branches")
 	}
 
 	logrus.Infof(
-		"Git objects push complete (%d branches, %d tags & main branch)",
-		len(d.state.versions.Ordered()), len(branchList),
+		"Git objects push complete (%d branches & %d tags)",
+		len(branchList), len(d.state.versions.Ordered()),
 	)
 	return nil
 }
@@ -18,12 +18,20 @@ limitations under the License.
 package anago_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"k8s.io/release/pkg/anago"
 	"k8s.io/release/pkg/anago/anagofakes"
 	"k8s.io/release/pkg/release"
+	"sigs.k8s.io/release-sdk/git"
 )
 
 func generateTestingReleaseState(params *testStateParameters) *anago.ReleaseState {
@@ -102,6 +110,7 @@ func TestCheckPrerequisitesRelease(t *testing.T) {
 		sut.SetImpl(mock)
 
 		err := sut.CheckPrerequisites()
+		require.Equal(t, opts.TagSigning, mock.CheckPrerequisitesArgsForCall(0))
 		if tc.shouldError {
 			require.NotNil(t, err)
 		} else {
@@ -260,9 +269,57 @@ func TestPushArtifacts(t *testing.T) {
 	}
 }
 
+func TestPushArtifactsImageVerification(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	pkix, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.Nil(t, err)
+	publicKey := filepath.Join(t.TempDir(), "cosign.pub")
+	require.Nil(t, os.WriteFile(
+		publicKey,
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkix}),
+		os.FileMode(0o600),
+	))
+
+	for _, tc := range []struct {
+		verificationKey string
+		shouldVerify    bool
+		shouldError     bool
+	}{
+		{verificationKey: "", shouldVerify: false},
+		{verificationKey: publicKey, shouldVerify: true},
+		{verificationKey: filepath.Join(t.TempDir(), "missing.pub"), shouldError: true},
+	} {
+		opts := anago.DefaultReleaseOptions()
+		opts.ImageSigningKey = ""
+		opts.ImageVerificationKey = tc.verificationKey
+		sut := anago.NewDefaultRelease(opts)
+		sut.SetState(
+			generateTestingReleaseState(&testStateParameters{versionsTag: &testVersionTag}),
+		)
+		mock := &anagofakes.FakeReleaseImpl{}
+		sut.SetImpl(mock)
+
+		err := sut.PushArtifacts()
+		if tc.shouldError {
+			require.NotNil(t, err)
+			require.Zero(t, mock.ValidateImagesCallCount())
+			continue
+		}
+		require.Nil(t, err)
+
+		require.Equal(t, 1, mock.ValidateImagesCallCount())
+		_, _, _, verifier := mock.ValidateImagesArgsForCall(0)
+		require.Equal(t, tc.shouldVerify, verifier != nil)
+		pushOptions, _, _ := mock.CopyStagedFromGCSArgsForCall(0)
+		require.Equal(t, tc.verificationKey, pushOptions.ImageVerificationKey)
+	}
+}
+
 func TestPrepareWorkspaceRelease(t *testing.T) {
 	for _, tc := range []struct {
 		prepare     func(*anagofakes.FakeReleaseImpl)
+		signTags    bool
 		shouldError bool
 	}{
 		{ // success
@@ -275,13 +332,40 @@ func TestPrepareWorkspaceRelease(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{ // success with signed tags
+			prepare:     func(*anagofakes.FakeReleaseImpl) {},
+			signTags:    true,
+			shouldError: false,
+		},
+		{ // Verifying the tag signatures fails
+			prepare: func(mock *anagofakes.FakeReleaseImpl) {
+				mock.VerifyTagsReturns(err)
+			},
+			signTags:    true,
+			shouldError: true,
+		},
 	} {
 		opts := anago.DefaultReleaseOptions()
+		opts.TagSigning = &release.TagSignerOptions{}
+		if tc.signTags {
+			opts.TagSigning.Format = release.TagSigningFormatSSH
+			opts.TagSigning.KeyFile = "key"
+		}
 		sut := anago.NewDefaultRelease(opts)
+		sut.SetState(
+			generateTestingReleaseState(&testStateParameters{versionsTag: &testVersionTag}),
+		)
 		mock := &anagofakes.FakeReleaseImpl{}
 		tc.prepare(mock)
 		sut.SetImpl(mock)
 		err := sut.PrepareWorkspace()
+		if tc.signTags {
+			require.Equal(t, 1, mock.VerifyTagsCallCount())
+			_, _, tags := mock.VerifyTagsArgsForCall(0)
+			require.Equal(t, []string{testVersionTag}, tags)
+		} else {
+			require.Zero(t, mock.VerifyTagsCallCount())
+		}
 		if tc.shouldError {
 			require.NotNil(t, err)
 		} else {
@@ -356,38 +440,44 @@ func TestCreateAnnouncement(t *testing.T) {
 func TestPushGitObjects(t *testing.T) {
 	for _, tc := range []struct {
 		prepare     func(*anagofakes.FakeReleaseImpl)
+		signTags    bool
 		shouldError bool
 	}{
 		{ // success
 			prepare:     func(*anagofakes.FakeReleaseImpl) {},
 			shouldError: false,
 		},
-		{ // Pushing list of branches fails
+		{ // Pushing the tags and branches fails
 			prepare: func(mock *anagofakes.FakeReleaseImpl) {
-				mock.PushBranchesReturns(err)
+				mock.PushRefsReturns(err)
 			},
 			shouldError: true,
 		},
-		{ // Pushing list of tags fails
+		{ // Creating the git pusher object fails
 			prepare: func(mock *anagofakes.FakeReleaseImpl) {
-				mock.PushTagsReturns(err)
+				mock.NewGitPusherReturns(nil, err)
 			},
 			shouldError: true,
 		},
-		{ // Pushing the main branch fails
-			prepare: func(mock *anagofakes.FakeReleaseImpl) {
-				mock.PushMainBranchReturns(err)
-			},
-			shouldError: true,
+		{ // success with signed tags
+			prepare:     func(*anagofakes.FakeReleaseImpl) {},
+			signTags:    true,
+			shouldError: false,
 		},
-		{ // Creating the git pusher object fails
+		{ // Verifying the tag signatures fails
 			prepare: func(mock *anagofakes.FakeReleaseImpl) {
-				mock.NewGitPusherReturns(nil, err)
+				mock.VerifyTagsReturns(err)
 			},
+			signTags:    true,
 			shouldError: true,
 		},
 	} {
 		opts := anago.DefaultReleaseOptions()
+		opts.TagSigning = &release.TagSignerOptions{}
+		if tc.signTags {
+			opts.TagSigning.Format = release.TagSigningFormatSSH
+			opts.TagSigning.KeyFile = "key"
+		}
 		sut := anago.NewDefaultRelease(opts)
 		sut.SetState(
 			generateTestingReleaseState(&testStateParameters{versionsTag: &testVersionTag}),
@@ -396,6 +486,15 @@ func TestPushGitObjects(t *testing.T) {
 		tc.prepare(mock)
 		sut.SetImpl(mock)
 		err := sut.PushGitObjects()
+		if mock.PushRefsCallCount() > 0 {
+			_, _, branches := mock.PushRefsArgsForCall(0)
+			require.Contains(t, branches, git.DefaultBranch)
+		}
+		if tc.signTags {
+			require.Equal(t, 1, mock.VerifyTagsCallCount())
+		} else {
+			require.Zero(t, mock.VerifyTagsCallCount())
+		}
 		if tc.shouldError {
 			require.NotNil(t, err)
 		} else {
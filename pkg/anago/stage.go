@@ -32,11 +32,13 @@ import (
 	"k8s.io/release/pkg/build"
 	"k8s.io/release/pkg/changelog"
 	"k8s.io/release/pkg/gcp/gcb"
+	"k8s.io/release/pkg/kubecross"
 	"k8s.io/release/pkg/provenance"
 	"k8s.io/release/pkg/release"
 	"k8s.io/release/pkg/spdx"
 	"sigs.k8s.io/release-sdk/git"
 	"sigs.k8s.io/release-utils/log"
+	"sigs.k8s.io/release-utils/util"
 )
 
 // stageClient is a client for staging releases.
@@ -58,7 +60,7 @@ type stageClient interface {
 	// variable is set. It also checks for the existence and version of
 	// required packages and if the correct Google Cloud project is set. A
 	// basic hardware check will ensure that enough disk space is available,
-	// too.
+	// too. If tag signing is configured, the signing key has to be usable.
 	CheckPrerequisites() error
 
 	// CheckReleaseBranchState discovers if the provided release branch has to
@@ -133,7 +135,7 @@ type defaultStageImpl struct{}
 type stageImpl interface {
 	Submit(options *gcb.Options) error
 	ToFile(fileName string) error
-	CheckPrerequisites() error
+	CheckPrerequisites(tagSigning *release.TagSignerOptions) error
 	BranchNeedsCreation(
 		branch, releaseType string, buildVersion semver.Version,
 	) (bool, error)
@@ -148,6 +150,7 @@ type stageImpl interface {
 	CurrentBranch(repo *git.Repo) (string, error)
 	CommitEmpty(repo *git.Repo, msg string) error
 	Tag(repo *git.Repo, name, message string) error
+	SignTag(opts *release.TagSignerOptions, repoPath, name, message string) error
 	Merge(repo *git.Repo, rev string) error
 	CheckReleaseBucket(options *build.Options) error
 	DockerHubLogin() error
@@ -186,8 +189,13 @@ func (d *defaultStageImpl) ToFile(fileName string) error {
 	return log.ToFile(fileName)
 }
 
-func (d *defaultStageImpl) CheckPrerequisites() error {
-	return release.NewPrerequisitesChecker().Run(workspaceDir)
+func (d *defaultStageImpl) CheckPrerequisites(
+	tagSigning *release.TagSignerOptions,
+) error {
+	checker := release.NewPrerequisitesChecker()
+	checker.Options().CheckSigningKey = tagSigning.Enabled()
+	checker.Options().TagSigning = tagSigning
+	return checker.Run(workspaceDir)
 }
 
 func (d *defaultStageImpl) BranchNeedsCreation(
@@ -241,6 +249,18 @@ func (d *defaultStageImpl) Tag(repo *git.Repo, name, message string) error {
 	return repo.Tag(name, message)
 }
 
+func (d *defaultStageImpl) SignTag(
+	opts *release.TagSignerOptions, repoPath, name, message string,
+) error {
+	signer := release.NewTagSigner(opts)
+	defer signer.Cleanup()
+
+	if err := signer.Sign(repoPath, name, message); err != nil {
+		return err
+	}
+	return signer.Verify(repoPath, name)
+}
+
 func (d *defaultStageImpl) Merge(repo *git.Repo, rev string) error {
 	return repo.Merge(rev)
 }
@@ -298,6 +318,7 @@ func (d *DefaultStage) Submit(stream bool) error {
 	options.NoMock = d.options.NoMock
 	options.Branch = d.options.ReleaseBranch
 	options.ReleaseType = d.options.ReleaseType
+	options.BuildVersion = d.options.BuildVersion
 	return d.impl.Submit(options)
 }
 
@@ -324,8 +345,9 @@ func (d *defaultStageImpl) VerifyArtifacts(versions []string) error {
 	// the produced artifacts.
 	checker := release.NewArtifactCheckerWithOptions(
 		&release.ArtifactCheckerOptions{
-			GitRoot:  gitRoot,
-			Versions: versions,
+			GitRoot:   gitRoot,
+			Versions:  versions,
+			GoVersion: kubeCrossGoVersion(versions),
 		},
 	)
 
@@ -334,9 +356,50 @@ func (d *defaultStageImpl) VerifyArtifacts(versions []string) error {
 		return errors.Wrap(err, "checking binary architectures")
 	}
 
+	// Ensure binaries are built according to the release policy
+	if err := checker.CheckBinaryBuildInfo(); err != nil {
+		return errors.Wrap(err, "checking binary build info")
+	}
+
+	// Ensure the tarballs contain all expected files
+	if err := checker.CheckTarballContents(); err != nil {
+		return errors.Wrap(err, "checking tarball contents")
+	}
+
 	return nil
 }
 
+// kubeCrossGoVersion returns the Go version of the kube-cross image used to
+// build the versions. An empty string is returned if the version cannot be
+// determined, which skips the Go version check.
+func kubeCrossGoVersion(versions []string) string {
+	if len(versions) == 0 {
+		return ""
+	}
+	kc := kubecross.New()
+
+	goVersion := ""
+	if tag, err := util.TagStringToSemver(versions[0]); err == nil {
+		goVersion, err = kc.GoVersionForBranch(
+			fmt.Sprintf("release-%d.%d", tag.Major, tag.Minor),
+		)
+		if err != nil {
+			logrus.Infof("Falling back to latest kube-cross Go version: %v", err)
+		}
+	}
+	if goVersion == "" {
+		latest, err := kc.Latest()
+		if err == nil {
+			goVersion, err = kubecross.GoVersion(latest)
+		}
+		if err != nil {
+			logrus.Warnf("Skipping Go version check of binaries: %v", err)
+			return ""
+		}
+	}
+	return goVersion
+}
+
 func (d *DefaultStage) InitLogFile() error {
 	logrus.SetFormatter(
 		&logrus.TextFormatter{FullTimestamp: true, ForceColors: true},
@@ -362,7 +425,7 @@ func (d *DefaultStage) ValidateOptions() error {
 }
 
 func (d *DefaultStage) CheckPrerequisites() error {
-	return d.impl.CheckPrerequisites()
+	return d.impl.CheckPrerequisites(d.options.TagSigning)
 }
 
 func (d *DefaultStage) CheckReleaseBranchState() error {
@@ -521,13 +584,16 @@ func (d *DefaultStage) TagRepository() error {
 
 		// Tag the repository:
 		logrus.Infof("Tagging version %s", version)
-		if err := d.impl.Tag(
-			repo,
-			version,
-			fmt.Sprintf(
-				"Kubernetes %s release %s", d.options.ReleaseType, version,
-			),
-		); err != nil {
+		message := fmt.Sprintf(
+			"Kubernetes %s release %s", d.options.ReleaseType, version,
+		)
+		if d.options.TagSigning.Enabled() {
+			if err := d.impl.SignTag(
+				d.options.TagSigning, gitRoot, version, message,
+			); err != nil {
+				return errors.Wrap(err, "sign tag version")
+			}
+		} else if err := d.impl.Tag(repo, version, message); err != nil {
 			return errors.Wrap(err, "tag version")
 		}
 
@@ -779,6 +845,8 @@ func (d *DefaultStage) StageArtifacts() error {
 		Registry:                   d.options.ContainerRegistry(),
 		AllowDup:                   true,
 		ValidateRemoteImageDigests: true,
+		ImageSigningKey:            d.options.ImageSigningKey,
+		ImageVerificationKey:       d.options.ImageVerificationKey,
 	}
 	if err := d.impl.CheckReleaseBucket(pushBuildOptions); err != nil {
 		return errors.Wrap(err, "check release bucket access")
@@ -106,6 +106,7 @@ func TestCheckPrerequisitesStage(t *testing.T) {
 		sut.SetImpl(mock)
 
 		err := sut.CheckPrerequisites()
+		require.Equal(t, opts.TagSigning, mock.CheckPrerequisitesArgsForCall(0))
 		if tc.shouldError {
 			require.NotNil(t, err)
 		} else {
@@ -237,6 +238,7 @@ func TestTagRepository(t *testing.T) {
 		versions            *release.Versions
 		releaseBranch       string
 		createReleaseBranch bool
+		signTags            bool
 		shouldError         bool
 	}{
 		{ // success new rc creating release branch
@@ -249,6 +251,30 @@ func TestTagRepository(t *testing.T) {
 			createReleaseBranch: true,
 			shouldError:         false,
 		},
+		{ // success new signed rc creating release branch
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.RevParseTagReturns("", err)
+				mock.CurrentBranchReturnsOnCall(0, "release-1.20", nil)
+				mock.TagReturns(err)
+			},
+			versions:            newRCVersions,
+			releaseBranch:       "release-1.20",
+			createReleaseBranch: true,
+			signTags:            true,
+			shouldError:         false,
+		},
+		{ // failure on SignTag new rc creating release branch
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.RevParseTagReturns("", err)
+				mock.CurrentBranchReturnsOnCall(0, "release-1.20", nil)
+				mock.SignTagReturns(err)
+			},
+			versions:            newRCVersions,
+			releaseBranch:       "release-1.20",
+			createReleaseBranch: true,
+			signTags:            true,
+			shouldError:         true,
+		},
 		{ // failure on CommitEmpty new rc creating release branch
 			prepare: func(mock *anagofakes.FakeStageImpl) {
 				mock.RevParseTagReturns("", err)
@@ -367,6 +393,11 @@ func TestTagRepository(t *testing.T) {
 		opts := anago.DefaultStageOptions()
 		opts.BuildVersion = "v1.20.0-beta.1.358+4628c605aadb9b"
 		opts.ReleaseBranch = tc.releaseBranch
+		opts.TagSigning = &release.TagSignerOptions{}
+		if tc.signTags {
+			opts.TagSigning.Format = release.TagSigningFormatSSH
+			opts.TagSigning.KeyFile = "key"
+		}
 		state := anago.DefaultState()
 		err := opts.Validate(state)
 		require.Nil(t, err)
@@ -569,6 +600,27 @@ func TestStageArtifacts(t *testing.T) {
 	}
 }
 
+func TestStageArtifactsImageSigning(t *testing.T) {
+	opts := anago.DefaultStageOptions()
+	opts.ImageSigningKey = "/path/to/cosign.key"
+	opts.ImageVerificationKey = "/path/to/cosign.pub"
+	sut := anago.NewDefaultStage(opts)
+	mock := &anagofakes.FakeStageImpl{}
+	mock.GenerateAttestationReturns(provenance.NewSLSAStatement(), nil)
+	sut.SetImpl(mock)
+	sut.SetState(
+		generateTestingStageState(
+			&testStateParameters{versionsTag: &testVersionTag},
+		),
+	)
+
+	require.Nil(t, sut.StageArtifacts())
+	require.Equal(t, 1, mock.PushContainerImagesCallCount())
+	pushOptions := mock.PushContainerImagesArgsForCall(0)
+	require.Equal(t, "/path/to/cosign.key", pushOptions.ImageSigningKey)
+	require.Equal(t, "/path/to/cosign.pub", pushOptions.ImageVerificationKey)
+}
+
 func TestSubmitStageImpl(t *testing.T) {
 	for _, tc := range []struct {
 		prepare     func(*anagofakes.FakeStageImpl)
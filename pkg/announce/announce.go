@@ -32,8 +32,13 @@ import (
 )
 
 const (
-	announcementFile = "announcement.html"
-	subjectFile      = "announcement-subject.txt"
+	// AnnouncementFile is the name of the file containing the HTML
+	// announcement, written to the working directory.
+	AnnouncementFile = "announcement.html"
+
+	// SubjectFile is the name of the file containing the announcement mail
+	// subject, written to the working directory.
+	SubjectFile = "announcement-subject.txt"
 )
 
 const branchAnnouncement = `Kubernetes Community,
@@ -134,7 +139,7 @@ func CreateForRelease(opts *Options) error {
 }
 
 func create(workDir, subject, message string) error {
-	subjectFile := filepath.Join(workDir, subjectFile)
+	subjectFile := filepath.Join(workDir, SubjectFile)
 	//nolint:gosec // TODO(gosec): G306: Expect WriteFile permissions to be
 	// 0600 or less
 	if err := os.WriteFile(
@@ -146,7 +151,7 @@ func create(workDir, subject, message string) error {
 	}
 	logrus.Debugf("Wrote file %s", subjectFile)
 
-	announcementFile := filepath.Join(workDir, announcementFile)
+	announcementFile := filepath.Join(workDir, AnnouncementFile)
 	//nolint:gosec // TODO(gosec): G306: Expect WriteFile permissions to be
 	// 0600 or less
 	if err := os.WriteFile(
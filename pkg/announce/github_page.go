@@ -18,14 +18,17 @@ package announce
 
 import (
 	"bytes"
+	"context"
 	"html/template"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
+	gogithub "github.com/google/go-github/v39/github"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
 
 	"sigs.k8s.io/release-sdk/git"
 	"sigs.k8s.io/release-sdk/github"
@@ -259,6 +262,66 @@ func UpdateGitHubPage(opts *GitHubPageOptions) (err error) {
 	return nil
 }
 
+// yankNotice is prepended to the body of yanked release pages
+const yankNotice = "> **This release has been yanked and must not be used.**\n\n"
+
+// YankGitHubPage marks the release page of tag as yanked by converting it
+// into a draft with a notice on top of its body. If `remove` is set, the
+// release page gets deleted instead. The git tag is left untouched in both
+// cases.
+func YankGitHubPage(owner, repo, tag string, remove bool) error {
+	token := os.Getenv(github.TokenEnvKey)
+	if token == "" {
+		return errors.New("cannot yank release page without a GitHub token")
+	}
+
+	gh := github.New()
+	releases, err := gh.Releases(owner, repo, true)
+	if err != nil {
+		return errors.Wrap(err, "listing the repositories releases")
+	}
+
+	var release *gogithub.RepositoryRelease
+	for _, r := range releases {
+		if r.GetTagName() == tag {
+			release = r
+			break
+		}
+	}
+	if release == nil {
+		logrus.Infof("No release page found for %s, nothing to yank", tag)
+		return nil
+	}
+
+	if remove {
+		logrus.Infof("Deleting release page %d for %s", release.GetID(), tag)
+		client := gogithub.NewClient(oauth2.NewClient(
+			context.Background(),
+			oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
+		))
+		if _, err := client.Repositories.DeleteRelease(
+			context.Background(), owner, repo, release.GetID(),
+		); err != nil {
+			return errors.Wrapf(err, "deleting the %s release page", tag)
+		}
+		return nil
+	}
+
+	body := release.GetBody()
+	if !strings.HasPrefix(body, yankNotice) {
+		body = yankNotice + body
+	}
+	logrus.Infof("Marking release page %d for %s as yanked", release.GetID(), tag)
+	if _, err := gh.UpdateReleasePage(
+		owner, repo, release.GetID(),
+		tag, release.GetTargetCommitish(), release.GetName(), body,
+		true, release.GetPrerelease(),
+	); err != nil {
+		return errors.Wrapf(err, "marking the %s release page as yanked", tag)
+	}
+	return nil
+}
+
 // processAssetFiles reads the command line strings and returns
 // a map holding the needed info from the asset files
 func processAssetFiles(assetFiles []string) (releaseAssets []map[string]string, err error) {
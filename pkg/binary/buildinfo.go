@@ -0,0 +1,347 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binary
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// buildInfoMagic marks the start of the build info blob written by the
+	// Go linker
+	buildInfoMagic = "\xff Go buildinf:"
+
+	// buildInfoAlign is the alignment of the build info blob
+	buildInfoAlign = 16
+
+	// buildInfoSize is the size of the build info header
+	buildInfoSize = 32
+
+	// buildInfoMaxSearch limits the bytes scanned for the build info blob
+	buildInfoMaxSearch = 64 * 1024
+
+	// Flags of the build info header
+	buildInfoFlagBigEndian  = 0x1
+	buildInfoFlagVersionInl = 0x2
+)
+
+// Keys of the build settings recorded by the Go toolchain
+const (
+	BuildSettingLDFlags     = "-ldflags"
+	BuildSettingTrimPath    = "-trimpath"
+	BuildSettingCGOEnabled  = "CGO_ENABLED"
+	BuildSettingVCSModified = "vcs.modified"
+)
+
+// BuildInfo is the Go build information embedded into a binary
+type BuildInfo struct {
+	// GoVersion is the version of the toolchain that built the binary,
+	// for example "go1.17.3"
+	GoVersion string
+
+	// Path is the package path of the main package
+	Path string
+
+	// Module is the path of the main module
+	Module string
+
+	// Settings are the build settings of the binary. They are only recorded
+	// by Go 1.18 and later, nil otherwise.
+	Settings map[string]string
+}
+
+// HasSettings returns true if the binary records its build settings
+func (b *BuildInfo) HasSettings() bool {
+	return b.Settings != nil
+}
+
+// HasLDFlags returns true if the linker flags are recorded, which is not the
+// case for binaries built with -trimpath
+func (b *BuildInfo) HasLDFlags() bool {
+	_, ok := b.Settings[BuildSettingLDFlags]
+	return ok
+}
+
+// LDFlags returns the linker flags the binary was built with
+func (b *BuildInfo) LDFlags() string {
+	return b.Settings[BuildSettingLDFlags]
+}
+
+// CGOEnabled returns true if the binary was built with cgo enabled
+func (b *BuildInfo) CGOEnabled() bool {
+	return b.Settings[BuildSettingCGOEnabled] == "1"
+}
+
+// TrimPath returns true if the binary was built with -trimpath
+func (b *BuildInfo) TrimPath() bool {
+	return b.Settings[BuildSettingTrimPath] == "true"
+}
+
+// VCSModified returns true if the binary was built from a modified tree
+func (b *BuildInfo) VCSModified() bool {
+	return b.Settings[BuildSettingVCSModified] == "true"
+}
+
+// VersionVariables returns the variables set by `-X` in the linker flags,
+// keyed by their fully qualified name.
+func (b *BuildInfo) VersionVariables() map[string]string {
+	res := map[string]string{}
+	fields := splitFlags(b.LDFlags())
+	for i := 0; i < len(fields); i++ {
+		var definition string
+		switch {
+		case fields[i] == "-X" && i+1 < len(fields):
+			i++
+			definition = fields[i]
+		case strings.HasPrefix(fields[i], "-X="):
+			definition = strings.TrimPrefix(fields[i], "-X=")
+		default:
+			continue
+		}
+		parts := strings.SplitN(definition, "=", 2)
+		if len(parts) == 2 {
+			res[parts[0]] = parts[1]
+		}
+	}
+	return res
+}
+
+// BuildInfo reads the Go build information of the binary
+func (b *Binary) BuildInfo() (*BuildInfo, error) {
+	return ReadBuildInfo(b.options.Path)
+}
+
+// ReadBuildInfo reads the Go build information of the ELF, Mach-O or PE
+// executable at the path.
+func ReadBuildInfo(path string) (*BuildInfo, error) {
+	exe, err := openExe(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening executable %s", path)
+	}
+	defer exe.Close()
+
+	goVersion, mod, err := readBuildInfoStrings(exe)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading build info from %s", path)
+	}
+
+	info, err := parseModInfo(mod)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing module info of %s", path)
+	}
+	info.GoVersion = goVersion
+	return info, nil
+}
+
+// readBuildInfoStrings locates the build info blob of the executable and
+// returns the Go version and the raw module info.
+func readBuildInfoStrings(x exe) (goVersion, mod string, err error) {
+	data, err := x.ReadData(x.DataStart(), buildInfoMaxSearch)
+	if err != nil {
+		return "", "", errors.Wrap(err, "reading data section")
+	}
+
+	for {
+		i := bytes.Index(data, []byte(buildInfoMagic))
+		if i < 0 || len(data)-i < buildInfoSize {
+			return "", "", errors.New("not a Go executable")
+		}
+		if i%buildInfoAlign == 0 && len(data)-i >= buildInfoSize {
+			data = data[i:]
+			break
+		}
+		data = data[(i+buildInfoAlign-1)&^(buildInfoAlign-1):]
+	}
+
+	ptrSize := int(data[14])
+	flags := data[15]
+	if flags&buildInfoFlagVersionInl != 0 {
+		// Go 1.18 and later store the strings inline after the header
+		var ok bool
+		goVersion, data, ok = decodeString(data[buildInfoSize:])
+		if !ok {
+			return "", "", errors.New("malformed Go version")
+		}
+		mod, _, ok = decodeString(data)
+		if !ok {
+			return "", "", errors.New("malformed module info")
+		}
+	} else {
+		// Older versions store pointers to the string headers
+		var bo binary.ByteOrder = binary.LittleEndian
+		if flags&buildInfoFlagBigEndian != 0 {
+			bo = binary.BigEndian
+		}
+		readPtr, err := ptrReader(ptrSize, bo)
+		if err != nil {
+			return "", "", err
+		}
+		goVersion = readStringAt(x, ptrSize, readPtr, readPtr(data[16:]))
+		mod = readStringAt(x, ptrSize, readPtr, readPtr(data[16+ptrSize:]))
+	}
+
+	if goVersion == "" {
+		return "", "", errors.New("not a Go executable")
+	}
+
+	// The module info is surrounded by 16 byte sentinels
+	if len(mod) >= 33 && mod[len(mod)-17] == '\n' {
+		mod = mod[16 : len(mod)-16]
+	} else {
+		mod = ""
+	}
+	return goVersion, mod, nil
+}
+
+func ptrReader(ptrSize int, bo binary.ByteOrder) (func([]byte) uint64, error) {
+	switch ptrSize {
+	case 4:
+		return func(b []byte) uint64 { return uint64(bo.Uint32(b)) }, nil
+	case 8:
+		return bo.Uint64, nil
+	}
+	return nil, errors.Errorf("unsupported pointer size %d", ptrSize)
+}
+
+// decodeString reads a varint length prefixed string
+func decodeString(data []byte) (s string, rest []byte, ok bool) {
+	length, n := binary.Uvarint(data)
+	if n <= 0 || length > uint64(len(data)-n) {
+		return "", nil, false
+	}
+	return string(data[n : uint64(n)+length]), data[uint64(n)+length:], true
+}
+
+// readStringAt reads a Go string header at addr and returns its content
+func readStringAt(
+	x exe, ptrSize int, readPtr func([]byte) uint64, addr uint64,
+) string {
+	hdr, err := x.ReadData(addr, uint64(2*ptrSize))
+	if err != nil || len(hdr) < 2*ptrSize {
+		return ""
+	}
+	dataAddr := readPtr(hdr)
+	dataLen := readPtr(hdr[ptrSize:])
+	if dataLen > buildInfoMaxSearch*16 {
+		return ""
+	}
+	data, err := x.ReadData(dataAddr, dataLen)
+	if err != nil || uint64(len(data)) < dataLen {
+		return ""
+	}
+	return string(data)
+}
+
+// parseModInfo parses the module info text as written by the Go toolchain
+func parseModInfo(mod string) (*BuildInfo, error) {
+	info := &BuildInfo{}
+	for _, line := range strings.Split(mod, "\n") {
+		fields := strings.SplitN(line, "\t", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		switch fields[0] {
+		case "path":
+			info.Path = fields[1]
+		case "mod":
+			info.Module = strings.SplitN(fields[1], "\t", 2)[0]
+		case "build":
+			if info.Settings == nil {
+				info.Settings = map[string]string{}
+			}
+			key, value, err := parseBuildSetting(fields[1])
+			if err != nil {
+				return nil, errors.Wrapf(err, "parsing build setting %q", fields[1])
+			}
+			info.Settings[key] = value
+		}
+	}
+	return info, nil
+}
+
+// parseBuildSetting parses a `key=value` build setting, where both key and
+// value may be quoted.
+func parseBuildSetting(setting string) (key, value string, err error) {
+	if strings.HasPrefix(setting, `"`) {
+		quoted, err := strconv.QuotedPrefix(setting)
+		if err != nil {
+			return "", "", err
+		}
+		if key, err = strconv.Unquote(quoted); err != nil {
+			return "", "", err
+		}
+		setting = setting[len(quoted):]
+	} else {
+		i := strings.Index(setting, "=")
+		if i < 0 {
+			return "", "", errors.New("missing '='")
+		}
+		key = setting[:i]
+		setting = setting[i:]
+	}
+
+	if !strings.HasPrefix(setting, "=") {
+		return "", "", errors.New("missing '='")
+	}
+	value = setting[1:]
+	if strings.HasPrefix(value, `"`) {
+		if value, err = strconv.Unquote(value); err != nil {
+			return "", "", err
+		}
+	}
+	return key, value, nil
+}
+
+// splitFlags splits linker flags like the go command does, respecting
+// single and double quotes.
+func splitFlags(flags string) []string {
+	res := []string{}
+	var (
+		current strings.Builder
+		quote   rune
+		inField bool
+	)
+	for _, r := range flags {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inField = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inField {
+				res = append(res, current.String())
+				current.Reset()
+				inField = false
+			}
+		default:
+			current.WriteRune(r)
+			inField = true
+		}
+	}
+	if inField {
+		res = append(res, current.String())
+	}
+	return res
+}
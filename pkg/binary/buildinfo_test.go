@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binary_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/release/pkg/binary"
+)
+
+const testModule = "example.com/buildinfo"
+
+// buildTestBinary compiles a minimal Go program for the platform and returns
+// the path to the executable.
+func buildTestBinary(t *testing.T, goos, goarch string, flags ...string) string {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	dir := t.TempDir()
+	require.Nil(t, os.WriteFile(
+		filepath.Join(dir, "go.mod"),
+		[]byte("module "+testModule+"\n\ngo 1.17\n"),
+		os.FileMode(0o644),
+	))
+	require.Nil(t, os.WriteFile(
+		filepath.Join(dir, "main.go"),
+		[]byte("package main\n\nvar version string\n\nfunc main() { println(version) }\n"),
+		os.FileMode(0o644),
+	))
+
+	output := filepath.Join(dir, "bin")
+	cmd := exec.Command(goBin, append(
+		append([]string{"build", "-o", output}, flags...), ".",
+	)...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GOOS="+goos, "GOARCH="+goarch, "CGO_ENABLED=0", "GOFLAGS=-buildvcs=false",
+	)
+	out, err := cmd.CombinedOutput()
+	require.Nil(t, err, string(out))
+	return output
+}
+
+func TestReadBuildInfo(t *testing.T) {
+	for _, platform := range []struct{ goos, goarch string }{
+		{binary.LINUX, binary.AMD64},
+		{binary.DARWIN, binary.AMD64},
+		{binary.WIN, binary.AMD64},
+	} {
+		path := buildTestBinary(t, platform.goos, platform.goarch,
+			"-ldflags", "-s -w -X 'main.version=v1.22.3' -X main.other=value",
+		)
+
+		info, err := binary.ReadBuildInfo(path)
+		require.Nil(t, err, platform)
+		require.True(t, strings.HasPrefix(info.GoVersion, "go"))
+		require.Equal(t, testModule, info.Path)
+		require.Equal(t, testModule, info.Module)
+		require.True(t, info.HasSettings())
+		require.False(t, info.CGOEnabled())
+		require.False(t, info.TrimPath())
+		require.False(t, info.VCSModified())
+		require.True(t, info.HasLDFlags())
+		require.Equal(t, map[string]string{
+			"main.version": "v1.22.3",
+			"main.other":   "value",
+		}, info.VersionVariables())
+	}
+}
+
+func TestReadBuildInfoTrimPath(t *testing.T) {
+	path := buildTestBinary(t, binary.LINUX, binary.AMD64,
+		"-trimpath", "-ldflags", "-X main.version=v1.22.3",
+	)
+
+	bin, err := binary.New(path)
+	require.Nil(t, err)
+	info, err := bin.BuildInfo()
+	require.Nil(t, err)
+	require.True(t, info.TrimPath())
+	require.False(t, info.HasLDFlags())
+	require.Empty(t, info.VersionVariables())
+}
+
+func TestReadBuildInfoFailure(t *testing.T) {
+	// Not an executable
+	path := filepath.Join(t.TempDir(), "file")
+	require.Nil(t, os.WriteFile(path, []byte("test"), os.FileMode(0o644)))
+	_, err := binary.ReadBuildInfo(path)
+	require.NotNil(t, err)
+
+	// Not existing
+	_, err = binary.ReadBuildInfo(filepath.Join(t.TempDir(), "missing"))
+	require.NotNil(t, err)
+}
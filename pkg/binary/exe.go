@@ -0,0 +1,372 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binary
+
+import (
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// exe is an executable file of any format supporting reads of its memory
+// image
+type exe interface {
+	// ReadData reads up to size bytes starting at the virtual address addr
+	ReadData(addr, size uint64) ([]byte, error)
+
+	// DataStart returns the address of the section containing the build info
+	DataStart() uint64
+
+	// Libraries returns the shared libraries required by the executable
+	Libraries() ([]string, error)
+
+	// Interpreter returns the dynamic linker requested by the executable,
+	// which is only recorded by ELF files
+	Interpreter() (string, error)
+
+	// Stripped returns true if the executable contains no symbol table
+	Stripped() bool
+
+	// Hardening returns the security hardening features of the executable
+	Hardening() (*Hardening, error)
+
+	Close() error
+}
+
+func openExe(path string) (exe, error) {
+	if f, err := elf.Open(path); err == nil {
+		return &elfExe{f}, nil
+	}
+	if f, err := macho.Open(path); err == nil {
+		return &machoExe{f}, nil
+	}
+	if f, err := pe.Open(path); err == nil {
+		return &peExe{f}, nil
+	}
+	return nil, errors.New("file is not an executable or is an unknown format")
+}
+
+type elfExe struct {
+	f *elf.File
+}
+
+func (x *elfExe) Close() error {
+	return x.f.Close()
+}
+
+func (x *elfExe) ReadData(addr, size uint64) ([]byte, error) {
+	for _, prog := range x.f.Progs {
+		if prog.Vaddr <= addr && addr <= prog.Vaddr+prog.Filesz-1 {
+			n := prog.Vaddr + prog.Filesz - addr
+			if n > size {
+				n = size
+			}
+			data := make([]byte, n)
+			if _, err := prog.ReadAt(data, int64(addr-prog.Vaddr)); err != nil {
+				return nil, err
+			}
+			return data, nil
+		}
+	}
+	return nil, errors.Errorf("address %#x not found in executable", addr)
+}
+
+func (x *elfExe) DataStart() uint64 {
+	for _, s := range x.f.Sections {
+		if s.Name == ".go.buildinfo" {
+			return s.Addr
+		}
+	}
+	for _, prog := range x.f.Progs {
+		if prog.Type == elf.PT_LOAD && prog.Flags&(elf.PF_X|elf.PF_W) == elf.PF_W {
+			return prog.Vaddr
+		}
+	}
+	return 0
+}
+
+func (x *elfExe) Libraries() ([]string, error) {
+	libs, err := x.f.ImportedLibraries()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading DT_NEEDED entries")
+	}
+	return sortedLibraries(libs), nil
+}
+
+func (x *elfExe) Interpreter() (string, error) {
+	for _, prog := range x.f.Progs {
+		if prog.Type != elf.PT_INTERP {
+			continue
+		}
+		data := make([]byte, prog.Filesz)
+		if _, err := prog.ReadAt(data, 0); err != nil {
+			return "", errors.Wrap(err, "reading PT_INTERP header")
+		}
+		return strings.TrimRight(string(data), "\x00"), nil
+	}
+	return "", nil
+}
+
+func (x *elfExe) Stripped() bool {
+	return x.f.Section(".symtab") == nil
+}
+
+func (x *elfExe) Hardening() (*Hardening, error) {
+	res := &Hardening{
+		PIE:   x.f.Type == elf.ET_DYN,
+		RELRO: RELRONone,
+	}
+
+	// Without a PT_GNU_STACK header the stack is executable
+	for _, prog := range x.f.Progs {
+		switch prog.Type {
+		case elf.PT_GNU_STACK:
+			res.NX = prog.Flags&elf.PF_X == 0
+		case elf.PT_GNU_RELRO:
+			res.RELRO = RELROPartial
+		}
+	}
+
+	if res.RELRO == RELROPartial {
+		dynamic, err := x.dynamicEntries()
+		if err != nil {
+			return nil, errors.Wrap(err, "reading dynamic section")
+		}
+		if _, ok := dynamic[elf.DT_BIND_NOW]; ok ||
+			dynamic[elf.DT_FLAGS]&uint64(elf.DF_BIND_NOW) != 0 ||
+			dynamic[elf.DT_FLAGS_1]&uint64(elf.DF_1_NOW) != 0 {
+			res.RELRO = RELROFull
+		}
+	}
+	return res, nil
+}
+
+// dynamicEntries returns the tags and values of the dynamic section
+func (x *elfExe) dynamicEntries() (map[elf.DynTag]uint64, error) {
+	res := map[elf.DynTag]uint64{}
+	section := x.f.SectionByType(elf.SHT_DYNAMIC)
+	if section == nil {
+		return res, nil
+	}
+	data, err := section.Data()
+	if err != nil {
+		return nil, err
+	}
+
+	entrySize := 16
+	readWord := func(b []byte) uint64 { return x.f.ByteOrder.Uint64(b) }
+	if x.f.Class == elf.ELFCLASS32 {
+		entrySize = 8
+		readWord = func(b []byte) uint64 { return uint64(x.f.ByteOrder.Uint32(b)) }
+	}
+	for len(data) >= entrySize {
+		tag := elf.DynTag(readWord(data))
+		if tag == elf.DT_NULL {
+			break
+		}
+		res[tag] |= readWord(data[entrySize/2:])
+		data = data[entrySize:]
+	}
+	return res, nil
+}
+
+type machoExe struct {
+	f *macho.File
+}
+
+func (x *machoExe) Close() error {
+	return x.f.Close()
+}
+
+func (x *machoExe) ReadData(addr, size uint64) ([]byte, error) {
+	for _, load := range x.f.Loads {
+		seg, ok := load.(*macho.Segment)
+		if !ok || seg.Addr > addr || addr > seg.Addr+seg.Filesz-1 {
+			continue
+		}
+		if seg.Name == "__PAGEZERO" {
+			continue
+		}
+		n := seg.Addr + seg.Filesz - addr
+		if n > size {
+			n = size
+		}
+		data := make([]byte, n)
+		if _, err := seg.ReadAt(data, int64(addr-seg.Addr)); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+	return nil, errors.Errorf("address %#x not found in executable", addr)
+}
+
+func (x *machoExe) DataStart() uint64 {
+	for _, sect := range x.f.Sections {
+		if sect.Name == "__go_buildinfo" {
+			return sect.Addr
+		}
+	}
+	for _, load := range x.f.Loads {
+		if seg, ok := load.(*macho.Segment); ok && seg.Name == "__DATA" {
+			return seg.Addr
+		}
+	}
+	return 0
+}
+
+func (x *machoExe) Libraries() ([]string, error) {
+	libs, err := x.f.ImportedLibraries()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading dylib load commands")
+	}
+	return sortedLibraries(libs), nil
+}
+
+func (x *machoExe) Interpreter() (string, error) {
+	return "", nil
+}
+
+func (x *machoExe) Stripped() bool {
+	return x.f.Symtab == nil || len(x.f.Symtab.Syms) == 0
+}
+
+func (x *machoExe) Hardening() (*Hardening, error) {
+	const flagAllowStackExecution = 0x20000
+	return &Hardening{
+		PIE: x.f.Flags&macho.FlagPIE != 0,
+		NX:  x.f.Flags&flagAllowStackExecution == 0,
+	}, nil
+}
+
+type peExe struct {
+	f *pe.File
+}
+
+func (x *peExe) Close() error {
+	return x.f.Close()
+}
+
+func (x *peExe) imageBase() uint64 {
+	switch oh := x.f.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		return uint64(oh.ImageBase)
+	case *pe.OptionalHeader64:
+		return oh.ImageBase
+	}
+	return 0
+}
+
+func (x *peExe) ReadData(addr, size uint64) ([]byte, error) {
+	addr -= x.imageBase()
+	for _, sect := range x.f.Sections {
+		start := uint64(sect.VirtualAddress)
+		if start <= addr && addr <= start+uint64(sect.Size)-1 {
+			n := start + uint64(sect.Size) - addr
+			if n > size {
+				n = size
+			}
+			data := make([]byte, n)
+			if _, err := sect.ReadAt(data, int64(addr-start)); err != nil {
+				return nil, err
+			}
+			return data, nil
+		}
+	}
+	return nil, errors.Errorf("address %#x not found in executable", addr)
+}
+
+func (x *peExe) DataStart() uint64 {
+	const (
+		imageScnCntInitializedData = 0x00000040
+		imageScnMemRead            = 0x40000000
+		imageScnMemWrite           = 0x80000000
+		imageScnAlignMask          = 0x00f00000
+		dataFlags                  = imageScnCntInitializedData |
+			imageScnMemRead | imageScnMemWrite
+	)
+	for _, sect := range x.f.Sections {
+		if sect.VirtualAddress != 0 && sect.Size != 0 &&
+			sect.Characteristics&^imageScnAlignMask == dataFlags {
+			return uint64(sect.VirtualAddress) + x.imageBase()
+		}
+	}
+	return 0
+}
+
+func (x *peExe) Libraries() ([]string, error) {
+	// The imported symbols are of the form "symbol:library"
+	symbols, err := x.f.ImportedSymbols()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading import table")
+	}
+	libs := []string{}
+	for _, symbol := range symbols {
+		if i := strings.LastIndex(symbol, ":"); i >= 0 {
+			libs = append(libs, strings.ToLower(symbol[i+1:]))
+		}
+	}
+	return sortedLibraries(libs), nil
+}
+
+func (x *peExe) Interpreter() (string, error) {
+	return "", nil
+}
+
+func (x *peExe) Stripped() bool {
+	return x.f.NumberOfSymbols == 0
+}
+
+func (x *peExe) Hardening() (*Hardening, error) {
+	const (
+		dllCharacteristicsHighEntropyVA = 0x0020
+		dllCharacteristicsDynamicBase   = 0x0040
+		dllCharacteristicsNXCompat      = 0x0100
+	)
+
+	var characteristics uint16
+	switch oh := x.f.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		characteristics = oh.DllCharacteristics
+	case *pe.OptionalHeader64:
+		characteristics = oh.DllCharacteristics
+	default:
+		return nil, errors.New("missing optional header")
+	}
+	return &Hardening{
+		ASLR:          characteristics&dllCharacteristicsDynamicBase != 0,
+		HighEntropyVA: characteristics&dllCharacteristicsHighEntropyVA != 0,
+		DEP:           characteristics&dllCharacteristicsNXCompat != 0,
+	}, nil
+}
+
+// sortedLibraries returns the unique libraries in sorted order
+func sortedLibraries(libs []string) []string {
+	unique := map[string]bool{}
+	for _, lib := range libs {
+		unique[lib] = true
+	}
+	res := []string{}
+	for lib := range unique {
+		res = append(res, lib)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binary
+
+import (
+	"github.com/pkg/errors"
+)
+
+// RELRO levels of ELF binaries
+const (
+	RELRONone    = "none"
+	RELROPartial = "partial"
+	RELROFull    = "full"
+)
+
+// Hardening captures the security hardening features of a binary. Features
+// which do not apply to the executable format are always false.
+type Hardening struct {
+	// PIE is true for position independent ELF and Mach-O executables
+	PIE bool `json:"pie"`
+
+	// RELRO is the relocation read-only level of ELF executables
+	RELRO string `json:"relro,omitempty"`
+
+	// NX is true if ELF and Mach-O executables have a non executable stack
+	NX bool `json:"nx"`
+
+	// ASLR is true if PE executables support address space randomization
+	ASLR bool `json:"aslr"`
+
+	// HighEntropyVA is true if PE executables support 64 bit ASLR
+	HighEntropyVA bool `json:"highEntropyVA"`
+
+	// DEP is true if PE executables are compatible with data execution
+	// prevention
+	DEP bool `json:"dep"`
+}
+
+// Inspection is the result of inspecting a binary
+type Inspection struct {
+	Path      string     `json:"path"`
+	OS        string     `json:"os"`
+	Arch      string     `json:"arch"`
+	Libraries   []string   `json:"libraries"`
+	Interpreter string     `json:"interpreter,omitempty"`
+	Stripped    bool       `json:"stripped"`
+	Hardening   *Hardening `json:"hardening"`
+}
+
+// Static returns true if the binary does not require any shared libraries
+// and no dynamic linker
+func (i *Inspection) Static() bool {
+	return len(i.Libraries) == 0 && i.Interpreter == ""
+}
+
+// Libraries returns the shared libraries the binary requires at runtime. The
+// list is empty for statically linked binaries.
+func (b *Binary) Libraries() ([]string, error) {
+	x, err := openExe(b.options.Path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening executable %s", b.options.Path)
+	}
+	defer x.Close()
+	return x.Libraries()
+}
+
+// IsStripped returns true if the binary contains no symbol table
+func (b *Binary) IsStripped() (bool, error) {
+	x, err := openExe(b.options.Path)
+	if err != nil {
+		return false, errors.Wrapf(err, "opening executable %s", b.options.Path)
+	}
+	defer x.Close()
+	return x.Stripped(), nil
+}
+
+// Hardening returns the security hardening features of the binary
+func (b *Binary) Hardening() (*Hardening, error) {
+	x, err := openExe(b.options.Path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening executable %s", b.options.Path)
+	}
+	defer x.Close()
+	return x.Hardening()
+}
+
+// Inspect returns the linking, symbol and hardening details of the binary
+func (b *Binary) Inspect() (*Inspection, error) {
+	x, err := openExe(b.options.Path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening executable %s", b.options.Path)
+	}
+	defer x.Close()
+
+	libs, err := x.Libraries()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting shared libraries")
+	}
+	interpreter, err := x.Interpreter()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting interpreter")
+	}
+	hardening, err := x.Hardening()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting hardening features")
+	}
+
+	return &Inspection{
+		Path:        b.options.Path,
+		OS:          b.OS(),
+		Arch:        b.Arch(),
+		Libraries:   libs,
+		Interpreter: interpreter,
+		Stripped:    x.Stripped(),
+		Hardening:   hardening,
+	}, nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binary_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/release/pkg/binary"
+)
+
+func TestInspect(t *testing.T) {
+	for _, tc := range []struct {
+		goos, goarch string
+		flags        []string
+		assert       func(*binary.Inspection)
+	}{
+		{ // static and stripped linux binary
+			goos: binary.LINUX, goarch: binary.AMD64,
+			flags: []string{"-ldflags", "-s -w"},
+			assert: func(res *binary.Inspection) {
+				require.True(t, res.Static())
+				require.Empty(t, res.Interpreter)
+				require.True(t, res.Stripped)
+				require.False(t, res.Hardening.PIE)
+				require.Equal(t, binary.RELRONone, res.Hardening.RELRO)
+				require.True(t, res.Hardening.NX)
+			},
+		},
+		{ // position independent linux binary with symbols
+			goos: binary.LINUX, goarch: binary.AMD64,
+			flags: []string{"-buildmode=pie"},
+			assert: func(res *binary.Inspection) {
+				require.NotEmpty(t, res.Interpreter)
+				require.False(t, res.Static())
+				require.False(t, res.Stripped)
+				require.True(t, res.Hardening.PIE)
+				require.NotEqual(t, binary.RELRONone, res.Hardening.RELRO)
+				require.True(t, res.Hardening.NX)
+			},
+		},
+		{ // darwin binary
+			goos: binary.DARWIN, goarch: binary.AMD64,
+			assert: func(res *binary.Inspection) {
+				require.Contains(t, res.Libraries, "/usr/lib/libSystem.B.dylib")
+				require.False(t, res.Static())
+				require.True(t, res.Hardening.PIE)
+			},
+		},
+		{ // windows binary
+			goos: binary.WIN, goarch: binary.AMD64,
+			flags: []string{"-ldflags", "-s -w"},
+			assert: func(res *binary.Inspection) {
+				require.Contains(t, res.Libraries, "kernel32.dll")
+				require.True(t, res.Stripped)
+				require.True(t, res.Hardening.ASLR)
+				require.True(t, res.Hardening.DEP)
+			},
+		},
+	} {
+		path := buildTestBinary(t, tc.goos, tc.goarch, tc.flags...)
+		bin, err := binary.New(path)
+		require.Nil(t, err)
+
+		res, err := bin.Inspect()
+		require.Nil(t, err)
+		require.Equal(t, path, res.Path)
+		require.Equal(t, tc.goos, res.OS)
+		require.Equal(t, tc.goarch, res.Arch)
+		tc.assert(res)
+
+		libs, err := bin.Libraries()
+		require.Nil(t, err)
+		require.Equal(t, res.Libraries, libs)
+
+		stripped, err := bin.IsStripped()
+		require.Nil(t, err)
+		require.Equal(t, res.Stripped, stripped)
+
+		hardening, err := bin.Hardening()
+		require.Nil(t, err)
+		require.Equal(t, res.Hardening, hardening)
+	}
+}
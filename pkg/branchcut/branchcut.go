@@ -0,0 +1,382 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package branchcut
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/anago"
+	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/release"
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-utils/log"
+	"sigs.k8s.io/release-utils/util"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+// The steps of a branch cut in the order of their execution. Completed steps
+// are recorded in the state file and skipped when resuming.
+const (
+	StepPreconditions = "preconditions"
+	StepStage         = "stage"
+	StepRelease       = "release"
+	StepPubBotIssue   = "publishing-bot-issue"
+	StepAnnouncement  = "announcement"
+)
+
+// StateFileName is the default name of the state file inside of the working
+// directory.
+const StateFileName = "branch-cut-state.json"
+
+// Options are the settings for cutting a new release branch.
+type Options struct {
+	// Branch is the release branch to be created, for example release-1.23.
+	Branch string
+
+	// BuildVersion is the build version to be released. Defaults to the
+	// latest CI build for the branch if empty.
+	BuildVersion string
+
+	// ReleaseType is the type of the first release of the branch, either
+	// `rc` or `beta`.
+	ReleaseType string
+
+	// NoMock runs the branch cut for real, otherwise every step runs in mock
+	// mode.
+	NoMock bool
+
+	// WorkDir is the local directory for the announcement and the state
+	// file.
+	WorkDir string
+
+	// StateFile is the path to the file tracking the progress. Defaults to
+	// StateFileName inside of WorkDir.
+	StateFile string
+
+	// SendgridAPIKey is used for sending the branch announcement.
+	SendgridAPIKey string
+
+	// MailSenderName and MailSenderEmail are the sender of the announcement.
+	// The default sender of the Sendgrid account is used if not set.
+	MailSenderName  string
+	MailSenderEmail string
+}
+
+// DefaultOptions returns a new Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		ReleaseType: release.ReleaseTypeRC,
+		WorkDir:     filepath.Join(os.TempDir(), "krel-branch-cut"),
+	}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if o.Branch == git.DefaultBranch || !git.IsReleaseBranch(o.Branch) {
+		return errors.Errorf("%q is not a valid release branch", o.Branch)
+	}
+	if o.ReleaseType != release.ReleaseTypeBeta &&
+		o.ReleaseType != release.ReleaseTypeRC {
+		return errors.Errorf(
+			"invalid release type %q, expected %s or %s",
+			o.ReleaseType, release.ReleaseTypeBeta, release.ReleaseTypeRC,
+		)
+	}
+	if o.WorkDir == "" {
+		return errors.New("no working directory specified")
+	}
+	return nil
+}
+
+func (o *Options) stateFile() string {
+	if o.StateFile != "" {
+		return o.StateFile
+	}
+	return filepath.Join(o.WorkDir, StateFileName)
+}
+
+// State is the progress of a branch cut, which gets persisted after every
+// step.
+type State struct {
+	Branch       string               `json:"branch"`
+	BuildVersion string               `json:"buildVersion,omitempty"`
+	ReleaseType  string               `json:"releaseType,omitempty"`
+	NoMock       bool                 `json:"nomock"`
+	Completed    map[string]time.Time `json:"completed"`
+}
+
+// IsCompleted returns true if the step has been finished.
+func (s *State) IsCompleted(step string) bool {
+	_, ok := s.Completed[step]
+	return ok
+}
+
+// BranchCut orchestrates the creation of a new release branch.
+type BranchCut struct {
+	impl    branchCutImpl
+	options *Options
+	state   *State
+}
+
+// New creates a new BranchCut instance.
+func New(options *Options) *BranchCut {
+	return &BranchCut{
+		impl:    &defaultBranchCutImpl{},
+		options: options,
+	}
+}
+
+// SetImpl can be used to set the internal BranchCut implementation.
+func (b *BranchCut) SetImpl(impl branchCutImpl) {
+	b.impl = impl
+}
+
+// State returns the current state of the branch cut.
+func (b *BranchCut) State() *State {
+	return b.state
+}
+
+// Run executes all outstanding steps of the branch cut. A failed run can be
+// resumed by running it again with the same options.
+func (b *BranchCut) Run() error {
+	if err := b.options.Validate(); err != nil {
+		return errors.Wrap(err, "validating options")
+	}
+
+	if err := os.MkdirAll(b.options.WorkDir, os.FileMode(0o755)); err != nil {
+		return errors.Wrap(err, "creating working directory")
+	}
+
+	if err := b.loadState(); err != nil {
+		return errors.Wrap(err, "loading state")
+	}
+
+	if !b.options.NoMock {
+		logrus.Info("Using mock mode, which does not modify any remote content")
+	}
+
+	steps := []struct {
+		name string
+		run  func() error
+	}{
+		{StepPreconditions, b.checkPreconditions},
+		{StepStage, b.stage},
+		{StepRelease, b.release},
+		{StepPubBotIssue, b.createPubBotIssue},
+		{StepAnnouncement, b.announce},
+	}
+
+	logger := log.NewStepLogger(uint(len(steps)))
+	for _, step := range steps {
+		if b.state.IsCompleted(step.name) {
+			logger.WithStep().Infof(
+				"Skipping %s, already completed at %s",
+				step.name, b.state.Completed[step.name].Format(time.RFC3339),
+			)
+			continue
+		}
+
+		logger.WithStep().Infof("Running %s", step.name)
+		if err := step.run(); err != nil {
+			return errors.Wrapf(err, "running step %s", step.name)
+		}
+
+		b.state.Completed[step.name] = time.Now().UTC()
+		if err := b.saveState(); err != nil {
+			return errors.Wrap(err, "saving state")
+		}
+	}
+
+	fmt.Print(Checklist(b.options.Branch))
+	return nil
+}
+
+// loadState reads the state file if it exists and verifies that it belongs
+// to the current options.
+func (b *BranchCut) loadState() error {
+	b.state = &State{
+		Branch:       b.options.Branch,
+		BuildVersion: b.options.BuildVersion,
+		ReleaseType:  b.options.ReleaseType,
+		NoMock:       b.options.NoMock,
+		Completed:    map[string]time.Time{},
+	}
+
+	stateFile := b.options.stateFile()
+	content, err := os.ReadFile(stateFile)
+	if os.IsNotExist(err) {
+		logrus.Infof("Starting new branch cut, tracking progress in %s", stateFile)
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "reading state file %s", stateFile)
+	}
+
+	state := &State{}
+	if err := json.Unmarshal(content, state); err != nil {
+		return errors.Wrapf(err, "unmarshal state file %s", stateFile)
+	}
+
+	if state.Branch != b.options.Branch || state.NoMock != b.options.NoMock {
+		return errors.Errorf(
+			"state file %s belongs to branch %s (nomock: %v), "+
+				"remove it to start over",
+			stateFile, state.Branch, state.NoMock,
+		)
+	}
+	if b.options.BuildVersion != "" && state.BuildVersion != "" &&
+		b.options.BuildVersion != state.BuildVersion {
+		return errors.Errorf(
+			"state file %s uses build version %s instead of %s",
+			stateFile, state.BuildVersion, b.options.BuildVersion,
+		)
+	}
+	if state.ReleaseType == "" {
+		state.ReleaseType = b.options.ReleaseType
+	}
+	if state.ReleaseType != b.options.ReleaseType {
+		return errors.Errorf(
+			"state file %s uses release type %s instead of %s",
+			stateFile, state.ReleaseType, b.options.ReleaseType,
+		)
+	}
+	if state.Completed == nil {
+		state.Completed = map[string]time.Time{}
+	}
+
+	logrus.Infof("Resuming branch cut from %s", stateFile)
+	b.state = state
+	return nil
+}
+
+func (b *BranchCut) saveState() error {
+	content, err := json.MarshalIndent(b.state, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal state")
+	}
+	return errors.Wrapf(
+		os.WriteFile(b.options.stateFile(), content, os.FileMode(0o644)),
+		"writing state file %s", b.options.stateFile(),
+	)
+}
+
+// checkPreconditions resolves the build version and verifies that the
+// branch does not exist yet.
+func (b *BranchCut) checkPreconditions() error {
+	if b.options.NoMock && !b.impl.IsEnvSet(github.TokenEnvKey) {
+		return errors.Errorf(
+			"%s has to be set for creating the publishing bot issue",
+			github.TokenEnvKey,
+		)
+	}
+	if b.options.SendgridAPIKey == "" {
+		return errors.New("no Sendgrid API key set for sending the announcement")
+	}
+
+	if b.state.BuildVersion == "" {
+		buildVersion, err := b.impl.GetKubeVersionForBranch(
+			release.VersionTypeCILatest, b.options.Branch,
+		)
+		if err != nil {
+			return errors.Wrap(err, "retrieving latest build version")
+		}
+		b.state.BuildVersion = buildVersion
+	}
+	logrus.Infof("Using build version %s", b.state.BuildVersion)
+
+	buildVersion, err := util.TagStringToSemver(b.state.BuildVersion)
+	if err != nil {
+		return errors.Wrapf(err, "invalid build version %s", b.state.BuildVersion)
+	}
+
+	createBranch, err := b.impl.BranchNeedsCreation(
+		b.options.Branch, b.options.ReleaseType, buildVersion,
+	)
+	if err != nil {
+		return errors.Wrap(err, "checking release branch")
+	}
+	if !createBranch {
+		return errors.Errorf("branch %s already exists", b.options.Branch)
+	}
+	return nil
+}
+
+// anagoOptions returns the generic stage and release options for the first
+// release of the branch, which creates the branch.
+func (b *BranchCut) anagoOptions() *anago.Options {
+	options := anago.DefaultOptions()
+	options.NoMock = b.options.NoMock
+	options.ReleaseType = b.options.ReleaseType
+	options.ReleaseBranch = b.options.Branch
+	options.BuildVersion = b.state.BuildVersion
+	return options
+}
+
+func (b *BranchCut) stage() error {
+	return b.impl.SubmitStage(&anago.StageOptions{Options: b.anagoOptions()})
+}
+
+func (b *BranchCut) release() error {
+	return b.impl.SubmitRelease(&anago.ReleaseOptions{Options: b.anagoOptions()})
+}
+
+func (b *BranchCut) createPubBotIssue() error {
+	if !b.options.NoMock {
+		logrus.Info("Not creating publishing bot issue in mock mode")
+		return nil
+	}
+	return b.impl.CreatePubBotBranchIssue(b.options.Branch)
+}
+
+func (b *BranchCut) announce() error {
+	if err := b.impl.CreateAnnouncement(
+		announce.NewOptions().
+			WithWorkDir(b.options.WorkDir).
+			WithBranch(b.options.Branch),
+	); err != nil {
+		return errors.Wrap(err, "creating branch announcement")
+	}
+	if err := b.impl.SendAnnouncement(b.options); err != nil {
+		return errors.Wrap(err, "sending branch announcement")
+	}
+	return nil
+}
+
+// Checklist returns the manual follow-up tasks after creating the branch.
+func Checklist(branch string) string {
+	version := strings.TrimPrefix(branch, "release-")
+	return fmt.Sprintf(`
+The branch %[1]s has been created. Outstanding follow-up tasks:
+
+- Fast forward %[1]s regularly until code thaw: krel ff --branch %[1]s
+- Fork the CI jobs and TestGrid dashboards for %[2]s in kubernetes/test-infra
+  (releng/config-forker) and update the branch protection
+- Follow up on the publishing-bot issue in %[3]s/%[4]s
+- Set the kube-cross version for %[1]s in kubernetes/release
+- Announce the cherry-pick process for %[1]s on #sig-release
+`, branch, version, release.PubBotRepoOrg, release.PubBotRepoName)
+}
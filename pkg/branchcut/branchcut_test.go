@@ -0,0 +1,219 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package branchcut_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/branchcut"
+	"k8s.io/release/pkg/branchcut/branchcutfakes"
+	"k8s.io/release/pkg/release"
+)
+
+var err = errors.New("error")
+
+func writeState(t *testing.T, workDir string, state *branchcut.State) {
+	content, err := json.Marshal(state)
+	require.Nil(t, err)
+	require.Nil(t, os.WriteFile(
+		filepath.Join(workDir, branchcut.StateFileName), content, os.FileMode(0o644),
+	))
+}
+
+func TestRun(t *testing.T) {
+	for _, tc := range []struct {
+		prepare     func(*branchcutfakes.FakeBranchCutImpl, string)
+		nomock      bool
+		releaseType string
+		completed   []string
+		shouldErr   bool
+		assert      func(*branchcutfakes.FakeBranchCutImpl, *branchcut.State)
+	}{
+		{ // success in mock mode
+			prepare: func(*branchcutfakes.FakeBranchCutImpl, string) {},
+			assert: func(mock *branchcutfakes.FakeBranchCutImpl, state *branchcut.State) {
+				require.Equal(t, 1, mock.SubmitStageCallCount())
+				require.Equal(t, 1, mock.SubmitReleaseCallCount())
+				require.Zero(t, mock.CreatePubBotBranchIssueCallCount())
+				require.Equal(t, 1, mock.SendAnnouncementCallCount())
+				require.Equal(t, "v1.23.0-beta.0.42+0123456789abcd", state.BuildVersion)
+
+				options := mock.SubmitStageArgsForCall(0)
+				require.False(t, options.NoMock)
+				require.Equal(t, release.ReleaseTypeRC, options.ReleaseType)
+				require.Equal(t, "release-1.23", options.ReleaseBranch)
+				require.Equal(t, state.BuildVersion, options.BuildVersion)
+
+				_, releaseType, _ := mock.BranchNeedsCreationArgsForCall(0)
+				require.Equal(t, release.ReleaseTypeRC, releaseType)
+			},
+		},
+		{ // success with beta release type
+			prepare:     func(*branchcutfakes.FakeBranchCutImpl, string) {},
+			releaseType: release.ReleaseTypeBeta,
+			assert: func(mock *branchcutfakes.FakeBranchCutImpl, state *branchcut.State) {
+				_, releaseType, _ := mock.BranchNeedsCreationArgsForCall(0)
+				require.Equal(t, release.ReleaseTypeBeta, releaseType)
+				require.Equal(t, release.ReleaseTypeBeta, mock.SubmitStageArgsForCall(0).ReleaseType)
+				require.Equal(t, release.ReleaseTypeBeta, mock.SubmitReleaseArgsForCall(0).ReleaseType)
+				require.Equal(t, release.ReleaseTypeBeta, state.ReleaseType)
+			},
+		},
+		{ // state file uses another release type
+			prepare: func(_ *branchcutfakes.FakeBranchCutImpl, workDir string) {
+				writeState(t, workDir, &branchcut.State{
+					Branch: "release-1.23", ReleaseType: release.ReleaseTypeBeta,
+				})
+			},
+			shouldErr: true,
+		},
+		{ // success in nomock mode
+			prepare: func(*branchcutfakes.FakeBranchCutImpl, string) {},
+			nomock:  true,
+			assert: func(mock *branchcutfakes.FakeBranchCutImpl, state *branchcut.State) {
+				require.Equal(t, 1, mock.CreatePubBotBranchIssueCallCount())
+				require.Equal(t, "release-1.23", mock.CreatePubBotBranchIssueArgsForCall(0))
+				require.True(t, mock.SubmitReleaseArgsForCall(0).NoMock)
+			},
+		},
+		{ // resume after a finished stage
+			prepare: func(*branchcutfakes.FakeBranchCutImpl, string) {},
+			completed: []string{
+				branchcut.StepPreconditions, branchcut.StepStage,
+			},
+			assert: func(mock *branchcutfakes.FakeBranchCutImpl, state *branchcut.State) {
+				require.Zero(t, mock.BranchNeedsCreationCallCount())
+				require.Zero(t, mock.SubmitStageCallCount())
+				require.Equal(t, 1, mock.SubmitReleaseCallCount())
+				require.True(t, state.IsCompleted(branchcut.StepAnnouncement))
+			},
+		},
+		{ // branch already exists
+			prepare: func(mock *branchcutfakes.FakeBranchCutImpl, _ string) {
+				mock.BranchNeedsCreationReturns(false, nil)
+			},
+			shouldErr: true,
+			assert: func(mock *branchcutfakes.FakeBranchCutImpl, state *branchcut.State) {
+				require.Zero(t, mock.SubmitStageCallCount())
+			},
+		},
+		{ // GitHub token not set in nomock mode
+			prepare: func(mock *branchcutfakes.FakeBranchCutImpl, _ string) {
+				mock.IsEnvSetReturns(false)
+			},
+			nomock:    true,
+			shouldErr: true,
+		},
+		{ // release fails and keeps the progress
+			prepare: func(mock *branchcutfakes.FakeBranchCutImpl, _ string) {
+				mock.SubmitReleaseReturns(err)
+			},
+			shouldErr: true,
+			assert: func(mock *branchcutfakes.FakeBranchCutImpl, state *branchcut.State) {
+				require.True(t, state.IsCompleted(branchcut.StepStage))
+				require.False(t, state.IsCompleted(branchcut.StepRelease))
+				require.Zero(t, mock.SendAnnouncementCallCount())
+			},
+		},
+		{ // sending the announcement fails
+			prepare: func(mock *branchcutfakes.FakeBranchCutImpl, _ string) {
+				mock.SendAnnouncementReturns(err)
+			},
+			shouldErr: true,
+		},
+		{ // state file belongs to another branch
+			prepare: func(_ *branchcutfakes.FakeBranchCutImpl, workDir string) {
+				writeState(t, workDir, &branchcut.State{Branch: "release-1.22"})
+			},
+			shouldErr: true,
+		},
+	} {
+		workDir := t.TempDir()
+		mock := &branchcutfakes.FakeBranchCutImpl{}
+		mock.IsEnvSetReturns(true)
+		mock.GetKubeVersionForBranchReturns("v1.23.0-beta.0.42+0123456789abcd", nil)
+		mock.BranchNeedsCreationReturns(true, nil)
+		tc.prepare(mock, workDir)
+
+		if len(tc.completed) > 0 {
+			state := &branchcut.State{
+				Branch:       "release-1.23",
+				BuildVersion: "v1.23.0-beta.0.42+0123456789abcd",
+				NoMock:       tc.nomock,
+				Completed:    map[string]time.Time{},
+			}
+			for _, step := range tc.completed {
+				state.Completed[step] = time.Now()
+			}
+			writeState(t, workDir, state)
+		}
+
+		options := branchcut.DefaultOptions()
+		options.Branch = "release-1.23"
+		options.NoMock = tc.nomock
+		options.WorkDir = workDir
+		if tc.releaseType != "" {
+			options.ReleaseType = tc.releaseType
+		}
+		options.SendgridAPIKey = "key"
+
+		sut := branchcut.New(options)
+		sut.SetImpl(mock)
+		err := sut.Run()
+		if tc.shouldErr {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+		if tc.assert != nil {
+			tc.assert(mock, sut.State())
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		branch      string
+		releaseType string
+		shouldErr   bool
+	}{
+		{branch: "release-1.23"},
+		{branch: "release-1.23", releaseType: release.ReleaseTypeBeta},
+		{branch: "release-1.23", releaseType: release.ReleaseTypeAlpha, shouldErr: true},
+		{branch: "master", shouldErr: true},
+		{branch: "", shouldErr: true},
+	} {
+		options := branchcut.DefaultOptions()
+		options.Branch = tc.branch
+		if tc.releaseType != "" {
+			options.ReleaseType = tc.releaseType
+		}
+		err := options.Validate()
+		if tc.shouldErr {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+	}
+}
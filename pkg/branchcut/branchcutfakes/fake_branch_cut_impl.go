@@ -0,0 +1,663 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package branchcutfakes
+
+import (
+	"sync"
+
+	"github.com/blang/semver"
+	"k8s.io/release/pkg/anago"
+	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/branchcut"
+	"k8s.io/release/pkg/release"
+)
+
+type FakeBranchCutImpl struct {
+	BranchNeedsCreationStub        func(string, string, semver.Version) (bool, error)
+	branchNeedsCreationMutex       sync.RWMutex
+	branchNeedsCreationArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 semver.Version
+	}
+	branchNeedsCreationReturns struct {
+		result1 bool
+		result2 error
+	}
+	branchNeedsCreationReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	CreateAnnouncementStub        func(*announce.Options) error
+	createAnnouncementMutex       sync.RWMutex
+	createAnnouncementArgsForCall []struct {
+		arg1 *announce.Options
+	}
+	createAnnouncementReturns struct {
+		result1 error
+	}
+	createAnnouncementReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreatePubBotBranchIssueStub        func(string) error
+	createPubBotBranchIssueMutex       sync.RWMutex
+	createPubBotBranchIssueArgsForCall []struct {
+		arg1 string
+	}
+	createPubBotBranchIssueReturns struct {
+		result1 error
+	}
+	createPubBotBranchIssueReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetKubeVersionForBranchStub        func(release.VersionType, string) (string, error)
+	getKubeVersionForBranchMutex       sync.RWMutex
+	getKubeVersionForBranchArgsForCall []struct {
+		arg1 release.VersionType
+		arg2 string
+	}
+	getKubeVersionForBranchReturns struct {
+		result1 string
+		result2 error
+	}
+	getKubeVersionForBranchReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	IsEnvSetStub        func(string) bool
+	isEnvSetMutex       sync.RWMutex
+	isEnvSetArgsForCall []struct {
+		arg1 string
+	}
+	isEnvSetReturns struct {
+		result1 bool
+	}
+	isEnvSetReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	SendAnnouncementStub        func(*branchcut.Options) error
+	sendAnnouncementMutex       sync.RWMutex
+	sendAnnouncementArgsForCall []struct {
+		arg1 *branchcut.Options
+	}
+	sendAnnouncementReturns struct {
+		result1 error
+	}
+	sendAnnouncementReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SubmitReleaseStub        func(*anago.ReleaseOptions) error
+	submitReleaseMutex       sync.RWMutex
+	submitReleaseArgsForCall []struct {
+		arg1 *anago.ReleaseOptions
+	}
+	submitReleaseReturns struct {
+		result1 error
+	}
+	submitReleaseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SubmitStageStub        func(*anago.StageOptions) error
+	submitStageMutex       sync.RWMutex
+	submitStageArgsForCall []struct {
+		arg1 *anago.StageOptions
+	}
+	submitStageReturns struct {
+		result1 error
+	}
+	submitStageReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBranchCutImpl) BranchNeedsCreation(arg1 string, arg2 string, arg3 semver.Version) (bool, error) {
+	fake.branchNeedsCreationMutex.Lock()
+	ret, specificReturn := fake.branchNeedsCreationReturnsOnCall[len(fake.branchNeedsCreationArgsForCall)]
+	fake.branchNeedsCreationArgsForCall = append(fake.branchNeedsCreationArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 semver.Version
+	}{arg1, arg2, arg3})
+	stub := fake.BranchNeedsCreationStub
+	fakeReturns := fake.branchNeedsCreationReturns
+	fake.recordInvocation("BranchNeedsCreation", []interface{}{arg1, arg2, arg3})
+	fake.branchNeedsCreationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBranchCutImpl) BranchNeedsCreationCallCount() int {
+	fake.branchNeedsCreationMutex.RLock()
+	defer fake.branchNeedsCreationMutex.RUnlock()
+	return len(fake.branchNeedsCreationArgsForCall)
+}
+
+func (fake *FakeBranchCutImpl) BranchNeedsCreationCalls(stub func(string, string, semver.Version) (bool, error)) {
+	fake.branchNeedsCreationMutex.Lock()
+	defer fake.branchNeedsCreationMutex.Unlock()
+	fake.BranchNeedsCreationStub = stub
+}
+
+func (fake *FakeBranchCutImpl) BranchNeedsCreationArgsForCall(i int) (string, string, semver.Version) {
+	fake.branchNeedsCreationMutex.RLock()
+	defer fake.branchNeedsCreationMutex.RUnlock()
+	argsForCall := fake.branchNeedsCreationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBranchCutImpl) BranchNeedsCreationReturns(result1 bool, result2 error) {
+	fake.branchNeedsCreationMutex.Lock()
+	defer fake.branchNeedsCreationMutex.Unlock()
+	fake.BranchNeedsCreationStub = nil
+	fake.branchNeedsCreationReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBranchCutImpl) BranchNeedsCreationReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.branchNeedsCreationMutex.Lock()
+	defer fake.branchNeedsCreationMutex.Unlock()
+	fake.BranchNeedsCreationStub = nil
+	if fake.branchNeedsCreationReturnsOnCall == nil {
+		fake.branchNeedsCreationReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.branchNeedsCreationReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBranchCutImpl) CreateAnnouncement(arg1 *announce.Options) error {
+	fake.createAnnouncementMutex.Lock()
+	ret, specificReturn := fake.createAnnouncementReturnsOnCall[len(fake.createAnnouncementArgsForCall)]
+	fake.createAnnouncementArgsForCall = append(fake.createAnnouncementArgsForCall, struct {
+		arg1 *announce.Options
+	}{arg1})
+	stub := fake.CreateAnnouncementStub
+	fakeReturns := fake.createAnnouncementReturns
+	fake.recordInvocation("CreateAnnouncement", []interface{}{arg1})
+	fake.createAnnouncementMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBranchCutImpl) CreateAnnouncementCallCount() int {
+	fake.createAnnouncementMutex.RLock()
+	defer fake.createAnnouncementMutex.RUnlock()
+	return len(fake.createAnnouncementArgsForCall)
+}
+
+func (fake *FakeBranchCutImpl) CreateAnnouncementCalls(stub func(*announce.Options) error) {
+	fake.createAnnouncementMutex.Lock()
+	defer fake.createAnnouncementMutex.Unlock()
+	fake.CreateAnnouncementStub = stub
+}
+
+func (fake *FakeBranchCutImpl) CreateAnnouncementArgsForCall(i int) *announce.Options {
+	fake.createAnnouncementMutex.RLock()
+	defer fake.createAnnouncementMutex.RUnlock()
+	argsForCall := fake.createAnnouncementArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBranchCutImpl) CreateAnnouncementReturns(result1 error) {
+	fake.createAnnouncementMutex.Lock()
+	defer fake.createAnnouncementMutex.Unlock()
+	fake.CreateAnnouncementStub = nil
+	fake.createAnnouncementReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBranchCutImpl) CreateAnnouncementReturnsOnCall(i int, result1 error) {
+	fake.createAnnouncementMutex.Lock()
+	defer fake.createAnnouncementMutex.Unlock()
+	fake.CreateAnnouncementStub = nil
+	if fake.createAnnouncementReturnsOnCall == nil {
+		fake.createAnnouncementReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createAnnouncementReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBranchCutImpl) CreatePubBotBranchIssue(arg1 string) error {
+	fake.createPubBotBranchIssueMutex.Lock()
+	ret, specificReturn := fake.createPubBotBranchIssueReturnsOnCall[len(fake.createPubBotBranchIssueArgsForCall)]
+	fake.createPubBotBranchIssueArgsForCall = append(fake.createPubBotBranchIssueArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.CreatePubBotBranchIssueStub
+	fakeReturns := fake.createPubBotBranchIssueReturns
+	fake.recordInvocation("CreatePubBotBranchIssue", []interface{}{arg1})
+	fake.createPubBotBranchIssueMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBranchCutImpl) CreatePubBotBranchIssueCallCount() int {
+	fake.createPubBotBranchIssueMutex.RLock()
+	defer fake.createPubBotBranchIssueMutex.RUnlock()
+	return len(fake.createPubBotBranchIssueArgsForCall)
+}
+
+func (fake *FakeBranchCutImpl) CreatePubBotBranchIssueCalls(stub func(string) error) {
+	fake.createPubBotBranchIssueMutex.Lock()
+	defer fake.createPubBotBranchIssueMutex.Unlock()
+	fake.CreatePubBotBranchIssueStub = stub
+}
+
+func (fake *FakeBranchCutImpl) CreatePubBotBranchIssueArgsForCall(i int) string {
+	fake.createPubBotBranchIssueMutex.RLock()
+	defer fake.createPubBotBranchIssueMutex.RUnlock()
+	argsForCall := fake.createPubBotBranchIssueArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBranchCutImpl) CreatePubBotBranchIssueReturns(result1 error) {
+	fake.createPubBotBranchIssueMutex.Lock()
+	defer fake.createPubBotBranchIssueMutex.Unlock()
+	fake.CreatePubBotBranchIssueStub = nil
+	fake.createPubBotBranchIssueReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBranchCutImpl) CreatePubBotBranchIssueReturnsOnCall(i int, result1 error) {
+	fake.createPubBotBranchIssueMutex.Lock()
+	defer fake.createPubBotBranchIssueMutex.Unlock()
+	fake.CreatePubBotBranchIssueStub = nil
+	if fake.createPubBotBranchIssueReturnsOnCall == nil {
+		fake.createPubBotBranchIssueReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createPubBotBranchIssueReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBranchCutImpl) GetKubeVersionForBranch(arg1 release.VersionType, arg2 string) (string, error) {
+	fake.getKubeVersionForBranchMutex.Lock()
+	ret, specificReturn := fake.getKubeVersionForBranchReturnsOnCall[len(fake.getKubeVersionForBranchArgsForCall)]
+	fake.getKubeVersionForBranchArgsForCall = append(fake.getKubeVersionForBranchArgsForCall, struct {
+		arg1 release.VersionType
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetKubeVersionForBranchStub
+	fakeReturns := fake.getKubeVersionForBranchReturns
+	fake.recordInvocation("GetKubeVersionForBranch", []interface{}{arg1, arg2})
+	fake.getKubeVersionForBranchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBranchCutImpl) GetKubeVersionForBranchCallCount() int {
+	fake.getKubeVersionForBranchMutex.RLock()
+	defer fake.getKubeVersionForBranchMutex.RUnlock()
+	return len(fake.getKubeVersionForBranchArgsForCall)
+}
+
+func (fake *FakeBranchCutImpl) GetKubeVersionForBranchCalls(stub func(release.VersionType, string) (string, error)) {
+	fake.getKubeVersionForBranchMutex.Lock()
+	defer fake.getKubeVersionForBranchMutex.Unlock()
+	fake.GetKubeVersionForBranchStub = stub
+}
+
+func (fake *FakeBranchCutImpl) GetKubeVersionForBranchArgsForCall(i int) (release.VersionType, string) {
+	fake.getKubeVersionForBranchMutex.RLock()
+	defer fake.getKubeVersionForBranchMutex.RUnlock()
+	argsForCall := fake.getKubeVersionForBranchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBranchCutImpl) GetKubeVersionForBranchReturns(result1 string, result2 error) {
+	fake.getKubeVersionForBranchMutex.Lock()
+	defer fake.getKubeVersionForBranchMutex.Unlock()
+	fake.GetKubeVersionForBranchStub = nil
+	fake.getKubeVersionForBranchReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBranchCutImpl) GetKubeVersionForBranchReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getKubeVersionForBranchMutex.Lock()
+	defer fake.getKubeVersionForBranchMutex.Unlock()
+	fake.GetKubeVersionForBranchStub = nil
+	if fake.getKubeVersionForBranchReturnsOnCall == nil {
+		fake.getKubeVersionForBranchReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getKubeVersionForBranchReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBranchCutImpl) IsEnvSet(arg1 string) bool {
+	fake.isEnvSetMutex.Lock()
+	ret, specificReturn := fake.isEnvSetReturnsOnCall[len(fake.isEnvSetArgsForCall)]
+	fake.isEnvSetArgsForCall = append(fake.isEnvSetArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.IsEnvSetStub
+	fakeReturns := fake.isEnvSetReturns
+	fake.recordInvocation("IsEnvSet", []interface{}{arg1})
+	fake.isEnvSetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBranchCutImpl) IsEnvSetCallCount() int {
+	fake.isEnvSetMutex.RLock()
+	defer fake.isEnvSetMutex.RUnlock()
+	return len(fake.isEnvSetArgsForCall)
+}
+
+func (fake *FakeBranchCutImpl) IsEnvSetCalls(stub func(string) bool) {
+	fake.isEnvSetMutex.Lock()
+	defer fake.isEnvSetMutex.Unlock()
+	fake.IsEnvSetStub = stub
+}
+
+func (fake *FakeBranchCutImpl) IsEnvSetArgsForCall(i int) string {
+	fake.isEnvSetMutex.RLock()
+	defer fake.isEnvSetMutex.RUnlock()
+	argsForCall := fake.isEnvSetArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBranchCutImpl) IsEnvSetReturns(result1 bool) {
+	fake.isEnvSetMutex.Lock()
+	defer fake.isEnvSetMutex.Unlock()
+	fake.IsEnvSetStub = nil
+	fake.isEnvSetReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBranchCutImpl) IsEnvSetReturnsOnCall(i int, result1 bool) {
+	fake.isEnvSetMutex.Lock()
+	defer fake.isEnvSetMutex.Unlock()
+	fake.IsEnvSetStub = nil
+	if fake.isEnvSetReturnsOnCall == nil {
+		fake.isEnvSetReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isEnvSetReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBranchCutImpl) SendAnnouncement(arg1 *branchcut.Options) error {
+	fake.sendAnnouncementMutex.Lock()
+	ret, specificReturn := fake.sendAnnouncementReturnsOnCall[len(fake.sendAnnouncementArgsForCall)]
+	fake.sendAnnouncementArgsForCall = append(fake.sendAnnouncementArgsForCall, struct {
+		arg1 *branchcut.Options
+	}{arg1})
+	stub := fake.SendAnnouncementStub
+	fakeReturns := fake.sendAnnouncementReturns
+	fake.recordInvocation("SendAnnouncement", []interface{}{arg1})
+	fake.sendAnnouncementMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBranchCutImpl) SendAnnouncementCallCount() int {
+	fake.sendAnnouncementMutex.RLock()
+	defer fake.sendAnnouncementMutex.RUnlock()
+	return len(fake.sendAnnouncementArgsForCall)
+}
+
+func (fake *FakeBranchCutImpl) SendAnnouncementCalls(stub func(*branchcut.Options) error) {
+	fake.sendAnnouncementMutex.Lock()
+	defer fake.sendAnnouncementMutex.Unlock()
+	fake.SendAnnouncementStub = stub
+}
+
+func (fake *FakeBranchCutImpl) SendAnnouncementArgsForCall(i int) *branchcut.Options {
+	fake.sendAnnouncementMutex.RLock()
+	defer fake.sendAnnouncementMutex.RUnlock()
+	argsForCall := fake.sendAnnouncementArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBranchCutImpl) SendAnnouncementReturns(result1 error) {
+	fake.sendAnnouncementMutex.Lock()
+	defer fake.sendAnnouncementMutex.Unlock()
+	fake.SendAnnouncementStub = nil
+	fake.sendAnnouncementReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBranchCutImpl) SendAnnouncementReturnsOnCall(i int, result1 error) {
+	fake.sendAnnouncementMutex.Lock()
+	defer fake.sendAnnouncementMutex.Unlock()
+	fake.SendAnnouncementStub = nil
+	if fake.sendAnnouncementReturnsOnCall == nil {
+		fake.sendAnnouncementReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.sendAnnouncementReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBranchCutImpl) SubmitRelease(arg1 *anago.ReleaseOptions) error {
+	fake.submitReleaseMutex.Lock()
+	ret, specificReturn := fake.submitReleaseReturnsOnCall[len(fake.submitReleaseArgsForCall)]
+	fake.submitReleaseArgsForCall = append(fake.submitReleaseArgsForCall, struct {
+		arg1 *anago.ReleaseOptions
+	}{arg1})
+	stub := fake.SubmitReleaseStub
+	fakeReturns := fake.submitReleaseReturns
+	fake.recordInvocation("SubmitRelease", []interface{}{arg1})
+	fake.submitReleaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBranchCutImpl) SubmitReleaseCallCount() int {
+	fake.submitReleaseMutex.RLock()
+	defer fake.submitReleaseMutex.RUnlock()
+	return len(fake.submitReleaseArgsForCall)
+}
+
+func (fake *FakeBranchCutImpl) SubmitReleaseCalls(stub func(*anago.ReleaseOptions) error) {
+	fake.submitReleaseMutex.Lock()
+	defer fake.submitReleaseMutex.Unlock()
+	fake.SubmitReleaseStub = stub
+}
+
+func (fake *FakeBranchCutImpl) SubmitReleaseArgsForCall(i int) *anago.ReleaseOptions {
+	fake.submitReleaseMutex.RLock()
+	defer fake.submitReleaseMutex.RUnlock()
+	argsForCall := fake.submitReleaseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBranchCutImpl) SubmitReleaseReturns(result1 error) {
+	fake.submitReleaseMutex.Lock()
+	defer fake.submitReleaseMutex.Unlock()
+	fake.SubmitReleaseStub = nil
+	fake.submitReleaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBranchCutImpl) SubmitReleaseReturnsOnCall(i int, result1 error) {
+	fake.submitReleaseMutex.Lock()
+	defer fake.submitReleaseMutex.Unlock()
+	fake.SubmitReleaseStub = nil
+	if fake.submitReleaseReturnsOnCall == nil {
+		fake.submitReleaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.submitReleaseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBranchCutImpl) SubmitStage(arg1 *anago.StageOptions) error {
+	fake.submitStageMutex.Lock()
+	ret, specificReturn := fake.submitStageReturnsOnCall[len(fake.submitStageArgsForCall)]
+	fake.submitStageArgsForCall = append(fake.submitStageArgsForCall, struct {
+		arg1 *anago.StageOptions
+	}{arg1})
+	stub := fake.SubmitStageStub
+	fakeReturns := fake.submitStageReturns
+	fake.recordInvocation("SubmitStage", []interface{}{arg1})
+	fake.submitStageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBranchCutImpl) SubmitStageCallCount() int {
+	fake.submitStageMutex.RLock()
+	defer fake.submitStageMutex.RUnlock()
+	return len(fake.submitStageArgsForCall)
+}
+
+func (fake *FakeBranchCutImpl) SubmitStageCalls(stub func(*anago.StageOptions) error) {
+	fake.submitStageMutex.Lock()
+	defer fake.submitStageMutex.Unlock()
+	fake.SubmitStageStub = stub
+}
+
+func (fake *FakeBranchCutImpl) SubmitStageArgsForCall(i int) *anago.StageOptions {
+	fake.submitStageMutex.RLock()
+	defer fake.submitStageMutex.RUnlock()
+	argsForCall := fake.submitStageArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBranchCutImpl) SubmitStageReturns(result1 error) {
+	fake.submitStageMutex.Lock()
+	defer fake.submitStageMutex.Unlock()
+	fake.SubmitStageStub = nil
+	fake.submitStageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBranchCutImpl) SubmitStageReturnsOnCall(i int, result1 error) {
+	fake.submitStageMutex.Lock()
+	defer fake.submitStageMutex.Unlock()
+	fake.SubmitStageStub = nil
+	if fake.submitStageReturnsOnCall == nil {
+		fake.submitStageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.submitStageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBranchCutImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.branchNeedsCreationMutex.RLock()
+	defer fake.branchNeedsCreationMutex.RUnlock()
+	fake.createAnnouncementMutex.RLock()
+	defer fake.createAnnouncementMutex.RUnlock()
+	fake.createPubBotBranchIssueMutex.RLock()
+	defer fake.createPubBotBranchIssueMutex.RUnlock()
+	fake.getKubeVersionForBranchMutex.RLock()
+	defer fake.getKubeVersionForBranchMutex.RUnlock()
+	fake.isEnvSetMutex.RLock()
+	defer fake.isEnvSetMutex.RUnlock()
+	fake.sendAnnouncementMutex.RLock()
+	defer fake.sendAnnouncementMutex.RUnlock()
+	fake.submitReleaseMutex.RLock()
+	defer fake.submitReleaseMutex.RUnlock()
+	fake.submitStageMutex.RLock()
+	defer fake.submitStageMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeBranchCutImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
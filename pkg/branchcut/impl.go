@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package branchcut
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/anago"
+	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/mail"
+	"k8s.io/release/pkg/release"
+)
+
+//counterfeiter:generate . branchCutImpl
+type branchCutImpl interface {
+	IsEnvSet(key string) bool
+	GetKubeVersionForBranch(versionType release.VersionType, branch string) (string, error)
+	BranchNeedsCreation(
+		branch, releaseType string, buildVersion semver.Version,
+	) (bool, error)
+	SubmitStage(options *anago.StageOptions) error
+	SubmitRelease(options *anago.ReleaseOptions) error
+	CreatePubBotBranchIssue(branch string) error
+	CreateAnnouncement(options *announce.Options) error
+	SendAnnouncement(options *Options) error
+}
+
+type defaultBranchCutImpl struct{}
+
+func (*defaultBranchCutImpl) IsEnvSet(key string) bool {
+	return os.Getenv(key) != ""
+}
+
+func (*defaultBranchCutImpl) GetKubeVersionForBranch(
+	versionType release.VersionType, branch string,
+) (string, error) {
+	return release.NewVersion().GetKubeVersionForBranch(versionType, branch)
+}
+
+func (*defaultBranchCutImpl) BranchNeedsCreation(
+	branch, releaseType string, buildVersion semver.Version,
+) (bool, error) {
+	return release.NewBranchChecker().NeedsCreation(
+		branch, releaseType, buildVersion,
+	)
+}
+
+func (*defaultBranchCutImpl) SubmitStage(options *anago.StageOptions) error {
+	if err := options.Validate(&anago.State{}); err != nil {
+		return errors.Wrap(err, "prechecking stage options")
+	}
+	return anago.NewStage(options).Submit(true)
+}
+
+func (*defaultBranchCutImpl) SubmitRelease(options *anago.ReleaseOptions) error {
+	if err := options.Validate(&anago.State{}); err != nil {
+		return errors.Wrap(err, "prechecking release options")
+	}
+	return anago.NewRelease(options).Submit(true)
+}
+
+func (*defaultBranchCutImpl) CreatePubBotBranchIssue(branch string) error {
+	return release.CreatePubBotBranchIssue(branch)
+}
+
+func (*defaultBranchCutImpl) CreateAnnouncement(options *announce.Options) error {
+	return announce.CreateForBranch(options)
+}
+
+func (*defaultBranchCutImpl) SendAnnouncement(options *Options) error {
+	subject, err := os.ReadFile(filepath.Join(options.WorkDir, announce.SubjectFile))
+	if err != nil {
+		return errors.Wrap(err, "reading announcement subject")
+	}
+	content, err := os.ReadFile(filepath.Join(options.WorkDir, announce.AnnouncementFile))
+	if err != nil {
+		return errors.Wrap(err, "reading announcement")
+	}
+
+	m := mail.NewSender(options.SendgridAPIKey)
+	if options.MailSenderName != "" && options.MailSenderEmail != "" {
+		if err := m.SetSender(options.MailSenderName, options.MailSenderEmail); err != nil {
+			return errors.Wrap(err, "unable to set mail sender")
+		}
+	} else {
+		logrus.Info("Retrieving default sender from sendgrid API")
+		if err := m.SetDefaultSender(); err != nil {
+			return errors.Wrap(err, "setting default sender")
+		}
+	}
+
+	groups := []mail.GoogleGroup{mail.KubernetesAnnounceTestGoogleGroup}
+	if options.NoMock {
+		groups = []mail.GoogleGroup{
+			mail.KubernetesAnnounceGoogleGroup,
+			mail.KubernetesDevGoogleGroup,
+		}
+	}
+	logrus.Infof("Using Google Groups as announcement target: %v", groups)
+
+	if err := m.SetGoogleGroupRecipients(groups...); err != nil {
+		return errors.Wrap(err, "unable to set mail recipients")
+	}
+
+	if err := m.Send(string(content), strings.TrimSpace(string(subject))); err != nil {
+		return errors.Wrap(err, "unable to send mail")
+	}
+	return nil
+}
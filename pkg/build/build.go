@@ -119,6 +119,17 @@ type Options struct {
 	// Validate that the remote image digests exists.
 	ValidateRemoteImageDigests bool
 
+	// Path to the private key used to sign the published container images
+	// and manifest lists. Encrypted cosign keys are decrypted using the
+	// password from the `COSIGN_PASSWORD` environment variable. Images are not
+	// signed if empty.
+	ImageSigningKey string
+
+	// Path to the public key used to verify the image signatures during the
+	// remote image validation. Defaults to the public key of
+	// `ImageSigningKey` if empty.
+	ImageVerificationKey string
+
 	// Stage additional files defined by `ExtraGcpStageFiles` and
 	// `ExtraWindowsStageFiles`, otherwise they will be skipped.
 	StageExtraFiles bool
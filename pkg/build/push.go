@@ -29,6 +29,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/sign"
 	"sigs.k8s.io/release-utils/tar"
 	"sigs.k8s.io/release-utils/util"
 )
@@ -426,6 +427,9 @@ func (bi *Instance) PushContainerImages() error {
 	}
 
 	images := release.NewImages()
+	if err := bi.setImageSigning(images); err != nil {
+		return errors.Wrap(err, "set up image signing")
+	}
 	logrus.Infof("Publishing container images for %s", bi.opts.Version)
 
 	if err := images.Publish(
@@ -448,6 +452,36 @@ func (bi *Instance) PushContainerImages() error {
 	return nil
 }
 
+// setImageSigning configures the signer and verifier of images based on the
+// `ImageSigningKey` and `ImageVerificationKey` options.
+func (bi *Instance) setImageSigning(images *release.Images) error {
+	if bi.opts.ImageSigningKey != "" {
+		logrus.Infof("Signing images with key %s", bi.opts.ImageSigningKey)
+		signer, err := sign.NewSignerFromFile(
+			bi.opts.ImageSigningKey, []byte(os.Getenv(sign.PasswordEnvKey)),
+		)
+		if err != nil {
+			return errors.Wrap(err, "load image signing key")
+		}
+		images.SetSigner(signer)
+		images.SetVerifier(signer.Verifier())
+	}
+
+	if bi.opts.ImageVerificationKey != "" {
+		logrus.Infof(
+			"Verifying image signatures with key %s",
+			bi.opts.ImageVerificationKey,
+		)
+		verifier, err := sign.NewVerifierFromFile(bi.opts.ImageVerificationKey)
+		if err != nil {
+			return errors.Wrap(err, "load image verification key")
+		}
+		images.SetVerifier(verifier)
+	}
+
+	return nil
+}
+
 // CopyStagedFromGCS copies artifacts from GCS and between buckets as needed.
 // TODO: Investigate if it's worthwhile to use any of the bi.objStore.Get*Path()
 //       functions here or create a new one to populate staging paths
@@ -45,6 +45,7 @@ type Options struct {
 	JSONFile     string
 	RecordDir    string
 	ReplayDir    string
+	CacheDir     string
 	CVEDataDir   string
 	CloneCVEMaps bool
 	Dependencies bool
@@ -252,6 +253,7 @@ func (c *Changelog) generateReleaseNotes(
 	notesOptions.Debug = logrus.StandardLogger().Level >= logrus.DebugLevel
 	notesOptions.RecordDir = c.options.RecordDir
 	notesOptions.ReplayDir = c.options.ReplayDir
+	notesOptions.CacheDir = c.options.CacheDir
 	notesOptions.Pull = false
 
 	if c.options.CVEDataDir != "" {
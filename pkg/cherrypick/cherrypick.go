@@ -0,0 +1,411 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cherrypick
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	gogithub "github.com/google/go-github/v39/github"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"sigs.k8s.io/release-sdk/git"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+// Status is the classification of a cherry pick pull request.
+type Status string
+
+const (
+	// StatusReady means that the pull request can be merged.
+	StatusReady Status = "ready"
+
+	// StatusNeedsAttention means that the pull request lacks approvals or
+	// its tests are still running.
+	StatusNeedsAttention Status = "needs-attention"
+
+	// StatusBlocked means that the pull request cannot merge until its author
+	// acts, for example because of failing tests or a hold.
+	StatusBlocked Status = "blocked"
+)
+
+// The labels every cherry pick requires before it can merge.
+var requiredLabels = []string{"lgtm", "approved", "cherry-pick-approved"}
+
+// Labels which block a pull request until its author acts.
+var blockingLabels = []string{"needs-rebase", "needs-ok-to-test"}
+
+const (
+	// All do-not-merge labels block a pull request, except the ones which
+	// only signal a missing approval or release note.
+	doNotMergeLabelPrefix      = "do-not-merge/"
+	cherryPickNotApprovedLabel = "do-not-merge/cherry-pick-not-approved"
+	releaseNoteNeededLabel     = "do-not-merge/release-note-label-needed"
+)
+
+const dateLayout = "2006-01-02"
+
+// Options are the settings for creating a cherry pick report.
+type Options struct {
+	// GitHub organization and repository of the pull requests.
+	Org  string
+	Repo string
+
+	// Branch is the release branch, for example release-1.22.
+	Branch string
+
+	// ScheduleFile is the patch release schedule of the schedule-builder,
+	// used for looking up the next patch release and the cherry pick
+	// deadline. Optional.
+	ScheduleFile string
+
+	// Deadline overrides the cherry pick deadline of the schedule, in the
+	// format YYYY-MM-DD.
+	Deadline string
+
+	// RecordDir records all GitHub API calls, cannot be used together with
+	// ReplayDir.
+	RecordDir string
+
+	// ReplayDir replays previously recorded GitHub API calls.
+	ReplayDir string
+}
+
+// DefaultOptions returns a new Options instance for kubernetes/kubernetes.
+func DefaultOptions() *Options {
+	return &Options{
+		Org:  git.DefaultGithubOrg,
+		Repo: git.DefaultGithubRepo,
+	}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if o.Branch == git.DefaultBranch || !git.IsReleaseBranch(o.Branch) {
+		return errors.Errorf("%q is not a valid release branch", o.Branch)
+	}
+	if o.Deadline != "" {
+		if _, err := time.Parse(dateLayout, o.Deadline); err != nil {
+			return errors.Wrapf(err, "parsing deadline %s", o.Deadline)
+		}
+	}
+	return nil
+}
+
+// PullRequest is a cherry pick pull request and its classification.
+type PullRequest struct {
+	Number  int      `json:"number"`
+	Title   string   `json:"title"`
+	URL     string   `json:"url"`
+	Author  string   `json:"author"`
+	Status  Status   `json:"status"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// Report is the state of the cherry pick queue of a release branch.
+type Report struct {
+	Branch       string         `json:"branch"`
+	PatchRelease string         `json:"patchRelease,omitempty"`
+	Deadline     *time.Time     `json:"deadline,omitempty"`
+	Date         time.Time      `json:"date"`
+	PullRequests []*PullRequest `json:"pullRequests"`
+}
+
+// CherryPicks creates reports of the cherry pick queue.
+type CherryPicks struct {
+	client  Client
+	options *Options
+}
+
+// New creates a new CherryPicks instance, which needs a client set via
+// SetClient.
+func New(options *Options) *CherryPicks {
+	return &CherryPicks{options: options}
+}
+
+// SetClient can be used to set the GitHub client.
+func (c *CherryPicks) SetClient(client Client) {
+	c.client = client
+}
+
+// Report lists and classifies all open pull requests against the release
+// branch.
+func (c *CherryPicks) Report() (*Report, error) {
+	if err := c.options.Validate(); err != nil {
+		return nil, errors.Wrap(err, "validating options")
+	}
+
+	report := &Report{Branch: c.options.Branch, Date: time.Now().UTC()}
+	if err := c.setSchedule(report); err != nil {
+		return nil, errors.Wrap(err, "reading patch release schedule")
+	}
+
+	logrus.Infof(
+		"Listing open pull requests for %s/%s on branch %s",
+		c.options.Org, c.options.Repo, c.options.Branch,
+	)
+	prs, err := c.listPullRequests()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing pull requests")
+	}
+
+	for _, pr := range prs {
+		result, err := c.classify(pr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "classifying pull request #%d", pr.GetNumber())
+		}
+		report.PullRequests = append(report.PullRequests, result)
+	}
+	sort.SliceStable(report.PullRequests, func(i, j int) bool {
+		return report.PullRequests[i].Number < report.PullRequests[j].Number
+	})
+	return report, nil
+}
+
+func (c *CherryPicks) listPullRequests() ([]*gogithub.PullRequest, error) {
+	opts := &gogithub.PullRequestListOptions{
+		State:       "open",
+		Base:        c.options.Branch,
+		ListOptions: gogithub.ListOptions{PerPage: 100},
+	}
+	prs, resp, err := c.client.ListPullRequests(
+		context.Background(), c.options.Org, c.options.Repo, opts,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return prs, nil
+	}
+
+	for page := 2; page <= resp.LastPage; page++ {
+		opts.ListOptions.Page = page
+		result, _, err := c.client.ListPullRequests(
+			context.Background(), c.options.Org, c.options.Repo, opts,
+		)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, result...)
+	}
+	return prs, nil
+}
+
+func (c *CherryPicks) classify(pr *gogithub.PullRequest) (*PullRequest, error) {
+	result := &PullRequest{
+		Number: pr.GetNumber(),
+		Title:  pr.GetTitle(),
+		URL:    pr.GetHTMLURL(),
+		Author: pr.GetUser().GetLogin(),
+	}
+
+	labels := map[string]bool{}
+	for _, label := range pr.Labels {
+		labels[label.GetName()] = true
+	}
+
+	var blocked, attention []string
+
+	if pr.GetDraft() {
+		blocked = append(blocked, "draft")
+	}
+
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.HasPrefix(name, doNotMergeLabelPrefix) &&
+			name != cherryPickNotApprovedLabel && name != releaseNoteNeededLabel {
+			blocked = append(blocked, name)
+		}
+	}
+	for _, name := range blockingLabels {
+		if labels[name] {
+			blocked = append(blocked, name)
+		}
+	}
+
+	for _, name := range requiredLabels {
+		if !labels[name] {
+			attention = append(attention, "missing "+name)
+		}
+	}
+	if labels[releaseNoteNeededLabel] {
+		attention = append(attention, "missing release-note")
+	}
+
+	status, _, err := c.client.GetCombinedStatus(
+		context.Background(), c.options.Org, c.options.Repo,
+		pr.GetHead().GetSHA(), &gogithub.ListOptions{PerPage: 100},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting combined status")
+	}
+	switch status.GetState() {
+	case "success":
+	case "pending":
+		attention = append(attention, "tests pending")
+	default:
+		failing := []string{}
+		for _, s := range status.Statuses {
+			if s.GetState() == "failure" || s.GetState() == "error" {
+				failing = append(failing, s.GetContext())
+			}
+		}
+		sort.Strings(failing)
+		if len(failing) > 0 {
+			blocked = append(blocked, "failing "+strings.Join(failing, ", "))
+		} else {
+			blocked = append(blocked, "tests "+status.GetState())
+		}
+	}
+
+	switch {
+	case len(blocked) > 0:
+		result.Status = StatusBlocked
+	case len(attention) > 0:
+		result.Status = StatusNeedsAttention
+	default:
+		result.Status = StatusReady
+	}
+	result.Reasons = append(blocked, attention...)
+	return result, nil
+}
+
+// patchSchedule is the part of the schedule-builder patch release schedule
+// used for the report. Unquoted versions like 1.20 are kept as they are by
+// yaml.v2, in contrast to sigs.k8s.io/yaml which parses them as float.
+type patchSchedule struct {
+	Schedules []struct {
+		Release            string `yaml:"release"`
+		Next               string `yaml:"next"`
+		CherryPickDeadline string `yaml:"cherryPickDeadline"`
+	} `yaml:"schedules"`
+}
+
+func (c *CherryPicks) setSchedule(report *Report) error {
+	deadline := c.options.Deadline
+
+	if c.options.ScheduleFile != "" {
+		content, err := os.ReadFile(c.options.ScheduleFile)
+		if err != nil {
+			return errors.Wrap(err, "reading schedule file")
+		}
+		schedule := &patchSchedule{}
+		if err := yaml.Unmarshal(content, schedule); err != nil {
+			return errors.Wrap(err, "unmarshal schedule file")
+		}
+
+		release := strings.TrimPrefix(c.options.Branch, "release-")
+		found := false
+		for _, s := range schedule.Schedules {
+			if s.Release != release {
+				continue
+			}
+			found = true
+			report.PatchRelease = s.Next
+			if deadline == "" {
+				deadline = s.CherryPickDeadline
+			}
+		}
+		if !found {
+			logrus.Warnf("No patch release scheduled for %s", release)
+		}
+	}
+
+	if deadline == "" {
+		return nil
+	}
+	parsed, err := time.Parse(dateLayout, deadline)
+	if err != nil {
+		return errors.Wrapf(err, "parsing cherry pick deadline %s", deadline)
+	}
+	report.Deadline = &parsed
+	return nil
+}
+
+// ByStatus returns the pull requests with the provided status.
+func (r *Report) ByStatus(status Status) (prs []*PullRequest) {
+	for _, pr := range r.PullRequests {
+		if pr.Status == status {
+			prs = append(prs, pr)
+		}
+	}
+	return prs
+}
+
+// Markdown renders the report for the patch release issue.
+func (r *Report) Markdown() string {
+	var sb strings.Builder
+
+	title := r.Branch
+	if r.PatchRelease != "" {
+		title = fmt.Sprintf("%s (v%s)", r.Branch, strings.TrimPrefix(r.PatchRelease, "v"))
+	}
+	sb.WriteString(fmt.Sprintf("## Cherry picks for %s\n\n", title))
+
+	if r.Deadline != nil {
+		days := int(r.Deadline.Sub(r.Date.Truncate(24*time.Hour)).Hours() / 24)
+		remaining := fmt.Sprintf("%d days left", days)
+		switch {
+		case days == 0:
+			remaining = "today"
+		case days < 0:
+			remaining = fmt.Sprintf("passed %d days ago", -days)
+		}
+		sb.WriteString(fmt.Sprintf(
+			"Cherry pick deadline: **%s** (%s)\n\n",
+			r.Deadline.Format(dateLayout), remaining,
+		))
+	}
+
+	for _, section := range []struct {
+		title  string
+		status Status
+	}{
+		{"Ready", StatusReady},
+		{"Needs attention", StatusNeedsAttention},
+		{"Blocked", StatusBlocked},
+	} {
+		prs := r.ByStatus(section.status)
+		sb.WriteString(fmt.Sprintf("### %s (%d)\n\n", section.title, len(prs)))
+		if len(prs) == 0 {
+			sb.WriteString("_None_\n\n")
+			continue
+		}
+		for _, pr := range prs {
+			sb.WriteString(fmt.Sprintf(
+				"- [ ] [#%d](%s) %s (@%s)", pr.Number, pr.URL, pr.Title, pr.Author,
+			))
+			if len(pr.Reasons) > 0 {
+				sb.WriteString(": " + strings.Join(pr.Reasons, ", "))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cherrypick_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	gogithub "github.com/google/go-github/v39/github"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/cherrypick"
+	"k8s.io/release/pkg/cherrypick/cherrypickfakes"
+)
+
+const testSchedule = `schedules:
+- release: 1.22
+  next: 1.22.4
+  cherryPickDeadline: 2021-11-12
+  targetDate: 2021-11-17
+- release: 1.20
+  next: 1.20.13
+  cherryPickDeadline: 2021-11-12
+  targetDate: 2021-11-17
+`
+
+func testPullRequest(number int, sha string, draft bool, labels ...string) *gogithub.PullRequest {
+	pr := &gogithub.PullRequest{
+		Number:  gogithub.Int(number),
+		Title:   gogithub.String("Automated cherry pick of #1"),
+		HTMLURL: gogithub.String("https://github.com/kubernetes/kubernetes/pull/1"),
+		User:    &gogithub.User{Login: gogithub.String("author")},
+		Draft:   gogithub.Bool(draft),
+		Head:    &gogithub.PullRequestBranch{SHA: gogithub.String(sha)},
+	}
+	for _, label := range labels {
+		pr.Labels = append(pr.Labels, &gogithub.Label{Name: gogithub.String(label)})
+	}
+	return pr
+}
+
+func testCombinedStatus(state string, failing ...string) *gogithub.CombinedStatus {
+	status := &gogithub.CombinedStatus{State: gogithub.String(state)}
+	for _, context := range failing {
+		status.Statuses = append(status.Statuses, &gogithub.RepoStatus{
+			Context: gogithub.String(context), State: gogithub.String("failure"),
+		})
+	}
+	return status
+}
+
+func newTestClient() *cherrypickfakes.FakeClient {
+	client := &cherrypickfakes.FakeClient{}
+	client.ListPullRequestsReturns([]*gogithub.PullRequest{
+		testPullRequest(3, "failing", false, "lgtm", "approved", "cherry-pick-approved"),
+		testPullRequest(1, "success", false, "lgtm", "approved", "cherry-pick-approved"),
+		testPullRequest(2, "pending", false,
+			"lgtm", "do-not-merge/cherry-pick-not-approved",
+			"do-not-merge/release-note-label-needed",
+		),
+		testPullRequest(4, "success", true, "lgtm", "approved", "do-not-merge/hold"),
+	}, &gogithub.Response{}, nil)
+	client.GetCombinedStatusReturnsOnCall(0, testCombinedStatus("failure", "pull-kubernetes-e2e-gce"), nil, nil)
+	client.GetCombinedStatusReturnsOnCall(1, testCombinedStatus("success"), nil, nil)
+	client.GetCombinedStatusReturnsOnCall(2, testCombinedStatus("pending"), nil, nil)
+	client.GetCombinedStatusReturnsOnCall(3, testCombinedStatus("success"), nil, nil)
+	return client
+}
+
+func TestReport(t *testing.T) {
+	scheduleFile := filepath.Join(t.TempDir(), "schedule.yaml")
+	require.Nil(t, os.WriteFile(scheduleFile, []byte(testSchedule), os.FileMode(0o644)))
+
+	opts := cherrypick.DefaultOptions()
+	opts.Branch = "release-1.20"
+	opts.ScheduleFile = scheduleFile
+
+	sut := cherrypick.New(opts)
+	sut.SetClient(newTestClient())
+	report, err := sut.Report()
+	require.Nil(t, err)
+
+	require.Equal(t, "1.20.13", report.PatchRelease)
+	require.NotNil(t, report.Deadline)
+	require.Equal(t, "2021-11-12", report.Deadline.Format("2006-01-02"))
+
+	require.Len(t, report.PullRequests, 4)
+	for i, expected := range []struct {
+		status  cherrypick.Status
+		reasons []string
+	}{
+		{status: cherrypick.StatusReady},
+		{
+			status: cherrypick.StatusNeedsAttention,
+			reasons: []string{
+				"missing approved", "missing cherry-pick-approved",
+				"missing release-note", "tests pending",
+			},
+		},
+		{
+			status:  cherrypick.StatusBlocked,
+			reasons: []string{"failing pull-kubernetes-e2e-gce"},
+		},
+		{
+			status: cherrypick.StatusBlocked,
+			reasons: []string{
+				"draft", "do-not-merge/hold", "missing cherry-pick-approved",
+			},
+		},
+	} {
+		pr := report.PullRequests[i]
+		require.Equal(t, i+1, pr.Number)
+		require.Equal(t, expected.status, pr.Status, pr.Reasons)
+		require.Equal(t, expected.reasons, pr.Reasons)
+	}
+
+	markdown := report.Markdown()
+	require.Contains(t, markdown, "## Cherry picks for release-1.20 (v1.20.13)")
+	require.Contains(t, markdown, "Cherry pick deadline: **2021-11-12**")
+	require.Contains(t, markdown, "### Ready (1)")
+	require.Contains(t, markdown, "### Needs attention (1)")
+	require.Contains(t, markdown, "### Blocked (2)")
+}
+
+func TestReportFailure(t *testing.T) {
+	for _, tc := range []struct {
+		prepare func(*cherrypick.Options, *cherrypickfakes.FakeClient)
+	}{
+		{ // invalid branch
+			prepare: func(opts *cherrypick.Options, _ *cherrypickfakes.FakeClient) {
+				opts.Branch = "master"
+			},
+		},
+		{ // invalid deadline
+			prepare: func(opts *cherrypick.Options, _ *cherrypickfakes.FakeClient) {
+				opts.Deadline = "tomorrow"
+			},
+		},
+		{ // listing pull requests fails
+			prepare: func(_ *cherrypick.Options, client *cherrypickfakes.FakeClient) {
+				client.ListPullRequestsReturns(nil, nil, errors.New("error"))
+			},
+		},
+		{ // getting the status fails
+			prepare: func(_ *cherrypick.Options, client *cherrypickfakes.FakeClient) {
+				client.GetCombinedStatusReturnsOnCall(0, nil, nil, errors.New("error"))
+			},
+		},
+	} {
+		opts := cherrypick.DefaultOptions()
+		opts.Branch = "release-1.22"
+		client := newTestClient()
+		tc.prepare(opts, client)
+
+		sut := cherrypick.New(opts)
+		sut.SetClient(client)
+		_, err := sut.Report()
+		require.NotNil(t, err)
+	}
+}
+
+func TestRecordReplay(t *testing.T) {
+	recordDir := t.TempDir()
+	opts := cherrypick.DefaultOptions()
+	opts.Branch = "release-1.22"
+
+	sut := cherrypick.New(opts)
+	sut.SetClient(cherrypick.NewRecorder(newTestClient(), recordDir))
+	recorded, err := sut.Report()
+	require.Nil(t, err)
+
+	opts.ReplayDir = recordDir
+	replayer, err := cherrypick.NewClient(opts)
+	require.Nil(t, err)
+
+	sut = cherrypick.New(opts)
+	sut.SetClient(replayer)
+	replayed, err := sut.Report()
+	require.Nil(t, err)
+	require.Equal(t, recorded.PullRequests, replayed.PullRequests)
+}
@@ -0,0 +1,237 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package cherrypickfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/go-github/v39/github"
+	"k8s.io/release/pkg/cherrypick"
+)
+
+type FakeClient struct {
+	GetCombinedStatusStub        func(context.Context, string, string, string, *github.ListOptions) (*github.CombinedStatus, *github.Response, error)
+	getCombinedStatusMutex       sync.RWMutex
+	getCombinedStatusArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 *github.ListOptions
+	}
+	getCombinedStatusReturns struct {
+		result1 *github.CombinedStatus
+		result2 *github.Response
+		result3 error
+	}
+	getCombinedStatusReturnsOnCall map[int]struct {
+		result1 *github.CombinedStatus
+		result2 *github.Response
+		result3 error
+	}
+	ListPullRequestsStub        func(context.Context, string, string, *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error)
+	listPullRequestsMutex       sync.RWMutex
+	listPullRequestsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 *github.PullRequestListOptions
+	}
+	listPullRequestsReturns struct {
+		result1 []*github.PullRequest
+		result2 *github.Response
+		result3 error
+	}
+	listPullRequestsReturnsOnCall map[int]struct {
+		result1 []*github.PullRequest
+		result2 *github.Response
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeClient) GetCombinedStatus(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+	fake.getCombinedStatusMutex.Lock()
+	ret, specificReturn := fake.getCombinedStatusReturnsOnCall[len(fake.getCombinedStatusArgsForCall)]
+	fake.getCombinedStatusArgsForCall = append(fake.getCombinedStatusArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 *github.ListOptions
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.GetCombinedStatusStub
+	fakeReturns := fake.getCombinedStatusReturns
+	fake.recordInvocation("GetCombinedStatus", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.getCombinedStatusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) GetCombinedStatusCallCount() int {
+	fake.getCombinedStatusMutex.RLock()
+	defer fake.getCombinedStatusMutex.RUnlock()
+	return len(fake.getCombinedStatusArgsForCall)
+}
+
+func (fake *FakeClient) GetCombinedStatusCalls(stub func(context.Context, string, string, string, *github.ListOptions) (*github.CombinedStatus, *github.Response, error)) {
+	fake.getCombinedStatusMutex.Lock()
+	defer fake.getCombinedStatusMutex.Unlock()
+	fake.GetCombinedStatusStub = stub
+}
+
+func (fake *FakeClient) GetCombinedStatusArgsForCall(i int) (context.Context, string, string, string, *github.ListOptions) {
+	fake.getCombinedStatusMutex.RLock()
+	defer fake.getCombinedStatusMutex.RUnlock()
+	argsForCall := fake.getCombinedStatusArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeClient) GetCombinedStatusReturns(result1 *github.CombinedStatus, result2 *github.Response, result3 error) {
+	fake.getCombinedStatusMutex.Lock()
+	defer fake.getCombinedStatusMutex.Unlock()
+	fake.GetCombinedStatusStub = nil
+	fake.getCombinedStatusReturns = struct {
+		result1 *github.CombinedStatus
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) GetCombinedStatusReturnsOnCall(i int, result1 *github.CombinedStatus, result2 *github.Response, result3 error) {
+	fake.getCombinedStatusMutex.Lock()
+	defer fake.getCombinedStatusMutex.Unlock()
+	fake.GetCombinedStatusStub = nil
+	if fake.getCombinedStatusReturnsOnCall == nil {
+		fake.getCombinedStatusReturnsOnCall = make(map[int]struct {
+			result1 *github.CombinedStatus
+			result2 *github.Response
+			result3 error
+		})
+	}
+	fake.getCombinedStatusReturnsOnCall[i] = struct {
+		result1 *github.CombinedStatus
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) ListPullRequests(arg1 context.Context, arg2 string, arg3 string, arg4 *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error) {
+	fake.listPullRequestsMutex.Lock()
+	ret, specificReturn := fake.listPullRequestsReturnsOnCall[len(fake.listPullRequestsArgsForCall)]
+	fake.listPullRequestsArgsForCall = append(fake.listPullRequestsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 *github.PullRequestListOptions
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ListPullRequestsStub
+	fakeReturns := fake.listPullRequestsReturns
+	fake.recordInvocation("ListPullRequests", []interface{}{arg1, arg2, arg3, arg4})
+	fake.listPullRequestsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) ListPullRequestsCallCount() int {
+	fake.listPullRequestsMutex.RLock()
+	defer fake.listPullRequestsMutex.RUnlock()
+	return len(fake.listPullRequestsArgsForCall)
+}
+
+func (fake *FakeClient) ListPullRequestsCalls(stub func(context.Context, string, string, *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error)) {
+	fake.listPullRequestsMutex.Lock()
+	defer fake.listPullRequestsMutex.Unlock()
+	fake.ListPullRequestsStub = stub
+}
+
+func (fake *FakeClient) ListPullRequestsArgsForCall(i int) (context.Context, string, string, *github.PullRequestListOptions) {
+	fake.listPullRequestsMutex.RLock()
+	defer fake.listPullRequestsMutex.RUnlock()
+	argsForCall := fake.listPullRequestsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeClient) ListPullRequestsReturns(result1 []*github.PullRequest, result2 *github.Response, result3 error) {
+	fake.listPullRequestsMutex.Lock()
+	defer fake.listPullRequestsMutex.Unlock()
+	fake.ListPullRequestsStub = nil
+	fake.listPullRequestsReturns = struct {
+		result1 []*github.PullRequest
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) ListPullRequestsReturnsOnCall(i int, result1 []*github.PullRequest, result2 *github.Response, result3 error) {
+	fake.listPullRequestsMutex.Lock()
+	defer fake.listPullRequestsMutex.Unlock()
+	fake.ListPullRequestsStub = nil
+	if fake.listPullRequestsReturnsOnCall == nil {
+		fake.listPullRequestsReturnsOnCall = make(map[int]struct {
+			result1 []*github.PullRequest
+			result2 *github.Response
+			result3 error
+		})
+	}
+	fake.listPullRequestsReturnsOnCall[i] = struct {
+		result1 []*github.PullRequest
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getCombinedStatusMutex.RLock()
+	defer fake.getCombinedStatusMutex.RUnlock()
+	fake.listPullRequestsMutex.RLock()
+	defer fake.listPullRequestsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ cherrypick.Client = new(FakeClient)
@@ -0,0 +1,236 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cherrypick
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	gogithub "github.com/google/go-github/v39/github"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+
+	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-utils/env"
+)
+
+// Client is the GitHub API used for querying the cherry pick queue. It
+// extends the operations of the release-sdk GitHub client, which does not
+// support listing pull requests by their base branch.
+//
+//counterfeiter:generate . Client
+type Client interface {
+	ListPullRequests(
+		context.Context, string, string, *gogithub.PullRequestListOptions,
+	) ([]*gogithub.PullRequest, *gogithub.Response, error)
+
+	GetCombinedStatus(
+		context.Context, string, string, string, *gogithub.ListOptions,
+	) (*gogithub.CombinedStatus, *gogithub.Response, error)
+}
+
+// NewClient returns a Client for the provided options. Depending on them,
+// it is either a real client talking to the GitHub API, a client which in
+// addition records the responses to RecordDir, or a client which replays
+// the responses from ReplayDir without talking to the GitHub API at all.
+func NewClient(opts *Options) (Client, error) {
+	if opts.ReplayDir != "" && opts.RecordDir != "" {
+		return nil, errors.New("please do not use record and replay together")
+	}
+
+	if opts.ReplayDir != "" {
+		return NewReplayer(opts.ReplayDir), nil
+	}
+
+	httpClient := http.DefaultClient
+	if token := env.Default(github.TokenEnvKey, ""); token != "" {
+		httpClient = oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: token},
+		))
+	}
+	client := &githubClient{gogithub.NewClient(httpClient)}
+
+	if opts.RecordDir != "" {
+		if err := os.MkdirAll(opts.RecordDir, os.FileMode(0o755)); err != nil {
+			return nil, errors.Wrap(err, "creating record dir")
+		}
+		return NewRecorder(client, opts.RecordDir), nil
+	}
+
+	return client, nil
+}
+
+type githubClient struct {
+	*gogithub.Client
+}
+
+func (g *githubClient) ListPullRequests(
+	ctx context.Context, owner, repo string, opts *gogithub.PullRequestListOptions,
+) ([]*gogithub.PullRequest, *gogithub.Response, error) {
+	return g.PullRequests.List(ctx, owner, repo, opts)
+}
+
+func (g *githubClient) GetCombinedStatus(
+	ctx context.Context, owner, repo, ref string, opts *gogithub.ListOptions,
+) (*gogithub.CombinedStatus, *gogithub.Response, error) {
+	return g.Repositories.GetCombinedStatus(ctx, owner, repo, ref, opts)
+}
+
+const (
+	apiListPullRequests  = "ListPullRequests"
+	apiGetCombinedStatus = "GetCombinedStatus"
+)
+
+// apiRecord is the on disk format of a recorded API call, which matches the
+// recordings of the release notes GitHub client.
+type apiRecord struct {
+	Result   interface{}
+	LastPage int
+}
+
+// NewRecorder returns a Client which records all responses of the provided
+// client to recordDir.
+func NewRecorder(client Client, recordDir string) Client {
+	return &recordClient{
+		client: client, recordDir: recordDir, state: map[string]int{},
+	}
+}
+
+type recordClient struct {
+	client    Client
+	recordDir string
+	mutex     sync.Mutex
+	state     map[string]int
+}
+
+func (c *recordClient) ListPullRequests(
+	ctx context.Context, owner, repo string, opts *gogithub.PullRequestListOptions,
+) ([]*gogithub.PullRequest, *gogithub.Response, error) {
+	prs, resp, err := c.client.ListPullRequests(ctx, owner, repo, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := c.record(apiListPullRequests, prs, resp); err != nil {
+		return nil, nil, err
+	}
+	return prs, resp, nil
+}
+
+func (c *recordClient) GetCombinedStatus(
+	ctx context.Context, owner, repo, ref string, opts *gogithub.ListOptions,
+) (*gogithub.CombinedStatus, *gogithub.Response, error) {
+	status, resp, err := c.client.GetCombinedStatus(ctx, owner, repo, ref, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := c.record(apiGetCombinedStatus, status, resp); err != nil {
+		return nil, nil, err
+	}
+	return status, resp, nil
+}
+
+func (c *recordClient) record(
+	api string, result interface{}, resp *gogithub.Response,
+) error {
+	logrus.Debugf("Recording API call %s to %s", api, c.recordDir)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := 0
+	if j, ok := c.state[api]; ok {
+		i = j + 1
+	}
+	c.state[api] = i
+
+	lastPage := 0
+	if resp != nil {
+		lastPage = resp.LastPage
+	}
+
+	content, err := json.MarshalIndent(&apiRecord{result, lastPage}, "", " ")
+	if err != nil {
+		return errors.Wrapf(err, "marshal %s record", api)
+	}
+	return os.WriteFile(
+		filepath.Join(c.recordDir, fmt.Sprintf("%s-%d.json", api, i)),
+		content, os.FileMode(0o644),
+	)
+}
+
+// NewReplayer returns a Client which replays the responses recorded to
+// replayDir.
+func NewReplayer(replayDir string) Client {
+	return &replayClient{replayDir: replayDir, state: map[string]int{}}
+}
+
+type replayClient struct {
+	replayDir string
+	mutex     sync.Mutex
+	state     map[string]int
+}
+
+func (c *replayClient) ListPullRequests(
+	context.Context, string, string, *gogithub.PullRequestListOptions,
+) ([]*gogithub.PullRequest, *gogithub.Response, error) {
+	result := []*gogithub.PullRequest{}
+	resp, err := c.replay(apiListPullRequests, &result)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, resp, nil
+}
+
+func (c *replayClient) GetCombinedStatus(
+	context.Context, string, string, string, *gogithub.ListOptions,
+) (*gogithub.CombinedStatus, *gogithub.Response, error) {
+	result := &gogithub.CombinedStatus{}
+	resp, err := c.replay(apiGetCombinedStatus, result)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, resp, nil
+}
+
+func (c *replayClient) replay(api string, result interface{}) (*gogithub.Response, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := 0
+	if j, ok := c.state[api]; ok {
+		i = j + 1
+	}
+	c.state[api] = i
+
+	path := filepath.Join(c.replayDir, fmt.Sprintf("%s-%d.json", api, i))
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading recorded API call %s", path)
+	}
+
+	record := &apiRecord{Result: result}
+	if err := json.Unmarshal(content, record); err != nil {
+		return nil, errors.Wrapf(err, "unmarshal recorded API call %s", path)
+	}
+	return &gogithub.Response{LastPage: record.LastPage}, nil
+}
@@ -52,7 +52,10 @@ func (cve *CVE) ReadRawInterface(cvedata interface{}) error {
 	if val, ok := cvedata.(map[interface{}]interface{})["vector"].(string); ok {
 		cve.CVSSVector = val
 	}
-	if val, ok := cvedata.(map[interface{}]interface{})["score"].(float64); ok {
+	switch val := cvedata.(map[interface{}]interface{})["score"].(type) {
+	case float64:
+		cve.CVSSScore = float32(val)
+	case int:
 		cve.CVSSScore = float32(val)
 	}
 	if val, ok := cvedata.(map[interface{}]interface{})["rating"].(string); ok {
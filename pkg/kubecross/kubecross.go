@@ -18,6 +18,7 @@ package kubecross
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -25,6 +26,10 @@ import (
 	"sigs.k8s.io/release-sdk/git"
 )
 
+// goVersionRegex matches the Go version of a kube-cross version like
+// v1.22.0-go1.16.6-buster.0
+var goVersionRegex = regexp.MustCompile(`-go(\d+\.\d+(?:\.\d+)?)`)
+
 // KubeCross is the main structure of this package.
 type KubeCross struct {
 	impl impl
@@ -58,3 +63,24 @@ func (k *KubeCross) ForBranch(branch string) (string, error) {
 	logrus.Infof("Retrieved kube-cross version: %s", version)
 	return version, nil
 }
+
+// GoVersionForBranch returns the Go version of the kubecross image for the
+// provided branch, for example "1.16.6".
+func (k *KubeCross) GoVersionForBranch(branch string) (string, error) {
+	version, err := k.ForBranch(branch)
+	if err != nil {
+		return "", errors.Wrap(err, "get kube-cross version")
+	}
+	return GoVersion(version)
+}
+
+// GoVersion returns the Go version contained in a kube-cross version.
+func GoVersion(kubecrossVersion string) (string, error) {
+	match := goVersionRegex.FindStringSubmatch(kubecrossVersion)
+	if len(match) != 2 {
+		return "", errors.Errorf(
+			"no Go version found in kube-cross version %s", kubecrossVersion,
+		)
+	}
+	return match[1], nil
+}
@@ -94,3 +94,39 @@ func TestForBranch(t *testing.T) {
 		tc.expect(res, err)
 	}
 }
+
+func TestGoVersion(t *testing.T) {
+	for _, tc := range []struct {
+		version   string
+		expected  string
+		shouldErr bool
+	}{
+		{version: "v1.22.0-go1.16.6-buster.0", expected: "1.16.6"},
+		{version: "v1.23.0-go1.17-bullseye.0", expected: "1.17"},
+		{version: "v1.20.0-1", shouldErr: true},
+	} {
+		res, err := GoVersion(tc.version)
+		if tc.shouldErr {
+			require.NotNil(t, err)
+			continue
+		}
+		require.Nil(t, err)
+		require.Equal(t, tc.expected, res)
+	}
+}
+
+func TestGoVersionForBranch(t *testing.T) {
+	mock := &kubecrossfakes.FakeImpl{}
+	mock.GetURLResponseReturns("v1.22.0-go1.16.6-buster.0", nil)
+
+	kc := New()
+	kc.impl = mock
+
+	res, err := kc.GoVersionForBranch("release-1.22")
+	require.Nil(t, err)
+	require.Equal(t, "1.16.6", res)
+
+	mock.GetURLResponseReturns("", errors.New(""))
+	_, err = kc.GoVersionForBranch("release-1.22")
+	require.NotNil(t, err)
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// credentialHeaders are the request headers carrying the API token of the
+// supported forges.
+var credentialHeaders = []string{"Authorization", "Private-Token"}
+
+// immutableRE matches the API paths of commits identified by a full SHA.
+// Their cached responses are used without asking the API again. The pull
+// requests of a commit are not immutable, because their body and labels can
+// be edited.
+var immutableRE = regexp.MustCompile(`/commits/[0-9a-f]{40}$`)
+
+// Transport is a http.RoundTripper which caches the successful responses of
+// GET requests on disk. The cache files are named after the request URL, so
+// for example the pull request 123 of kubernetes/kubernetes is stored in
+// `<dir>/api.github.com/repos/kubernetes/kubernetes/pulls/123.json`.
+// Responses of authenticated requests are stored below a directory named
+// after a hash of the credentials, like `<dir>/api.github.com/token-<hash>/`,
+// so a response visible to one token is never served to another one.
+//
+// Responses of commits identified by their SHA are served from the cache
+// without any request. All other responses, including the pull requests of a
+// commit, are revalidated by a conditional request using their `ETag` or
+// `Last-Modified` header, which does not count against the GitHub API rate
+// limit if the resource did not change.
+type Transport struct {
+	dir  string
+	next http.RoundTripper
+}
+
+// entry is the on disk format of a cached response.
+type entry struct {
+	URL    string      `json:"url"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+// NewTransport creates a new caching Transport which stores the responses in
+// dir and uses next for the requests, or http.DefaultTransport if next is nil.
+func NewTransport(dir string, next http.RoundTripper) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Transport{dir: dir, next: next}
+}
+
+// Client returns a http.Client using the Transport.
+func (t *Transport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// RoundTrip executes a single HTTP transaction and serves or revalidates the
+// response from the cache if possible.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return t.next.RoundTrip(req)
+	}
+
+	path := t.path(req)
+	cached, err := t.read(path)
+	if err != nil {
+		logrus.Warnf("Ignoring unreadable cache file %s: %v", path, err)
+		cached = nil
+	}
+
+	if cached != nil && immutableRE.MatchString(req.URL.Path) {
+		logrus.Debugf("Using cached response for %s", req.URL)
+		return cached.response(req, nil), nil
+	}
+
+	if cached != nil {
+		// Do not modify the original request, as required by the
+		// http.RoundTripper interface
+		req = req.Clone(req.Context())
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := cached.Header.Get("Last-Modified"); lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached != nil && resp.StatusCode == http.StatusNotModified {
+		logrus.Debugf("Using revalidated cached response for %s", req.URL)
+		resp.Body.Close()
+		return cached.response(req, resp.Header), nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading response of %s", req.URL)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err := t.write(path, &entry{
+		URL: req.URL.String(), Header: resp.Header, Body: body,
+	}); err != nil {
+		logrus.Warnf("Unable to cache response of %s: %v", req.URL, err)
+	}
+	return resp, nil
+}
+
+// path returns the cache file for the provided request.
+func (t *Transport) path(req *http.Request) string {
+	elems := []string{t.dir, sanitize(req.URL.Host)}
+	if credentials := credentials(req); credentials != "" {
+		elems = append(elems, "token-"+hash(credentials))
+	}
+	for _, elem := range strings.Split(strings.Trim(req.URL.EscapedPath(), "/"), "/") {
+		elems = append(elems, sanitize(elem))
+	}
+
+	name := elems[len(elems)-1]
+	if req.URL.RawQuery != "" {
+		name += "-" + hash(req.URL.RawQuery)
+	}
+	elems[len(elems)-1] = name + ".json"
+	return filepath.Join(elems...)
+}
+
+// credentials returns the values of all credential headers of the request.
+func credentials(req *http.Request) string {
+	values := []string{}
+	for _, header := range credentialHeaders {
+		if value := req.Header.Get(header); value != "" {
+			values = append(values, header+": "+value)
+		}
+	}
+	return strings.Join(values, "\n")
+}
+
+// hash returns a shortened SHA256 sum of the provided string.
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// sanitize makes a single URL path element usable as file name.
+func sanitize(elem string) string {
+	if elem == "" || elem == "." || elem == ".." {
+		return "_" + elem
+	}
+	return strings.ReplaceAll(elem, string(filepath.Separator), "_")
+}
+
+func (t *Transport) read(path string) (*entry, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "reading cache file")
+	}
+	res := &entry{}
+	if err := json.Unmarshal(content, res); err != nil {
+		return nil, errors.Wrap(err, "unmarshal cache file")
+	}
+	return res, nil
+}
+
+// write stores the entry using a temporary file, which allows concurrent
+// requests for the same resource.
+func (t *Transport) write(path string, e *entry) error {
+	content, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "marshal cache entry")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0o755)); err != nil {
+		return errors.Wrap(err, "creating cache dir")
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return errors.Wrap(err, "creating cache file")
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return errors.Wrap(err, "writing cache file")
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrap(err, "closing cache file")
+	}
+	return errors.Wrap(os.Rename(file.Name(), path), "renaming cache file")
+}
+
+// response builds a HTTP response from the cached entry. The header of a
+// revalidation response takes precedence over the cached one, otherwise the
+// outdated rate limit headers get removed.
+func (e *entry) response(req *http.Request, header http.Header) *http.Response {
+	merged := e.Header.Clone()
+	if merged == nil {
+		merged = http.Header{}
+	}
+	if header == nil {
+		for key := range merged {
+			if strings.HasPrefix(strings.ToLower(key), "x-ratelimit-") {
+				merged.Del(key)
+			}
+		}
+	}
+	for key, values := range header {
+		if key != "Content-Length" {
+			merged[key] = values
+		}
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        merged,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
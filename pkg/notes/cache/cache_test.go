@@ -0,0 +1,224 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testSHA = "5b2c1f6a0d8e4c3b9a7f1e2d3c4b5a6978695a4b"
+
+type testServer struct {
+	*httptest.Server
+	requests    int32
+	conditional int32
+	body        string
+}
+
+// newTestServer returns a server which responds with the current body and
+// its ETag, or 304 if the If-None-Match header matches.
+func newTestServer(t *testing.T) *testServer {
+	s := &testServer{body: `{"number":1}`}
+	s.Server = httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&s.requests, 1)
+			if r.URL.Path == "/missing" {
+				http.NotFound(w, r)
+				return
+			}
+
+			etag := `"` + s.body + `"`
+			w.Header().Set("ETag", etag)
+			w.Header().Set("X-RateLimit-Remaining", "4999")
+			if r.Header.Get("If-None-Match") != "" {
+				atomic.AddInt32(&s.conditional, 1)
+				if r.Header.Get("If-None-Match") == etag {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+			}
+			_, err := w.Write([]byte(s.body))
+			require.Nil(t, err)
+		},
+	))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func get(t *testing.T, client *http.Client, url string) (*http.Response, string) {
+	resp, err := client.Get(url)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.Nil(t, err)
+	return resp, string(body)
+}
+
+func TestRevalidate(t *testing.T) {
+	server := newTestServer(t)
+	client := NewTransport(t.TempDir(), nil).Client()
+	url := server.URL + "/repos/owner/repo/pulls/1"
+
+	// Initial request
+	resp, body := get(t, client, url)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, `{"number":1}`, body)
+	require.EqualValues(t, 1, server.requests)
+	require.EqualValues(t, 0, server.conditional)
+
+	// Not modified
+	resp, body = get(t, client, url)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, `{"number":1}`, body)
+	require.Equal(t, "4999", resp.Header.Get("X-RateLimit-Remaining"))
+	require.EqualValues(t, 2, server.requests)
+	require.EqualValues(t, 1, server.conditional)
+
+	// Modified
+	server.body = `{"number":1,"title":"changed"}`
+	_, body = get(t, client, url)
+	require.Equal(t, `{"number":1,"title":"changed"}`, body)
+	_, body = get(t, client, url)
+	require.Equal(t, `{"number":1,"title":"changed"}`, body)
+	require.EqualValues(t, 4, server.requests)
+	require.EqualValues(t, 3, server.conditional)
+}
+
+func TestImmutable(t *testing.T) {
+	server := newTestServer(t)
+	dir := t.TempDir()
+
+	for _, path := range []string{
+		"/repos/owner/repo/git/commits/" + testSHA,
+		"/repos/owner/repo/commits/" + testSHA,
+		"/api/v4/projects/group%2Fproject/repository/commits/" + testSHA,
+	} {
+		requests := server.requests
+		for i := 0; i < 3; i++ {
+			// A new transport simulates a subsequent run
+			_, body := get(t, NewTransport(dir, nil).Client(), server.URL+path)
+			require.Equal(t, `{"number":1}`, body)
+		}
+		require.Equal(t, requests+1, server.requests, path)
+	}
+	require.EqualValues(t, 0, server.conditional)
+}
+
+func TestRevalidatePullRequestsOfCommit(t *testing.T) {
+	server := newTestServer(t)
+	dir := t.TempDir()
+
+	for _, path := range []string{
+		"/repos/owner/repo/commits/" + testSHA + "/pulls",
+		"/api/v4/projects/group%2Fproject/repository/commits/" + testSHA + "/merge_requests",
+		"/api/v1/repos/owner/repo/commits/" + testSHA + "/pull",
+	} {
+		server.body = `{"number":1}`
+		_, body := get(t, NewTransport(dir, nil).Client(), server.URL+path)
+		require.Equal(t, `{"number":1}`, body)
+
+		// An edited pull request body is seen by subsequent runs
+		server.body = `{"number":1,"body":"edited"}`
+		conditional := server.conditional
+		_, body = get(t, NewTransport(dir, nil).Client(), server.URL+path)
+		require.Equal(t, `{"number":1,"body":"edited"}`, body)
+		require.Equal(t, conditional+1, server.conditional, path)
+	}
+}
+
+func TestCacheFiles(t *testing.T) {
+	server := newTestServer(t)
+	dir := t.TempDir()
+	client := NewTransport(dir, nil).Client()
+
+	get(t, client, server.URL+"/repos/owner/repo/pulls/1")
+	get(t, client, server.URL+"/repos/owner/repo/commits?page=2")
+	get(t, client, server.URL+"/repos/owner/repo/commits?page=3")
+	resp, _ := get(t, client, server.URL+"/missing")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	host := filepath.Join(dir, server.Listener.Addr().String())
+	require.FileExists(t, filepath.Join(host, "repos", "owner", "repo", "pulls", "1.json"))
+	require.NoFileExists(t, filepath.Join(host, "missing.json"))
+
+	commits, err := filepath.Glob(filepath.Join(host, "repos", "owner", "repo", "commits-*.json"))
+	require.Nil(t, err)
+	require.Len(t, commits, 2)
+}
+
+func TestCacheFilesPerToken(t *testing.T) {
+	server := newTestServer(t)
+	dir := t.TempDir()
+	client := NewTransport(dir, nil).Client()
+	url := server.URL + "/repos/owner/repo/git/commits/" + testSHA
+
+	for _, token := range []string{"token a", "token b", "token a", ""} {
+		req, err := http.NewRequest(http.MethodGet, url, http.NoBody)
+		require.Nil(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		resp, err := client.Do(req)
+		require.Nil(t, err)
+		resp.Body.Close()
+	}
+
+	// Every distinct token requests the immutable resource once
+	require.EqualValues(t, 3, server.requests)
+
+	host := filepath.Join(dir, server.Listener.Addr().String())
+	files, err := filepath.Glob(filepath.Join(
+		host, "token-*", "repos", "owner", "repo", "git", "commits", testSHA+".json",
+	))
+	require.Nil(t, err)
+	require.Len(t, files, 2)
+	require.FileExists(t, filepath.Join(host, "repos", "owner", "repo", "git", "commits", testSHA+".json"))
+}
+
+func TestCorruptCacheFile(t *testing.T) {
+	server := newTestServer(t)
+	dir := t.TempDir()
+	path := filepath.Join(
+		dir, server.Listener.Addr().String(), "repos", "owner", "repo", "git", "commits", testSHA+".json",
+	)
+	require.Nil(t, os.MkdirAll(filepath.Dir(path), os.FileMode(0o755)))
+	require.Nil(t, os.WriteFile(path, []byte("invalid"), os.FileMode(0o644)))
+
+	_, body := get(t, NewTransport(dir, nil).Client(), server.URL+"/repos/owner/repo/git/commits/"+testSHA)
+	require.Equal(t, `{"number":1}`, body)
+	require.EqualValues(t, 1, server.requests)
+}
+
+func TestNonGetRequest(t *testing.T) {
+	server := newTestServer(t)
+	client := NewTransport(t.TempDir(), nil).Client()
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Post(server.URL+"/repos/owner/repo/git/commits/"+testSHA, "application/json", nil)
+		require.Nil(t, err)
+		resp.Body.Close()
+	}
+	require.EqualValues(t, 2, server.requests)
+}
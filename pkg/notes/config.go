@@ -0,0 +1,341 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// noteGroupName is the named regexp group every note pattern has to contain.
+const noteGroupName = "note"
+
+// Config defines how release notes get extracted from pull requests and how
+// they are categorized. DefaultConfig returns the conventions of the
+// Kubernetes project, which are used if no other configuration is provided.
+type Config struct {
+	// NotePatterns are the regular expressions for extracting the release
+	// note from the pull request body. They are tried in order and each has
+	// to contain a named group `note`, like `(?P<note>.+)`.
+	NotePatterns []string `yaml:"notePatterns,omitempty"`
+
+	// ExcludePatterns match pull request bodies which do not contain a
+	// release note, for example an explicit `NONE`.
+	ExcludePatterns []string `yaml:"excludePatterns,omitempty"`
+
+	// IncludePatterns match pull request bodies which probably contain a
+	// release note.
+	IncludePatterns []string `yaml:"includePatterns,omitempty"`
+
+	// Labels maps the pull request labels to the release note fields.
+	Labels LabelConfig `yaml:"labels,omitempty"`
+
+	// Kinds defines the taxonomy of the release note kinds.
+	Kinds KindConfig `yaml:"kinds,omitempty"`
+
+	// CommitTypes maps the types of Conventional Commits to the note kinds.
+	// Commits of other types are skipped, unless they are breaking changes.
+	CommitTypes map[string]Kind `yaml:"commitTypes,omitempty"`
+
+	notePatterns    []*regexp.Regexp
+	excludePatterns []*regexp.Regexp
+	includePatterns []*regexp.Regexp
+}
+
+// LabelConfig maps the pull request labels to the release note fields.
+type LabelConfig struct {
+	// Kind is the label prefix for the note kinds, without the trailing
+	// slash. For example `kind` for labels like `kind/bug`.
+	Kind string `yaml:"kind,omitempty"`
+
+	// SIG is the label prefix for the owning SIGs or teams.
+	SIG string `yaml:"sig,omitempty"`
+
+	// Area is the label prefix for the areas.
+	Area string `yaml:"area,omitempty"`
+
+	// ActionRequired is the label which marks a note as action required.
+	ActionRequired string `yaml:"actionRequired,omitempty"`
+
+	// DoNotPublish is the label which excludes a note from publishing.
+	DoNotPublish string `yaml:"doNotPublish,omitempty"`
+}
+
+// KindConfig defines the taxonomy of the release note kinds.
+type KindConfig struct {
+	// Feature is the kind which marks a note as feature.
+	Feature Kind `yaml:"feature,omitempty"`
+
+	// Priority is the order of the kinds in the release notes document. It
+	// also decides the kind of a note with multiple kinds.
+	Priority []Kind `yaml:"priority,omitempty"`
+
+	// Map merges kinds into another one, for example regressions into bugs.
+	Map map[Kind]Kind `yaml:"map,omitempty"`
+
+	// Titles are the section titles of the kinds. Kinds without a title
+	// are rendered with their name in title case.
+	Titles map[Kind]string `yaml:"titles,omitempty"`
+}
+
+// DefaultConfig returns the release notes configuration of the Kubernetes
+// project.
+func DefaultConfig() *Config {
+	config := &Config{
+		NotePatterns: []string{
+			// (?s) is needed for '.' to be matching on newlines, by default that's disabled
+			// we need to match ungreedy 'U', because after the notes a `docs` block can occur
+			"(?sU)```release-note[s]?\\r\\n(?P<note>.+)\\r\\n```",
+			"(?sU)```dev-release-note[s]?\\r\\n(?P<note>.+)",
+			"(?sU)```\\r\\n(?P<note>.+)\\r\\n```",
+			"(?sU)```release-note[s]?\n(?P<note>.+)\n```",
+		},
+		ExcludePatterns: []string{
+			// 'none','n/a','na' case insensitive with optional trailing
+			// whitespace, wrapped in ``` with/without release-note identifier
+			// the 'none','n/a','na' can also optionally be wrapped in quotes ' or "
+			"(?i)```release-note[s]?\\s*('|\")?(none|n/a|na)?('|\")?\\s*```",
+
+			// simple '/release-note-none' tag
+			"/release-note-none",
+		},
+		IncludePatterns: []string{
+			"release-note",
+			"Does this PR introduce a user-facing change?",
+		},
+		Labels: LabelConfig{
+			Kind:           "kind",
+			SIG:            "sig",
+			Area:           "area",
+			ActionRequired: "release-note-action-required",
+			DoNotPublish:   "release-note-none",
+		},
+		Kinds: KindConfig{
+			Feature: KindFeature,
+			Priority: []Kind{
+				KindDeprecation,
+				KindAPIChange,
+				KindFeature,
+				KindDesign,
+				KindDocumentation,
+				KindFailingTest,
+				KindBug,
+				KindRegression,
+				KindCleanup,
+				KindFlake,
+				KindOther,
+				KindUncategorized,
+			},
+			Map: map[Kind]Kind{
+				KindRegression: KindBug,
+				KindCleanup:    KindOther,
+				KindFlake:      KindOther,
+			},
+			Titles: map[Kind]string{
+				KindAPIChange:   "API Change",
+				KindFailingTest: "Failing Test",
+				KindBug:         "Bug or Regression",
+				KindOther:       string(KindOther),
+			},
+		},
+		CommitTypes: map[string]Kind{
+			"feat":     KindFeature,
+			"fix":      KindBug,
+			"perf":     KindCleanup,
+			"refactor": KindCleanup,
+			"revert":   KindCleanup,
+			"docs":     KindDocumentation,
+		},
+	}
+
+	if err := config.compile(); err != nil {
+		panic(errors.Wrap(err, "compiling default release notes config"))
+	}
+	return config
+}
+
+// defaultConfig is used by the package level helpers and everywhere no
+// explicit configuration has been set.
+var defaultConfig = DefaultConfig()
+
+// LoadConfig reads the release notes configuration from the provided YAML
+// file. All unset fields default to the ones of DefaultConfig.
+func LoadConfig(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading release notes config")
+	}
+
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(content, config); err != nil {
+		return nil, errors.Wrapf(err, "unmarshal release notes config %s", path)
+	}
+	config.setDefaults()
+
+	if err := config.compile(); err != nil {
+		return nil, errors.Wrapf(err, "validating release notes config %s", path)
+	}
+	return config, nil
+}
+
+func (c *Config) setDefaults() {
+	defaults := DefaultConfig()
+
+	if len(c.NotePatterns) == 0 {
+		c.NotePatterns = defaults.NotePatterns
+	}
+	if len(c.ExcludePatterns) == 0 {
+		c.ExcludePatterns = defaults.ExcludePatterns
+	}
+	if len(c.IncludePatterns) == 0 {
+		c.IncludePatterns = defaults.IncludePatterns
+	}
+
+	for _, field := range []struct{ value, fallback *string }{
+		{&c.Labels.Kind, &defaults.Labels.Kind},
+		{&c.Labels.SIG, &defaults.Labels.SIG},
+		{&c.Labels.Area, &defaults.Labels.Area},
+		{&c.Labels.ActionRequired, &defaults.Labels.ActionRequired},
+		{&c.Labels.DoNotPublish, &defaults.Labels.DoNotPublish},
+	} {
+		if *field.value == "" {
+			*field.value = *field.fallback
+		}
+	}
+
+	if c.Kinds.Feature == "" {
+		c.Kinds.Feature = defaults.Kinds.Feature
+	}
+	if len(c.Kinds.Priority) == 0 {
+		c.Kinds.Priority = defaults.Kinds.Priority
+	}
+	if c.Kinds.Map == nil {
+		c.Kinds.Map = defaults.Kinds.Map
+	}
+	if c.Kinds.Titles == nil {
+		c.Kinds.Titles = defaults.Kinds.Titles
+	}
+	if c.CommitTypes == nil {
+		c.CommitTypes = defaults.CommitTypes
+	}
+}
+
+// compile validates the patterns and compiles them into regular expressions.
+func (c *Config) compile() (err error) {
+	c.notePatterns, err = compilePatterns(c.NotePatterns)
+	if err != nil {
+		return errors.Wrap(err, "compiling note patterns")
+	}
+	for _, exp := range c.notePatterns {
+		if exp.SubexpIndex(noteGroupName) < 0 {
+			return errors.Errorf(
+				"note pattern %q has no named group %q", exp, noteGroupName,
+			)
+		}
+	}
+
+	c.excludePatterns, err = compilePatterns(c.ExcludePatterns)
+	if err != nil {
+		return errors.Wrap(err, "compiling exclude patterns")
+	}
+
+	c.includePatterns, err = compilePatterns(c.IncludePatterns)
+	if err != nil {
+		return errors.Wrap(err, "compiling include patterns")
+	}
+	return nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	result := []*regexp.Regexp{}
+	for _, pattern := range patterns {
+		exp, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "compiling %q", pattern)
+		}
+		result = append(result, exp)
+	}
+	return result, nil
+}
+
+// NoteText returns the text of the release note given a string which may
+// contain the commit message, the PR description, etc.
+func (c *Config) NoteText(s string) (string, error) {
+	for _, exp := range c.notePatterns {
+		match := exp.FindStringSubmatch(s)
+		if len(match) == 0 {
+			continue
+		}
+
+		note := strings.ReplaceAll(match[exp.SubexpIndex(noteGroupName)], "\r", "")
+		note = stripActionRequired(note)
+		note = dashify(note)
+		note = unlist(note)
+		note = strings.TrimSpace(note)
+		return note, nil
+	}
+
+	return "", errors.New("no matches found when parsing note text from commit string")
+}
+
+// MatchesExcludeFilter returns true if the string matches an excluded
+// release note.
+func (c *Config) MatchesExcludeFilter(msg string) bool {
+	return matchesFilter(msg, c.excludePatterns)
+}
+
+// MatchesIncludeFilter returns true if the string matches an included
+// release note.
+func (c *Config) MatchesIncludeFilter(msg string) bool {
+	return matchesFilter(msg, c.includePatterns)
+}
+
+// HighestPriorityKind returns the kind with the highest priority. Kinds
+// which are not part of the priority list rank below all others.
+func (c *Config) HighestPriorityKind(kinds []string) Kind {
+	for _, prioKind := range c.Kinds.Priority {
+		for _, k := range kinds {
+			kind := Kind(k)
+			if kind == prioKind {
+				return kind
+			}
+		}
+	}
+
+	// Kind not in priority slice, returning the first one
+	return Kind(kinds[0])
+}
+
+// MapKind returns the kind the provided one is merged into.
+func (c *Config) MapKind(kind Kind) Kind {
+	if newKind, ok := c.Kinds.Map[kind]; ok {
+		return newKind
+	}
+	return kind
+}
+
+// KindTitle returns the section title of the provided kind.
+func (c *Config) KindTitle(kind Kind) string {
+	if title, ok := c.Kinds.Titles[kind]; ok {
+		return title
+	}
+	return strings.Title(string(kind))
+}
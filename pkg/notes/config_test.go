@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gogithub "github.com/google/go-github/v39/github"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `notePatterns:
+- "(?s)## Changelog\\n(?P<note>.+)\\n## "
+excludePatterns:
+- "(?i)## Changelog\\s+none"
+includePatterns:
+- "## Changelog"
+labels:
+  kind: type
+  sig: team
+  actionRequired: breaking-change
+kinds:
+  feature: enhancement
+  priority:
+  - enhancement
+  - bug
+  titles:
+    enhancement: Enhancements
+`
+
+func writeTestConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.Nil(t, os.WriteFile(path, []byte(content), os.FileMode(0o644)))
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	config, err := LoadConfig(writeTestConfig(t, testConfig))
+	require.Nil(t, err)
+
+	// Custom values
+	require.Equal(t, "type", config.Labels.Kind)
+	require.Equal(t, "team", config.Labels.SIG)
+	require.Equal(t, "breaking-change", config.Labels.ActionRequired)
+	require.Equal(t, Kind("enhancement"), config.Kinds.Feature)
+	require.Equal(t, []Kind{"enhancement", "bug"}, config.Kinds.Priority)
+	require.Equal(t, "Enhancements", config.KindTitle("enhancement"))
+	require.Equal(t, "Bug", config.KindTitle("bug"))
+
+	// Defaults
+	defaults := DefaultConfig()
+	require.Equal(t, defaults.Labels.Area, config.Labels.Area)
+	require.Equal(t, defaults.Labels.DoNotPublish, config.Labels.DoNotPublish)
+	require.Equal(t, defaults.Kinds.Map, config.Kinds.Map)
+
+	// Patterns
+	text, err := config.NoteText("## Changelog\n- Add a flag\n## Other")
+	require.Nil(t, err)
+	require.Equal(t, "Add a flag", text)
+	require.True(t, config.MatchesExcludeFilter("## Changelog\nNONE"))
+	require.True(t, config.MatchesIncludeFilter("## Changelog\nNONE"))
+	require.False(t, config.MatchesIncludeFilter("```release-note\nfoo\n```"))
+}
+
+func TestLoadConfigFailure(t *testing.T) {
+	for _, content := range []string{
+		"notePatterns: [",                   // invalid YAML
+		"unknown: field",                    // unknown field
+		"notePatterns:\n- \"(?P<note>.+\"",  // invalid regexp
+		"notePatterns:\n- \"(?P<text>.+)\"", // missing note group
+		"excludePatterns:\n- \"[\"",         // invalid exclude pattern
+		"includePatterns:\n- \"(\"",         // invalid include pattern
+	} {
+		_, err := LoadConfig(writeTestConfig(t, content))
+		require.NotNil(t, err, content)
+	}
+
+	_, err := LoadConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.NotNil(t, err)
+}
+
+func TestReleaseNoteFromCommitWithConfig(t *testing.T) {
+	config, err := LoadConfig(writeTestConfig(t, testConfig))
+	require.Nil(t, err)
+
+	gatherer := NewGathererWithClient(context.Background(), nil)
+	gatherer.SetConfig(config)
+
+	pr := &gogithub.PullRequest{
+		Number: gogithub.Int(1),
+		Body:   gogithub.String("## Changelog\nAdd the `--foo` flag\n## Testing"),
+		User:   &gogithub.User{Login: gogithub.String("author")},
+	}
+	for _, label := range []string{
+		"type/enhancement", "team/cli", "breaking-change", "kind/bug",
+	} {
+		pr.Labels = append(pr.Labels, &gogithub.Label{Name: gogithub.String(label)})
+	}
+
+	note, err := gatherer.ReleaseNoteFromCommit(&Result{
+		commit:      &gogithub.RepositoryCommit{SHA: gogithub.String("sha")},
+		pullRequest: pr,
+	})
+	require.Nil(t, err)
+	require.Equal(t, "Add the `--foo` flag", note.Text)
+	require.Equal(t, []string{"enhancement"}, note.Kinds)
+	require.Equal(t, []string{"cli"}, note.SIGs)
+	require.True(t, note.Feature)
+	require.True(t, note.ActionRequired)
+	require.False(t, note.DoNotPublish)
+	require.Equal(t, "Add the `--foo` flag (#1, @author) [SIG CLI]", note.Markdown)
+}
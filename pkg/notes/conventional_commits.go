@@ -0,0 +1,293 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	gitobject "github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// conventionalCommitRE matches the header of a Conventional Commit, like
+	// `feat(scope)!: description`.
+	conventionalCommitRE = regexp.MustCompile(
+		`^(?P<type>[a-zA-Z]+)(?:\((?P<scope>[^()\r\n]+)\))?(?P<breaking>!)?: (?P<description>.+)$`,
+	)
+
+	// breakingChangeRE matches the breaking change footer of a Conventional
+	// Commit and everything after it.
+	breakingChangeRE = regexp.MustCompile(`(?ms)^BREAKING[ -]CHANGE: (?P<text>.+)`)
+
+	// squashPRSuffixRE matches the pull request number GitHub appends to the
+	// header of squash merged commits.
+	squashPRSuffixRE = regexp.MustCompile(`\s*\(#\d+\)$`)
+)
+
+// ConventionalCommit is a commit message following the Conventional Commits
+// specification: https://www.conventionalcommits.org
+type ConventionalCommit struct {
+	// Type of the commit, like `feat` or `fix`.
+	Type string
+
+	// Scope is the optional scope of the commit.
+	Scope string
+
+	// Description is the summary of the header.
+	Description string
+
+	// Breaking is true if the header contains a `!` or the body a
+	// `BREAKING CHANGE` footer.
+	Breaking bool
+
+	// BreakingChange is the text of the `BREAKING CHANGE` footer.
+	BreakingChange string
+}
+
+// ParseConventionalCommit parses the provided commit message. It returns an
+// error if the header does not follow the Conventional Commits
+// specification.
+func ParseConventionalCommit(message string) (*ConventionalCommit, error) {
+	header := strings.TrimSpace(strings.SplitN(message, "\n", 2)[0])
+	match := conventionalCommitRE.FindStringSubmatch(header)
+	if match == nil {
+		return nil, errors.Errorf("header %q is not a conventional commit", header)
+	}
+
+	commit := &ConventionalCommit{
+		Type:        strings.ToLower(match[conventionalCommitRE.SubexpIndex("type")]),
+		Scope:       strings.TrimSpace(match[conventionalCommitRE.SubexpIndex("scope")]),
+		Description: squashPRSuffixRE.ReplaceAllString(match[conventionalCommitRE.SubexpIndex("description")], ""),
+		Breaking:    match[conventionalCommitRE.SubexpIndex("breaking")] != "",
+	}
+
+	if footer := breakingChangeRE.FindStringSubmatch(message); footer != nil {
+		commit.Breaking = true
+		commit.BreakingChange = strings.TrimSpace(
+			strings.ReplaceAll(footer[breakingChangeRE.SubexpIndex("text")], "\r", ""),
+		)
+	}
+
+	return commit, nil
+}
+
+// ListReleaseNotesFromCommits produces the release notes from the Conventional
+// Commits between StartSHA and EndSHA of the local repository. Notes of
+// commits which reference a pull request are enriched with its data if the
+// GitHub API is available. Notes without a pull request are stored using
+// negative numbers.
+func (g *Gatherer) ListReleaseNotesFromCommits() (*ReleaseNotes, error) {
+	mapProviders := []MapProvider{}
+	for _, initString := range g.options.MapProviderStrings {
+		provider, err := NewProviderFromInitString(initString)
+		if err != nil {
+			return nil, errors.Wrap(err, "while getting release notes map providers")
+		}
+		mapProviders = append(mapProviders, provider)
+	}
+
+	commits, err := g.listRangeCommits()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing commits")
+	}
+	logrus.Infof("Processing %d commits for conventional commit notes", len(commits))
+
+	releaseNotes := NewReleaseNotes()
+	releaseNotes.SetConfig(g.Config())
+
+	withoutPR := 0
+	for _, commit := range commits {
+		note, err := g.releaseNoteFromConventionalCommit(commit)
+		if err != nil {
+			return nil, errors.Wrapf(err, "building release note for commit %s", commit.Hash)
+		}
+		if note == nil {
+			continue
+		}
+
+		key := note.PrNumber
+		if key == 0 || releaseNotes.Get(key) != nil {
+			withoutPR--
+			key = withoutPR
+		} else {
+			for _, provider := range mapProviders {
+				noteMaps, err := provider.GetMapsForPR(key)
+				if err != nil {
+					return nil, errors.Wrapf(
+						err, "checking if a map exists for PR %d", key,
+					)
+				}
+				for _, noteMap := range noteMaps {
+					if err := note.ApplyMap(noteMap, g.options.AddMarkdownLinks); err != nil {
+						return nil, errors.Wrapf(err, "applying notemap for PR #%d", key)
+					}
+				}
+			}
+		}
+		releaseNotes.Set(key, note)
+	}
+
+	return releaseNotes, nil
+}
+
+// listRangeCommits returns all commits reachable from EndSHA, but not from
+// StartSHA, with the newest commit first.
+func (g *Gatherer) listRangeCommits() ([]*gitobject.Commit, error) {
+	repo, err := git.PlainOpen(g.options.RepoPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening repository %s", g.options.RepoPath)
+	}
+
+	startCommit, err := repo.CommitObject(plumbing.NewHash(g.options.StartSHA))
+	if err != nil {
+		return nil, errors.Wrap(err, "finding commit of StartSHA")
+	}
+
+	endCommit, err := repo.CommitObject(plumbing.NewHash(g.options.EndSHA))
+	if err != nil {
+		return nil, errors.Wrap(err, "finding commit of EndSHA")
+	}
+
+	seen := map[plumbing.Hash]bool{}
+	if err := gitobject.NewCommitPreorderIter(startCommit, nil, nil).ForEach(
+		func(c *gitobject.Commit) error {
+			seen[c.Hash] = true
+			return nil
+		},
+	); err != nil {
+		return nil, errors.Wrap(err, "listing commits of StartSHA")
+	}
+
+	commits := []*gitobject.Commit{}
+	if err := gitobject.NewCommitPreorderIter(endCommit, seen, nil).ForEach(
+		func(c *gitobject.Commit) error {
+			commits = append(commits, c)
+			return nil
+		},
+	); err != nil {
+		return nil, errors.Wrap(err, "listing commits of EndSHA")
+	}
+	return commits, nil
+}
+
+// releaseNoteFromConventionalCommit builds the release note of the provided
+// commit. It returns nil if the commit is not a conventional commit or its
+// type should not be part of the release notes.
+func (g *Gatherer) releaseNoteFromConventionalCommit(
+	commit *gitobject.Commit,
+) (*ReleaseNote, error) {
+	logger := logrus.WithField("sha", commit.Hash.String())
+
+	cc, err := ParseConventionalCommit(commit.Message)
+	if err != nil {
+		logger.Debugf("skip: %v", err)
+		return nil, nil
+	}
+
+	config := g.Config()
+	kind, ok := config.CommitTypes[cc.Type]
+	if !ok && !cc.Breaking {
+		logger.Debugf("skip: commit type %q is not part of the release notes", cc.Type)
+		return nil, nil
+	}
+
+	text := cc.Description
+	if cc.BreakingChange != "" {
+		text = fmt.Sprintf("%s\n\n%s", text, cc.BreakingChange)
+	}
+
+	note := &ReleaseNote{
+		Commit:         commit.Hash.String(),
+		Text:           text,
+		Author:         commit.Author.Name,
+		SIGs:           []string{},
+		Kinds:          []string{},
+		Areas:          []string{},
+		Feature:        ok && kind == config.Kinds.Feature,
+		ActionRequired: cc.Breaking,
+	}
+	if ok {
+		note.Kinds = append(note.Kinds, string(kind))
+	}
+	if cc.Scope != "" {
+		note.Areas = append(note.Areas, cc.Scope)
+	}
+
+	if prNums, err := prsNumForCommitFromMessage(commit.Message); err == nil {
+		note.PrNumber = prNums[len(prNums)-1]
+		note.PrURL = fmt.Sprintf(
+			"https://github.com/%s/%s/pull/%d",
+			g.options.GithubOrg, g.options.GithubRepo, note.PrNumber,
+		)
+		if g.enrichFromGitHub {
+			g.enrichReleaseNote(note)
+		}
+	}
+
+	indented := strings.ReplaceAll(text, "\n", "\n  ")
+	switch {
+	case note.AuthorURL != "" && g.options.AddMarkdownLinks:
+		note.Markdown = fmt.Sprintf("%s ([#%d](%s), [@%s](%s))",
+			indented, note.PrNumber, note.PrURL, note.Author, note.AuthorURL)
+	case note.AuthorURL != "":
+		note.Markdown = fmt.Sprintf("%s (#%d, @%s)", indented, note.PrNumber, note.Author)
+	case note.PrNumber != 0 && g.options.AddMarkdownLinks:
+		note.Markdown = fmt.Sprintf("%s ([#%d](%s))", indented, note.PrNumber, note.PrURL)
+	case note.PrNumber != 0:
+		note.Markdown = fmt.Sprintf("%s (#%d)", indented, note.PrNumber)
+	default:
+		note.Markdown = fmt.Sprintf("%s (%s)", indented, commit.Hash.String()[:7])
+	}
+
+	if noteSuffix := prettifySIGList(note.SIGs); noteSuffix != "" {
+		note.Markdown = fmt.Sprintf("%s [%s]", note.Markdown, noteSuffix)
+	}
+	note.Markdown = capitalizeString(note.Markdown)
+
+	return note, nil
+}
+
+// enrichReleaseNote adds the author and the SIGs of the pull request to the
+// note. Errors are only logged, because the note is complete without them.
+func (g *Gatherer) enrichReleaseNote(note *ReleaseNote) {
+	pr, _, err := g.client.GetPullRequest(
+		g.context, g.options.GithubOrg, g.options.GithubRepo, note.PrNumber,
+	)
+	if err != nil {
+		logrus.WithField("pr", note.PrNumber).Warnf(
+			"Unable to enrich note with pull request data: %v", err,
+		)
+		return
+	}
+
+	config := g.Config()
+	note.Author = pr.GetUser().GetLogin()
+	note.AuthorURL = pr.GetUser().GetHTMLURL()
+	note.PrURL = pr.GetHTMLURL()
+	note.SIGs = labelsWithPrefix(pr, config.Labels.SIG)
+	note.Duplicate = len(note.SIGs) > 1
+	note.DoNotPublish = labelExactMatch(pr, config.Labels.DoNotPublish)
+	if labelExactMatch(pr, config.Labels.ActionRequired) {
+		note.ActionRequired = true
+	}
+}
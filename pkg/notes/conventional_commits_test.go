@@ -0,0 +1,194 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	gitobject "github.com/go-git/go-git/v5/plumbing/object"
+	gogithub "github.com/google/go-github/v39/github"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/release-sdk/github/githubfakes"
+)
+
+func TestParseConventionalCommit(t *testing.T) {
+	for _, tc := range []struct {
+		message   string
+		expected  *ConventionalCommit
+		shouldErr bool
+	}{
+		{
+			message:  "feat: add a flag",
+			expected: &ConventionalCommit{Type: "feat", Description: "add a flag"},
+		},
+		{
+			message: "fix(cli)!: remove the --foo flag (#123)\n\nSome details.",
+			expected: &ConventionalCommit{
+				Type: "fix", Scope: "cli", Description: "remove the --foo flag",
+				Breaking: true,
+			},
+		},
+		{
+			message: "Feat(api): change the API\r\n\r\nBREAKING CHANGE: The field\r\nfoo is gone.\r\n",
+			expected: &ConventionalCommit{
+				Type: "feat", Scope: "api", Description: "change the API",
+				Breaking: true, BreakingChange: "The field\nfoo is gone.",
+			},
+		},
+		{
+			message: "chore: bump deps\n\nBREAKING-CHANGE: requires go 1.17",
+			expected: &ConventionalCommit{
+				Type: "chore", Description: "bump deps",
+				Breaking: true, BreakingChange: "requires go 1.17",
+			},
+		},
+		{message: "Merge pull request #1 from foo/bar", shouldErr: true},
+		{message: "feat:missing space", shouldErr: true},
+		{message: "", shouldErr: true},
+	} {
+		res, err := ParseConventionalCommit(tc.message)
+		if tc.shouldErr {
+			require.NotNil(t, err, tc.message)
+			continue
+		}
+		require.Nil(t, err, tc.message)
+		require.Equal(t, tc.expected, res)
+	}
+}
+
+func commitToTestRepo(t *testing.T, repo *git.Repository, message string) string {
+	worktree, err := repo.Worktree()
+	require.Nil(t, err)
+	hash, err := worktree.Commit(message, &git.CommitOptions{
+		Author: &gitobject.Signature{
+			Name: "Jane Doe", Email: "jane@example.com", When: time.Now(),
+		},
+	})
+	require.Nil(t, err)
+	return hash.String()
+}
+
+func TestListReleaseNotesFromCommits(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.Nil(t, err)
+
+	start := commitToTestRepo(t, repo, "feat: before the start")
+	commitToTestRepo(t, repo, "fix(cli): fix the --bar flag (#10)")
+	commitToTestRepo(t, repo, "chore: update dependencies")
+	commitToTestRepo(t, repo, "no conventional commit")
+	commitToTestRepo(t, repo, "feat(api)!: replace the v1 API (#11)")
+	end := commitToTestRepo(t, repo,
+		"docs: describe the API (#12)\n\nBREAKING CHANGE: The docs moved.",
+	)
+
+	client := &githubfakes.FakeClient{}
+	client.GetPullRequestCalls(func(
+		_ context.Context, _, _ string, number int,
+	) (*gogithub.PullRequest, *gogithub.Response, error) {
+		if number == 12 {
+			return nil, nil, errors.New("error")
+		}
+		return &gogithub.PullRequest{
+			Number:  gogithub.Int(number),
+			HTMLURL: gogithub.String("https://github.com/org/repo/pull/1"),
+			User: &gogithub.User{
+				Login:   gogithub.String("jane"),
+				HTMLURL: gogithub.String("https://github.com/jane"),
+			},
+			Labels: []*gogithub.Label{{Name: gogithub.String("sig/cli")}},
+		}, nil, nil
+	})
+
+	gatherer := NewGathererWithClient(context.Background(), client)
+	gatherer.options.RepoPath = dir
+	gatherer.options.StartSHA = start
+	gatherer.options.EndSHA = end
+	gatherer.options.GithubOrg = "org"
+	gatherer.options.GithubRepo = "repo"
+
+	res, err := gatherer.ListReleaseNotesFromCommits()
+	require.Nil(t, err)
+	require.Equal(t, ReleaseNotesHistory{12, 11, 10}, res.History())
+	require.Equal(t, 3, client.GetPullRequestCallCount())
+
+	// Enriching the note failed
+	docs := res.Get(12)
+	require.Equal(t, end, docs.Commit)
+	require.Equal(t, "describe the API\n\nThe docs moved.", docs.Text)
+	require.Equal(t, "Describe the API\n  \n  The docs moved. (#12)", docs.Markdown)
+	require.Equal(t, []string{string(KindDocumentation)}, docs.Kinds)
+	require.Equal(t, "Jane Doe", docs.Author)
+	require.Equal(t, "https://github.com/org/repo/pull/12", docs.PrURL)
+	require.True(t, docs.ActionRequired)
+
+	api := res.Get(11)
+	require.Equal(t, "Replace the v1 API (#11, @jane) [SIG CLI]", api.Markdown)
+	require.Equal(t, []string{"api"}, api.Areas)
+	require.Equal(t, []string{"cli"}, api.SIGs)
+	require.Equal(t, "https://github.com/jane", api.AuthorURL)
+	require.True(t, api.Feature)
+	require.True(t, api.ActionRequired)
+
+	fix := res.Get(10)
+	require.Equal(t, []string{string(KindBug)}, fix.Kinds)
+	require.False(t, fix.Feature)
+	require.False(t, fix.ActionRequired)
+
+	// Without GitHub access
+	gatherer.enrichFromGitHub = false
+	gatherer.options.AddMarkdownLinks = true
+	gatherer.options.StartSHA = res.Get(10).Commit
+	noPR := commitToTestRepo(t, repo, "feat: add a flag")
+	gatherer.options.EndSHA = noPR
+
+	res, err = gatherer.ListReleaseNotesFromCommits()
+	require.Nil(t, err)
+	require.Equal(t, ReleaseNotesHistory{-1, 12, 11}, res.History())
+	require.Equal(t, 3, client.GetPullRequestCallCount())
+	require.Equal(t, "Add a flag ("+noPR[:7]+")", res.Get(-1).Markdown)
+	require.Equal(t,
+		"Replace the v1 API ([#11](https://github.com/org/repo/pull/11))",
+		res.Get(11).Markdown,
+	)
+}
+
+func TestListReleaseNotesFromCommitsFailure(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.Nil(t, err)
+	head := commitToTestRepo(t, repo, "feat: add a flag")
+
+	gatherer := NewGathererWithClient(context.Background(), nil)
+	for _, tc := range []struct{ repoPath, start, end string }{
+		{repoPath: t.TempDir(), start: head, end: head},
+		{repoPath: dir, start: plumbing.ZeroHash.String(), end: head},
+		{repoPath: dir, start: head, end: plumbing.ZeroHash.String()},
+	} {
+		gatherer.options.RepoPath = tc.repoPath
+		gatherer.options.StartSHA = tc.start
+		gatherer.options.EndSHA = tc.end
+		_, err := gatherer.ListReleaseNotesFromCommits()
+		require.NotNil(t, err)
+	}
+}
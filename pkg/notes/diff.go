@@ -0,0 +1,316 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// NotesDiff is the difference between two sets of release notes, for example
+// between a release candidate and the final release.
+type NotesDiff struct {
+	// Added contains the notes of PRs which only exist in the new set
+	Added []*ReleaseNote `json:"added,omitempty"`
+
+	// Removed contains the notes of PRs which only exist in the old set
+	Removed []*ReleaseNote `json:"removed,omitempty"`
+
+	// Changed contains the PRs whose notes exist in both sets but differ
+	Changed []*NoteChange `json:"changed,omitempty"`
+}
+
+// NoteChange contains the changed fields of a single release note.
+type NoteChange struct {
+	PrNumber int           `json:"pr_number"`
+	PrURL    string        `json:"pr_url,omitempty"`
+	Fields   []FieldChange `json:"fields"`
+}
+
+// FieldChange is a changed field of a release note, where the field is named
+// after its JSON key.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// ReadReleaseNotesByPR reads the release notes from a JSON file as written by
+// `release-notes --format=json`.
+func ReadReleaseNotesByPR(path string) (ReleaseNotesByPR, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading release notes file %s", path)
+	}
+	res := ReleaseNotesByPR{}
+	if err := json.Unmarshal(content, &res); err != nil {
+		return nil, errors.Wrapf(err, "unmarshal release notes file %s", path)
+	}
+	return res, nil
+}
+
+// DiffReleaseNotes compares the old and new release notes by their PR
+// number. Changes of the text, the kinds, SIGs and areas as well as the flags
+// of a note are reported.
+func DiffReleaseNotes(oldNotes, newNotes ReleaseNotesByPR) *NotesDiff {
+	res := &NotesDiff{}
+	for _, pr := range sortedPRs(oldNotes) {
+		if _, ok := newNotes[pr]; !ok {
+			res.Removed = append(res.Removed, oldNotes[pr])
+		}
+	}
+
+	for _, pr := range sortedPRs(newNotes) {
+		newNote := newNotes[pr]
+		oldNote, ok := oldNotes[pr]
+		if !ok {
+			res.Added = append(res.Added, newNote)
+			continue
+		}
+
+		if fields := diffNote(oldNote, newNote); len(fields) > 0 {
+			prURL := newNote.PrURL
+			if prURL == "" {
+				prURL = oldNote.PrURL
+			}
+			res.Changed = append(res.Changed, &NoteChange{
+				PrNumber: pr, PrURL: prURL, Fields: fields,
+			})
+		}
+	}
+	return res
+}
+
+func sortedPRs(notes ReleaseNotesByPR) []int {
+	prs := make([]int, 0, len(notes))
+	for pr := range notes {
+		prs = append(prs, pr)
+	}
+	sort.Ints(prs)
+	return prs
+}
+
+func diffNote(oldNote, newNote *ReleaseNote) []FieldChange {
+	fields := []FieldChange{}
+	add := func(field, oldValue, newValue string) {
+		if oldValue != newValue {
+			fields = append(fields, FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+
+	add("text", strings.TrimSpace(oldNote.Text), strings.TrimSpace(newNote.Text))
+	add("kinds", joinSorted(oldNote.Kinds), joinSorted(newNote.Kinds))
+	add("sigs", joinSorted(oldNote.SIGs), joinSorted(newNote.SIGs))
+	add("areas", joinSorted(oldNote.Areas), joinSorted(newNote.Areas))
+	for _, flag := range []struct {
+		name     string
+		old, new bool
+	}{
+		{"action_required", oldNote.ActionRequired, newNote.ActionRequired},
+		{"do_not_publish", oldNote.DoNotPublish, newNote.DoNotPublish},
+		{"feature", oldNote.Feature, newNote.Feature},
+		{"duplicate", oldNote.Duplicate, newNote.Duplicate},
+		{"duplicate_kind", oldNote.DuplicateKind, newNote.DuplicateKind},
+	} {
+		add(flag.name, strconv.FormatBool(flag.old), strconv.FormatBool(flag.new))
+	}
+	return fields
+}
+
+func joinSorted(values []string) string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", ")
+}
+
+// Empty returns true if both sets of release notes are equal.
+func (d *NotesDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// String returns the terminal representation of the diff.
+func (d *NotesDiff) String() string {
+	var sb strings.Builder
+	if d.Empty() {
+		sb.WriteString("The release notes are identical\n")
+		return sb.String()
+	}
+
+	for _, section := range []struct {
+		title, prefix string
+		notes         []*ReleaseNote
+	}{
+		{"Added", "+", d.Added},
+		{"Removed", "-", d.Removed},
+	} {
+		if len(section.notes) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "%s (%d):\n", section.title, len(section.notes))
+		for _, note := range section.notes {
+			lines := strings.Split(strings.TrimSpace(note.Text), "\n")
+			fmt.Fprintf(&sb, "  %s #%d %s\n", section.prefix, note.PrNumber, lines[0])
+			for _, line := range lines[1:] {
+				fmt.Fprintf(&sb, "         %s\n", line)
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(d.Changed) > 0 {
+		fmt.Fprintf(&sb, "Changed (%d):\n", len(d.Changed))
+		for _, change := range d.Changed {
+			fmt.Fprintf(&sb, "  ~ #%d %s\n", change.PrNumber, change.PrURL)
+			for _, field := range change.Fields {
+				if field.Field == "text" {
+					sb.WriteString("      text:\n")
+					for _, line := range diffLines(field.Old, field.New) {
+						fmt.Fprintf(&sb, "        %s\n", line)
+					}
+					continue
+				}
+				fmt.Fprintf(&sb, "      %s: %s → %s\n", field.Field, orNone(field.Old), orNone(field.New))
+			}
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n") + "\n"
+}
+
+// Markdown returns the markdown representation of the diff, which can be
+// used for example as review comment.
+func (d *NotesDiff) Markdown() string {
+	var sb strings.Builder
+	if d.Empty() {
+		sb.WriteString("_The release notes are identical._\n")
+		return sb.String()
+	}
+
+	for _, section := range []struct {
+		title string
+		notes []*ReleaseNote
+	}{
+		{"Added", d.Added},
+		{"Removed", d.Removed},
+	} {
+		if len(section.notes) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "### %s (%d)\n\n", section.title, len(section.notes))
+		for _, note := range section.notes {
+			text := strings.ReplaceAll(strings.TrimSpace(note.Text), "\n", "\n  ")
+			fmt.Fprintf(&sb, "- %s: %s\n", prLink(note.PrNumber, note.PrURL), text)
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(d.Changed) > 0 {
+		fmt.Fprintf(&sb, "### Changed (%d)\n\n", len(d.Changed))
+		for _, change := range d.Changed {
+			fmt.Fprintf(&sb, "#### %s\n\n", prLink(change.PrNumber, change.PrURL))
+			var text *FieldChange
+			for i := range change.Fields {
+				field := &change.Fields[i]
+				if field.Field == "text" {
+					text = field
+					continue
+				}
+				fmt.Fprintf(&sb, "- **%s**: `%s` → `%s`\n", field.Field, orNone(field.Old), orNone(field.New))
+			}
+			if text != nil {
+				if len(change.Fields) > 1 {
+					sb.WriteString("\n")
+				}
+				sb.WriteString("```diff\n")
+				for _, line := range diffLines(text.Old, text.New) {
+					fmt.Fprintf(&sb, "%s\n", line)
+				}
+				sb.WriteString("```\n")
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func prLink(number int, url string) string {
+	if url == "" {
+		return fmt.Sprintf("#%d", number)
+	}
+	return fmt.Sprintf("[#%d](%s)", number, url)
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+// diffLines returns a line based diff of both texts, where every line is
+// prefixed by `-` if it got removed, `+` if it got added or a space if it is
+// unchanged.
+func diffLines(oldText, newText string) []string {
+	a := strings.Split(oldText, "\n")
+	b := strings.Split(newText, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	res := []string{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			res = append(res, "  "+a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			res = append(res, "- "+a[i])
+			i++
+		default:
+			res = append(res, "+ "+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		res = append(res, "- "+a[i])
+	}
+	for ; j < len(b); j++ {
+		res = append(res, "+ "+b[j])
+	}
+	return res
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testNotesDiff() *NotesDiff {
+	oldNotes := ReleaseNotesByPR{
+		1: {PrNumber: 1, Text: "Unchanged note", Kinds: []string{"bug"}},
+		2: {PrNumber: 2, Text: "Removed note"},
+		3: {
+			PrNumber: 3,
+			PrURL:    "https://github.com/kubernetes/kubernetes/pull/3",
+			Text:     "First line\nSecond line\nThird line",
+			Kinds:    []string{"bug"},
+			SIGs:     []string{"node", "apps"},
+		},
+	}
+	newNotes := ReleaseNotesByPR{
+		1: {PrNumber: 1, Text: "Unchanged note ", Kinds: []string{"bug"}},
+		3: {
+			PrNumber:       3,
+			PrURL:          "https://github.com/kubernetes/kubernetes/pull/3",
+			Text:           "First line\nChanged line\nThird line",
+			Kinds:          []string{"feature"},
+			SIGs:           []string{"apps", "node"},
+			ActionRequired: true,
+		},
+		4: {PrNumber: 4, Text: "Added note", PrURL: "https://github.com/kubernetes/kubernetes/pull/4"},
+	}
+	return DiffReleaseNotes(oldNotes, newNotes)
+}
+
+func TestDiffReleaseNotes(t *testing.T) {
+	diff := testNotesDiff()
+	require.Len(t, diff.Added, 1)
+	require.Equal(t, 4, diff.Added[0].PrNumber)
+	require.Len(t, diff.Removed, 1)
+	require.Equal(t, 2, diff.Removed[0].PrNumber)
+	require.Equal(t, []*NoteChange{{
+		PrNumber: 3,
+		PrURL:    "https://github.com/kubernetes/kubernetes/pull/3",
+		Fields: []FieldChange{
+			{Field: "text", Old: "First line\nSecond line\nThird line", New: "First line\nChanged line\nThird line"},
+			{Field: "kinds", Old: "bug", New: "feature"},
+			{Field: "action_required", Old: "false", New: "true"},
+		},
+	}}, diff.Changed)
+	require.False(t, diff.Empty())
+
+	require.True(t, DiffReleaseNotes(ReleaseNotesByPR{}, ReleaseNotesByPR{}).Empty())
+}
+
+func TestNotesDiffString(t *testing.T) {
+	require.Equal(t, `Added (1):
+  + #4 Added note
+
+Removed (1):
+  - #2 Removed note
+
+Changed (1):
+  ~ #3 https://github.com/kubernetes/kubernetes/pull/3
+      text:
+          First line
+        - Second line
+        + Changed line
+          Third line
+      kinds: bug → feature
+      action_required: false → true
+`, testNotesDiff().String())
+
+	require.Equal(t, "The release notes are identical\n", (&NotesDiff{}).String())
+}
+
+func TestNotesDiffMarkdown(t *testing.T) {
+	require.Equal(t, "### Added (1)\n\n"+
+		"- [#4](https://github.com/kubernetes/kubernetes/pull/4): Added note\n\n"+
+		"### Removed (1)\n\n"+
+		"- #2: Removed note\n\n"+
+		"### Changed (1)\n\n"+
+		"#### [#3](https://github.com/kubernetes/kubernetes/pull/3)\n\n"+
+		"- **kinds**: `bug` → `feature`\n"+
+		"- **action_required**: `false` → `true`\n\n"+
+		"```diff\n"+
+		"  First line\n"+
+		"- Second line\n"+
+		"+ Changed line\n"+
+		"  Third line\n"+
+		"```\n", testNotesDiff().Markdown())
+}
+
+func TestDiffLines(t *testing.T) {
+	for _, tc := range []struct {
+		old, new string
+		expected []string
+	}{
+		{"a", "a", []string{"  a"}},
+		{"a", "b", []string{"- a", "+ b"}},
+		{"a\nb", "a\nb\nc", []string{"  a", "  b", "+ c"}},
+		{"a\nb\nc", "b", []string{"- a", "  b", "- c"}},
+	} {
+		require.Equal(t, tc.expected, diffLines(tc.old, tc.new))
+	}
+}
+
+func TestReadReleaseNotesByPR(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.json")
+	require.Nil(t, os.WriteFile(path, []byte(`{"123":{"pr_number":123,"text":"A note","kinds":["bug"]}}`), os.FileMode(0o644)))
+
+	res, err := ReadReleaseNotesByPR(path)
+	require.Nil(t, err)
+	require.Equal(t, ReleaseNotesByPR{123: {PrNumber: 123, Text: "A note", Kinds: []string{"bug"}}}, res)
+
+	_, err = ReadReleaseNotesByPR(filepath.Join(t.TempDir(), "missing.json"))
+	require.NotNil(t, err)
+}
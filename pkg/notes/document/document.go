@@ -18,6 +18,7 @@ package document
 
 import (
 	"fmt"
+	htmltemplate "html/template"
 	"io"
 	"os"
 	"path/filepath"
@@ -43,6 +44,9 @@ type Document struct {
 	CurrentRevision         string         `json:"release_tag"`
 	PreviousRevision        string
 	CVEList                 []cve.CVE
+	Dependencies            *Dependencies `json:"dependencies,omitempty"`
+
+	config *notes.Config
 }
 
 // FileMetadata contains metadata about files associated with the release.
@@ -155,27 +159,6 @@ func (n *NoteCollection) Sort(kindPriority []notes.Kind) {
 	})
 }
 
-var kindPriority = []notes.Kind{
-	notes.KindDeprecation,
-	notes.KindAPIChange,
-	notes.KindFeature,
-	notes.KindDesign,
-	notes.KindDocumentation,
-	notes.KindFailingTest,
-	notes.KindBug,
-	notes.KindRegression,
-	notes.KindCleanup,
-	notes.KindFlake,
-	notes.KindOther,
-	notes.KindUncategorized,
-}
-
-var kindMap = map[notes.Kind]notes.Kind{
-	notes.KindRegression: notes.KindBug,
-	notes.KindCleanup:    notes.KindOther,
-	notes.KindFlake:      notes.KindOther,
-}
-
 // GatherReleaseNotesDocument creates a new gatherer and collects the release
 // notes into a fresh document
 func GatherReleaseNotesDocument(
@@ -194,7 +177,8 @@ func GatherReleaseNotesDocument(
 	return doc, nil
 }
 
-// New assembles an organized document from an unorganized set of release notes
+// New assembles an organized document from an unorganized set of release
+// notes, which are categorized by the configuration of the release notes.
 func New(
 	releaseNotes *notes.ReleaseNotes,
 	previousRev, currentRev string,
@@ -204,6 +188,7 @@ func New(
 		Notes:                   NoteCollection{},
 		CurrentRevision:         currentRev,
 		PreviousRevision:        previousRev,
+		config:                  releaseNotes.Config(),
 	}
 
 	stripRE := regexp.MustCompile(`^([-\*]+\s+)`)
@@ -242,7 +227,7 @@ func New(
 
 		// TODO: Refactor the logic here and add testing.
 		if note.DuplicateKind {
-			kind := mapKind(highestPriorityKind(note.Kinds))
+			kind := doc.config.MapKind(doc.config.HighestPriorityKind(note.Kinds))
 			if existing, ok := kindCategory[kind]; ok {
 				*existing.NoteEntries = append(*existing.NoteEntries, processNote(note.Markdown))
 			} else {
@@ -252,7 +237,7 @@ func New(
 			doc.NotesWithActionRequired = append(doc.NotesWithActionRequired, processNote(note.Markdown))
 		} else {
 			for _, kind := range note.Kinds {
-				mappedKind := mapKind(notes.Kind(kind))
+				mappedKind := doc.config.MapKind(notes.Kind(kind))
 
 				if existing, ok := kindCategory[mappedKind]; ok {
 					*existing.NoteEntries = append(*existing.NoteEntries, processNote(note.Markdown))
@@ -278,7 +263,7 @@ func New(
 		doc.Notes = append(doc.Notes, category)
 	}
 
-	doc.Notes.Sort(kindPriority)
+	doc.Notes.Sort(doc.config.Kinds.Priority)
 	sort.Strings(doc.NotesWithActionRequired)
 	return doc, nil
 }
@@ -287,6 +272,15 @@ func New(
 // `templateSpec`. If `templateSpec` is set to `options.GoTemplateDefault`,
 // then it renders in the default template markdown format.
 func (d *Document) RenderMarkdownTemplate(bucket, fileDir, templateSpec string) (string, error) {
+	return d.Render(options.FormatMarkdown, bucket, fileDir, templateSpec)
+}
+
+// Render renders a document in the provided `format` using the golang
+// template in `templateSpec`. If `templateSpec` is set to
+// `options.GoTemplateDefault`, then the default template of the format is
+// used. HTML templates are rendered using html/template, all other formats
+// use text/template.
+func (d *Document) Render(format, bucket, fileDir, templateSpec string) (string, error) {
 	urlPrefix := release.URLPrefixForBucket(bucket)
 
 	fileMetadata, err := fetchMetadata(fileDir, urlPrefix, d.CurrentRevision)
@@ -295,31 +289,47 @@ func (d *Document) RenderMarkdownTemplate(bucket, fileDir, templateSpec string)
 	}
 	d.Downloads = fileMetadata
 
-	goTemplate, err := d.template(templateSpec)
+	goTemplate, err := d.template(format, templateSpec)
 	if err != nil {
 		return "", errors.Wrap(err, "fetching template")
 	}
-	tmpl, err := template.New("markdown").
-		Funcs(template.FuncMap{"prettyKind": prettyKind}).
-		Parse(goTemplate)
-	if err != nil {
-		return "", errors.Wrap(err, "parsing template")
-	}
 
 	var s strings.Builder
-	if err := tmpl.Execute(&s, d); err != nil {
-		return "", errors.Wrapf(err, "rendering with template")
+	if format == options.FormatHTML {
+		tmpl, err := htmltemplate.New(format).
+			Funcs(htmltemplate.FuncMap(d.funcMap(format))).
+			Parse(goTemplate)
+		if err != nil {
+			return "", errors.Wrap(err, "parsing template")
+		}
+		if err := tmpl.Execute(&s, d); err != nil {
+			return "", errors.Wrapf(err, "rendering with template")
+		}
+	} else {
+		tmpl, err := template.New(format).
+			Funcs(d.funcMap(format)).
+			Parse(goTemplate)
+		if err != nil {
+			return "", errors.Wrap(err, "parsing template")
+		}
+		if err := tmpl.Execute(&s, d); err != nil {
+			return "", errors.Wrapf(err, "rendering with template")
+		}
 	}
 	return strings.TrimSpace(s.String()), nil
 }
 
-// template returns either the default template, a template from file or an
-// inline string template. The `templateSpec` must be in the format of
-// `go-template:{default|path/to/template.ext}` or
+// template returns either the default template of the format, a template
+// from file or an inline string template. The `templateSpec` must be in the
+// format of `go-template:{default|path/to/template.ext}` or
 // `go-template:inline:string`
-func (d *Document) template(templateSpec string) (string, error) {
+func (d *Document) template(format, templateSpec string) (string, error) {
 	if templateSpec == options.GoTemplateDefault {
-		return defaultReleaseNotesTemplate, nil
+		goTemplate, ok := defaultTemplates[format]
+		if !ok {
+			return "", errors.Errorf("no default template for format %q", format)
+		}
+		return goTemplate, nil
 	}
 
 	if !strings.HasPrefix(templateSpec, options.GoTemplatePrefix) {
@@ -405,36 +415,10 @@ func CreateDownloadsTable(w io.Writer, bucket, tars, prevTag, newTag string) err
 	return nil
 }
 
-func highestPriorityKind(kinds []string) notes.Kind {
-	for _, prioKind := range kindPriority {
-		for _, k := range kinds {
-			kind := notes.Kind(k)
-			if kind == prioKind {
-				return kind
-			}
-		}
-	}
-
-	// Kind not in priority slice, returning the first one
-	return notes.Kind(kinds[0])
-}
-
-func mapKind(kind notes.Kind) notes.Kind {
-	if newKind, ok := kindMap[kind]; ok {
-		return newKind
-	}
-	return kind
-}
-
-func prettyKind(kind notes.Kind) string {
-	if kind == notes.KindAPIChange {
-		return "API Change"
-	} else if kind == notes.KindFailingTest {
-		return "Failing Test"
-	} else if kind == notes.KindBug {
-		return "Bug or Regression"
-	} else if kind == notes.KindOther {
-		return string(notes.KindOther)
+// prettyKind returns the section title of the kind.
+func (d *Document) prettyKind(kind notes.Kind) string {
+	if d.config == nil {
+		return notes.DefaultConfig().KindTitle(kind)
 	}
-	return strings.Title(string(kind))
+	return d.config.KindTitle(kind)
 }
@@ -423,8 +423,10 @@ func TestNew(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := New(tt.getNotes(), "", "")
+			releaseNotes := tt.getNotes()
+			got, err := New(releaseNotes, "", "")
 			require.NoError(t, err)
+			tt.want.config = releaseNotes.Config()
 			require.Equal(t, got, tt.want, "Unexpected return.")
 		})
 	}
@@ -524,6 +526,40 @@ func TestDocument_RenderMarkdownTemplate(t *testing.T) {
 	}
 }
 
+func TestNewWithConfig(t *testing.T) {
+	config := notes.DefaultConfig()
+	config.Kinds.Priority = []notes.Kind{"enhancement", "bug", notes.KindUncategorized}
+	config.Kinds.Map = map[notes.Kind]notes.Kind{"regression": "bug"}
+	config.Kinds.Titles = map[notes.Kind]string{"enhancement": "Enhancements"}
+
+	testNotes := notes.NewReleaseNotes()
+	testNotes.SetConfig(config)
+	testNotes.Set(0, makeReleaseNote("regression", "Fix a regression."))
+	testNotes.Set(1, makeReleaseNote("", "Uncategorized note."))
+	testNotes.Set(2, makeReleaseNote("enhancement", "Add a flag."))
+
+	duplicate := makeReleaseNote("bug", "Enhance and fix.")
+	duplicate.Kinds = append(duplicate.Kinds, "enhancement")
+	duplicate.DuplicateKind = true
+	testNotes.Set(3, duplicate)
+
+	doc, err := New(testNotes, "v0.1.0", "v0.2.0")
+	require.NoError(t, err)
+
+	kinds := []notes.Kind{}
+	for _, category := range doc.Notes {
+		kinds = append(kinds, category.Kind)
+	}
+	require.Equal(t, []notes.Kind{"enhancement", "bug", notes.KindUncategorized}, kinds)
+	require.Equal(t, notes.Notes{"Add a flag.", "Enhance and fix."}, *doc.Notes[0].NoteEntries)
+
+	got, err := doc.RenderMarkdownTemplate("", "", options.GoTemplateDefault)
+	require.NoError(t, err)
+	require.Contains(t, got, "### Enhancements\n")
+	require.Contains(t, got, "### Bug\n")
+	require.Contains(t, got, "### Uncategorized\n")
+}
+
 func makeReleaseNote(kind notes.Kind, markdown string) *notes.ReleaseNote {
 	n := &notes.ReleaseNote{Markdown: markdown}
 	if kind != "" {
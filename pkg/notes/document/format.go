@@ -0,0 +1,305 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package document
+
+import (
+	"bufio"
+	"bytes"
+	htmltemplate "html/template"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"gopkg.in/yaml.v2"
+
+	"k8s.io/release/pkg/cve"
+	"k8s.io/release/pkg/notes/options"
+)
+
+// Dependencies contains the changed go modules of a release. Every entry is
+// a markdown formatted `module: version` string as reported by
+// `notes.Dependencies`.
+type Dependencies struct {
+	Added   []string `json:"added" yaml:"added"`
+	Changed []string `json:"changed" yaml:"changed"`
+	Removed []string `json:"removed" yaml:"removed"`
+}
+
+// ParseDependencies parses the markdown dependency report of
+// `notes.Dependencies` to be used in non markdown documents.
+func ParseDependencies(report string) *Dependencies {
+	res := &Dependencies{}
+	var section *[]string
+	scanner := bufio.NewScanner(strings.NewReader(report))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "#"):
+			switch strings.TrimSpace(strings.TrimLeft(line, "#")) {
+			case "Added":
+				section = &res.Added
+			case "Changed":
+				section = &res.Changed
+			case "Removed":
+				section = &res.Removed
+			default:
+				section = nil
+			}
+		case strings.HasPrefix(line, "- ") && section != nil:
+			*section = append(*section, strings.TrimPrefix(line, "- "))
+		}
+	}
+	return res
+}
+
+// funcMap returns the template functions available for the provided format.
+func (d *Document) funcMap(format string) template.FuncMap {
+	funcs := template.FuncMap{"prettyKind": d.prettyKind}
+	switch format {
+	case options.FormatAsciiDoc:
+		funcs["asciidoc"] = toAsciiDoc
+	case options.FormatRST:
+		funcs["rst"] = toRST
+		funcs["heading"] = rstHeading
+	case options.FormatHTML:
+		funcs["html"] = toHTML
+	case options.FormatYAML:
+		funcs["toYAML"] = toYAML
+	}
+	return funcs
+}
+
+// inlineMarkdownRE matches the inline markdown elements of release notes:
+// code spans, links and strong emphasis.
+var inlineMarkdownRE = regexp.MustCompile(
+	"`([^`]+)`|\\[([^\\]]+)\\]\\(([^)\\s]+)\\)|\\*\\*([^*]+)\\*\\*",
+)
+
+// inlineConverter converts inline markdown into another markup language.
+type inlineConverter struct {
+	text func(string) string
+	code func(string) string
+	link func(text, url string) string
+	bold func(string) string
+}
+
+func (c *inlineConverter) convert(s string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range inlineMarkdownRE.FindAllStringSubmatchIndex(s, -1) {
+		b.WriteString(c.text(s[last:m[0]]))
+		switch {
+		case m[2] >= 0:
+			b.WriteString(c.code(s[m[2]:m[3]]))
+		case m[4] >= 0:
+			b.WriteString(c.link(s[m[4]:m[5]], s[m[6]:m[7]]))
+		default:
+			b.WriteString(c.bold(c.text(s[m[8]:m[9]])))
+		}
+		last = m[1]
+	}
+	b.WriteString(c.text(s[last:]))
+	return b.String()
+}
+
+// noteLine is a single line of a release note.
+type noteLine struct {
+	text string
+
+	// depth is the nesting level of a list item, starting at 1, or 0 if
+	// the line is not a list item.
+	depth int
+}
+
+var listItemRE = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+
+// noteLines splits a markdown release note into its lines. The continuation
+// lines of a note are indented by two spaces, which gets removed here.
+func noteLines(note string) []noteLine {
+	lines := strings.Split(strings.TrimSpace(note), "\n")
+	res := []noteLine{{text: lines[0]}}
+	for _, line := range lines[1:] {
+		line = strings.TrimRight(strings.TrimPrefix(line, "  "), " \t")
+		if m := listItemRE.FindStringSubmatch(line); m != nil {
+			res = append(res, noteLine{text: m[2], depth: len(m[1])/2 + 1})
+			continue
+		}
+		res = append(res, noteLine{text: strings.TrimSpace(line)})
+	}
+	return res
+}
+
+var asciiDocConverter = &inlineConverter{
+	text: func(s string) string { return s },
+	code: func(s string) string { return "`+" + s + "+`" },
+	link: func(text, url string) string {
+		return "link:" + url + "[" + strings.ReplaceAll(text, "]", `\]`) + "]"
+	},
+	bold: func(s string) string { return "*" + s + "*" },
+}
+
+// toAsciiDoc converts a markdown release note into the content of an
+// AsciiDoc list item. Nested lists are kept and additional paragraphs are
+// attached to the item by a list continuation.
+func toAsciiDoc(note string) string {
+	lines := noteLines(note)
+	res := []string{asciiDocConverter.convert(lines[0].text)}
+	blank := false
+	for _, line := range lines[1:] {
+		switch {
+		case line.text == "":
+			blank = true
+			continue
+		case line.depth > 0:
+			res = append(res, strings.Repeat("*", line.depth+1)+" "+asciiDocConverter.convert(line.text))
+		default:
+			if blank {
+				res = append(res, "+")
+			}
+			res = append(res, asciiDocConverter.convert(line.text))
+		}
+		blank = false
+	}
+	return strings.Join(res, "\n")
+}
+
+// rstEscapeRE matches the characters which start inline markup in
+// reStructuredText.
+var rstEscapeRE = regexp.MustCompile("[\\\\*|`]|_\\b")
+
+var rstConverter = &inlineConverter{
+	text: func(s string) string { return rstEscapeRE.ReplaceAllString(s, `\$0`) },
+	code: func(s string) string { return "``" + s + "``" },
+	link: func(text, url string) string {
+		return "`" + rstEscapeRE.ReplaceAllString(text, `\$0`) + " <" + url + ">`__"
+	},
+	bold: func(s string) string { return "**" + s + "**" },
+}
+
+// toRST converts a markdown release note into the content of a
+// reStructuredText bullet list item. The continuation lines are indented to
+// match a `- ` bullet.
+func toRST(note string) string {
+	lines := noteLines(note)
+	res := []string{rstConverter.convert(lines[0].text)}
+	previous := lines[0]
+	for _, line := range lines[1:] {
+		if line.text == "" {
+			if previous.text != "" {
+				res = append(res, "")
+			}
+			previous = line
+			continue
+		}
+
+		// Lists and paragraphs have to be separated by blank lines
+		if previous.text != "" && (line.depth == 0) != (previous.depth == 0) {
+			res = append(res, "")
+		}
+		if line.depth > 0 {
+			res = append(res, strings.Repeat("  ", line.depth)+"- "+rstConverter.convert(line.text))
+		} else {
+			res = append(res, "  "+rstConverter.convert(line.text))
+		}
+		previous = line
+	}
+	return strings.Join(res, "\n")
+}
+
+// rstHeading returns the reStructuredText section title, underlined using
+// the provided character.
+func rstHeading(char, title string) string {
+	return title + "\n" + strings.Repeat(char, utf8.RuneCountInString(title))
+}
+
+// toHTML converts a markdown release note into HTML. Single paragraphs are
+// not wrapped into a `<p>` element to be usable as list item.
+func toHTML(note string) (htmltemplate.HTML, error) {
+	lines := strings.Split(strings.TrimSpace(note), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimPrefix(lines[i], "  ")
+	}
+
+	var b bytes.Buffer
+	if err := goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+	).Convert([]byte(strings.Join(lines, "\n")), &b); err != nil {
+		return "", errors.Wrap(err, "converting markdown to HTML")
+	}
+
+	res := strings.TrimSpace(b.String())
+	if strings.Count(res, "<p>") == 1 && strings.HasPrefix(res, "<p>") && strings.HasSuffix(res, "</p>") {
+		res = strings.TrimSuffix(strings.TrimPrefix(res, "<p>"), "</p>")
+	}
+	//nolint:gosec // goldmark does not render raw HTML by default
+	return htmltemplate.HTML(res), nil
+}
+
+// yamlDocument is the YAML representation of a Document.
+type yamlDocument struct {
+	ReleaseTag         string          `yaml:"release_tag"`
+	PreviousReleaseTag string          `yaml:"previous_release_tag,omitempty"`
+	Downloads          *FileMetadata   `yaml:"downloads,omitempty"`
+	CVEs               []cve.CVE       `yaml:"cves,omitempty"`
+	ActionRequired     []string        `yaml:"action_required,omitempty"`
+	Notes              []yamlNotesKind `yaml:"notes,omitempty"`
+	Dependencies       *Dependencies   `yaml:"dependencies,omitempty"`
+}
+
+// yamlNotesKind contains the notes of a single kind.
+type yamlNotesKind struct {
+	Kind  string   `yaml:"kind"`
+	Title string   `yaml:"title"`
+	Notes []string `yaml:"notes"`
+}
+
+// MarshalYAML implements the yaml.Marshaler interface. The notes are kept
+// in markdown.
+func (d *Document) MarshalYAML() (interface{}, error) {
+	res := &yamlDocument{
+		ReleaseTag:         d.CurrentRevision,
+		PreviousReleaseTag: d.PreviousRevision,
+		Downloads:          d.Downloads,
+		CVEs:               d.CVEList,
+		ActionRequired:     d.NotesWithActionRequired,
+		Dependencies:       d.Dependencies,
+	}
+	for _, category := range d.Notes {
+		kind := yamlNotesKind{
+			Kind:  string(category.Kind),
+			Title: d.prettyKind(category.Kind),
+		}
+		if category.NoteEntries != nil {
+			kind.Notes = *category.NoteEntries
+		}
+		res.Notes = append(res.Notes, kind)
+	}
+	return res, nil
+}
+
+// toYAML marshals the provided value into YAML.
+func toYAML(v interface{}) (string, error) {
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal YAML")
+	}
+	return string(b), nil
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package document
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/cve"
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/options"
+	"k8s.io/release/pkg/release"
+)
+
+const testDependencies = `## Dependencies
+
+### Added
+- github.com/cilium/ebpf: [95b36a5](https://github.com/cilium/ebpf/tree/95b36a5)
+
+### Changed
+- github.com/golang/mock: [v1.2.0 → v1.3.1](https://github.com/golang/mock/compare/v1.2.0...v1.3.1)
+- rsc.io/pdf: v0.1.1
+
+### Removed
+_Nothing has changed._
+`
+
+func TestParseDependencies(t *testing.T) {
+	require.Equal(t, &Dependencies{
+		Added: []string{
+			"github.com/cilium/ebpf: [95b36a5](https://github.com/cilium/ebpf/tree/95b36a5)",
+		},
+		Changed: []string{
+			"github.com/golang/mock: [v1.2.0 → v1.3.1](https://github.com/golang/mock/compare/v1.2.0...v1.3.1)",
+			"rsc.io/pdf: v0.1.1",
+		},
+	}, ParseDependencies(testDependencies))
+}
+
+func TestConvertNote(t *testing.T) {
+	for _, tc := range []struct {
+		note, asciidoc, rst, html string
+	}{
+		{ // plain text
+			note:     "Fix a bug.",
+			asciidoc: "Fix a bug.",
+			rst:      "Fix a bug.",
+			html:     "Fix a bug.",
+		},
+		{ // inline markup
+			note:     "Add `--flag` to **kubelet** ([#1](https://github.com/kubernetes/kubernetes/pull/1), [@user](https://github.com/user))",
+			asciidoc: "Add `+--flag+` to *kubelet* (link:https://github.com/kubernetes/kubernetes/pull/1[#1], link:https://github.com/user[@user])",
+			rst:      "Add ``--flag`` to **kubelet** (`#1 <https://github.com/kubernetes/kubernetes/pull/1>`__, `@user <https://github.com/user>`__)",
+			html:     `Add <code>--flag</code> to <strong>kubelet</strong> (<a href="https://github.com/kubernetes/kubernetes/pull/1">#1</a>, <a href="https://github.com/user">@user</a>)`,
+		},
+		{ // escaping
+			note:     "Rename *.go files and the ref_ field",
+			asciidoc: "Rename *.go files and the ref_ field",
+			rst:      `Rename \*.go files and the ref\_ field`,
+			html:     "Rename *.go files and the ref_ field",
+		},
+		{ // multiple lines
+			note:     "Change the defaults:\n  - a\n    - b\n  \n  More text (#1, @user)",
+			asciidoc: "Change the defaults:\n** a\n*** b\n+\nMore text (#1, @user)",
+			rst:      "Change the defaults:\n\n  - a\n    - b\n\n  More text (#1, @user)",
+			html:     "<p>Change the defaults:</p>\n<ul>\n<li>a\n<ul>\n<li>b</li>\n</ul>\n</li>\n</ul>\n<p>More text (#1, @user)</p>",
+		},
+	} {
+		require.Equal(t, tc.asciidoc, toAsciiDoc(tc.note))
+		require.Equal(t, tc.rst, toRST(tc.note))
+		html, err := toHTML(tc.note)
+		require.Nil(t, err)
+		require.EqualValues(t, tc.html, html)
+	}
+}
+
+func TestDocument_Render(t *testing.T) {
+	testNotes := notes.NewReleaseNotes()
+	testNotes.Set(0, makeReleaseNote(notes.KindDeprecation, "Deprecate `--flag` ([#1](https://github.com/kubernetes/kubernetes/pull/1), [@user](https://github.com/user))"))
+	testNotes.Set(1, makeReleaseNote(notes.KindBug, "Fix a bug:\n  - in the kubelet\n  - in the scheduler (#2, @user)"))
+	testNotes.Set(2, makeReleaseNote(notes.KindFeature, "A **feature** & more."))
+	actionNeeded := makeReleaseNote(notes.KindAPIChange, "Action required note.")
+	actionNeeded.ActionRequired = true
+	testNotes.Set(3, actionNeeded)
+
+	doc, err := New(testNotes, "v1.16.0", "v1.16.1")
+	require.Nil(t, err)
+	doc.CVEList = []cve.CVE{{
+		ID:            "CVE-2021-12345",
+		Title:         "Privilege escalation",
+		Description:   "A privilege escalation in the kubelet.",
+		TrackingIssue: "https://github.com/kubernetes/kubernetes/issues/123",
+		CVSSVector:    "CVSS:3.1/AV:N/AC:H/PR:H/UI:R/S:U/C:H/I:H/A:H",
+		CVSSScore:     6.2,
+		CVSSRating:    "Medium",
+		CalcLink:      "https://www.first.org/cvss/calculator/3.1#CVSS:3.1/AV:N/AC:H/PR:H/UI:R/S:U/C:H/I:H/A:H",
+	}}
+	doc.Dependencies = ParseDependencies(testDependencies)
+
+	dir := t.TempDir()
+	for _, file := range []string{"kubernetes.tar.gz", "kubernetes-client-linux-amd64.tar.gz"} {
+		require.Nil(t, os.WriteFile(
+			filepath.Join(dir, file), []byte{1, 2, 3}, os.FileMode(0o644),
+		))
+	}
+
+	for _, tc := range []struct {
+		format, golden string
+	}{
+		{options.FormatAsciiDoc, "document.adoc.golden"},
+		{options.FormatRST, "document.rst.golden"},
+		{options.FormatHTML, "document.html.golden"},
+		{options.FormatYAML, "document.yaml.golden"},
+	} {
+		got, err := doc.Render(tc.format, release.ProductionBucket, dir, options.GoTemplateDefault)
+		require.Nil(t, err, tc.format)
+		require.Equal(t, readFile(t, filepath.Join("testdata", tc.golden)), got, tc.format)
+	}
+}
+
+func TestDocument_RenderTemplateOverride(t *testing.T) {
+	testNotes := notes.NewReleaseNotes()
+	testNotes.Set(0, makeReleaseNote(notes.KindFeature, "A **feature** & more."))
+	doc, err := New(testNotes, "v1.16.0", "v1.16.1")
+	require.Nil(t, err)
+
+	for _, tc := range []struct {
+		format, template, expected string
+	}{
+		{
+			options.FormatAsciiDoc,
+			`{{ range .Notes }}{{ range .NoteEntries }}{{ asciidoc . }}{{ end }}{{ end }}`,
+			"A *feature* & more.",
+		},
+		{
+			options.FormatRST,
+			`{{ heading "=" .CurrentRevision }}`,
+			"v1.16.1\n=======",
+		},
+		{
+			options.FormatHTML,
+			`<h1>{{ .CurrentRevision }}</h1>{{ range .Notes }}{{ range .NoteEntries }}{{ html . }}{{ end }}{{ end }}`,
+			"<h1>v1.16.1</h1>A <strong>feature</strong> &amp; more.",
+		},
+		{
+			options.FormatYAML,
+			`release_tag: {{ printf "%q" .CurrentRevision }}`,
+			`release_tag: "v1.16.1"`,
+		},
+	} {
+		got, err := doc.Render(tc.format, "", "", options.GoTemplateInline+tc.template)
+		require.Nil(t, err, tc.format)
+		require.Equal(t, tc.expected, got, tc.format)
+	}
+
+	_, err = doc.Render("invalid", "", "", options.GoTemplateDefault)
+	require.NotNil(t, err)
+}
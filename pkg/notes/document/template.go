@@ -16,6 +16,8 @@ limitations under the License.
 
 package document
 
+import "k8s.io/release/pkg/notes/options"
+
 // defaultReleaseNotesTemplate is the text template for the default release notes.
 // k8s/release/cmd/release-notes uses text/template to render markdown
 // templates.
@@ -95,3 +97,325 @@ This release contains changes that address the following vulnerabilities:
 {{- end -}}
 {{- end -}}
 `
+
+// defaultTemplates are the default templates of every supported format.
+var defaultTemplates = map[string]string{
+	options.FormatMarkdown: defaultReleaseNotesTemplate,
+	options.FormatAsciiDoc: defaultAsciiDocTemplate,
+	options.FormatRST:      defaultRSTTemplate,
+	options.FormatHTML:     defaultHTMLTemplate,
+	options.FormatYAML:     defaultYAMLTemplate,
+}
+
+// defaultAsciiDocTemplate is the text template for the AsciiDoc release
+// notes. It follows the structure of the default markdown template, while
+// the notes get converted by the `asciidoc` function.
+const defaultAsciiDocTemplate = `
+{{- define "files" -}}
+[options="header"]
+|===
+|filename |sha512 hash
+{{ range . }}
+|link:{{.URL}}[{{.Name}}] |` + "`+{{.Checksum}}+`" + `
+{{- end }}
+|===
+{{ end -}}
+
+{{- define "modules" -}}
+{{ range . }}* {{ asciidoc . }}
+{{ else }}_Nothing has changed._
+{{ end -}}
+{{- end -}}
+
+{{- $CurrentRevision := .CurrentRevision -}}
+
+{{- if .Downloads }}
+== Downloads for {{$CurrentRevision}}
+{{ with .Downloads.Source }}
+=== Source Code
+
+{{ template "files" . }}
+{{- end }}
+{{- with .Downloads.Client }}
+=== Client Binaries
+
+{{ template "files" . }}
+{{- end }}
+{{- with .Downloads.Server }}
+=== Server Binaries
+
+{{ template "files" . }}
+{{- end }}
+{{- with .Downloads.Node }}
+=== Node Binaries
+
+{{ template "files" . }}
+{{- end }}
+{{- end }}
+
+{{- with .CVEList }}
+== Important Security Information
+
+This release contains changes that address the following vulnerabilities:
+{{ range . }}
+=== {{.ID}}: {{.Title}}
+
+{{.Description}}
+
+*CVSS Rating:* {{.CVSSRating}} ({{.CVSSScore}}) link:{{.CalcLink}}[{{.CVSSVector}}]
+{{- if .TrackingIssue }} +
+*Tracking Issue:* {{.TrackingIssue}}
+{{- end }}
+{{ end }}
+{{- end }}
+
+{{- with .NotesWithActionRequired }}
+== Urgent Upgrade Notes
+
+=== (No, really, you MUST read this before you upgrade)
+
+{{ range . }}* {{ asciidoc . }}
+{{ end }}
+{{- end }}
+
+{{- with .Notes }}
+== Changes by Kind
+{{ range . }}
+=== {{.Kind | prettyKind}}
+
+{{ range .NoteEntries }}* {{ asciidoc . }}
+{{ end }}
+{{- end }}
+{{- end }}
+
+{{- with .Dependencies }}
+== Dependencies
+
+=== Added
+
+{{ template "modules" .Added }}
+=== Changed
+
+{{ template "modules" .Changed }}
+=== Removed
+
+{{ template "modules" .Removed }}
+{{- end }}
+`
+
+// defaultRSTTemplate is the text template for the reStructuredText release
+// notes. It follows the structure of the default markdown template, while
+// the notes get converted by the `rst` function.
+const defaultRSTTemplate = `
+{{- define "files" -}}
+.. list-table::
+   :header-rows: 1
+
+   * - filename
+     - sha512 hash
+{{- range . }}
+   * - ` + "`{{.Name}} <{{.URL}}>`__" + `
+     - ` + "``{{.Checksum}}``" + `
+{{- end }}
+{{ end -}}
+
+{{- define "modules" -}}
+{{ range . }}- {{ rst . }}
+{{ else }}*Nothing has changed.*
+{{ end -}}
+{{- end -}}
+
+{{- $CurrentRevision := .CurrentRevision -}}
+
+{{- if .Downloads }}
+{{ heading "=" (printf "Downloads for %s" $CurrentRevision) }}
+{{ with .Downloads.Source }}
+{{ heading "-" "Source Code" }}
+
+{{ template "files" . }}
+{{- end }}
+{{- with .Downloads.Client }}
+{{ heading "-" "Client Binaries" }}
+
+{{ template "files" . }}
+{{- end }}
+{{- with .Downloads.Server }}
+{{ heading "-" "Server Binaries" }}
+
+{{ template "files" . }}
+{{- end }}
+{{- with .Downloads.Node }}
+{{ heading "-" "Node Binaries" }}
+
+{{ template "files" . }}
+{{- end }}
+{{- end }}
+
+{{- with .CVEList }}
+{{ heading "=" "Important Security Information" }}
+
+This release contains changes that address the following vulnerabilities:
+{{ range . }}
+{{ heading "-" (printf "%s: %s" .ID .Title) }}
+
+{{.Description}}
+
+**CVSS Rating:** {{.CVSSRating}} ({{.CVSSScore}}) ` + "`{{.CVSSVector}} <{{.CalcLink}}>`__" + `
+{{- if .TrackingIssue }}
+
+**Tracking Issue:** {{.TrackingIssue}}
+{{- end }}
+{{ end }}
+{{- end }}
+
+{{- with .NotesWithActionRequired }}
+{{ heading "=" "Urgent Upgrade Notes" }}
+
+{{ heading "-" "(No, really, you MUST read this before you upgrade)" }}
+
+{{ range . }}- {{ rst . }}
+{{ end }}
+{{- end }}
+
+{{- with .Notes }}
+{{ heading "=" "Changes by Kind" }}
+{{ range . }}
+{{ heading "-" (prettyKind .Kind) }}
+
+{{ range .NoteEntries }}- {{ rst . }}
+{{ end }}
+{{- end }}
+{{- end }}
+
+{{- with .Dependencies }}
+{{ heading "=" "Dependencies" }}
+
+{{ heading "-" "Added" }}
+
+{{ template "modules" .Added }}
+{{ heading "-" "Changed" }}
+
+{{ template "modules" .Changed }}
+{{ heading "-" "Removed" }}
+
+{{ template "modules" .Removed }}
+{{- end }}
+`
+
+// defaultHTMLTemplate is the html template for the standalone HTML release
+// notes. It follows the structure of the default markdown template, while
+// the notes get converted by the `html` function.
+const defaultHTMLTemplate = `
+{{- define "files" }}
+    <table>
+      <thead>
+        <tr><th>filename</th><th>sha512 hash</th></tr>
+      </thead>
+      <tbody>
+{{- range . }}
+        <tr><td><a href="{{.URL}}">{{.Name}}</a></td><td><code>{{.Checksum}}</code></td></tr>
+{{- end }}
+      </tbody>
+    </table>
+{{- end -}}
+
+{{- define "modules" -}}
+{{- with . }}
+    <ul>
+{{- range . }}
+      <li>{{ html . }}</li>
+{{- end }}
+    </ul>
+{{- else }}
+    <p><em>Nothing has changed.</em></p>
+{{- end }}
+{{- end -}}
+
+<!DOCTYPE html>
+<html>
+  <head>
+    <meta charset="utf-8" />
+    <meta name="viewport" content="width=device-width" />
+    <title>{{.CurrentRevision}}</title>
+    <style type="text/css">
+      table,
+      th,
+      tr,
+      td {
+        border: 1px solid gray;
+        border-collapse: collapse;
+        padding: 5px;
+      }
+    </style>
+  </head>
+  <body>
+    <h1>{{.CurrentRevision}}</h1>
+{{- if .Downloads }}
+    <h2>Downloads for {{.CurrentRevision}}</h2>
+{{- with .Downloads.Source }}
+    <h3>Source Code</h3>
+{{- template "files" . }}
+{{- end }}
+{{- with .Downloads.Client }}
+    <h3>Client Binaries</h3>
+{{- template "files" . }}
+{{- end }}
+{{- with .Downloads.Server }}
+    <h3>Server Binaries</h3>
+{{- template "files" . }}
+{{- end }}
+{{- with .Downloads.Node }}
+    <h3>Node Binaries</h3>
+{{- template "files" . }}
+{{- end }}
+{{- end }}
+{{- with .CVEList }}
+    <h2>Important Security Information</h2>
+    <p>This release contains changes that address the following vulnerabilities:</p>
+{{- range . }}
+    <h3>{{.ID}}: {{.Title}}</h3>
+    <p>{{.Description}}</p>
+    <p>
+      <strong>CVSS Rating:</strong> {{.CVSSRating}} ({{.CVSSScore}}) <a href="{{.CalcLink}}">{{.CVSSVector}}</a>
+{{- if .TrackingIssue }}<br />
+      <strong>Tracking Issue:</strong> {{.TrackingIssue}}
+{{- end }}
+    </p>
+{{- end }}
+{{- end }}
+{{- with .NotesWithActionRequired }}
+    <h2>Urgent Upgrade Notes</h2>
+    <h3>(No, really, you MUST read this before you upgrade)</h3>
+    <ul>
+{{- range . }}
+      <li>{{ html . }}</li>
+{{- end }}
+    </ul>
+{{- end }}
+{{- with .Notes }}
+    <h2>Changes by Kind</h2>
+{{- range . }}
+    <h3>{{.Kind | prettyKind}}</h3>
+    <ul>
+{{- range .NoteEntries }}
+      <li>{{ html . }}</li>
+{{- end }}
+    </ul>
+{{- end }}
+{{- end }}
+{{- with .Dependencies }}
+    <h2>Dependencies</h2>
+    <h3>Added</h3>
+{{- template "modules" .Added }}
+    <h3>Changed</h3>
+{{- template "modules" .Changed }}
+    <h3>Removed</h3>
+{{- template "modules" .Removed }}
+{{- end }}
+  </body>
+</html>
+`
+
+// defaultYAMLTemplate is the text template for the YAML release notes, which
+// contain the notes in markdown.
+const defaultYAMLTemplate = `{{ toYAML . }}`
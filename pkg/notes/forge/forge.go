@@ -0,0 +1,146 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forge
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	gogithub "github.com/google/go-github/v39/github"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-sdk/github"
+)
+
+// The supported forges hosting the repository of the release notes.
+const (
+	GitHub = "github"
+	GitLab = "gitlab"
+	Gitea  = "gitea"
+)
+
+const (
+	// GitLabTokenEnvKey is the environment variable of the GitLab API token.
+	GitLabTokenEnvKey = "GITLAB_TOKEN"
+
+	// GiteaTokenEnvKey is the environment variable of the Gitea API token.
+	GiteaTokenEnvKey = "GITEA_TOKEN"
+
+	// DefaultGitLabURL is the GitLab instance used if no URL is provided.
+	DefaultGitLabURL = "https://gitlab.com"
+
+	// DefaultGiteaURL is the Gitea instance used if no URL is provided.
+	DefaultGiteaURL = "https://gitea.com"
+)
+
+// Provider is the forge API used for gathering release notes: listing the
+// commits of a branch, resolving commits to the pull requests (or merge
+// requests) which introduced them and getting the pull requests including
+// their description, labels and author.
+//
+// The release notes are based on the GitHub API model, so the GitLab and Gitea
+// implementations translate their responses into the types of go-github.
+type Provider interface {
+	GetCommit(
+		context.Context, string, string, string,
+	) (*gogithub.Commit, *gogithub.Response, error)
+
+	ListCommits(
+		context.Context, string, string, *gogithub.CommitsListOptions,
+	) ([]*gogithub.RepositoryCommit, *gogithub.Response, error)
+
+	ListPullRequestsWithCommit(
+		context.Context, string, string, string, *gogithub.PullRequestListOptions,
+	) ([]*gogithub.PullRequest, *gogithub.Response, error)
+
+	GetPullRequest(
+		context.Context, string, string, int,
+	) (*gogithub.PullRequest, *gogithub.Response, error)
+}
+
+// IsValid returns true if the forge is supported.
+func IsValid(forge string) bool {
+	return forge == GitHub || forge == GitLab || forge == Gitea
+}
+
+// TokenEnvKey returns the environment variable of the API token for the
+// provided forge.
+func TokenEnvKey(forge string) string {
+	switch forge {
+	case GitLab:
+		return GitLabTokenEnvKey
+	case Gitea:
+		return GiteaTokenEnvKey
+	default:
+		return github.TokenEnvKey
+	}
+}
+
+// New creates a client for the forge instance at baseURL, which defaults to
+// the public instance of the forge, using httpClient for the requests. The
+// client supports all operations of the Provider, the other ones of the
+// release-sdk GitHub client return an error. This allows the usage of the
+// release-sdk API recorder and replayer.
+func New(forge, baseURL, token string, httpClient *http.Client) (github.Client, error) {
+	switch forge {
+	case GitHub:
+		return NewGitHub(baseURL, token, httpClient)
+	case GitLab:
+		if baseURL == "" {
+			baseURL = DefaultGitLabURL
+		}
+		return NewGitLab(baseURL, token, httpClient), nil
+	case Gitea:
+		if baseURL == "" {
+			baseURL = DefaultGiteaURL
+		}
+		return NewGitea(baseURL, token, httpClient), nil
+	default:
+		return nil, errors.Errorf("unsupported forge: %s", forge)
+	}
+}
+
+// RepoURL returns the HTTPS clone URL of the repository on the forge instance
+// at baseURL, which defaults to the public instance of the forge.
+func RepoURL(forge, baseURL, owner, repo string) string {
+	switch forge {
+	case GitLab:
+		if baseURL == "" {
+			baseURL = DefaultGitLabURL
+		}
+	case Gitea:
+		if baseURL == "" {
+			baseURL = DefaultGiteaURL
+		}
+	default:
+		return git.GetRepoURL(owner, repo, false)
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/" + projectPath(owner, repo) + ".git"
+}
+
+// projectPath returns the full path of a repository.
+func projectPath(owner, repo string) string {
+	return strings.Trim(owner, "/") + "/" + strings.Trim(repo, "/")
+}
+
+var (
+	_ github.Client = &GitHubClient{}
+	_ github.Client = &GitLabClient{}
+	_ github.Client = &GiteaClient{}
+)
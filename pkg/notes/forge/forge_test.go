@@ -0,0 +1,170 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forge
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/release-sdk/github"
+)
+
+// newTestServer returns a server which responds to the provided paths with
+// the content of the fixture files and with 404 to all other requests.
+func newTestServer(t *testing.T, routes map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fixture, ok := routes[r.URL.EscapedPath()]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			content, err := os.ReadFile(filepath.Join("testdata", fixture))
+			require.Nil(t, err)
+			w.Header().Set("Content-Type", "application/json")
+			_, err = w.Write(content)
+			require.Nil(t, err)
+		},
+	))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestIsValid(t *testing.T) {
+	for forge, valid := range map[string]bool{
+		GitHub:  true,
+		GitLab:  true,
+		Gitea:   true,
+		"":      false,
+		"wrong": false,
+	} {
+		require.Equal(t, valid, IsValid(forge), forge)
+	}
+}
+
+func TestTokenEnvKey(t *testing.T) {
+	require.Equal(t, github.TokenEnvKey, TokenEnvKey(GitHub))
+	require.Equal(t, GitLabTokenEnvKey, TokenEnvKey(GitLab))
+	require.Equal(t, GiteaTokenEnvKey, TokenEnvKey(Gitea))
+}
+
+func TestRepoURL(t *testing.T) {
+	require.Equal(t, "https://github.com/kubernetes/kubernetes",
+		RepoURL(GitHub, "", "kubernetes", "kubernetes"))
+	require.Equal(t, "https://gitlab.com/group/sub/project.git",
+		RepoURL(GitLab, "", "group/sub", "project"))
+	require.Equal(t, "https://git.example.com/org/repo.git",
+		RepoURL(Gitea, "https://git.example.com/", "org", "repo"))
+}
+
+func TestNew(t *testing.T) {
+	for _, tc := range []struct {
+		forge       string
+		baseURL     string
+		expectedURL string
+		shouldErr   bool
+	}{
+		{forge: GitLab, expectedURL: DefaultGitLabURL + "/api/v4"},
+		{forge: GitLab, baseURL: "https://gitlab.example.com/", expectedURL: "https://gitlab.example.com/api/v4"},
+		{forge: Gitea, expectedURL: DefaultGiteaURL + "/api/v1"},
+		{forge: GitHub},
+		{forge: GitHub, baseURL: "https://github.example.com/api/v3", expectedURL: "https://github.example.com/api/v3/"},
+		{forge: "wrong", shouldErr: true},
+	} {
+		client, err := New(tc.forge, tc.baseURL, "token", nil)
+		if tc.shouldErr {
+			require.NotNil(t, err)
+			continue
+		}
+		require.Nil(t, err)
+
+		switch c := client.(type) {
+		case *GitHubClient:
+			if tc.expectedURL != "" {
+				require.Equal(t, tc.expectedURL, c.client.BaseURL.String())
+			}
+		case *GitLabClient:
+			require.Equal(t, tc.expectedURL, c.rest.apiURL)
+			require.Equal(t, "token", c.rest.header.Get("PRIVATE-TOKEN"))
+		case *GiteaClient:
+			require.Equal(t, tc.expectedURL, c.rest.apiURL)
+			require.Equal(t, "token token", c.rest.header.Get("Authorization"))
+		default:
+			t.Fatalf("unexpected client type %T", client)
+		}
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	_, _, err := NewGitLab(DefaultGitLabURL, "", nil).GetIssue(context.Background(), "owner", "repo", 1)
+	require.Equal(t, ErrUnsupported, err)
+}
+
+func TestNewResponse(t *testing.T) {
+	for _, tc := range []struct {
+		header           http.Header
+		expectedNextPage int
+		expectedLastPage int
+	}{
+		{
+			header: http.Header{"Link": []string{
+				`<https://gitea.example.com/api/v1/repos/o/r/commits?page=2&limit=1>; rel="next",` +
+					`<https://gitea.example.com/api/v1/repos/o/r/commits?page=5&limit=1>; rel="last"`,
+			}},
+			expectedNextPage: 2,
+			expectedLastPage: 5,
+		},
+		{
+			header: http.Header{
+				"X-Next-Page":   []string{"3"},
+				"X-Total-Pages": []string{"4"},
+			},
+			expectedNextPage: 3,
+			expectedLastPage: 4,
+		},
+		{header: http.Header{}},
+	} {
+		resp := newResponse(&http.Response{Header: tc.header})
+		require.Equal(t, tc.expectedNextPage, resp.NextPage)
+		require.Equal(t, tc.expectedLastPage, resp.LastPage)
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"/api/v1/repos/owner/repo/pulls/3": "gitea/pull.json",
+	})
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	recorder := github.NewRecorder(NewGitea(server.URL, "", nil), dir)
+	recorded, _, err := recorder.GetPullRequest(ctx, "owner", "repo", 3)
+	require.Nil(t, err)
+
+	replayed, _, err := github.NewReplayer(dir).GetPullRequest(ctx, "owner", "repo", 3)
+	require.Nil(t, err)
+	require.Equal(t, recorded.GetNumber(), replayed.GetNumber())
+	require.Equal(t, recorded.GetBody(), replayed.GetBody())
+	require.Equal(t, recorded.GetUser().GetLogin(), replayed.GetUser().GetLogin())
+	require.Len(t, replayed.Labels, 1)
+}
@@ -0,0 +1,145 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package forgefakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/go-github/v39/github"
+	"k8s.io/release/pkg/notes/forge"
+)
+
+type FakeBatchProvider struct {
+	GetPullRequestsStub        func(context.Context, string, string, []int) (map[int]*github.PullRequest, error)
+	getPullRequestsMutex       sync.RWMutex
+	getPullRequestsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 []int
+	}
+	getPullRequestsReturns struct {
+		result1 map[int]*github.PullRequest
+		result2 error
+	}
+	getPullRequestsReturnsOnCall map[int]struct {
+		result1 map[int]*github.PullRequest
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBatchProvider) GetPullRequests(arg1 context.Context, arg2 string, arg3 string, arg4 []int) (map[int]*github.PullRequest, error) {
+	var arg4Copy []int
+	if arg4 != nil {
+		arg4Copy = make([]int, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.getPullRequestsMutex.Lock()
+	ret, specificReturn := fake.getPullRequestsReturnsOnCall[len(fake.getPullRequestsArgsForCall)]
+	fake.getPullRequestsArgsForCall = append(fake.getPullRequestsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 []int
+	}{arg1, arg2, arg3, arg4Copy})
+	stub := fake.GetPullRequestsStub
+	fakeReturns := fake.getPullRequestsReturns
+	fake.recordInvocation("GetPullRequests", []interface{}{arg1, arg2, arg3, arg4Copy})
+	fake.getPullRequestsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBatchProvider) GetPullRequestsCallCount() int {
+	fake.getPullRequestsMutex.RLock()
+	defer fake.getPullRequestsMutex.RUnlock()
+	return len(fake.getPullRequestsArgsForCall)
+}
+
+func (fake *FakeBatchProvider) GetPullRequestsCalls(stub func(context.Context, string, string, []int) (map[int]*github.PullRequest, error)) {
+	fake.getPullRequestsMutex.Lock()
+	defer fake.getPullRequestsMutex.Unlock()
+	fake.GetPullRequestsStub = stub
+}
+
+func (fake *FakeBatchProvider) GetPullRequestsArgsForCall(i int) (context.Context, string, string, []int) {
+	fake.getPullRequestsMutex.RLock()
+	defer fake.getPullRequestsMutex.RUnlock()
+	argsForCall := fake.getPullRequestsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeBatchProvider) GetPullRequestsReturns(result1 map[int]*github.PullRequest, result2 error) {
+	fake.getPullRequestsMutex.Lock()
+	defer fake.getPullRequestsMutex.Unlock()
+	fake.GetPullRequestsStub = nil
+	fake.getPullRequestsReturns = struct {
+		result1 map[int]*github.PullRequest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBatchProvider) GetPullRequestsReturnsOnCall(i int, result1 map[int]*github.PullRequest, result2 error) {
+	fake.getPullRequestsMutex.Lock()
+	defer fake.getPullRequestsMutex.Unlock()
+	fake.GetPullRequestsStub = nil
+	if fake.getPullRequestsReturnsOnCall == nil {
+		fake.getPullRequestsReturnsOnCall = make(map[int]struct {
+			result1 map[int]*github.PullRequest
+			result2 error
+		})
+	}
+	fake.getPullRequestsReturnsOnCall[i] = struct {
+		result1 map[int]*github.PullRequest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBatchProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getPullRequestsMutex.RLock()
+	defer fake.getPullRequestsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeBatchProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ forge.BatchProvider = new(FakeBatchProvider)
@@ -0,0 +1,242 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	gogithub "github.com/google/go-github/v39/github"
+)
+
+// GiteaClient is the client for the REST API v1 of a Gitea instance.
+type GiteaClient struct {
+	unsupported
+	baseURL string
+	rest    *restClient
+}
+
+// NewGitea creates a new Gitea client for the instance at baseURL, for
+// example https://gitea.com. The token is optional and httpClient defaults to
+// http.DefaultClient.
+func NewGitea(baseURL, token string, httpClient *http.Client) *GiteaClient {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	header := http.Header{}
+	if token != "" {
+		header.Set("Authorization", "token "+token)
+	}
+	return &GiteaClient{
+		baseURL: baseURL,
+		rest:    newRESTClient(baseURL+"/api/v1", header, httpClient),
+	}
+}
+
+type giteaUser struct {
+	Login   string `json:"login"`
+	HTMLURL string `json:"html_url"`
+}
+
+type giteaCommitUser struct {
+	Name  string     `json:"name"`
+	Email string     `json:"email"`
+	Date  *time.Time `json:"date"`
+}
+
+type giteaCommit struct {
+	SHA     string `json:"sha"`
+	HTMLURL string `json:"html_url"`
+	Commit  struct {
+		Message   string          `json:"message"`
+		Author    giteaCommitUser `json:"author"`
+		Committer giteaCommitUser `json:"committer"`
+	} `json:"commit"`
+	Author *giteaUser `json:"author"`
+}
+
+type giteaPullRequest struct {
+	Number         int        `json:"number"`
+	Title          string     `json:"title"`
+	Body           string     `json:"body"`
+	State          string     `json:"state"`
+	HTMLURL        string     `json:"html_url"`
+	User           giteaUser  `json:"user"`
+	MergedAt       *time.Time `json:"merged_at"`
+	MergeCommitSHA string     `json:"merge_commit_sha"`
+	Labels         []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+	Head struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	} `json:"head"`
+}
+
+func (g *GiteaClient) toUser(user *giteaUser) *gogithub.User {
+	htmlURL := user.HTMLURL
+	if htmlURL == "" && user.Login != "" {
+		htmlURL = g.baseURL + "/" + user.Login
+	}
+	return &gogithub.User{
+		Login:   gogithub.String(user.Login),
+		HTMLURL: gogithub.String(htmlURL),
+	}
+}
+
+func (c *giteaCommit) toCommit() *gogithub.Commit {
+	return &gogithub.Commit{
+		SHA:     gogithub.String(c.SHA),
+		Message: gogithub.String(c.Commit.Message),
+		HTMLURL: gogithub.String(c.HTMLURL),
+		Author: &gogithub.CommitAuthor{
+			Name:  gogithub.String(c.Commit.Author.Name),
+			Email: gogithub.String(c.Commit.Author.Email),
+			Date:  c.Commit.Author.Date,
+		},
+		Committer: &gogithub.CommitAuthor{
+			Name:  gogithub.String(c.Commit.Committer.Name),
+			Email: gogithub.String(c.Commit.Committer.Email),
+			Date:  c.Commit.Committer.Date,
+		},
+	}
+}
+
+func (g *GiteaClient) toPullRequest(pr *giteaPullRequest) *gogithub.PullRequest {
+	result := &gogithub.PullRequest{
+		Number:   gogithub.Int(pr.Number),
+		Title:    gogithub.String(pr.Title),
+		Body:     gogithub.String(pr.Body),
+		State:    gogithub.String(pr.State),
+		HTMLURL:  gogithub.String(pr.HTMLURL),
+		User:     g.toUser(&pr.User),
+		Base:     &gogithub.PullRequestBranch{Ref: gogithub.String(pr.Base.Ref)},
+		Head:     &gogithub.PullRequestBranch{Ref: gogithub.String(pr.Head.Ref), SHA: gogithub.String(pr.Head.SHA)},
+		MergedAt: pr.MergedAt,
+	}
+	if pr.MergeCommitSHA != "" {
+		result.MergeCommitSHA = gogithub.String(pr.MergeCommitSHA)
+	}
+	for _, label := range pr.Labels {
+		result.Labels = append(result.Labels, &gogithub.Label{Name: gogithub.String(label.Name)})
+	}
+	return result
+}
+
+func (g *GiteaClient) repoURL(owner, repo string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo)
+}
+
+// GetCommit returns the commit for the provided SHA.
+func (g *GiteaClient) GetCommit(
+	ctx context.Context, owner, repo, sha string,
+) (*gogithub.Commit, *gogithub.Response, error) {
+	commit := &giteaCommit{}
+	resp, err := g.rest.get(
+		ctx, g.repoURL(owner, repo)+"/git/commits/"+url.PathEscape(sha), nil, commit,
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	return commit.toCommit(), resp, nil
+}
+
+// ListCommits lists the commits of the branch set as SHA in the options.
+// Gitea versions without support for the time range return all commits, so
+// they get filtered by their committer date in addition.
+func (g *GiteaClient) ListCommits(
+	ctx context.Context, owner, repo string, opts *gogithub.CommitsListOptions,
+) ([]*gogithub.RepositoryCommit, *gogithub.Response, error) {
+	if opts == nil {
+		opts = &gogithub.CommitsListOptions{}
+	}
+	query := listQuery(&opts.ListOptions, "limit")
+	if opts.SHA != "" {
+		query.Set("sha", opts.SHA)
+	}
+	if !opts.Since.IsZero() {
+		query.Set("since", opts.Since.Format(time.RFC3339))
+	}
+	if !opts.Until.IsZero() {
+		query.Set("until", opts.Until.Format(time.RFC3339))
+	}
+
+	commits := []*giteaCommit{}
+	resp, err := g.rest.get(ctx, g.repoURL(owner, repo)+"/commits", query, &commits)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	result := []*gogithub.RepositoryCommit{}
+	for _, commit := range commits {
+		if date := commit.Commit.Committer.Date; date != nil {
+			if (!opts.Since.IsZero() && date.Before(opts.Since)) ||
+				(!opts.Until.IsZero() && date.After(opts.Until)) {
+				continue
+			}
+		}
+
+		repoCommit := &gogithub.RepositoryCommit{
+			SHA:     gogithub.String(commit.SHA),
+			HTMLURL: gogithub.String(commit.HTMLURL),
+			Commit:  commit.toCommit(),
+		}
+		if commit.Author != nil {
+			repoCommit.Author = g.toUser(commit.Author)
+		}
+		result = append(result, repoCommit)
+	}
+	return result, resp, nil
+}
+
+// ListPullRequestsWithCommit returns the pull request which introduced the
+// commit, Gitea does not provide all pull requests containing it.
+func (g *GiteaClient) ListPullRequestsWithCommit(
+	ctx context.Context, owner, repo, sha string, _ *gogithub.PullRequestListOptions,
+) ([]*gogithub.PullRequest, *gogithub.Response, error) {
+	pr := &giteaPullRequest{}
+	resp, err := g.rest.get(
+		ctx, fmt.Sprintf("%s/commits/%s/pull", g.repoURL(owner, repo), url.PathEscape(sha)),
+		nil, pr,
+	)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return []*gogithub.PullRequest{}, resp, nil
+	}
+	if err != nil {
+		return nil, resp, err
+	}
+	return []*gogithub.PullRequest{g.toPullRequest(pr)}, resp, nil
+}
+
+// GetPullRequest returns the pull request for the provided number.
+func (g *GiteaClient) GetPullRequest(
+	ctx context.Context, owner, repo string, number int,
+) (*gogithub.PullRequest, *gogithub.Response, error) {
+	pr := &giteaPullRequest{}
+	resp, err := g.rest.get(
+		ctx, fmt.Sprintf("%s/pulls/%d", g.repoURL(owner, repo), number), nil, pr,
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	return g.toPullRequest(pr), resp, nil
+}
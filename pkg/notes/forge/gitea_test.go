@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gogithub "github.com/google/go-github/v39/github"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGiteaListCommits(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"/api/v1/repos/owner/repo/commits": "gitea/commits.json",
+	})
+
+	commits, _, err := NewGitea(server.URL, "", nil).ListCommits(
+		context.Background(), "owner", "repo",
+		&gogithub.CommitsListOptions{
+			SHA:   "main",
+			Since: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+	)
+	require.Nil(t, err)
+	require.Len(t, commits, 1)
+	require.Equal(t, "9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b", commits[0].GetSHA())
+	require.Equal(t, "jane", commits[0].GetAuthor().GetLogin())
+	require.Equal(t, server.URL+"/jane", commits[0].GetAuthor().GetHTMLURL())
+}
+
+func TestGiteaListPullRequestsWithCommit(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"/api/v1/repos/owner/repo/commits/9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b/pull": "gitea/pull.json",
+	})
+	client := NewGitea(server.URL, "", nil)
+
+	prs, _, err := client.ListPullRequestsWithCommit(
+		context.Background(), "owner", "repo", "9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b", nil,
+	)
+	require.Nil(t, err)
+	require.Len(t, prs, 1)
+	require.Equal(t, 3, prs[0].GetNumber())
+	require.Equal(t, "kind/feature", prs[0].Labels[0].GetName())
+	require.Equal(t, "main", prs[0].GetBase().GetRef())
+
+	// Commits without a pull request result in an empty list
+	prs, _, err = client.ListPullRequestsWithCommit(
+		context.Background(), "owner", "repo", "1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b", nil,
+	)
+	require.Nil(t, err)
+	require.Empty(t, prs)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forge
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	gogithub "github.com/google/go-github/v39/github"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+// GitHubClient is the client for the GitHub API which, in contrast to the
+// release-sdk one, uses a custom HTTP client, for example for caching. Like
+// the release-sdk client, it retries requests hitting the rate limit.
+type GitHubClient struct {
+	unsupported
+	client     *gogithub.Client
+	errChecker func() func(error) bool
+}
+
+// NewGitHub creates a new GitHub client using httpClient for the requests.
+// The baseURL is only required for GitHub Enterprise and the token is
+// optional.
+func NewGitHub(baseURL, token string, httpClient *http.Client) (*GitHubClient, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if token != "" {
+		httpClient = oauth2.NewClient(
+			context.WithValue(context.Background(), oauth2.HTTPClient, httpClient),
+			oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
+		)
+	}
+
+	if baseURL == "" {
+		return &GitHubClient{
+			client:     gogithub.NewClient(httpClient),
+			errChecker: defaultGithubErrChecker,
+		}, nil
+	}
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	client, err := gogithub.NewEnterpriseClient(baseURL, baseURL, httpClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating GitHub Enterprise client")
+	}
+	return &GitHubClient{client: client, errChecker: defaultGithubErrChecker}, nil
+}
+
+// GetCommit returns the commit for the provided SHA.
+func (g *GitHubClient) GetCommit(
+	ctx context.Context, owner, repo, sha string,
+) (*gogithub.Commit, *gogithub.Response, error) {
+	for shouldRetry := g.errChecker(); ; {
+		commit, resp, err := g.client.Git.GetCommit(ctx, owner, repo, sha)
+		if !shouldRetry(err) {
+			return commit, resp, err
+		}
+	}
+}
+
+// ListCommits lists the commits of the repository.
+func (g *GitHubClient) ListCommits(
+	ctx context.Context, owner, repo string, opts *gogithub.CommitsListOptions,
+) ([]*gogithub.RepositoryCommit, *gogithub.Response, error) {
+	for shouldRetry := g.errChecker(); ; {
+		commits, resp, err := g.client.Repositories.ListCommits(ctx, owner, repo, opts)
+		if !shouldRetry(err) {
+			return commits, resp, err
+		}
+	}
+}
+
+// ListPullRequestsWithCommit lists the pull requests containing the commit.
+func (g *GitHubClient) ListPullRequestsWithCommit(
+	ctx context.Context, owner, repo, sha string, opts *gogithub.PullRequestListOptions,
+) ([]*gogithub.PullRequest, *gogithub.Response, error) {
+	for shouldRetry := g.errChecker(); ; {
+		prs, resp, err := g.client.PullRequests.ListPullRequestsWithCommit(
+			ctx, owner, repo, sha, opts,
+		)
+		if !shouldRetry(err) {
+			return prs, resp, err
+		}
+	}
+}
+
+// GetPullRequest returns the pull request for the provided number.
+func (g *GitHubClient) GetPullRequest(
+	ctx context.Context, owner, repo string, number int,
+) (*gogithub.PullRequest, *gogithub.Response, error) {
+	for shouldRetry := g.errChecker(); ; {
+		pr, resp, err := g.client.PullRequests.Get(ctx, owner, repo, number)
+		if !shouldRetry(err) {
+			return pr, resp, err
+		}
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forge
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	gogithub "github.com/google/go-github/v39/github"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubRetry(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests == 1 {
+				w.Header().Set("X-RateLimit-Limit", "5000")
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Unix(), 10))
+				w.WriteHeader(http.StatusForbidden)
+				_, err := w.Write([]byte(`{"message":"API rate limit exceeded"}`))
+				require.Nil(t, err)
+				return
+			}
+			_, err := w.Write([]byte(`{"number":1}`))
+			require.Nil(t, err)
+		},
+	))
+	defer server.Close()
+
+	client, err := NewGitHub(server.URL, "", nil)
+	require.Nil(t, err)
+	sleeps := []time.Duration{}
+	client.errChecker = func() func(error) bool {
+		return githubErrChecker(maxGithubRetries, func(d time.Duration) {
+			sleeps = append(sleeps, d)
+		})
+	}
+
+	pr, _, err := client.GetPullRequest(context.Background(), "owner", "repo", 1)
+	require.Nil(t, err)
+	require.Equal(t, 1, pr.GetNumber())
+	require.Equal(t, 2, requests)
+	require.Len(t, sleeps, 1)
+}
+
+func TestGithubErrChecker(t *testing.T) {
+	retryAfter := 5 * time.Second
+	for _, tc := range []struct {
+		err           error
+		expectedRetry []bool
+		expectedSleep time.Duration
+	}{
+		{err: nil, expectedRetry: []bool{false}},
+		{err: errors.New("other"), expectedRetry: []bool{false}},
+		{
+			err:           &gogithub.RateLimitError{},
+			expectedRetry: []bool{true, true, false},
+			expectedSleep: defaultGithubSleep,
+		},
+		{
+			err:           &gogithub.AbuseRateLimitError{RetryAfter: &retryAfter},
+			expectedRetry: []bool{true, true, false},
+			expectedSleep: retryAfter,
+		},
+	} {
+		sleeps := []time.Duration{}
+		shouldRetry := githubErrChecker(2, func(d time.Duration) {
+			sleeps = append(sleeps, d)
+		})
+		for _, expected := range tc.expectedRetry {
+			require.Equal(t, expected, shouldRetry(tc.err))
+		}
+		for _, sleep := range sleeps {
+			require.Equal(t, tc.expectedSleep, sleep)
+		}
+		require.Len(t, sleeps, len(tc.expectedRetry)-1)
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	gogithub "github.com/google/go-github/v39/github"
+)
+
+// GitLabClient is the client for the REST API v4 of a GitLab instance. Merge
+// requests are represented as pull requests, using their project internal ID
+// as number. The owner can contain subgroups, like `group/subgroup`.
+type GitLabClient struct {
+	unsupported
+	rest *restClient
+}
+
+// NewGitLab creates a new GitLab client for the instance at baseURL, for
+// example https://gitlab.com. The token is optional and httpClient defaults to
+// http.DefaultClient.
+func NewGitLab(baseURL, token string, httpClient *http.Client) *GitLabClient {
+	header := http.Header{}
+	if token != "" {
+		header.Set("PRIVATE-TOKEN", token)
+	}
+	return &GitLabClient{
+		rest: newRESTClient(strings.TrimSuffix(baseURL, "/")+"/api/v4", header, httpClient),
+	}
+}
+
+type gitlabCommit struct {
+	ID             string     `json:"id"`
+	Message        string     `json:"message"`
+	AuthorName     string     `json:"author_name"`
+	AuthorEmail    string     `json:"author_email"`
+	AuthoredDate   *time.Time `json:"authored_date"`
+	CommitterName  string     `json:"committer_name"`
+	CommitterEmail string     `json:"committer_email"`
+	CommittedDate  *time.Time `json:"committed_date"`
+	WebURL         string     `json:"web_url"`
+}
+
+type gitlabUser struct {
+	Username string `json:"username"`
+	WebURL   string `json:"web_url"`
+}
+
+type gitlabMergeRequest struct {
+	IID             int        `json:"iid"`
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	State           string     `json:"state"`
+	WebURL          string     `json:"web_url"`
+	Author          gitlabUser `json:"author"`
+	Labels          []string   `json:"labels"`
+	TargetBranch    string     `json:"target_branch"`
+	SourceBranch    string     `json:"source_branch"`
+	SHA             string     `json:"sha"`
+	MergeCommitSHA  string     `json:"merge_commit_sha"`
+	SquashCommitSHA string     `json:"squash_commit_sha"`
+	MergedAt        *time.Time `json:"merged_at"`
+}
+
+func (c *gitlabCommit) toCommit() *gogithub.Commit {
+	return &gogithub.Commit{
+		SHA:     gogithub.String(c.ID),
+		Message: gogithub.String(c.Message),
+		HTMLURL: gogithub.String(c.WebURL),
+		Author: &gogithub.CommitAuthor{
+			Name:  gogithub.String(c.AuthorName),
+			Email: gogithub.String(c.AuthorEmail),
+			Date:  c.AuthoredDate,
+		},
+		Committer: &gogithub.CommitAuthor{
+			Name:  gogithub.String(c.CommitterName),
+			Email: gogithub.String(c.CommitterEmail),
+			Date:  c.CommittedDate,
+		},
+	}
+}
+
+func (mr *gitlabMergeRequest) toPullRequest() *gogithub.PullRequest {
+	pr := &gogithub.PullRequest{
+		Number:  gogithub.Int(mr.IID),
+		Title:   gogithub.String(mr.Title),
+		Body:    gogithub.String(mr.Description),
+		State:   gogithub.String(mr.State),
+		HTMLURL: gogithub.String(mr.WebURL),
+		User: &gogithub.User{
+			Login:   gogithub.String(mr.Author.Username),
+			HTMLURL: gogithub.String(mr.Author.WebURL),
+		},
+		Base:     &gogithub.PullRequestBranch{Ref: gogithub.String(mr.TargetBranch)},
+		Head:     &gogithub.PullRequestBranch{Ref: gogithub.String(mr.SourceBranch), SHA: gogithub.String(mr.SHA)},
+		MergedAt: mr.MergedAt,
+	}
+	if mr.SquashCommitSHA != "" {
+		pr.MergeCommitSHA = gogithub.String(mr.SquashCommitSHA)
+	} else if mr.MergeCommitSHA != "" {
+		pr.MergeCommitSHA = gogithub.String(mr.MergeCommitSHA)
+	}
+	for _, label := range mr.Labels {
+		pr.Labels = append(pr.Labels, &gogithub.Label{Name: gogithub.String(label)})
+	}
+	return pr
+}
+
+func (g *GitLabClient) projectURL(owner, repo string) string {
+	return "/projects/" + url.PathEscape(projectPath(owner, repo))
+}
+
+// GetCommit returns the commit for the provided SHA.
+func (g *GitLabClient) GetCommit(
+	ctx context.Context, owner, repo, sha string,
+) (*gogithub.Commit, *gogithub.Response, error) {
+	commit := &gitlabCommit{}
+	resp, err := g.rest.get(
+		ctx, g.projectURL(owner, repo)+"/repository/commits/"+url.PathEscape(sha),
+		nil, commit,
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	return commit.toCommit(), resp, nil
+}
+
+// ListCommits lists the commits of the branch set as SHA in the options.
+func (g *GitLabClient) ListCommits(
+	ctx context.Context, owner, repo string, opts *gogithub.CommitsListOptions,
+) ([]*gogithub.RepositoryCommit, *gogithub.Response, error) {
+	if opts == nil {
+		opts = &gogithub.CommitsListOptions{}
+	}
+	query := listQuery(&opts.ListOptions, "per_page")
+	if opts.SHA != "" {
+		query.Set("ref_name", opts.SHA)
+	}
+	if !opts.Since.IsZero() {
+		query.Set("since", opts.Since.Format(time.RFC3339))
+	}
+	if !opts.Until.IsZero() {
+		query.Set("until", opts.Until.Format(time.RFC3339))
+	}
+
+	commits := []*gitlabCommit{}
+	resp, err := g.rest.get(
+		ctx, g.projectURL(owner, repo)+"/repository/commits", query, &commits,
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	result := []*gogithub.RepositoryCommit{}
+	for _, commit := range commits {
+		result = append(result, &gogithub.RepositoryCommit{
+			SHA:     gogithub.String(commit.ID),
+			HTMLURL: gogithub.String(commit.WebURL),
+			Commit:  commit.toCommit(),
+		})
+	}
+	return result, resp, nil
+}
+
+// ListPullRequestsWithCommit lists the merge requests containing the commit.
+func (g *GitLabClient) ListPullRequestsWithCommit(
+	ctx context.Context, owner, repo, sha string, opts *gogithub.PullRequestListOptions,
+) ([]*gogithub.PullRequest, *gogithub.Response, error) {
+	var listOpts *gogithub.ListOptions
+	if opts != nil {
+		listOpts = &opts.ListOptions
+	}
+
+	mrs := []*gitlabMergeRequest{}
+	resp, err := g.rest.get(
+		ctx,
+		fmt.Sprintf("%s/repository/commits/%s/merge_requests", g.projectURL(owner, repo), url.PathEscape(sha)),
+		listQuery(listOpts, "per_page"), &mrs,
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	result := []*gogithub.PullRequest{}
+	for _, mr := range mrs {
+		result = append(result, mr.toPullRequest())
+	}
+	return result, resp, nil
+}
+
+// GetPullRequest returns the merge request for the provided internal ID.
+func (g *GitLabClient) GetPullRequest(
+	ctx context.Context, owner, repo string, number int,
+) (*gogithub.PullRequest, *gogithub.Response, error) {
+	mr := &gitlabMergeRequest{}
+	resp, err := g.rest.get(
+		ctx, fmt.Sprintf("%s/merge_requests/%d", g.projectURL(owner, repo), number),
+		nil, mr,
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	return mr.toPullRequest(), resp, nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forge
+
+import (
+	"context"
+	"testing"
+
+	gogithub "github.com/google/go-github/v39/github"
+	"github.com/stretchr/testify/require"
+)
+
+const gitlabProject = "/api/v4/projects/group%2Fsubgroup%2Fproject"
+
+func TestGitLabListCommits(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		gitlabProject + "/repository/commits": "gitlab/commits.json",
+	})
+
+	commits, resp, err := NewGitLab(server.URL, "", nil).ListCommits(
+		context.Background(), "group/subgroup", "project",
+		&gogithub.CommitsListOptions{SHA: "main"},
+	)
+	require.Nil(t, err)
+	require.NotNil(t, resp)
+	require.Len(t, commits, 1)
+	require.Equal(t, "5b2c1f6a0d8e4c3b9a7f1e2d3c4b5a6978695a4b", commits[0].GetSHA())
+	require.Equal(t, "Jane Doe", commits[0].GetCommit().GetAuthor().GetName())
+	require.Contains(t, commits[0].GetCommit().GetMessage(), "See merge request group/subgroup/project!7")
+	require.Nil(t, commits[0].Author)
+}
+
+func TestGitLabGetPullRequest(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		gitlabProject + "/merge_requests/7": "gitlab/merge_request.json",
+	})
+
+	pr, _, err := NewGitLab(server.URL, "", nil).GetPullRequest(
+		context.Background(), "group/subgroup", "project", 7,
+	)
+	require.Nil(t, err)
+	require.Equal(t, 7, pr.GetNumber())
+	require.Equal(t, "jane", pr.GetUser().GetLogin())
+	require.Equal(t, "https://gitlab.example.com/jane", pr.GetUser().GetHTMLURL())
+	require.Equal(t, "5b2c1f6a0d8e4c3b9a7f1e2d3c4b5a6978695a4b", pr.GetMergeCommitSHA())
+	require.Contains(t, pr.GetBody(), "Added the `--verbose` flag.")
+	require.Len(t, pr.Labels, 2)
+	require.Equal(t, "kind/feature", pr.Labels[0].GetName())
+}
+
+func TestGitLabListPullRequestsWithCommit(t *testing.T) {
+	server := newTestServer(t, map[string]string{})
+
+	_, resp, err := NewGitLab(server.URL, "", nil).ListPullRequestsWithCommit(
+		context.Background(), "group/subgroup", "project", "abc", nil,
+	)
+	require.NotNil(t, err)
+	require.NotNil(t, resp)
+	require.Equal(t, 404, resp.StatusCode)
+}
@@ -0,0 +1,423 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gogithub "github.com/google/go-github/v39/github"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultGraphQLURL is the endpoint of the public GitHub GraphQL API.
+	DefaultGraphQLURL = "https://api.github.com/graphql"
+
+	// graphQLBatchSize is the maximum number of pull requests per query.
+	graphQLBatchSize = 100
+
+	// maxParallelGraphQLRequests is the maximum number of parallel queries,
+	// which gets reduced if GitHub asks us to slow down.
+	maxParallelGraphQLRequests = 4
+
+	// maxGraphQLAttempts is the number of attempts for a single query.
+	maxGraphQLAttempts = 5
+
+	// secondaryRateLimitWait is the time to wait after hitting the secondary
+	// rate limit if GitHub does not provide a `Retry-After` header.
+	secondaryRateLimitWait = time.Minute
+)
+
+// BatchProvider fetches many pull requests at once.
+//
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . BatchProvider
+type BatchProvider interface {
+	// GetPullRequests returns the pull requests for the provided numbers.
+	// Pull requests which do not exist are missing in the result.
+	GetPullRequests(
+		ctx context.Context, owner, repo string, numbers []int,
+	) (map[int]*gogithub.PullRequest, error)
+}
+
+// GraphQLClient fetches pull requests in batches of 100 from the GitHub
+// GraphQL API. The queries run in parallel, with a concurrency adapting to
+// the rate limits of GitHub.
+type GraphQLClient struct {
+	url        string
+	token      string
+	httpClient *http.Client
+	limiter    *adaptiveLimiter
+
+	// sleep waits for the provided duration, can be replaced for testing
+	sleep func(context.Context, time.Duration) error
+}
+
+// NewGraphQL creates a new GitHub GraphQL client. The baseURL of the REST API
+// is only required for GitHub Enterprise and httpClient defaults to
+// http.DefaultClient.
+func NewGraphQL(baseURL, token string, httpClient *http.Client) *GraphQLClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &GraphQLClient{
+		url:        graphQLURL(baseURL),
+		token:      token,
+		httpClient: httpClient,
+		limiter:    newAdaptiveLimiter(maxParallelGraphQLRequests),
+		sleep:      sleepContext,
+	}
+}
+
+// graphQLURL returns the GraphQL endpoint for the REST API baseURL, which is
+// `https://HOSTNAME/api/v3/` for GitHub Enterprise.
+func graphQLURL(baseURL string) string {
+	if baseURL == "" {
+		return DefaultGraphQLURL
+	}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	baseURL = strings.TrimSuffix(baseURL, "/v3")
+	baseURL = strings.TrimSuffix(baseURL, "/api")
+	return baseURL + "/api/graphql"
+}
+
+const graphQLPullRequestFragment = `
+fragment pr on PullRequest {
+  number
+  body
+  url
+  author { login url }
+  labels(first: 100) { nodes { name } }
+}`
+
+type graphQLPullRequest struct {
+	Number int    `json:"number"`
+	Body   string `json:"body"`
+	URL    string `json:"url"`
+	Author *struct {
+		Login string `json:"login"`
+		URL   string `json:"url"`
+	} `json:"author"`
+	Labels struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+}
+
+type graphQLError struct {
+	Type    string        `json:"type"`
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path"`
+}
+
+type graphQLResponse struct {
+	Data *struct {
+		Repository map[string]*graphQLPullRequest `json:"repository"`
+	} `json:"data"`
+	Errors []graphQLError `json:"errors"`
+}
+
+func (pr *graphQLPullRequest) toPullRequest() *gogithub.PullRequest {
+	result := &gogithub.PullRequest{
+		Number:  gogithub.Int(pr.Number),
+		Body:    gogithub.String(pr.Body),
+		HTMLURL: gogithub.String(pr.URL),
+		User:    &gogithub.User{},
+	}
+	// The author is missing for deleted accounts
+	if pr.Author != nil {
+		result.User.Login = gogithub.String(pr.Author.Login)
+		result.User.HTMLURL = gogithub.String(pr.Author.URL)
+	}
+	for _, label := range pr.Labels.Nodes {
+		result.Labels = append(result.Labels, &gogithub.Label{Name: gogithub.String(label.Name)})
+	}
+	return result
+}
+
+// GetPullRequests returns the pull requests for the provided numbers.
+func (c *GraphQLClient) GetPullRequests(
+	ctx context.Context, owner, repo string, numbers []int,
+) (map[int]*gogithub.PullRequest, error) {
+	unique := map[int]bool{}
+	for _, number := range numbers {
+		unique[number] = true
+	}
+	sorted := []int{}
+	for number := range unique {
+		sorted = append(sorted, number)
+	}
+	sort.Ints(sorted)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	result := map[int]*gogithub.PullRequest{}
+	for i := 0; i < len(sorted); i += graphQLBatchSize {
+		end := i + graphQLBatchSize
+		if end > len(sorted) {
+			end = len(sorted)
+		}
+		batch := sorted[i:end]
+
+		if err := c.limiter.acquire(ctx); err != nil {
+			return nil, err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer c.limiter.release()
+
+			prs, err := c.queryBatch(ctx, owner, repo, batch)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			for _, pr := range prs {
+				result[pr.GetNumber()] = pr
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return result, nil
+}
+
+// queryBatch fetches a single batch of pull requests and retries the query if
+// GitHub asks us to wait.
+func (c *GraphQLClient) queryBatch(
+	ctx context.Context, owner, repo string, numbers []int,
+) ([]*gogithub.PullRequest, error) {
+	fields := &strings.Builder{}
+	for _, number := range numbers {
+		fmt.Fprintf(fields, "    pr%d: pullRequest(number: %d) { ...pr }\n", number, number)
+	}
+	query := fmt.Sprintf(
+		"query($owner: String!, $name: String!) {\n  repository(owner: $owner, name: $name) {\n%s  }\n}\n%s",
+		fields, graphQLPullRequestFragment,
+	)
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": map[string]string{"owner": owner, "name": repo},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal GraphQL query")
+	}
+
+	for attempt := 1; ; attempt++ {
+		logrus.Debugf(
+			"Querying %d pull requests starting at #%d (attempt %d)",
+			len(numbers), numbers[0], attempt,
+		)
+		res, wait, err := c.query(ctx, body)
+		if err == nil {
+			c.limiter.increase()
+			return res, nil
+		}
+		if wait == 0 || attempt >= maxGraphQLAttempts {
+			return nil, err
+		}
+
+		c.limiter.decrease()
+		logrus.Warnf("%v, retrying in %v", err, wait)
+		if err := c.sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// query executes the GraphQL query. It returns the time to wait before
+// retrying for errors which can be retried.
+func (c *GraphQLClient) query(
+	ctx context.Context, body []byte,
+) (prs []*gogithub.PullRequest, wait time.Duration, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "creating GraphQL request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "bearer "+c.token)
+	}
+
+	httpResp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "executing GraphQL query")
+	}
+	defer httpResp.Body.Close()
+
+	content, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "reading GraphQL response")
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		err := errors.Errorf(
+			"GraphQL query failed: %s: %s",
+			httpResp.Status, strings.TrimSpace(string(content)),
+		)
+		switch {
+		case httpResp.StatusCode == http.StatusTooManyRequests,
+			httpResp.StatusCode == http.StatusForbidden && isRateLimited(httpResp.Header, content):
+			return nil, rateLimitWait(httpResp.Header, secondaryRateLimitWait), err
+		case httpResp.StatusCode >= http.StatusInternalServerError:
+			// GitHub responds with 502 if a query takes too long
+			return nil, rateLimitWait(httpResp.Header, 5*time.Second), err
+		default:
+			return nil, 0, err
+		}
+	}
+
+	resp := &graphQLResponse{}
+	if err := json.Unmarshal(content, resp); err != nil {
+		return nil, 0, errors.Wrap(err, "unmarshal GraphQL response")
+	}
+
+	for _, e := range resp.Errors {
+		switch e.Type {
+		case "NOT_FOUND":
+			logrus.Debugf("Skipping missing pull request: %s", e.Message)
+		case "RATE_LIMITED":
+			return nil, rateLimitWait(httpResp.Header, secondaryRateLimitWait),
+				errors.Errorf("GraphQL rate limit exceeded: %s", e.Message)
+		default:
+			return nil, 0, errors.Errorf("GraphQL query failed: %s", e.Message)
+		}
+	}
+	if resp.Data == nil {
+		return nil, 0, errors.New("GraphQL response contains no data")
+	}
+
+	for _, pr := range resp.Data.Repository {
+		if pr != nil {
+			prs = append(prs, pr.toPullRequest())
+		}
+	}
+	return prs, 0, nil
+}
+
+// isRateLimited returns true if a forbidden response has been caused by the
+// primary or secondary rate limit and not by missing permissions.
+func isRateLimited(header http.Header, body []byte) bool {
+	return header.Get("Retry-After") != "" ||
+		header.Get("X-RateLimit-Remaining") == "0" ||
+		strings.Contains(strings.ToLower(string(body)), "rate limit")
+}
+
+// rateLimitWait returns the time to wait based on the `Retry-After` header or
+// the reset time of an exhausted primary rate limit, or the fallback if both
+// are not set.
+func rateLimitWait(header http.Header, fallback time.Duration) time.Duration {
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return time.Duration(seconds)*time.Second + time.Second
+	}
+	if header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			if wait := time.Until(time.Unix(reset, 0)); wait > 0 {
+				return wait + time.Second
+			}
+			return time.Second
+		}
+	}
+	return fallback
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// adaptiveLimiter limits the number of parallel requests. The limit gets
+// halved if GitHub asks us to slow down and increased again on success.
+type adaptiveLimiter struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	limit  int
+	max    int
+	active int
+}
+
+func newAdaptiveLimiter(max int) *adaptiveLimiter {
+	l := &adaptiveLimiter{limit: max, max: max}
+	l.cond = sync.NewCond(&l.mu)
+	return l
+}
+
+func (l *adaptiveLimiter) acquire(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for l.active >= l.limit {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		l.cond.Wait()
+	}
+	l.active++
+	return nil
+}
+
+func (l *adaptiveLimiter) release() {
+	l.mu.Lock()
+	l.active--
+	l.mu.Unlock()
+	l.cond.Broadcast()
+}
+
+func (l *adaptiveLimiter) increase() {
+	l.mu.Lock()
+	if l.limit < l.max {
+		l.limit++
+	}
+	l.mu.Unlock()
+	l.cond.Broadcast()
+}
+
+func (l *adaptiveLimiter) decrease() {
+	l.mu.Lock()
+	if l.limit > 1 {
+		l.limit /= 2
+		logrus.Infof("Reducing parallel GraphQL queries to %d", l.limit)
+	}
+	l.mu.Unlock()
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var prAliasRE = regexp.MustCompile(`pr(\d+): pullRequest`)
+
+// newGraphQLServer returns a server which answers pull request queries. The
+// handler can intercept a request by returning true.
+func newGraphQLServer(
+	t *testing.T, intercept func(w http.ResponseWriter, attempt int) bool,
+) (server *httptest.Server, queries func() [][]int) {
+	var (
+		mu      sync.Mutex
+		numbers [][]int
+	)
+	server = httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/api/graphql", r.URL.Path)
+			require.Equal(t, "bearer token", r.Header.Get("Authorization"))
+
+			req := struct {
+				Query     string            `json:"query"`
+				Variables map[string]string `json:"variables"`
+			}{}
+			require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, map[string]string{"owner": "org", "name": "repo"}, req.Variables)
+
+			mu.Lock()
+			attempt := len(numbers)
+			query := []int{}
+			for _, match := range prAliasRE.FindAllStringSubmatch(req.Query, -1) {
+				number, err := strconv.Atoi(match[1])
+				require.Nil(t, err)
+				query = append(query, number)
+			}
+			numbers = append(numbers, query)
+			mu.Unlock()
+
+			if intercept != nil && intercept(w, attempt) {
+				return
+			}
+
+			prs := []string{}
+			errs := []string{}
+			for _, number := range query {
+				// Odd numbers above 1000 do not exist
+				if number > 1000 && number%2 == 1 {
+					prs = append(prs, fmt.Sprintf(`"pr%d": null`, number))
+					errs = append(errs, fmt.Sprintf(
+						`{"type": "NOT_FOUND", "path": ["repository", "pr%d"], "message": "not found"}`, number,
+					))
+					continue
+				}
+				prs = append(prs, fmt.Sprintf(`"pr%d": {
+					"number": %d, "body": "body %d", "url": "https://github.com/org/repo/pull/%d",
+					"author": {"login": "jane", "url": "https://github.com/jane"},
+					"labels": {"nodes": [{"name": "kind/feature"}]}
+				}`, number, number, number, number))
+			}
+			fmt.Fprintf(w, `{"data": {"repository": {%s}}, "errors": [%s]}`,
+				strings.Join(prs, ","), strings.Join(errs, ","))
+		},
+	))
+	t.Cleanup(server.Close)
+
+	return server, func() [][]int {
+		mu.Lock()
+		defer mu.Unlock()
+		return numbers
+	}
+}
+
+func newTestGraphQL(serverURL string) (client *GraphQLClient, waits *[]time.Duration) {
+	client = NewGraphQL(serverURL+"/api/v3/", "token", nil)
+	waits = &[]time.Duration{}
+	var mu sync.Mutex
+	client.sleep = func(_ context.Context, d time.Duration) error {
+		mu.Lock()
+		defer mu.Unlock()
+		*waits = append(*waits, d)
+		return nil
+	}
+	return client, waits
+}
+
+func TestGraphQLURL(t *testing.T) {
+	for baseURL, expected := range map[string]string{
+		"":                                   DefaultGraphQLURL,
+		"https://github.example.com/api/v3/": "https://github.example.com/api/graphql",
+		"https://github.example.com/api/v3":  "https://github.example.com/api/graphql",
+		"https://github.example.com/":        "https://github.example.com/api/graphql",
+	} {
+		require.Equal(t, expected, graphQLURL(baseURL), baseURL)
+	}
+}
+
+func TestGraphQLGetPullRequests(t *testing.T) {
+	server, queries := newGraphQLServer(t, nil)
+	client, _ := newTestGraphQL(server.URL)
+
+	numbers := []int{1001, 1002}
+	for i := 1; i <= 250; i++ {
+		numbers = append(numbers, i)
+	}
+	// Duplicates are queried only once
+	numbers = append(numbers, 1, 2)
+
+	prs, err := client.GetPullRequests(context.Background(), "org", "repo", numbers)
+	require.Nil(t, err)
+	require.Len(t, prs, 251)
+	require.Nil(t, prs[1001])
+
+	pr := prs[1002]
+	require.Equal(t, 1002, pr.GetNumber())
+	require.Equal(t, "body 1002", pr.GetBody())
+	require.Equal(t, "https://github.com/org/repo/pull/1002", pr.GetHTMLURL())
+	require.Equal(t, "jane", pr.GetUser().GetLogin())
+	require.Equal(t, "https://github.com/jane", pr.GetUser().GetHTMLURL())
+	require.Equal(t, "kind/feature", pr.Labels[0].GetName())
+
+	sizes := []int{}
+	for _, query := range queries() {
+		sizes = append(sizes, len(query))
+	}
+	require.ElementsMatch(t, []int{100, 100, 52}, sizes)
+}
+
+func TestGraphQLRetry(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		status        int
+		header        map[string]string
+		body          string
+		failures      int
+		expectedWait  time.Duration
+		expectedCalls int
+		shouldErr     bool
+	}{
+		{
+			name:          "secondary rate limit with Retry-After",
+			status:        http.StatusForbidden,
+			header:        map[string]string{"Retry-After": "30"},
+			failures:      2,
+			expectedWait:  31 * time.Second,
+			expectedCalls: 3,
+		},
+		{
+			name:          "secondary rate limit without Retry-After",
+			status:        http.StatusForbidden,
+			body:          `{"message": "You have exceeded a secondary rate limit."}`,
+			failures:      1,
+			expectedWait:  secondaryRateLimitWait,
+			expectedCalls: 2,
+		},
+		{
+			name:          "too many requests",
+			status:        http.StatusTooManyRequests,
+			failures:      1,
+			expectedWait:  secondaryRateLimitWait,
+			expectedCalls: 2,
+		},
+		{
+			name:   "primary rate limit",
+			status: http.StatusOK,
+			header: map[string]string{
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     "1",
+			},
+			body:          `{"errors": [{"type": "RATE_LIMITED", "message": "API rate limit exceeded"}]}`,
+			failures:      1,
+			expectedWait:  time.Second,
+			expectedCalls: 2,
+		},
+		{
+			name:          "server error",
+			status:        http.StatusBadGateway,
+			failures:      maxGraphQLAttempts,
+			expectedWait:  5 * time.Second,
+			expectedCalls: maxGraphQLAttempts,
+			shouldErr:     true,
+		},
+		{
+			name:          "missing permissions",
+			status:        http.StatusForbidden,
+			body:          `{"message": "Resource not accessible by integration"}`,
+			failures:      1,
+			expectedCalls: 1,
+			shouldErr:     true,
+		},
+		{
+			name:          "bad credentials",
+			status:        http.StatusUnauthorized,
+			failures:      1,
+			expectedCalls: 1,
+			shouldErr:     true,
+		},
+		{
+			name:          "invalid query",
+			status:        http.StatusOK,
+			body:          `{"errors": [{"message": "Parse error"}]}`,
+			failures:      1,
+			expectedCalls: 1,
+			shouldErr:     true,
+		},
+	} {
+		server, queries := newGraphQLServer(t, func(w http.ResponseWriter, attempt int) bool {
+			if attempt >= tc.failures {
+				return false
+			}
+			for key, value := range tc.header {
+				w.Header().Set(key, value)
+			}
+			w.WriteHeader(tc.status)
+			fmt.Fprint(w, tc.body)
+			return true
+		})
+		client, waits := newTestGraphQL(server.URL)
+
+		prs, err := client.GetPullRequests(context.Background(), "org", "repo", []int{1, 2})
+		require.Len(t, queries(), tc.expectedCalls, tc.name)
+		if tc.shouldErr {
+			require.NotNil(t, err, tc.name)
+			continue
+		}
+		require.Nil(t, err, tc.name)
+		require.Len(t, prs, 2, tc.name)
+		require.Len(t, *waits, tc.failures, tc.name)
+		for _, wait := range *waits {
+			require.Equal(t, tc.expectedWait, wait, tc.name)
+		}
+	}
+}
+
+func TestAdaptiveLimiter(t *testing.T) {
+	limiter := newAdaptiveLimiter(4)
+	limiter.decrease()
+	require.Equal(t, 2, limiter.limit)
+	limiter.decrease()
+	limiter.decrease()
+	require.Equal(t, 1, limiter.limit)
+
+	require.Nil(t, limiter.acquire(context.Background()))
+	acquired := make(chan struct{})
+	go func() {
+		require.Nil(t, limiter.acquire(context.Background()))
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("acquired more than the limit")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	limiter.increase()
+	<-acquired
+	require.Equal(t, 2, limiter.active)
+
+	for i := 0; i < 10; i++ {
+		limiter.increase()
+	}
+	require.Equal(t, 4, limiter.limit)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	gogithub "github.com/google/go-github/v39/github"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// restClient is a minimal JSON REST client for the forge APIs.
+type restClient struct {
+	apiURL     string
+	header     http.Header
+	httpClient *http.Client
+}
+
+func newRESTClient(apiURL string, header http.Header, httpClient *http.Client) *restClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &restClient{
+		apiURL:     strings.TrimSuffix(apiURL, "/"),
+		header:     header,
+		httpClient: httpClient,
+	}
+}
+
+// get requests the provided path and unmarshals the response into result.
+// The returned response is always set if the server answered, also for
+// errors, and contains the pagination of the result.
+func (c *restClient) get(
+	ctx context.Context, path string, query url.Values, result interface{},
+) (*gogithub.Response, error) {
+	u := c.apiURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	logrus.Debugf("Requesting %s", u)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+	for key, values := range c.header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Accept", "application/json")
+
+	httpResp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "requesting %s", path)
+	}
+	defer httpResp.Body.Close()
+
+	resp := newResponse(httpResp)
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return resp, errors.Wrapf(err, "reading response of %s", path)
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode > 299 {
+		return resp, errors.Errorf(
+			"requesting %s: %s: %s", path, httpResp.Status, strings.TrimSpace(string(body)),
+		)
+	}
+
+	if err := json.Unmarshal(body, result); err != nil {
+		return resp, errors.Wrapf(err, "unmarshal response of %s", path)
+	}
+	return resp, nil
+}
+
+var linkRE = regexp.MustCompile(`<([^>]+)>;\s*rel="([^"]+)"`)
+
+// newResponse wraps the HTTP response and sets the pagination from the
+// `Link` header, or the `X-Next-Page` and `X-Total-Pages` headers of GitLab.
+func newResponse(httpResp *http.Response) *gogithub.Response {
+	resp := &gogithub.Response{Response: httpResp}
+
+	for _, match := range linkRE.FindAllStringSubmatch(httpResp.Header.Get("Link"), -1) {
+		link, err := url.Parse(match[1])
+		if err != nil {
+			continue
+		}
+		page, err := strconv.Atoi(link.Query().Get("page"))
+		if err != nil {
+			continue
+		}
+		switch match[2] {
+		case "next":
+			resp.NextPage = page
+		case "prev":
+			resp.PrevPage = page
+		case "first":
+			resp.FirstPage = page
+		case "last":
+			resp.LastPage = page
+		}
+	}
+
+	if page, err := strconv.Atoi(httpResp.Header.Get("X-Next-Page")); err == nil && resp.NextPage == 0 {
+		resp.NextPage = page
+	}
+	if page, err := strconv.Atoi(httpResp.Header.Get("X-Total-Pages")); err == nil && resp.LastPage == 0 {
+		resp.LastPage = page
+	}
+	return resp
+}
+
+// listQuery returns the pagination query parameters of a list request, using
+// the provided name for the page size.
+func listQuery(opts *gogithub.ListOptions, perPageKey string) url.Values {
+	query := url.Values{}
+	if opts == nil {
+		return query
+	}
+	if opts.Page > 0 {
+		query.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.PerPage > 0 {
+		query.Set(perPageKey, strconv.Itoa(opts.PerPage))
+	}
+	return query
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forge
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+	"time"
+
+	gogithub "github.com/google/go-github/v39/github"
+	"github.com/sirupsen/logrus"
+)
+
+// The retry logic is a copy of the GitHub error checker of the release-sdk,
+// which is internal to that module and can therefore not be reused by the
+// GitHubClient.
+
+const (
+	// maxGithubRetries is the maximum amount of times we flag a GitHub error
+	// as retryable before we give up and do not flag the same call as
+	// retryable anymore.
+	maxGithubRetries = 3
+
+	// defaultGithubSleep is the amount of time we wait between two
+	// consecutive GitHub calls in case we cannot extract that information
+	// from the error itself.
+	defaultGithubSleep = time.Minute
+)
+
+// defaultGithubErrChecker is a githubErrChecker set up with a default amount
+// of retries and the default sleep function.
+func defaultGithubErrChecker() func(error) bool {
+	return githubErrChecker(maxGithubRetries, time.Sleep)
+}
+
+// githubErrChecker returns a function that checks errors from GitHub and
+// decides if they can / should be retried. It retries `maxTries` times at
+// most and uses `sleeper` to wait for the amount of time the rate limit or
+// abuse rate limit error told us to, or a default duration.
+//
+// It can be used like this:
+//
+//	for shouldRetry := githubErrChecker(10, time.Sleep); ; {
+//	  commit, res, err := client.GetCommit(...)
+//	  if !shouldRetry(err) {
+//	    return commit, res, err
+//	  }
+//	}
+func githubErrChecker(maxTries int, sleeper func(time.Duration)) func(error) bool {
+	try := 0
+
+	return func(err error) bool {
+		if err == nil {
+			return false
+		}
+		if try >= maxTries {
+			logrus.Errorf("Max retries (%d) reached, not retrying anymore: %v", maxTries, err)
+			return false
+		}
+
+		try++
+
+		if err, ok := err.(*gogithub.RateLimitError); ok {
+			waitDuration := defaultGithubSleep
+			until := time.Until(err.Rate.Reset.Time)
+			if until > 0 {
+				waitDuration = until
+			}
+			logrus.
+				WithField("err", err).
+				Infof("Hit the rate limit on try %d, sleeping for %s", try, waitDuration)
+			sleeper(waitDuration)
+			return true
+		}
+
+		if aerr, ok := err.(*gogithub.AbuseRateLimitError); ok {
+			waitDuration := defaultGithubSleep
+			if d := aerr.RetryAfter; d != nil {
+				waitDuration = *d
+			}
+			logrus.
+				WithField("err", aerr).
+				Infof("Hit the abuse rate limit on try %d, sleeping for %s", try, waitDuration)
+			sleeper(waitDuration)
+			return true
+		}
+
+		if strings.Contains(err.Error(), "secondary rate limit. Please wait") {
+			rtime, err := rand.Int(rand.Reader, big.NewInt(30))
+			if err != nil {
+				logrus.Error(err)
+				return false
+			}
+			waitDuration := time.Duration(rtime.Int64()*int64(time.Second)) + defaultGithubSleep
+			logrus.
+				WithField("err", err).
+				Infof("Hit the GitHub secondary rate limit on try %d, sleeping for %s", try, waitDuration)
+			sleeper(waitDuration)
+			return true
+		}
+
+		return false
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forge
+
+import (
+	"context"
+	"io"
+	"os"
+
+	gogithub "github.com/google/go-github/v39/github"
+	"github.com/pkg/errors"
+)
+
+// ErrUnsupported is returned by all operations of the release-sdk GitHub
+// client which are not part of the Provider.
+var ErrUnsupported = errors.New("operation is not supported by this forge")
+
+// unsupported implements the operations of the release-sdk GitHub client
+// which are not required for gathering release notes.
+type unsupported struct{}
+
+func (unsupported) GetIssue(
+	context.Context, string, string, int,
+) (*gogithub.Issue, *gogithub.Response, error) {
+	return nil, nil, ErrUnsupported
+}
+
+func (unsupported) GetRepoCommit(
+	context.Context, string, string, string,
+) (*gogithub.RepositoryCommit, *gogithub.Response, error) {
+	return nil, nil, ErrUnsupported
+}
+
+func (unsupported) ListMilestones(
+	context.Context, string, string, *gogithub.MilestoneListOptions,
+) ([]*gogithub.Milestone, *gogithub.Response, error) {
+	return nil, nil, ErrUnsupported
+}
+
+func (unsupported) ListReleases(
+	context.Context, string, string, *gogithub.ListOptions,
+) ([]*gogithub.RepositoryRelease, *gogithub.Response, error) {
+	return nil, nil, ErrUnsupported
+}
+
+func (unsupported) GetReleaseByTag(
+	context.Context, string, string, string,
+) (*gogithub.RepositoryRelease, *gogithub.Response, error) {
+	return nil, nil, ErrUnsupported
+}
+
+func (unsupported) DownloadReleaseAsset(
+	context.Context, string, string, int64,
+) (io.ReadCloser, string, error) {
+	return nil, "", ErrUnsupported
+}
+
+func (unsupported) ListTags(
+	context.Context, string, string, *gogithub.ListOptions,
+) ([]*gogithub.RepositoryTag, *gogithub.Response, error) {
+	return nil, nil, ErrUnsupported
+}
+
+func (unsupported) ListBranches(
+	context.Context, string, string, *gogithub.BranchListOptions,
+) ([]*gogithub.Branch, *gogithub.Response, error) {
+	return nil, nil, ErrUnsupported
+}
+
+func (unsupported) CreatePullRequest(
+	context.Context, string, string, string, string, string, string,
+) (*gogithub.PullRequest, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupported) CreateIssue(
+	context.Context, string, string, *gogithub.IssueRequest,
+) (*gogithub.Issue, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupported) GetRepository(
+	context.Context, string, string,
+) (*gogithub.Repository, *gogithub.Response, error) {
+	return nil, nil, ErrUnsupported
+}
+
+func (unsupported) UpdateReleasePage(
+	context.Context, string, string, int64, *gogithub.RepositoryRelease,
+) (*gogithub.RepositoryRelease, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupported) UploadReleaseAsset(
+	context.Context, string, string, int64, *gogithub.UploadOptions, *os.File,
+) (*gogithub.ReleaseAsset, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupported) DeleteReleaseAsset(
+	context.Context, string, string, int64,
+) error {
+	return ErrUnsupported
+}
+
+func (unsupported) ListReleaseAssets(
+	context.Context, string, string, int64, *gogithub.ListOptions,
+) ([]*gogithub.ReleaseAsset, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupported) CreateComment(
+	context.Context, string, string, int, string,
+) (*gogithub.IssueComment, *gogithub.Response, error) {
+	return nil, nil, ErrUnsupported
+}
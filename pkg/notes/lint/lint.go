@@ -0,0 +1,182 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/release/pkg/notes"
+)
+
+// Severity is the severity of a linter problem.
+type Severity string
+
+const (
+	// SeverityError marks problems which have to be fixed before publishing
+	// the note.
+	SeverityError Severity = "error"
+
+	// SeverityWarning marks problems which should be reviewed.
+	SeverityWarning Severity = "warning"
+)
+
+// Input is a single release note to be linted.
+type Input struct {
+	// Note is the release note to be checked
+	Note *notes.ReleaseNote
+
+	// Source describes the origin of the note, like the path of a notes map
+	// or the URL of a pull request
+	Source string
+
+	// PRTitle is the title of the pull request, if known
+	PRTitle string
+
+	// Partial indicates that the note contains only a subset of its fields,
+	// for example if it has been created from a notes map. Unset fields are
+	// not checked in that case.
+	Partial bool
+}
+
+// Problem is a single finding of a Rule.
+type Problem struct {
+	// Rule is the name of the rule which reported the problem
+	Rule string `json:"rule"`
+
+	// PR is the number of the pull request of the note
+	PR int `json:"pr"`
+
+	// Source is the origin of the note
+	Source string `json:"source,omitempty"`
+
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+
+	// Suggestion describes how the problem can be fixed
+	Suggestion string `json:"suggestion,omitempty"`
+
+	// Fix is the corrected note text, if the problem can be fixed
+	// automatically
+	Fix string `json:"fix,omitempty"`
+}
+
+// String returns the terminal representation of the problem.
+func (p *Problem) String() string {
+	var sb strings.Builder
+	source := ""
+	if p.Source != "" {
+		source = p.Source + ": "
+	}
+	fmt.Fprintf(&sb, "%s#%d %s [%s] %s\n", source, p.PR, p.Severity, p.Rule, p.Message)
+	if p.Suggestion != "" {
+		fmt.Fprintf(&sb, "    suggestion: %s\n", p.Suggestion)
+	}
+	if p.Fix != "" {
+		fmt.Fprintf(&sb, "    fixed text: %s\n", strings.ReplaceAll(p.Fix, "\n", "\n                "))
+	}
+	return sb.String()
+}
+
+// Rule checks a single aspect of release notes.
+type Rule interface {
+	// Name returns the unique name of the rule, like `missing-labels`.
+	Name() string
+
+	// Check returns the problems of the input, or nil if there are none.
+	// The rule name and the origin of the note are set by the Linter.
+	Check(input *Input) []*Problem
+}
+
+// Linter checks release notes using a set of rules.
+type Linter struct {
+	rules []Rule
+}
+
+// New creates a new Linter using the provided rules, or the DefaultRules if
+// no rule is provided.
+func New(rules ...Rule) *Linter {
+	if len(rules) == 0 {
+		rules = DefaultRules()
+	}
+	return &Linter{rules: rules}
+}
+
+// Rules returns the rules of the linter.
+func (l *Linter) Rules() []Rule {
+	return l.rules
+}
+
+// Disable removes the rules with the provided names from the linter. It
+// returns an error if a rule is unknown.
+func (l *Linter) Disable(names ...string) error {
+	for _, name := range names {
+		found := false
+		for i, rule := range l.rules {
+			if rule.Name() == name {
+				l.rules = append(l.rules[:i], l.rules[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.Errorf("unknown rule: %s", name)
+		}
+	}
+	return nil
+}
+
+// Lint checks all inputs and returns the problems sorted by their source
+// and PR. Notes which are not published are skipped.
+func (l *Linter) Lint(inputs ...*Input) []*Problem {
+	res := []*Problem{}
+	for _, input := range inputs {
+		if input.Note == nil || input.Note.DoNotPublish {
+			continue
+		}
+		for _, rule := range l.rules {
+			for _, problem := range rule.Check(input) {
+				problem.Rule = rule.Name()
+				problem.PR = input.Note.PrNumber
+				problem.Source = input.Source
+				res = append(res, problem)
+			}
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Source != res[j].Source {
+			return res[i].Source < res[j].Source
+		}
+		return res[i].PR < res[j].PR
+	})
+	return res
+}
+
+// Errors returns the number of problems with SeverityError.
+func Errors(problems []*Problem) int {
+	count := 0
+	for _, problem := range problems {
+		if problem.Severity == SeverityError {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/notes"
+)
+
+type testRule struct{}
+
+func (*testRule) Name() string { return "test" }
+
+func (*testRule) Check(input *Input) []*Problem {
+	if input.Note.Text != "bad" {
+		return nil
+	}
+	return []*Problem{{Severity: SeverityError, Message: "bad note"}}
+}
+
+func TestLint(t *testing.T) {
+	linter := New(&testRule{})
+	problems := linter.Lint(
+		&Input{Note: &notes.ReleaseNote{PrNumber: 2, Text: "bad"}, Source: "b.yaml"},
+		&Input{Note: &notes.ReleaseNote{PrNumber: 3, Text: "good"}, Source: "a.yaml"},
+		&Input{Note: &notes.ReleaseNote{PrNumber: 1, Text: "bad"}, Source: "b.yaml"},
+		&Input{Note: &notes.ReleaseNote{PrNumber: 4, Text: "bad", DoNotPublish: true}},
+		&Input{},
+	)
+	require.Equal(t, []*Problem{
+		{Rule: "test", PR: 1, Source: "b.yaml", Severity: SeverityError, Message: "bad note"},
+		{Rule: "test", PR: 2, Source: "b.yaml", Severity: SeverityError, Message: "bad note"},
+	}, problems)
+	require.Equal(t, 2, Errors(problems))
+	require.Equal(t, 0, Errors([]*Problem{{Severity: SeverityWarning}}))
+}
+
+func TestDisable(t *testing.T) {
+	linter := New()
+	require.Len(t, linter.Rules(), len(DefaultRules()))
+
+	require.Nil(t, linter.Disable("length", "first-person"))
+	require.Len(t, linter.Rules(), len(DefaultRules())-2)
+	for _, rule := range linter.Rules() {
+		require.NotEqual(t, "length", rule.Name())
+		require.NotEqual(t, "first-person", rule.Name())
+	}
+
+	require.NotNil(t, linter.Disable("length"))
+}
+
+func TestProblemString(t *testing.T) {
+	require.Equal(t, "maps/1.yaml: #1 warning [formatting] the note contains trailing whitespace\n"+
+		"    suggestion: remove the formatting artifacts\n"+
+		"    fixed text: First line\n"+
+		"                Second line\n",
+		(&Problem{
+			Rule:       "formatting",
+			PR:         1,
+			Source:     "maps/1.yaml",
+			Severity:   SeverityWarning,
+			Message:    "the note contains trailing whitespace",
+			Suggestion: "remove the formatting artifacts",
+			Fix:        "First line\nSecond line",
+		}).String(),
+	)
+}
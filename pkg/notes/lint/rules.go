@@ -0,0 +1,348 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultMaxLength is the default maximum number of characters of a note.
+const DefaultMaxLength = 1000
+
+// DefaultRules returns all available rules. The DocsURLRule checks only the
+// syntax of the URLs unless its Client is set.
+func DefaultRules() []Rule {
+	return []Rule{
+		&LabelsRule{},
+		&TitleRule{},
+		&TenseRule{},
+		&FirstPersonRule{},
+		&FormattingRule{},
+		&LengthRule{MaxLength: DefaultMaxLength},
+		&DocsURLRule{},
+		&ActionRequiredRule{},
+	}
+}
+
+// LabelsRule reports notes without SIG or kind labels.
+type LabelsRule struct{}
+
+// Name returns the name of the rule.
+func (*LabelsRule) Name() string { return "missing-labels" }
+
+// Check checks the input.
+func (*LabelsRule) Check(input *Input) (res []*Problem) {
+	note := input.Note
+	if len(note.Kinds) == 0 && !(input.Partial && note.Kinds == nil) {
+		res = append(res, &Problem{
+			Severity:   SeverityError,
+			Message:    "the note has no kind label",
+			Suggestion: "add a kind label to the PR, for example `/kind bug`, or set `kinds` in the notes map",
+		})
+	}
+	if len(note.SIGs) == 0 && !(input.Partial && note.SIGs == nil) {
+		res = append(res, &Problem{
+			Severity:   SeverityError,
+			Message:    "the note has no SIG label",
+			Suggestion: "add the owning SIG to the PR, for example `/sig node`, or set `sigs` in the notes map",
+		})
+	}
+	return res
+}
+
+// TitleRule reports notes which just repeat the title of their PR.
+type TitleRule struct{}
+
+// Name returns the name of the rule.
+func (*TitleRule) Name() string { return "repeats-title" }
+
+var nonAlphanumericRE = regexp.MustCompile(`[^a-z0-9]+`)
+
+func normalize(s string) string {
+	return strings.TrimSpace(nonAlphanumericRE.ReplaceAllString(strings.ToLower(s), " "))
+}
+
+// Check checks the input.
+func (*TitleRule) Check(input *Input) []*Problem {
+	if input.PRTitle == "" || input.Note.Text == "" {
+		return nil
+	}
+	if normalize(input.Note.Text) != normalize(input.PRTitle) {
+		return nil
+	}
+	return []*Problem{{
+		Severity:   SeverityWarning,
+		Message:    "the note repeats the PR title",
+		Suggestion: "describe the user facing change and its impact instead of the implementation",
+	}}
+}
+
+// noteVerbs are verbs which are commonly used at the beginning of a note.
+var noteVerbs = []string{
+	"add", "allow", "bump", "change", "deprecate", "disable", "drop", "enable",
+	"ensure", "expose", "extend", "fix", "graduate", "implement", "improve",
+	"introduce", "migrate", "move", "promote", "reduce", "refactor", "remove",
+	"rename", "replace", "restore", "revert", "support", "switch", "update",
+	"upgrade", "use", "validate", "make", "build", "run",
+}
+
+// irregularPastTense contains the verbs of noteVerbs which do not get their
+// past tense by appending `ed`.
+var irregularPastTense = map[string]string{
+	"drop":  "dropped",
+	"make":  "made",
+	"build": "built",
+	"run":   "ran",
+}
+
+func pastTense(verb string) string {
+	if past, ok := irregularPastTense[verb]; ok {
+		return past
+	}
+	if strings.HasSuffix(verb, "e") {
+		return verb + "d"
+	}
+	return verb + "ed"
+}
+
+// presentTenseVerb returns the verb of noteVerbs if word is its present
+// tense or imperative form.
+func presentTenseVerb(word string) (string, bool) {
+	word = strings.ToLower(word)
+	for _, verb := range noteVerbs {
+		if word == verb || word == verb+"s" || word == verb+"es" {
+			return verb, true
+		}
+	}
+	return "", false
+}
+
+var firstWordRE = regexp.MustCompile(`^[^A-Za-z]*([A-Za-z]+)`)
+
+// TenseRule reports notes which do not start with a verb in past tense.
+type TenseRule struct{}
+
+// Name returns the name of the rule.
+func (*TenseRule) Name() string { return "past-tense" }
+
+// Check checks the input.
+func (*TenseRule) Check(input *Input) []*Problem {
+	match := firstWordRE.FindStringSubmatch(input.Note.Text)
+	if match == nil {
+		return nil
+	}
+	verb, ok := presentTenseVerb(match[1])
+	if !ok {
+		return nil
+	}
+
+	past := pastTense(verb)
+	if match[1][0] >= 'A' && match[1][0] <= 'Z' {
+		past = strings.ToUpper(past[:1]) + past[1:]
+	}
+	return []*Problem{{
+		Severity:   SeverityWarning,
+		Message:    fmt.Sprintf("the note starts with %q, which is not in past tense", match[1]),
+		Suggestion: fmt.Sprintf("use past tense, for example %q", past),
+		Fix:        strings.Replace(input.Note.Text, match[1], past, 1),
+	}}
+}
+
+var (
+	codeSpanRE         = regexp.MustCompile("`[^`]*`")
+	firstPersonRE      = regexp.MustCompile(`\b(I|I'm|I've|I'd)\b|(?i:\b(we|we're|we've|our|ours|my|mine)\b)`)
+	firstPersonLowerRE = regexp.MustCompile(`\b(me|us)\b`)
+)
+
+// FirstPersonRule reports notes which are written in first person.
+type FirstPersonRule struct{}
+
+// Name returns the name of the rule.
+func (*FirstPersonRule) Name() string { return "first-person" }
+
+// Check checks the input.
+func (*FirstPersonRule) Check(input *Input) []*Problem {
+	text := codeSpanRE.ReplaceAllString(input.Note.Text, "")
+	word := firstPersonRE.FindString(text)
+	if word == "" {
+		word = firstPersonLowerRE.FindString(text)
+	}
+	if word == "" {
+		return nil
+	}
+	return []*Problem{{
+		Severity:   SeverityWarning,
+		Message:    fmt.Sprintf("the note is written in first person (%q)", word),
+		Suggestion: "describe the change from the perspective of the users, for example \"The kubelet now ...\"",
+	}}
+}
+
+var (
+	bulletRE             = regexp.MustCompile(`^\s*[-*]\s+`)
+	trailingWhitespaceRE = regexp.MustCompile(`(?m)[ \t]+$`)
+	codeFenceRE          = regexp.MustCompile("(?m)^[ \t]*```[a-z-]*[ \t]*$")
+)
+
+// FormattingRule reports whitespace and markdown artifacts, like trailing
+// whitespace, leading bullets or left over code fences of the PR template.
+type FormattingRule struct{}
+
+// Name returns the name of the rule.
+func (*FormattingRule) Name() string { return "formatting" }
+
+// Check checks the input.
+func (*FormattingRule) Check(input *Input) []*Problem {
+	text := input.Note.Text
+	if text == "" {
+		return nil
+	}
+
+	issues := []string{}
+	fixed := text
+	if bulletRE.MatchString(fixed) {
+		issues = append(issues, "a leading bullet")
+		fixed = bulletRE.ReplaceAllString(fixed, "")
+	}
+	if codeFenceRE.MatchString(fixed) {
+		issues = append(issues, "code fences")
+		fixed = codeFenceRE.ReplaceAllString(fixed, "")
+	}
+	if trailingWhitespaceRE.MatchString(fixed) {
+		issues = append(issues, "trailing whitespace")
+		fixed = trailingWhitespaceRE.ReplaceAllString(fixed, "")
+	}
+	if strings.TrimSpace(fixed) != fixed {
+		issues = append(issues, "leading or trailing blank lines")
+	}
+	fixed = strings.TrimSpace(fixed)
+
+	if len(issues) == 0 {
+		return nil
+	}
+	return []*Problem{{
+		Severity:   SeverityWarning,
+		Message:    "the note contains " + strings.Join(issues, ", "),
+		Suggestion: "remove the formatting artifacts",
+		Fix:        fixed,
+	}}
+}
+
+// LengthRule reports notes which are longer than MaxLength characters.
+type LengthRule struct {
+	MaxLength int
+}
+
+// Name returns the name of the rule.
+func (*LengthRule) Name() string { return "length" }
+
+// Check checks the input.
+func (r *LengthRule) Check(input *Input) []*Problem {
+	length := utf8.RuneCountInString(strings.TrimSpace(input.Note.Text))
+	if r.MaxLength <= 0 || length <= r.MaxLength {
+		return nil
+	}
+	return []*Problem{{
+		Severity: SeverityWarning,
+		Message:  fmt.Sprintf("the note has %d characters, which is more than %d", length, r.MaxLength),
+		Suggestion: "summarize the change and link further details " +
+			"via a `docs` block in the PR description",
+	}}
+}
+
+// DocsURLRule reports documentation URLs which are invalid, still contain
+// the placeholders of the PR template or, if Client is set, do not resolve.
+type DocsURLRule struct {
+	Client *http.Client
+}
+
+// Name returns the name of the rule.
+func (*DocsURLRule) Name() string { return "docs-url" }
+
+// Check checks the input.
+func (r *DocsURLRule) Check(input *Input) (res []*Problem) {
+	for _, doc := range input.Note.Documentation {
+		if doc == nil {
+			continue
+		}
+		if err := r.check(doc.URL); err != nil {
+			res = append(res, &Problem{
+				Severity:   SeverityError,
+				Message:    fmt.Sprintf("documentation URL %q %v", doc.URL, err),
+				Suggestion: "fix or remove the link in the `docs` block of the PR description or the notes map",
+			})
+		}
+	}
+	return res
+}
+
+func (r *DocsURLRule) check(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+		strings.ContainsAny(rawURL, "<>") {
+		return errors.New("is not a valid URL")
+	}
+	if r.Client == nil {
+		return nil
+	}
+
+	resp, err := r.Client.Head(rawURL)
+	if err == nil && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusForbidden) {
+		resp.Body.Close()
+		// Some servers do not support HEAD requests
+		resp, err = r.Client.Get(rawURL)
+	}
+	if err != nil {
+		return errors.Wrap(err, "does not resolve")
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("does not resolve: %s", resp.Status)
+	}
+	return nil
+}
+
+// upgradeGuidanceRE matches words which indicate instructions for users.
+var upgradeGuidanceRE = regexp.MustCompile(
+	`(?i)\b(must|should|need to|needs to|have to|has to|required?|upgrad\w*|migrat\w*|instead|before|after|replace\w*|switch\w*|set|use|remove|update)\b`,
+)
+
+// ActionRequiredRule reports action required notes which do not explain
+// what users have to do.
+type ActionRequiredRule struct{}
+
+// Name returns the name of the rule.
+func (*ActionRequiredRule) Name() string { return "action-required-guidance" }
+
+// Check checks the input.
+func (*ActionRequiredRule) Check(input *Input) []*Problem {
+	note := input.Note
+	if !note.ActionRequired || note.Text == "" || upgradeGuidanceRE.MatchString(note.Text) {
+		return nil
+	}
+	return []*Problem{{
+		Severity:   SeverityError,
+		Message:    "the action required note does not explain what users have to do",
+		Suggestion: "describe the required action, for example \"Users must set ... before upgrading\"",
+	}}
+}
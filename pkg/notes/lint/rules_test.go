@@ -0,0 +1,188 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/notes"
+)
+
+func labeled(text string) *notes.ReleaseNote {
+	return &notes.ReleaseNote{Text: text, Kinds: []string{"bug"}, SIGs: []string{"node"}}
+}
+
+func TestRules(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		rule     Rule
+		input    *Input
+		messages []string
+		fix      string
+	}{
+		{
+			name:  "labels set",
+			rule:  &LabelsRule{},
+			input: &Input{Note: labeled("Fixed a bug.")},
+		},
+		{
+			name:     "labels missing",
+			rule:     &LabelsRule{},
+			input:    &Input{Note: &notes.ReleaseNote{Text: "Fixed a bug."}},
+			messages: []string{"the note has no kind label", "the note has no SIG label"},
+		},
+		{
+			name:  "labels unset in partial note",
+			rule:  &LabelsRule{},
+			input: &Input{Note: &notes.ReleaseNote{Text: "Fixed a bug."}, Partial: true},
+		},
+		{
+			name:     "labels empty in partial note",
+			rule:     &LabelsRule{},
+			input:    &Input{Note: &notes.ReleaseNote{Kinds: []string{}}, Partial: true},
+			messages: []string{"the note has no kind label"},
+		},
+		{
+			name:     "repeats title",
+			rule:     &TitleRule{},
+			input:    &Input{Note: labeled("Fix kubelet panic."), PRTitle: "fix: kubelet panic"},
+			messages: []string{"the note repeats the PR title"},
+		},
+		{
+			name:  "differs from title",
+			rule:  &TitleRule{},
+			input: &Input{Note: labeled("Fixed a panic of the kubelet on startup."), PRTitle: "fix: kubelet panic"},
+		},
+		{
+			name:     "present tense",
+			rule:     &TenseRule{},
+			input:    &Input{Note: labeled("Fixes a bug where the kubelet panics.")},
+			messages: []string{`the note starts with "Fixes", which is not in past tense`},
+			fix:      "Fixed a bug where the kubelet panics.",
+		},
+		{
+			name:     "imperative",
+			rule:     &TenseRule{},
+			input:    &Input{Note: labeled("- make the flag optional")},
+			messages: []string{`the note starts with "make", which is not in past tense`},
+			fix:      "- made the flag optional",
+		},
+		{
+			name:  "past tense",
+			rule:  &TenseRule{},
+			input: &Input{Note: labeled("Removed the deprecated flag.")},
+		},
+		{
+			name:  "no verb",
+			rule:  &TenseRule{},
+			input: &Input{Note: labeled("Kubelet: the flag is now optional.")},
+		},
+		{
+			name:     "first person",
+			rule:     &FirstPersonRule{},
+			input:    &Input{Note: labeled("We removed the flag.")},
+			messages: []string{`the note is written in first person ("We")`},
+		},
+		{
+			name:     "first person object",
+			rule:     &FirstPersonRule{},
+			input:    &Input{Note: labeled("Allowed us to remove the flag.")},
+			messages: []string{`the note is written in first person ("us")`},
+		},
+		{
+			name:  "first person in code",
+			rule:  &FirstPersonRule{},
+			input: &Input{Note: labeled("Added the `--our-flag` flag for the US region.")},
+		},
+		{
+			name:     "formatting",
+			rule:     &FormattingRule{},
+			input:    &Input{Note: labeled("* Fixed a bug  \n```\n")},
+			messages: []string{"the note contains a leading bullet, code fences, trailing whitespace, leading or trailing blank lines"},
+			fix:      "Fixed a bug",
+		},
+		{
+			name:  "well formatted",
+			rule:  &FormattingRule{},
+			input: &Input{Note: labeled("Fixed a bug:\n- in the kubelet")},
+		},
+		{
+			name:     "too long",
+			rule:     &LengthRule{MaxLength: 10},
+			input:    &Input{Note: labeled("Fixed a long bug.")},
+			messages: []string{"the note has 17 characters, which is more than 10"},
+		},
+		{
+			name:     "invalid docs URL",
+			rule:     &DocsURLRule{},
+			input:    &Input{Note: &notes.ReleaseNote{Documentation: []*notes.Documentation{{URL: "<link>"}, {URL: "https://kep.k8s.io/1"}}}},
+			messages: []string{`documentation URL "<link>" is not a valid URL`},
+		},
+		{
+			name:     "action required without guidance",
+			rule:     &ActionRequiredRule{},
+			input:    &Input{Note: &notes.ReleaseNote{Text: "The flag got removed.", ActionRequired: true}},
+			messages: []string{"the action required note does not explain what users have to do"},
+		},
+		{
+			name:  "action required with guidance",
+			rule:  &ActionRequiredRule{},
+			input: &Input{Note: &notes.ReleaseNote{Text: "The flag got removed, use --new-flag instead.", ActionRequired: true}},
+		},
+	} {
+		problems := tc.rule.Check(tc.input)
+		messages := []string{}
+		fix := ""
+		for _, problem := range problems {
+			messages = append(messages, problem.Message)
+			require.NotEmpty(t, problem.Suggestion, tc.name)
+			fix = problem.Fix
+		}
+		if tc.messages == nil {
+			tc.messages = []string{}
+		}
+		require.Equal(t, tc.messages, messages, tc.name)
+		require.Equal(t, tc.fix, fix, tc.name)
+	}
+}
+
+func TestDocsURLRuleOnline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			http.NotFound(w, r)
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		}
+	}))
+	defer server.Close()
+
+	rule := &DocsURLRule{Client: server.Client()}
+	problems := rule.Check(&Input{Note: &notes.ReleaseNote{Documentation: []*notes.Documentation{
+		{URL: server.URL + "/docs"},
+		{URL: server.URL + "/no-head"},
+		{URL: server.URL + "/missing"},
+	}}})
+	require.Len(t, problems, 1)
+	require.Contains(t, problems[0].Message, "/missing\" does not resolve: 404 Not Found")
+}
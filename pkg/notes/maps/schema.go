@@ -0,0 +1,265 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maps
+
+import (
+	_ "embed" // used for the schema
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Schema is the JSON Schema of a single YAML document of a release notes map
+// file. It can be used by editors to validate maps while writing them.
+//
+//go:embed schema.json
+var Schema []byte
+
+// schema is the subset of JSON Schema used by the release notes map schema.
+type schema struct {
+	Type                 string             `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Items                *schema            `json:"items"`
+	Enum                 []interface{}      `json:"enum"`
+	Pattern              string             `json:"pattern"`
+	MinLength            *int               `json:"minLength"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+
+	pattern *regexp.Regexp
+}
+
+// parseSchema parses the JSON Schema and compiles its patterns.
+func parseSchema(content []byte) (*schema, error) {
+	res := &schema{}
+	if err := json.Unmarshal(content, res); err != nil {
+		return nil, errors.Wrap(err, "unmarshal schema")
+	}
+	if err := res.compile(); err != nil {
+		return nil, errors.Wrap(err, "compiling schema")
+	}
+	return res, nil
+}
+
+func (s *schema) compile() (err error) {
+	if s.Pattern != "" {
+		if s.pattern, err = regexp.Compile(s.Pattern); err != nil {
+			return errors.Wrapf(err, "invalid pattern %q", s.Pattern)
+		}
+	}
+	for _, property := range s.Properties {
+		if err := property.compile(); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		return s.Items.compile()
+	}
+	return nil
+}
+
+// validate checks the decoded YAML value against the schema and returns the
+// found problems, prefixed with the path of the field.
+func (s *schema) validate(field string, value interface{}) []string {
+	if msg := s.validateType(value); msg != "" {
+		return []string{fieldMessage(field, msg)}
+	}
+
+	res := []string{}
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		res = append(res, s.validateObject(field, v)...)
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				res = append(res, s.Items.validate(fmt.Sprintf("%s[%d]", field, i), item)...)
+			}
+		}
+	case string:
+		if s.MinLength != nil && utf8.RuneCountInString(strings.TrimSpace(v)) < *s.MinLength {
+			res = append(res, fieldMessage(field, "must not be empty"))
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			res = append(res, fieldMessage(field, fmt.Sprintf("%q does not match %q", v, s.Pattern)))
+		}
+	case int:
+		res = append(res, s.validateRange(field, float64(v))...)
+	case float64:
+		res = append(res, s.validateRange(field, v)...)
+	}
+
+	if len(s.Enum) > 0 && !s.inEnum(value) {
+		values := []string{}
+		for _, e := range s.Enum {
+			values = append(values, fmt.Sprint(e))
+		}
+		res = append(res, fieldMessage(field, fmt.Sprintf(
+			"%v is not one of %s", value, strings.Join(values, ", "),
+		)))
+	}
+	return res
+}
+
+func (s *schema) validateType(value interface{}) string {
+	ok := true
+	switch s.Type {
+	case "":
+		return ""
+	case "object":
+		_, ok = value.(map[interface{}]interface{})
+	case "array":
+		_, ok = value.([]interface{})
+	case "string":
+		_, ok = value.(string)
+	case "boolean":
+		_, ok = value.(bool)
+	case "integer":
+		_, ok = value.(int)
+	case "number":
+		switch value.(type) {
+		case int, float64:
+		default:
+			ok = false
+		}
+	}
+	if ok {
+		return ""
+	}
+	return fmt.Sprintf("must be of type %s, but is %s", s.Type, yamlType(value))
+}
+
+func (s *schema) validateObject(field string, object map[interface{}]interface{}) []string {
+	res := []string{}
+	for _, name := range s.Required {
+		if _, ok := object[name]; !ok {
+			res = append(res, fieldMessage(joinField(field, name), "is required"))
+		}
+	}
+
+	keys := []string{}
+	values := map[string]interface{}{}
+	for key, value := range object {
+		name := fmt.Sprint(key)
+		keys = append(keys, name)
+		values[name] = value
+	}
+	sort.Strings(keys)
+
+	for _, name := range keys {
+		property, ok := s.Properties[name]
+		if ok && values[name] == nil {
+			// Empty values are decoded as unset fields, like `releasenote:`
+			if s.isRequired(name) {
+				res = append(res, fieldMessage(joinField(field, name), "must not be empty"))
+			}
+			continue
+		}
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				res = append(res, fieldMessage(joinField(field, name), "is not a known field"+s.suggest(name)))
+			}
+			continue
+		}
+		res = append(res, property.validate(joinField(field, name), values[name])...)
+	}
+	return res
+}
+
+func (s *schema) isRequired(name string) bool {
+	for _, required := range s.Required {
+		if required == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *schema) validateRange(field string, value float64) []string {
+	if s.Minimum != nil && value < *s.Minimum {
+		return []string{fieldMessage(field, fmt.Sprintf("must be at least %v", *s.Minimum))}
+	}
+	if s.Maximum != nil && value > *s.Maximum {
+		return []string{fieldMessage(field, fmt.Sprintf("must be at most %v", *s.Maximum))}
+	}
+	return nil
+}
+
+func (s *schema) inEnum(value interface{}) bool {
+	for _, e := range s.Enum {
+		if reflect.DeepEqual(e, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// suggest returns a hint to a known property which differs from the
+// provided name only in case or separators, like `actionRequired`.
+func (s *schema) suggest(name string) string {
+	normalize := func(n string) string {
+		return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(n))
+	}
+	for property := range s.Properties {
+		if normalize(property) == normalize(name) {
+			return fmt.Sprintf(", did you mean %q?", property)
+		}
+	}
+	return ""
+}
+
+func yamlType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "empty"
+	case map[interface{}]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case int:
+		return "integer"
+	case float64:
+		return "number"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func joinField(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func fieldMessage(field, msg string) string {
+	if field == "" {
+		return "document " + msg
+	}
+	return fmt.Sprintf("%s %s", field, msg)
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package maps validates release notes map files before they are used to
+// generate the release notes.
+package maps
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"k8s.io/release/pkg/cve"
+	"k8s.io/release/pkg/notes"
+)
+
+// Severity is the severity of a validation problem.
+type Severity string
+
+const (
+	// SeverityError marks problems which break the release notes generation
+	// or result in wrong notes.
+	SeverityError Severity = "error"
+
+	// SeverityWarning marks problems which should be reviewed.
+	SeverityWarning Severity = "warning"
+)
+
+// Problem is a single finding of the validation.
+type Problem struct {
+	// File is the path of the map file
+	File string `json:"file"`
+
+	// Document is the index of the YAML document in the file, starting at 1,
+	// or 0 if the problem affects the whole file
+	Document int `json:"document,omitempty"`
+
+	// PR is the pull request number of the map, if known
+	PR int `json:"pr,omitempty"`
+
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// String returns the terminal representation of the problem.
+func (p *Problem) String() string {
+	location := p.File
+	if p.Document > 0 {
+		location = fmt.Sprintf("%s (document %d)", location, p.Document)
+	}
+	if p.PR > 0 {
+		location = fmt.Sprintf("%s #%d", location, p.PR)
+	}
+	return fmt.Sprintf("%s: %s %s\n", location, p.Severity, p.Message)
+}
+
+// Options are the settings of the validation.
+type Options struct {
+	// PRs are the pull requests of the release range. Every map has to
+	// refer to one of them if set.
+	PRs []int
+}
+
+// location is the origin of a single map.
+type location struct {
+	file     string
+	document int
+}
+
+func (l location) String() string {
+	return fmt.Sprintf("%s (document %d)", l.file, l.document)
+}
+
+// Validate checks all release notes map files below path and returns the
+// found problems, sorted by file and document. It checks the structure and
+// value types of the maps against the Schema, the embedded CVE data and
+// whether a PR is mapped in more than one file. Multiple documents for the
+// same PR inside one file are fine, because the maps get merged. An error is
+// only returned if the files cannot be read.
+func Validate(path string, opts *Options) ([]*Problem, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	s, err := parseSchema(Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return nil, errors.Wrapf(err, "checking release notes maps path %s", path)
+	}
+	files, err := notes.MapFiles(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing release notes maps in %s", path)
+	}
+
+	var inRange map[int]bool
+	if opts.PRs != nil {
+		inRange = map[int]bool{}
+		for _, pr := range opts.PRs {
+			inRange[pr] = true
+		}
+	}
+
+	res := []*Problem{}
+	// The first location of every PR per file
+	locations := map[int][]location{}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading release notes map %s", file)
+		}
+
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		for document := 1; ; document++ {
+			var value interface{}
+			if err := decoder.Decode(&value); err == io.EOF {
+				break
+			} else if err != nil {
+				// The decoder cannot continue after syntax errors
+				res = append(res, &Problem{
+					File: file, Document: document, Severity: SeverityError,
+					Message: fmt.Sprintf("invalid YAML: %v", err),
+				})
+				break
+			}
+			if value == nil {
+				// Empty documents, for example after a trailing `---`
+				continue
+			}
+
+			problems := validateDocument(s, value, inRange)
+			pr := 0
+			if object, ok := value.(map[interface{}]interface{}); ok {
+				pr, _ = object["pr"].(int)
+			}
+			for _, problem := range problems {
+				problem.File = file
+				problem.Document = document
+				problem.PR = pr
+			}
+			res = append(res, problems...)
+			if pr > 0 && !mappedInFile(locations[pr], file) {
+				locations[pr] = append(locations[pr], location{file, document})
+			}
+		}
+	}
+
+	for pr, found := range locations {
+		if len(found) < 2 {
+			continue
+		}
+		others := []string{}
+		for _, l := range found[1:] {
+			others = append(others, l.String())
+		}
+		res = append(res, &Problem{
+			File: found[0].file, Document: found[0].document, PR: pr,
+			Severity: SeverityWarning,
+			Message:  "PR is mapped again in " + strings.Join(others, ", "),
+		})
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].File != res[j].File {
+			return res[i].File < res[j].File
+		}
+		return res[i].Document < res[j].Document
+	})
+	return res, nil
+}
+
+// mappedInFile returns true if one of the locations is in file.
+func mappedInFile(locations []location, file string) bool {
+	for _, l := range locations {
+		if l.file == file {
+			return true
+		}
+	}
+	return false
+}
+
+// validateDocument checks a single decoded YAML document.
+func validateDocument(s *schema, value interface{}, inRange map[int]bool) []*Problem {
+	res := []*Problem{}
+	for _, msg := range s.validate("", value) {
+		res = append(res, &Problem{Severity: SeverityError, Message: msg})
+	}
+	if len(res) > 0 {
+		// The CVE and range checks rely on a valid structure
+		return res
+	}
+
+	object := value.(map[interface{}]interface{})
+	if pr := object["pr"].(int); inRange != nil && !inRange[pr] {
+		res = append(res, &Problem{
+			Severity: SeverityError,
+			Message:  "PR is not part of the release range",
+		})
+	}
+
+	datafields, ok := object["datafields"].(map[interface{}]interface{})
+	if !ok {
+		return res
+	}
+	data, ok := datafields["cve"]
+	if !ok {
+		return res
+	}
+	cveData := cve.CVE{}
+	if err := cveData.ReadRawInterface(data); err != nil {
+		return append(res, &Problem{
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("reading CVE data: %v", err),
+		})
+	}
+	if err := cveData.Validate(); err != nil {
+		res = append(res, &Problem{
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("invalid CVE data: %v", err),
+		})
+	}
+	return res
+}
+
+// Errors returns the number of problems with SeverityError.
+func Errors(problems []*Problem) int {
+	count := 0
+	for _, problem := range problems {
+		if problem.Severity == SeverityError {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maps
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/notes"
+)
+
+const validCVE = `pr: 3
+datafields:
+  cve:
+    id: CVE-2020-8555
+    title: Half-Blind SSRF in kube-controller-manager
+    description: There exists a Server Side Request Forgery vulnerability.
+    issue: https://github.com/kubernetes/kubernetes/issues/91542
+    vector: CVSS:3.0/AV:N/AC:H/PR:L/UI:N/S:C/C:H/I:N/A:N
+    score: 6.3
+    rating: Medium
+    published: 2020-05-28
+    linkedPRs:
+    - 89794
+`
+
+func writeMaps(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		require.Nil(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		files    map[string]string
+		prs      []int
+		expected []string
+	}{
+		{
+			name: "valid maps",
+			files: map[string]string{
+				"a.yaml": "pr: 1\ncommit: 1a89038\nreleasenote:\n  text: Fixed a bug.\n" +
+					"  kinds: [bug]\n  sigs: [node]\n  action_required: false\n" +
+					"  documentation:\n  - url: https://k8s.io\n    type: KEP\n" +
+					"datafields:\n  custom: 1\n---\n",
+				"nested/b.yml": "---\npr: 2\nreleasenote:\n---\n" + validCVE,
+				"README.md":    "not a map",
+			},
+			prs:      []int{1, 2, 3},
+			expected: []string{},
+		},
+		{
+			name: "wrong keys and types",
+			files: map[string]string{
+				"a.yaml": "pr: \"1\"\n---\npr: 2\nreleasenote:\n  actionRequired: true\n" +
+					"  sigs: node\n  text: \" \"\n---\npr: 0\nrelease_note: {}\n" +
+					"---\nreleasenote:\n  documentation:\n  - url: <url>\n    type: kep\n",
+			},
+			expected: []string{
+				"a.yaml:1:0:error:pr must be of type integer, but is string",
+				"a.yaml:2:2:error:releasenote.actionRequired is not a known field, did you mean \"action_required\"?",
+				"a.yaml:2:2:error:releasenote.sigs must be of type array, but is string",
+				"a.yaml:2:2:error:releasenote.text must not be empty",
+				"a.yaml:3:0:error:pr must be at least 1",
+				"a.yaml:3:0:error:release_note is not a known field, did you mean \"releasenote\"?",
+				"a.yaml:4:0:error:pr is required",
+				"a.yaml:4:0:error:releasenote.documentation[0].type kep is not one of external, KEP, official",
+				"a.yaml:4:0:error:releasenote.documentation[0].url \"<url>\" does not match \"^https?://\"",
+			},
+		},
+		{
+			name: "invalid YAML",
+			files: map[string]string{
+				"a.yaml": "pr: 1\n---\npr: [1\n",
+			},
+			expected: []string{
+				"a.yaml:2:0:error:invalid YAML: yaml: line 3: did not find expected ',' or ']'",
+			},
+		},
+		{
+			name: "duplicates and range",
+			files: map[string]string{
+				"a.yaml":        "pr: 1\n---\npr: 2\n",
+				"b.yaml":        "pr: 1\n",
+				"nested/c.yaml": "pr: 1\n",
+			},
+			prs: []int{1},
+			expected: []string{
+				"a.yaml:1:1:warning:PR is mapped again in b.yaml (document 1), nested/c.yaml (document 1)",
+				"a.yaml:2:2:error:PR is not part of the release range",
+			},
+		},
+		{
+			name: "same PR in one file",
+			files: map[string]string{
+				"a.yaml": "pr: 1\n---\npr: 1\n---\npr: 2\n",
+				"b.yaml": "pr: 2\n---\npr: 2\n",
+			},
+			expected: []string{
+				"a.yaml:3:2:warning:PR is mapped again in b.yaml (document 1)",
+			},
+		},
+		{
+			name: "invalid CVE",
+			files: map[string]string{
+				"a.yaml": strings.Replace(validCVE, "rating: Medium", "rating: CVSS:3.0/AV:N", 1) +
+					"---\n" + strings.NewReplacer("pr: 3", "pr: 4", "6.3", "0").Replace(validCVE) +
+					"---\n" + strings.NewReplacer("pr: 3", "pr: 5", "6.3", "6").Replace(validCVE),
+			},
+			expected: []string{
+				"a.yaml:1:3:error:datafields.cve.rating CVSS:3.0/AV:N is not one of None, Low, Medium, High, Critical",
+				"a.yaml:2:4:error:invalid CVE data: CVSS score missing from CVE data",
+			},
+		},
+	} {
+		dir := writeMaps(t, tc.files)
+		problems, err := Validate(dir, &Options{PRs: tc.prs})
+		require.Nil(t, err, tc.name)
+
+		res := []string{}
+		for _, p := range problems {
+			file, err := filepath.Rel(dir, p.File)
+			require.Nil(t, err)
+			res = append(res, strings.Join([]string{
+				file, strconv.Itoa(p.Document), strconv.Itoa(p.PR), string(p.Severity),
+				strings.ReplaceAll(p.Message, dir+string(filepath.Separator), ""),
+			}, ":"))
+		}
+		require.Equal(t, tc.expected, res, tc.name)
+	}
+}
+
+func TestValidateFailure(t *testing.T) {
+	_, err := Validate(filepath.Join(t.TempDir(), "missing"), nil)
+	require.NotNil(t, err)
+}
+
+func TestErrors(t *testing.T) {
+	require.Equal(t, 1, Errors([]*Problem{
+		{Severity: SeverityError}, {Severity: SeverityWarning},
+	}))
+}
+
+// TestSchemaFields ensures that the schema stays in sync with the fields of
+// the release notes maps.
+func TestSchemaFields(t *testing.T) {
+	s, err := parseSchema(Schema)
+	require.Nil(t, err)
+
+	fields := func(typ reflect.Type) []string {
+		res := []string{}
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+			if name == "" {
+				name = strings.ToLower(field.Name)
+			}
+			res = append(res, name)
+		}
+		return res
+	}
+	keys := func(properties map[string]*schema) []string {
+		res := []string{}
+		for key := range properties {
+			res = append(res, key)
+		}
+		return res
+	}
+
+	typ := reflect.TypeOf(notes.ReleaseNotesMap{})
+	require.ElementsMatch(t, fields(typ), keys(s.Properties))
+
+	releaseNote, ok := typ.FieldByName("ReleaseNote")
+	require.True(t, ok)
+	require.ElementsMatch(t, fields(releaseNote.Type), keys(s.Properties["releasenote"].Properties))
+	require.ElementsMatch(t,
+		fields(reflect.TypeOf(notes.Documentation{})),
+		keys(s.Properties["releasenote"].Properties["documentation"].Items.Properties),
+	)
+}
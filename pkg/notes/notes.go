@@ -39,8 +39,8 @@ import (
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 
+	"k8s.io/release/pkg/notes/forge"
 	"k8s.io/release/pkg/notes/options"
-	"sigs.k8s.io/release-sdk/github"
 )
 
 var (
@@ -158,6 +158,7 @@ const (
 type ReleaseNotes struct {
 	byPR    ReleaseNotesByPR
 	history ReleaseNotesHistory
+	config  *Config
 }
 
 // NewReleaseNotes can be used to create a new empty ReleaseNotes struct
@@ -186,6 +187,20 @@ func (r *ReleaseNotes) ByPR() ReleaseNotesByPR {
 	return r.byPR
 }
 
+// Config returns the configuration the release notes have been gathered
+// with, which defaults to DefaultConfig
+func (r *ReleaseNotes) Config() *Config {
+	if r.config == nil {
+		return defaultConfig
+	}
+	return r.config
+}
+
+// SetConfig can be used to set the configuration of the release notes
+func (r *ReleaseNotes) SetConfig(config *Config) {
+	r.config = config
+}
+
 // Get returns the ReleaseNote for the provided prNumber
 func (r *ReleaseNotes) Get(prNumber int) *ReleaseNote {
 	return r.byPR[prNumber]
@@ -203,10 +218,19 @@ type Result struct {
 }
 
 type Gatherer struct {
-	client       github.Client
+	client       forge.Provider
 	context      context.Context
 	options      *options.Options
+	config       *Config
 	MapProviders []*MapProvider
+
+	// enrichFromGitHub indicates if notes built from commits get enriched
+	// with the data of their pull requests.
+	enrichFromGitHub bool
+
+	// batchClient fetches the pull requests of ListReleaseNotesV2 at once,
+	// if set.
+	batchClient forge.BatchProvider
 }
 
 // NewGatherer creates a new notes gatherer
@@ -215,22 +239,50 @@ func NewGatherer(ctx context.Context, opts *options.Options) (*Gatherer, error)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create notes client")
 	}
+
+	config := DefaultConfig()
+	if opts.ConfigFile != "" {
+		config, err = LoadConfig(opts.ConfigFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "loading release notes config")
+		}
+	}
+
 	return &Gatherer{
 		client:  client,
 		context: ctx,
 		options: opts,
+		config:  config,
+
+		enrichFromGitHub: opts.GitHubAccess(),
+		batchClient:      opts.BatchClient(),
 	}, nil
 }
 
 // NewGathererWithClient creates a new notes gatherer with a specific client
-func NewGathererWithClient(ctx context.Context, c github.Client) *Gatherer {
+func NewGathererWithClient(ctx context.Context, c forge.Provider) *Gatherer {
 	return &Gatherer{
 		client:  c,
 		context: ctx,
 		options: options.New(),
+
+		enrichFromGitHub: true,
 	}
 }
 
+// Config returns the release notes configuration of the gatherer
+func (g *Gatherer) Config() *Config {
+	if g.config == nil {
+		return defaultConfig
+	}
+	return g.config
+}
+
+// SetConfig can be used to set the release notes configuration
+func (g *Gatherer) SetConfig(config *Config) {
+	g.config = config
+}
+
 // GatherReleaseNotes creates a new gatherer and collects the release notes
 // afterwards
 func GatherReleaseNotes(opts *options.Options) (*ReleaseNotes, error) {
@@ -242,7 +294,9 @@ func GatherReleaseNotes(opts *options.Options) (*ReleaseNotes, error) {
 
 	var releaseNotes *ReleaseNotes
 	startTime := time.Now()
-	if gatherer.options.ListReleaseNotesV2 {
+	if gatherer.options.Source == options.SourceConventionalCommits {
+		releaseNotes, err = gatherer.ListReleaseNotesFromCommits()
+	} else if gatherer.options.ListReleaseNotesV2 {
 		logrus.Warn("EXPERIMENTAL IMPLEMENTATION ListReleaseNotesV2 ENABLED")
 		releaseNotes, err = gatherer.ListReleaseNotesV2()
 	} else {
@@ -286,7 +340,7 @@ func (g *Gatherer) ListReleaseNotes() (*ReleaseNotes, error) {
 	logrus.Info("Checking PRs for mapped data")
 	for _, res := range resultsTemp {
 		// If the PR has no release note, check if we have to add it
-		if MatchesExcludeFilter(*res.pullRequest.Body) {
+		if g.Config().MatchesExcludeFilter(*res.pullRequest.Body) {
 			for _, provider := range mapProviders {
 				noteMaps, err := provider.GetMapsForPR(res.pullRequest.GetNumber())
 				if err != nil {
@@ -315,6 +369,7 @@ func (g *Gatherer) ListReleaseNotes() (*ReleaseNotes, error) {
 
 	dedupeCache := map[string]struct{}{}
 	notes := NewReleaseNotes()
+	notes.SetConfig(g.Config())
 	for _, result := range results {
 		if g.options.RequiredAuthor != "" {
 			if result.commit.GetAuthor().GetLogin() != g.options.RequiredAuthor {
@@ -361,36 +416,7 @@ func (g *Gatherer) ListReleaseNotes() (*ReleaseNotes, error) {
 // may contain the commit message, the PR description, etc.
 // This is generally the content inside the ```release-note ``` stanza.
 func noteTextFromString(s string) (string, error) {
-	exps := []*regexp.Regexp{
-		// (?s) is needed for '.' to be matching on newlines, by default that's disabled
-		// we need to match ungreedy 'U', because after the notes a `docs` block can occur
-		regexp.MustCompile("(?sU)```release-note[s]?\\r\\n(?P<note>.+)\\r\\n```"),
-		regexp.MustCompile("(?sU)```dev-release-note[s]?\\r\\n(?P<note>.+)"),
-		regexp.MustCompile("(?sU)```\\r\\n(?P<note>.+)\\r\\n```"),
-		regexp.MustCompile("(?sU)```release-note[s]?\n(?P<note>.+)\n```"),
-	}
-
-	for _, exp := range exps {
-		match := exp.FindStringSubmatch(s)
-		if len(match) == 0 {
-			continue
-		}
-		result := map[string]string{}
-		for i, name := range exp.SubexpNames() {
-			if i != 0 && name != "" {
-				result[name] = match[i]
-			}
-		}
-
-		note := strings.ReplaceAll(result["note"], "\r", "")
-		note = stripActionRequired(note)
-		note = dashify(note)
-		note = unlist(note)
-		note = strings.TrimSpace(note)
-		return note, nil
-	}
-
-	return "", errors.New("no matches found when parsing note text from commit string")
+	return defaultConfig.NoteText(s)
 }
 
 func DocumentationFromString(s string) []*Documentation {
@@ -456,7 +482,8 @@ func (g *Gatherer) ReleaseNoteFromCommit(result *Result) (*ReleaseNote, error) {
 	pr := result.pullRequest
 
 	prBody := pr.GetBody()
-	text, err := noteTextFromString(prBody)
+	config := g.Config()
+	text, err := config.NoteText(prBody)
 	if err != nil {
 		return nil, err
 	}
@@ -466,16 +493,16 @@ func (g *Gatherer) ReleaseNoteFromCommit(result *Result) (*ReleaseNote, error) {
 	author := pr.GetUser().GetLogin()
 	authorURL := pr.GetUser().GetHTMLURL()
 	prURL := pr.GetHTMLURL()
-	isFeature := hasString(labelsWithPrefix(pr, "kind"), "feature")
-	noteSuffix := prettifySIGList(labelsWithPrefix(pr, "sig"))
+	isFeature := hasString(labelsWithPrefix(pr, config.Labels.Kind), string(config.Kinds.Feature))
+	noteSuffix := prettifySIGList(labelsWithPrefix(pr, config.Labels.SIG))
 
 	isDuplicateSIG := false
-	if len(labelsWithPrefix(pr, "sig")) > 1 {
+	if len(labelsWithPrefix(pr, config.Labels.SIG)) > 1 {
 		isDuplicateSIG = true
 	}
 
 	isDuplicateKind := false
-	if len(labelsWithPrefix(pr, "kind")) > 1 {
+	if len(labelsWithPrefix(pr, config.Labels.Kind)) > 1 {
 		isDuplicateKind = true
 	}
 
@@ -504,17 +531,56 @@ func (g *Gatherer) ReleaseNoteFromCommit(result *Result) (*ReleaseNote, error) {
 		AuthorURL:      authorURL,
 		PrURL:          prURL,
 		PrNumber:       pr.GetNumber(),
-		SIGs:           labelsWithPrefix(pr, "sig"),
-		Kinds:          labelsWithPrefix(pr, "kind"),
-		Areas:          labelsWithPrefix(pr, "area"),
+		SIGs:           labelsWithPrefix(pr, config.Labels.SIG),
+		Kinds:          labelsWithPrefix(pr, config.Labels.Kind),
+		Areas:          labelsWithPrefix(pr, config.Labels.Area),
 		Feature:        isFeature,
 		Duplicate:      isDuplicateSIG,
 		DuplicateKind:  isDuplicateKind,
-		ActionRequired: labelExactMatch(pr, "release-note-action-required"),
-		DoNotPublish:   labelExactMatch(pr, "release-note-none"),
+		ActionRequired: labelExactMatch(pr, config.Labels.ActionRequired),
+		DoNotPublish:   labelExactMatch(pr, config.Labels.DoNotPublish),
 	}, nil
 }
 
+// ReleaseNoteForPullRequest fetches the pull request with the provided number
+// and returns its release note as well as the pull request itself.
+func (g *Gatherer) ReleaseNoteForPullRequest(number int) (*ReleaseNote, *gogithub.PullRequest, error) {
+	pr, _, err := g.client.GetPullRequest(g.context, g.options.GithubOrg, g.options.GithubRepo, number)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "fetching pull request #%d", number)
+	}
+
+	note, err := g.ReleaseNoteFromCommit(&Result{pullRequest: pr})
+	if err != nil {
+		return nil, pr, errors.Wrapf(err, "getting the release note of pull request #%d", number)
+	}
+	return note, pr, nil
+}
+
+// PullRequestNumbers returns the numbers of the pull requests merged between
+// the start and end SHA of the options. The numbers are parsed from the
+// commit messages, without fetching the pull requests themselves.
+func (g *Gatherer) PullRequestNumbers() ([]int, error) {
+	commits, err := g.listCommits(g.options.Branch, g.options.StartSHA, g.options.EndSHA)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing commits")
+	}
+
+	res := []int{}
+	for _, commit := range commits {
+		prs, err := prsNumForCommitFromMessage(commit.GetCommit().GetMessage())
+		if errors.Is(err, errNoPRIDFoundInCommitMessage) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing commit message of %s", commit.GetSHA())
+		}
+		res = append(res, prs...)
+	}
+	sort.Ints(res)
+	return res, nil
+}
+
 // listCommits lists all commits starting from a given commit SHA and ending at
 // a given commit SHA.
 func (g *Gatherer) listCommits(branch, start, end string) ([]*gogithub.RepositoryCommit, error) {
@@ -610,34 +676,16 @@ func (l *commitList) List() []*gogithub.RepositoryCommit {
 	return l.list
 }
 
-// noteExclusionFilters is a list of regular expressions that match commits
-// that do NOT contain release notes. Notably, this is all of the variations of
-// "release note none" that appear in the commit log.
-var noteExclusionFilters = []*regexp.Regexp{
-	// 'none','n/a','na' case insensitive with optional trailing
-	// whitespace, wrapped in ``` with/without release-note identifier
-	// the 'none','n/a','na' can also optionally be wrapped in quotes ' or "
-	regexp.MustCompile("(?i)```release-note[s]?\\s*('|\")?(none|n/a|na)?('|\")?\\s*```"),
-
-	// simple '/release-note-none' tag
-	regexp.MustCompile("/release-note-none"),
-}
-
-// Similarly, now that the known not-release-notes are filtered out, we can
-// use some patterns to find actual release notes.
-var noteInclusionFilters = []*regexp.Regexp{
-	regexp.MustCompile("release-note"),
-	regexp.MustCompile("Does this PR introduce a user-facing change?"),
-}
-
-// MatchesExcludeFilter returns true if the string matches an excluded release note
+// MatchesExcludeFilter returns true if the string matches an excluded release
+// note of the default configuration.
 func MatchesExcludeFilter(msg string) bool {
-	return matchesFilter(msg, noteExclusionFilters)
+	return defaultConfig.MatchesExcludeFilter(msg)
 }
 
-// MatchesIncludeFilter returns true if the string matches an included release note
+// MatchesIncludeFilter returns true if the string matches an included release
+// note of the default configuration.
 func MatchesIncludeFilter(msg string) bool {
-	return matchesFilter(msg, noteInclusionFilters)
+	return defaultConfig.MatchesIncludeFilter(msg)
 }
 
 func matchesFilter(msg string, filters []*regexp.Regexp) bool {
@@ -730,7 +778,7 @@ func (g *Gatherer) notesForCommit(commit *gogithub.RepositoryCommit) (*Result, e
 			"Got PR #%d for commit: %s", pr.GetNumber(), commit.GetSHA(),
 		)
 
-		if MatchesIncludeFilter(prBody) {
+		if g.Config().MatchesIncludeFilter(prBody) {
 			res := &Result{commit: commit, pullRequest: pr}
 			logrus.Infof("PR #%d seems to contain a release note", pr.GetNumber())
 			// Do not test further PRs for this commit as soon as one PR matched
@@ -982,6 +1030,13 @@ func prsNumForCommitFromMessage(commitMessage string) (prs []int, err error) {
 		prs = append(prs, pr)
 	}
 
+	// GitLab merge commits reference the merge request by its internal ID
+	regex = regexp.MustCompile(`See merge request \S*!(?P<number>\d+)`)
+	pr = prForRegex(regex, commitMessage)
+	if pr != 0 {
+		prs = append(prs, pr)
+	}
+
 	// If the PR was squash merged, the regexp is different
 	regex = regexp.MustCompile(`\(#(?P<number>\d+)\)`)
 	pr = prForRegex(regex, commitMessage)
@@ -17,28 +17,45 @@ limitations under the License.
 package notes
 
 import (
+	"bytes"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 
-	"sigs.k8s.io/release-sdk/object"
+	"k8s.io/release/pkg/objectstore"
+	"sigs.k8s.io/release-sdk/git"
 )
 
+// GitPrefix is the prefix of init strings which refer to maps in a git
+// repository, like `git+https://github.com/org/repo//path/to/maps@ref`.
+const GitPrefix = "git+"
+
 // MapProvider interface that obtains release notes maps from a source
 type MapProvider interface {
 	GetMapsForPR(int) ([]*ReleaseNotesMap, error)
 }
 
-// NewProviderFromInitString creates a new map provider from an initialization string
+// NewProviderFromInitString creates a new map provider from an initialization
+// string, which can be a local directory, an object store path like
+// `gs://bucket/maps/` or a git repository like
+// `git+https://github.com/org/repo//path/to/maps@ref`.
 func NewProviderFromInitString(initString string) (MapProvider, error) {
-	// If init string starts with gs:// return a CloudStorageProvider
-	if initString[0:5] == object.GcsPrefix {
-		// Currently for illustration purposes
-		return nil, errors.New("CloudStorageProvider is not yet implemented")
+	if strings.HasPrefix(initString, GitPrefix) {
+		return NewGitMapProvider(initString)
+	}
+
+	for _, prefix := range []string{
+		objectstore.GCSPrefix, objectstore.S3Prefix, objectstore.FilePrefix,
+	} {
+		if strings.HasPrefix(initString, prefix) {
+			return NewObjectStoreMapProvider(initString), nil
+		}
 	}
 
 	// Otherwise, build a DirectoryMapProvider using the
@@ -56,14 +73,20 @@ func NewProviderFromInitString(initString string) (MapProvider, error) {
 
 // ParseReleaseNotesMap Parses a Release Notes Map
 func ParseReleaseNotesMap(mapPath string) (*[]ReleaseNotesMap, error) {
-	notemaps := []ReleaseNotesMap{}
 	yamlReader, err := os.Open(mapPath)
 	if err != nil {
 		return nil, errors.Wrap(err, "opening maps")
 	}
 	defer yamlReader.Close()
 
-	decoder := yaml.NewDecoder(yamlReader)
+	return decodeReleaseNotesMaps(yamlReader)
+}
+
+// decodeReleaseNotesMaps decodes all YAML documents of the reader into
+// release notes maps.
+func decodeReleaseNotesMaps(reader io.Reader) (*[]ReleaseNotesMap, error) {
+	notemaps := []ReleaseNotesMap{}
+	decoder := yaml.NewDecoder(reader)
 
 	for {
 		noteMap := ReleaseNotesMap{}
@@ -127,34 +150,43 @@ type DirectoryMapProvider struct {
 	Maps map[int][]*ReleaseNotesMap
 }
 
-// readMaps Open the dir and read dir notes
-func (mp *DirectoryMapProvider) readMaps() error {
+// MapFiles returns the YAML files of the release notes maps below path, which
+// can be a directory or a single file.
+func MapFiles(path string) ([]string, error) {
 	var fileList []string
-	mp.Maps = map[int][]*ReleaseNotesMap{}
-
-	err := filepath.Walk(mp.Path, func(path string, info os.FileInfo, err error) error {
+	err := filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
 		if filepath.Ext(path) == ".yaml" || filepath.Ext(path) == ".yml" {
 			fileList = append(fileList, path)
 		}
 		return nil
 	})
+	return fileList, err
+}
+
+// readMaps Open the dir and read dir notes
+func (mp *DirectoryMapProvider) readMaps() error {
+	mp.Maps = map[int][]*ReleaseNotesMap{}
+
+	fileList, err := MapFiles(mp.Path)
 
 	for _, fileName := range fileList {
 		notemaps, err := ParseReleaseNotesMap(fileName)
 		if err != nil {
 			return errors.Wrapf(err, "while parsing note map in %s", fileName)
 		}
-		for i, notemap := range *notemaps {
-			if _, ok := mp.Maps[notemap.PR]; !ok {
-				mp.Maps[notemap.PR] = make([]*ReleaseNotesMap, 0)
-			}
-			mp.Maps[notemap.PR] = append(mp.Maps[notemap.PR], &(*notemaps)[i])
-		}
+		addMaps(mp.Maps, notemaps)
 	}
 	logrus.Infof("Successfully parsed release notes maps for %d PRs from %s", len(mp.Maps), mp.Path)
 	return err
 }
 
+// addMaps adds the notemaps to maps, indexed by their PR number.
+func addMaps(maps map[int][]*ReleaseNotesMap, notemaps *[]ReleaseNotesMap) {
+	for i, notemap := range *notemaps {
+		maps[notemap.PR] = append(maps[notemap.PR], &(*notemaps)[i])
+	}
+}
+
 // GetMapsForPR get the release notes maps for a specific PR number
 func (mp *DirectoryMapProvider) GetMapsForPR(pr int) (notesMap []*ReleaseNotesMap, err error) {
 	if mp.Maps == nil {
@@ -168,3 +200,160 @@ func (mp *DirectoryMapProvider) GetMapsForPR(pr int) (notesMap []*ReleaseNotesMa
 	}
 	return nil, nil
 }
+
+// ObjectStoreMapProvider is a provider that gets maps from an object store,
+// like a GCS bucket. Only the maps directly below Path are used, because
+// object stores do not have real directories. It uses the objectstore package
+// instead of the release-sdk object.Store, which can neither list nor read
+// objects and has no local implementation usable in tests.
+type ObjectStoreMapProvider struct {
+	Path  string
+	Store objectstore.Store
+	Maps  map[int][]*ReleaseNotesMap
+}
+
+// NewObjectStoreMapProvider creates a new ObjectStoreMapProvider for the path,
+// using the store matching its scheme.
+func NewObjectStoreMapProvider(storePath string) *ObjectStoreMapProvider {
+	return &ObjectStoreMapProvider{
+		Path:  storePath,
+		Store: objectstore.New(storePath),
+	}
+}
+
+// readMaps lists the maps in the object store and reads them once
+func (mp *ObjectStoreMapProvider) readMaps() error {
+	objects, err := mp.Store.List(mp.Path)
+	if err != nil {
+		return errors.Wrapf(err, "listing release notes maps in %s", mp.Path)
+	}
+
+	maps := map[int][]*ReleaseNotesMap{}
+	for _, object := range objects {
+		if ext := path.Ext(object); ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+		content, err := mp.Store.Read(object)
+		if err != nil {
+			return errors.Wrapf(err, "reading note map %s", object)
+		}
+		notemaps, err := decodeReleaseNotesMaps(bytes.NewReader(content))
+		if err != nil {
+			return errors.Wrapf(err, "while parsing note map in %s", object)
+		}
+		addMaps(maps, notemaps)
+	}
+	mp.Maps = maps
+	logrus.Infof("Successfully parsed release notes maps for %d PRs from %s", len(mp.Maps), mp.Path)
+	return nil
+}
+
+// GetMapsForPR get the release notes maps for a specific PR number
+func (mp *ObjectStoreMapProvider) GetMapsForPR(pr int) ([]*ReleaseNotesMap, error) {
+	if mp.Maps == nil {
+		if err := mp.readMaps(); err != nil {
+			return nil, errors.Wrap(err, "while reading release notes maps")
+		}
+	}
+	return mp.Maps[pr], nil
+}
+
+// GitMapProvider is a provider that gets maps from a directory of a git
+// repository at a pinned revision. The repository gets cloned into a
+// temporary directory on first use, which is removed again after the maps
+// have been read.
+type GitMapProvider struct {
+	// URL is the clone URL of the repository
+	URL string
+
+	// Path is the directory of the maps inside the repository
+	Path string
+
+	// Ref is the branch, tag or commit to be checked out. The default branch
+	// is used if empty.
+	Ref string
+
+	directory *DirectoryMapProvider
+}
+
+// NewGitMapProvider creates a new GitMapProvider from an init string in the
+// form `git+<url>[//<path>][@<ref>]`, for example
+// `git+https://github.com/kubernetes/sig-release//releases/release-1.23/release-notes/maps@master`.
+func NewGitMapProvider(initString string) (*GitMapProvider, error) {
+	repoURL := strings.TrimPrefix(initString, GitPrefix)
+
+	// Only consider the part after the host, because the user info of URLs
+	// like `ssh://git@github.com/org/repo` contains an `@` too
+	pathStart := 0
+	if i := strings.Index(repoURL, "://"); i >= 0 {
+		pathStart = i + len("://")
+		if j := strings.Index(repoURL[pathStart:], "/"); j >= 0 {
+			pathStart += j
+		}
+	}
+
+	mp := &GitMapProvider{}
+	if i := strings.LastIndex(repoURL[pathStart:], "@"); i >= 0 {
+		mp.Ref = repoURL[pathStart+i+1:]
+		repoURL = repoURL[:pathStart+i]
+	}
+	if i := strings.Index(repoURL[pathStart:], "//"); i >= 0 {
+		mp.Path = strings.Trim(repoURL[pathStart+i+2:], "/")
+		repoURL = repoURL[:pathStart+i]
+	}
+	mp.URL = repoURL
+
+	if pathStart == 0 || mp.URL == "" {
+		return nil, errors.Errorf("invalid git repository URL in %q", initString)
+	}
+	if strings.HasSuffix(initString, "@") {
+		return nil, errors.Errorf("empty git ref in %q", initString)
+	}
+	return mp, nil
+}
+
+// readMaps clones the repository and reads the maps of its directory
+func (mp *GitMapProvider) readMaps() error {
+	tempDir, err := os.MkdirTemp("", "release-notes-maps-")
+	if err != nil {
+		return errors.Wrap(err, "creating temporary clone directory")
+	}
+	defer os.RemoveAll(tempDir)
+
+	logrus.Infof("Cloning %s to read release notes maps", mp.URL)
+	repo, err := git.CloneOrOpenRepo(filepath.Join(tempDir, "repo"), mp.URL, false)
+	if err != nil {
+		return errors.Wrapf(err, "cloning %s", mp.URL)
+	}
+	if mp.Ref != "" {
+		if err := repo.Checkout(mp.Ref); err != nil {
+			return errors.Wrapf(err, "checking out %s of %s", mp.Ref, mp.URL)
+		}
+	}
+
+	dir := filepath.Join(repo.Dir(), filepath.FromSlash(mp.Path))
+	fileStat, err := os.Stat(dir)
+	if err != nil {
+		return errors.Wrapf(err, "release notes map path %s of %s", mp.Path, mp.URL)
+	}
+	if !fileStat.IsDir() {
+		return errors.Errorf("release notes map path %s of %s is not a directory", mp.Path, mp.URL)
+	}
+
+	directory := &DirectoryMapProvider{Path: dir}
+	if err := directory.readMaps(); err != nil {
+		return err
+	}
+	mp.directory = directory
+	return nil
+}
+
+// GetMapsForPR get the release notes maps for a specific PR number
+func (mp *GitMapProvider) GetMapsForPR(pr int) ([]*ReleaseNotesMap, error) {
+	if mp.directory == nil {
+		if err := mp.readMaps(); err != nil {
+			return nil, errors.Wrap(err, "while reading release notes maps")
+		}
+	}
+	return mp.directory.GetMapsForPR(pr)
+}
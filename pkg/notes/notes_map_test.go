@@ -17,8 +17,11 @@ limitations under the License.
 package notes
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/go-git/go-git/v5"
 	"github.com/stretchr/testify/require"
 )
 
@@ -29,7 +32,11 @@ func TestNewProviderFromInitString(t *testing.T) {
 	}{
 		{initString: "maps/testdata/unit/", returnsError: false},
 		{initString: "/this/shoud/not/really.exist/as/a/d33rect0ree", returnsError: true},
-		{initString: "gs://bucket-name/map/path/", returnsError: true},
+		{initString: "gs://bucket-name/map/path/", returnsError: false},
+		{initString: "file:///tmp/maps/", returnsError: false},
+		{initString: "git+https://github.com/kubernetes/sig-release//maps@master", returnsError: false},
+		{initString: "git+/no/scheme", returnsError: true},
+		{initString: "git+https://github.com/kubernetes/sig-release@", returnsError: true},
 		{initString: "github://kubernetes/sig-release/maps", returnsError: true},
 	}
 	for _, testCase := range testCases {
@@ -100,3 +107,158 @@ func TestReleaseNotesMapIntegrity(t *testing.T) {
 	require.NotNil(t, *testMap.ReleaseNote.ActionRequired)
 	require.Equal(t, false, *testMap.ReleaseNote.ActionRequired)
 }
+
+func TestNewGitMapProvider(t *testing.T) {
+	for _, tc := range []struct {
+		initString string
+		expected   *GitMapProvider
+	}{
+		{
+			initString: "git+https://github.com/kubernetes/sig-release",
+			expected:   &GitMapProvider{URL: "https://github.com/kubernetes/sig-release"},
+		},
+		{
+			initString: "git+https://github.com/kubernetes/sig-release@v1.0.0",
+			expected:   &GitMapProvider{URL: "https://github.com/kubernetes/sig-release", Ref: "v1.0.0"},
+		},
+		{
+			initString: "git+https://github.com/kubernetes/sig-release.git//releases/maps/@master",
+			expected: &GitMapProvider{
+				URL: "https://github.com/kubernetes/sig-release.git", Path: "releases/maps", Ref: "master",
+			},
+		},
+		{
+			initString: "git+ssh://git@github.com/kubernetes/sig-release//maps",
+			expected:   &GitMapProvider{URL: "ssh://git@github.com/kubernetes/sig-release", Path: "maps"},
+		},
+		{
+			initString: "git+file:///tmp/repo//maps@1a89038",
+			expected:   &GitMapProvider{URL: "file:///tmp/repo", Path: "maps", Ref: "1a89038"},
+		},
+	} {
+		provider, err := NewGitMapProvider(tc.initString)
+		require.Nil(t, err, tc.initString)
+		require.Equal(t, tc.expected, provider, tc.initString)
+	}
+}
+
+const (
+	testMapOne = `pr: 1
+releasenote:
+  text: First note
+`
+	testMapMulti = `pr: 1
+releasenote:
+  sigs: [node]
+---
+pr: 2
+releasenote:
+  text: Second note
+`
+)
+
+func TestObjectStoreMapProvider(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"one.yaml":        testMapOne,
+		"multi.yml":       testMapMulti,
+		"README.md":       "pr: 3",
+		"nested/two.yaml": "pr: 4",
+	} {
+		require.Nil(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+
+	provider, err := NewProviderFromInitString("file://" + dir)
+	require.Nil(t, err)
+	require.IsType(t, &ObjectStoreMapProvider{}, provider)
+
+	maps, err := provider.GetMapsForPR(1)
+	require.Nil(t, err)
+	require.Len(t, maps, 2)
+
+	// The maps are cached after the first read
+	require.Nil(t, os.RemoveAll(dir))
+	maps, err = provider.GetMapsForPR(2)
+	require.Nil(t, err)
+	require.Len(t, maps, 1)
+	require.Equal(t, "Second note", *maps[0].ReleaseNote.Text)
+
+	for _, pr := range []int{3, 4, 5} {
+		maps, err = provider.GetMapsForPR(pr)
+		require.Nil(t, err)
+		require.Empty(t, maps)
+	}
+}
+
+func TestObjectStoreMapProviderFailure(t *testing.T) {
+	dir := t.TempDir()
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "invalid.yaml"), []byte("pr: [1"), 0o644))
+
+	for _, initString := range []string{"file://" + dir, "file://" + filepath.Join(dir, "missing")} {
+		provider, err := NewProviderFromInitString(initString)
+		require.Nil(t, err)
+		_, err = provider.GetMapsForPR(1)
+		require.NotNil(t, err)
+	}
+}
+
+func TestGitMapProvider(t *testing.T) {
+	// The repository gets cloned into a temporary directory
+	tempDir := t.TempDir()
+	t.Setenv("TMPDIR", tempDir)
+	requireNoClones := func() {
+		clones, err := os.ReadDir(tempDir)
+		require.Nil(t, err)
+		require.Empty(t, clones)
+	}
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.Nil(t, err)
+	worktree, err := repo.Worktree()
+	require.Nil(t, err)
+
+	commitMap := func(content string) string {
+		require.Nil(t, os.MkdirAll(filepath.Join(dir, "maps"), 0o755))
+		require.Nil(t, os.WriteFile(filepath.Join(dir, "maps", "one.yaml"), []byte(content), 0o644))
+		_, err := worktree.Add("maps/one.yaml")
+		require.Nil(t, err)
+		return commitToTestRepo(t, repo, "update maps")
+	}
+	pinned := commitMap(testMapOne)
+	commitMap(testMapMulti)
+
+	for _, tc := range []struct {
+		ref         string
+		expected    string
+		expectedPR2 int
+	}{
+		{ref: "", expected: "", expectedPR2: 1},
+		{ref: "@" + pinned, expected: "First note", expectedPR2: 0},
+	} {
+		provider, err := NewProviderFromInitString("git+file://" + dir + "//maps" + tc.ref)
+		require.Nil(t, err)
+
+		maps, err := provider.GetMapsForPR(1)
+		require.Nil(t, err)
+		require.Len(t, maps, 1)
+		if tc.expected == "" {
+			require.Nil(t, maps[0].ReleaseNote.Text)
+		} else {
+			require.Equal(t, tc.expected, *maps[0].ReleaseNote.Text)
+		}
+
+		// The clone is removed, but the maps are kept
+		requireNoClones()
+		maps, err = provider.GetMapsForPR(2)
+		require.Nil(t, err)
+		require.Len(t, maps, tc.expectedPR2)
+	}
+
+	provider, err := NewProviderFromInitString("git+file://" + dir + "//missing")
+	require.Nil(t, err)
+	_, err = provider.GetMapsForPR(1)
+	require.NotNil(t, err)
+	requireNoClones()
+}
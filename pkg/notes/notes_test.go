@@ -18,15 +18,18 @@ package notes
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
 	"reflect"
 	"testing"
 
+	gogithub "github.com/google/go-github/v39/github"
 	"github.com/stretchr/testify/require"
 
 	kgithub "sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-sdk/github/githubfakes"
 )
 
 func githubClient(t *testing.T) (kgithub.Client, context.Context) {
@@ -211,6 +214,15 @@ func TestGetPRNumberFromCommitMessage(t *testing.T) {
 			commitMessage:    "Add swapoff to centos so kubelet starts (#504)",
 			expectedPRNumber: 504,
 		},
+		{
+			name: "Get merge request number from GitLab merge commit",
+			commitMessage: `Merge branch 'fix-typo' into 'main'
+
+Fix typo in README
+
+See merge request group/subgroup/project!42`,
+			expectedPRNumber: 42,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -496,3 +508,43 @@ func TestCapitalizeString(t *testing.T) {
 		require.Equal(t, tc.expected, result)
 	}
 }
+
+func TestReleaseNoteForPullRequest(t *testing.T) {
+	client := &githubfakes.FakeClient{}
+	client.GetPullRequestReturns(testPullRequest(5), nil, nil)
+	gatherer := NewGathererWithClient(context.Background(), client)
+	gatherer.options.GithubOrg = "org"
+	gatherer.options.GithubRepo = "repo"
+
+	note, pr, err := gatherer.ReleaseNoteForPullRequest(5)
+	require.Nil(t, err)
+	require.Equal(t, 5, pr.GetNumber())
+	require.Equal(t, "Note of #5", note.Text)
+	require.Equal(t, []string{"feature"}, note.Kinds)
+	require.Equal(t, []string{"release"}, note.SIGs)
+	_, org, repo, number := client.GetPullRequestArgsForCall(0)
+	require.Equal(t, []interface{}{"org", "repo", 5}, []interface{}{org, repo, number})
+
+	client.GetPullRequestReturns(nil, nil, errors.New("error"))
+	_, _, err = gatherer.ReleaseNoteForPullRequest(5)
+	require.NotNil(t, err)
+}
+
+func TestPullRequestNumbers(t *testing.T) {
+	client := &githubfakes.FakeClient{}
+	client.GetCommitReturns(&gogithub.Commit{}, nil, nil)
+	client.ListCommitsReturns([]*gogithub.RepositoryCommit{
+		{SHA: gogithub.String("1"), Commit: &gogithub.Commit{Message: gogithub.String("Merge pull request #20 from org/branch")}},
+		{SHA: gogithub.String("2"), Commit: &gogithub.Commit{Message: gogithub.String("Update docs")}},
+		{SHA: gogithub.String("3"), Commit: &gogithub.Commit{Message: gogithub.String("Fix the flag (#10)")}},
+	}, &gogithub.Response{}, nil)
+	gatherer := NewGathererWithClient(context.Background(), client)
+
+	prs, err := gatherer.PullRequestNumbers()
+	require.Nil(t, err)
+	require.Equal(t, []int{10, 20}, prs)
+
+	client.ListCommitsReturns(nil, nil, errors.New("error"))
+	_, err = gatherer.PullRequestNumbers()
+	require.NotNil(t, err)
+}
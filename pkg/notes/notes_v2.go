@@ -31,6 +31,7 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	gitobject "github.com/go-git/go-git/v5/plumbing/object"
+	gogithub "github.com/google/go-github/v39/github"
 	"github.com/mattn/go-isatty"
 	"github.com/nozzle/throttler"
 	"github.com/sirupsen/logrus"
@@ -39,6 +40,9 @@ import (
 type commitPrPair struct {
 	Commit *gitobject.Commit
 	PrNum  int
+
+	// PullRequest is set if it has been fetched by the batch client
+	PullRequest *gogithub.PullRequest
 }
 
 type releaseNotesAggregator struct {
@@ -53,6 +57,10 @@ func (g *Gatherer) ListReleaseNotesV2() (*ReleaseNotes, error) {
 		return nil, errors.Wrap(err, "listing offline commits")
 	}
 
+	if err := g.fetchPullRequests(pairs); err != nil {
+		return nil, errors.Wrap(err, "fetching pull requests")
+	}
+
 	// load map providers specified in options
 	mapProviders := []MapProvider{}
 	for _, initString := range g.options.MapProviderStrings {
@@ -68,6 +76,7 @@ func (g *Gatherer) ListReleaseNotesV2() (*ReleaseNotes, error) {
 	aggregator := releaseNotesAggregator{
 		releaseNotes: NewReleaseNotes(),
 	}
+	aggregator.releaseNotes.SetConfig(g.Config())
 
 	pairsCount := len(pairs)
 	logrus.Infof("processing release notes for %d commits", pairsCount)
@@ -141,19 +150,54 @@ func (g *Gatherer) ListReleaseNotesV2() (*ReleaseNotes, error) {
 	return aggregator.releaseNotes, nil
 }
 
-func (g *Gatherer) buildReleaseNote(pair *commitPrPair) (*ReleaseNote, error) {
-	pr, _, err := g.client.GetPullRequest(g.context, g.options.GithubOrg, g.options.GithubRepo, pair.PrNum)
+// fetchPullRequests sets the pull requests of all pairs using the batch
+// client, which needs only a fraction of the API requests compared to
+// fetching every pull request on its own. Pairs are left unchanged if there
+// is no batch client.
+func (g *Gatherer) fetchPullRequests(pairs []*commitPrPair) error {
+	if g.batchClient == nil || len(pairs) == 0 {
+		return nil
+	}
+
+	numbers := []int{}
+	for _, pair := range pairs {
+		numbers = append(numbers, pair.PrNum)
+	}
+
+	logrus.Infof("fetching %d pull requests in batches", len(numbers))
+	startTime := time.Now()
+	prs, err := g.batchClient.GetPullRequests(
+		g.context, g.options.GithubOrg, g.options.GithubRepo, numbers,
+	)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	logrus.Infof("fetched %d pull requests in %v", len(prs), time.Since(startTime))
+
+	for _, pair := range pairs {
+		pair.PullRequest = prs[pair.PrNum]
+	}
+	return nil
+}
+
+func (g *Gatherer) buildReleaseNote(pair *commitPrPair) (*ReleaseNote, error) {
+	pr := pair.PullRequest
+	if pr == nil {
+		var err error
+		pr, _, err = g.client.GetPullRequest(g.context, g.options.GithubOrg, g.options.GithubRepo, pair.PrNum)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	prBody := pr.GetBody()
 
-	if MatchesExcludeFilter(prBody) {
+	config := g.Config()
+	if config.MatchesExcludeFilter(prBody) {
 		return nil, nil
 	}
 
-	text, err := noteTextFromString(prBody)
+	text, err := config.NoteText(prBody)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"sha": pair.Commit.Hash.String(),
@@ -167,16 +211,16 @@ func (g *Gatherer) buildReleaseNote(pair *commitPrPair) (*ReleaseNote, error) {
 	author := pr.GetUser().GetLogin()
 	authorURL := pr.GetUser().GetHTMLURL()
 	prURL := pr.GetHTMLURL()
-	isFeature := hasString(labelsWithPrefix(pr, "kind"), "feature")
-	noteSuffix := prettifySIGList(labelsWithPrefix(pr, "sig"))
+	isFeature := hasString(labelsWithPrefix(pr, config.Labels.Kind), string(config.Kinds.Feature))
+	noteSuffix := prettifySIGList(labelsWithPrefix(pr, config.Labels.SIG))
 
 	isDuplicateSIG := false
-	if len(labelsWithPrefix(pr, "sig")) > 1 {
+	if len(labelsWithPrefix(pr, config.Labels.SIG)) > 1 {
 		isDuplicateSIG = true
 	}
 
 	isDuplicateKind := false
-	if len(labelsWithPrefix(pr, "kind")) > 1 {
+	if len(labelsWithPrefix(pr, config.Labels.Kind)) > 1 {
 		isDuplicateKind = true
 	}
 
@@ -205,14 +249,14 @@ func (g *Gatherer) buildReleaseNote(pair *commitPrPair) (*ReleaseNote, error) {
 		AuthorURL:      authorURL,
 		PrURL:          prURL,
 		PrNumber:       pr.GetNumber(),
-		SIGs:           labelsWithPrefix(pr, "sig"),
-		Kinds:          labelsWithPrefix(pr, "kind"),
-		Areas:          labelsWithPrefix(pr, "area"),
+		SIGs:           labelsWithPrefix(pr, config.Labels.SIG),
+		Kinds:          labelsWithPrefix(pr, config.Labels.Kind),
+		Areas:          labelsWithPrefix(pr, config.Labels.Area),
 		Feature:        isFeature,
 		Duplicate:      isDuplicateSIG,
 		DuplicateKind:  isDuplicateKind,
-		ActionRequired: labelExactMatch(pr, "release-note-action-required"),
-		DoNotPublish:   labelExactMatch(pr, "release-note-none"),
+		ActionRequired: labelExactMatch(pr, config.Labels.ActionRequired),
+		DoNotPublish:   labelExactMatch(pr, config.Labels.DoNotPublish),
 	}, nil
 }
 
@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	gogithub "github.com/google/go-github/v39/github"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/notes/forge/forgefakes"
+	"sigs.k8s.io/release-sdk/github/githubfakes"
+)
+
+func testPullRequest(number int) *gogithub.PullRequest {
+	return &gogithub.PullRequest{
+		Number:  gogithub.Int(number),
+		Body:    gogithub.String(fmt.Sprintf("```release-note\nNote of #%d\n```", number)),
+		HTMLURL: gogithub.String(fmt.Sprintf("https://github.com/org/repo/pull/%d", number)),
+		User: &gogithub.User{
+			Login:   gogithub.String("jane"),
+			HTMLURL: gogithub.String("https://github.com/jane"),
+		},
+		Labels: []*gogithub.Label{
+			{Name: gogithub.String("kind/feature")},
+			{Name: gogithub.String("sig/release")},
+		},
+	}
+}
+
+func TestListReleaseNotesV2BatchClient(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.Nil(t, err)
+
+	start := commitToTestRepo(t, repo, "Initial commit")
+	commitToTestRepo(t, repo, "Add a flag (#1)")
+	commitToTestRepo(t, repo, "No pull request")
+	commitToTestRepo(t, repo, "Merge pull request #2 from jane/branch")
+	end := commitToTestRepo(t, repo, "Fix a bug (#3)")
+
+	newGatherer := func(client *githubfakes.FakeClient) *Gatherer {
+		gatherer := NewGathererWithClient(context.Background(), client)
+		gatherer.options.RepoPath = dir
+		gatherer.options.StartSHA = start
+		gatherer.options.EndSHA = end
+		gatherer.options.GithubOrg = "org"
+		gatherer.options.GithubRepo = "repo"
+		return gatherer
+	}
+
+	// REST API only
+	restClient := &githubfakes.FakeClient{}
+	restClient.GetPullRequestCalls(func(
+		_ context.Context, _, _ string, number int,
+	) (*gogithub.PullRequest, *gogithub.Response, error) {
+		return testPullRequest(number), nil, nil
+	})
+	expected, err := newGatherer(restClient).ListReleaseNotesV2()
+	require.Nil(t, err)
+	require.Equal(t, 3, restClient.GetPullRequestCallCount())
+	require.Len(t, expected.History(), 3)
+
+	// Batched pull requests, where #2 is missing and fetched via REST
+	restClient = &githubfakes.FakeClient{}
+	restClient.GetPullRequestReturns(testPullRequest(2), nil, nil)
+	batchClient := &forgefakes.FakeBatchProvider{}
+	batchClient.GetPullRequestsReturns(map[int]*gogithub.PullRequest{
+		1: testPullRequest(1),
+		3: testPullRequest(3),
+	}, nil)
+
+	gatherer := newGatherer(restClient)
+	gatherer.batchClient = batchClient
+	res, err := gatherer.ListReleaseNotesV2()
+	require.Nil(t, err)
+	require.Equal(t, 1, batchClient.GetPullRequestsCallCount())
+	_, owner, repoName, numbers := batchClient.GetPullRequestsArgsForCall(0)
+	require.Equal(t, "org", owner)
+	require.Equal(t, "repo", repoName)
+	require.ElementsMatch(t, []int{1, 2, 3}, numbers)
+	require.Equal(t, 1, restClient.GetPullRequestCallCount())
+
+	for _, number := range []int{1, 2, 3} {
+		require.Equal(t, expected.Get(number), res.Get(number))
+	}
+	require.Equal(t, "Note of #1 (#1, @jane) [SIG Release]", res.Get(1).Markdown)
+
+	// Batch failure
+	batchClient.GetPullRequestsReturns(nil, errors.New("error"))
+	_, err = gatherer.ListReleaseNotesV2()
+	require.NotNil(t, err)
+}
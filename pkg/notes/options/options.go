@@ -17,12 +17,15 @@ limitations under the License.
 package options
 
 import (
+	"net/http"
 	"os"
 	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
+	"k8s.io/release/pkg/notes/cache"
+	"k8s.io/release/pkg/notes/forge"
 	"sigs.k8s.io/release-sdk/git"
 	"sigs.k8s.io/release-sdk/github"
 )
@@ -69,10 +72,10 @@ type Options struct {
 	EndRev string
 
 	// Format specifies the format of the release notes. Can be either
-	// `json` or `markdown`.
+	// `json`, `markdown`, `asciidoc`, `rst`, `html` or `yaml`.
 	Format string
 
-	// If the `Format` is `markdown`, then this specifies the selected go
+	// If the `Format` is not `json`, then this specifies the selected go
 	// template. Can be `go-template:default`, `go-template:<file.template>` or
 	// `go-template:inline:<template>`.
 	GoTemplate string
@@ -108,6 +111,18 @@ type Options struct {
 	// EXPERIMENTAL: Feature flag for using v2 implementation to list commits
 	ListReleaseNotesV2 bool
 
+	// Source specifies where the release notes are taken from. Can be either
+	// SourcePullRequests (default) or SourceConventionalCommits.
+	Source string
+
+	// Forge specifies the platform hosting the repository. Can be either
+	// forge.GitHub (default), forge.GitLab or forge.Gitea.
+	Forge string
+
+	// ForgeURL specifies the base URL of the GitLab or Gitea instance, for
+	// example https://gitlab.example.com. Defaults to the public instance.
+	ForgeURL string
+
 	// RecordDir specifies the directory for API call recordings. Cannot be
 	// used together with ReplayDir.
 	RecordDir string
@@ -116,8 +131,18 @@ type Options struct {
 	// API. Cannot be used together with RecordDir.
 	ReplayDir string
 
+	// CacheDir specifies the directory for caching the API responses between
+	// runs. Responses of commits are reused, all other ones get revalidated.
+	// Not used together with ReplayDir.
+	CacheDir string
+
 	githubToken string
-	gitCloneFn  func(string, string, string, bool) (*git.Repo, error)
+	gitCloneFn  func(string, string, bool) (*git.Repo, error)
+
+	// ConfigFile is the path to the release notes configuration, which
+	// defines how notes get extracted and categorized. Uses the Kubernetes
+	// conventions if not set.
+	ConfigFile string
 
 	// MapProviders list of release notes map providers to query during generations
 	MapProviderStrings []string
@@ -128,6 +153,18 @@ type Options struct {
 	AddMarkdownLinks bool
 }
 
+const (
+	// SourcePullRequests extracts the release notes from the descriptions of
+	// the pull requests, which requires access to the GitHub API.
+	SourcePullRequests = "pull-requests"
+
+	// SourceConventionalCommits builds the release notes from the commit
+	// messages of the local repository, which have to follow the
+	// Conventional Commits specification. The notes get enriched with pull
+	// request data if the GitHub API is available.
+	SourceConventionalCommits = "conventional-commits"
+)
+
 type RevisionDiscoveryMode string
 
 const (
@@ -141,6 +178,10 @@ const (
 const (
 	FormatJSON     = "json"
 	FormatMarkdown = "markdown"
+	FormatAsciiDoc = "asciidoc"
+	FormatRST      = "rst"
+	FormatHTML     = "html"
+	FormatYAML     = "yaml"
 
 	GoTemplatePrefix       = "go-template:"
 	GoTemplatePrefixInline = "inline:"
@@ -156,8 +197,10 @@ func New() *Options {
 		GithubRepo:         git.DefaultGithubRepo,
 		Format:             FormatMarkdown,
 		GoTemplate:         GoTemplateDefault,
+		Source:             SourcePullRequests,
+		Forge:              forge.GitHub,
 		Pull:               true,
-		gitCloneFn:         git.CloneOrOpenGitHubRepo,
+		gitCloneFn:         git.CloneOrOpenRepo,
 		MapProviderStrings: []string{},
 		AddMarkdownLinks:   false,
 	}
@@ -176,20 +219,35 @@ func (o *Options) ValidateAndFinish() (err error) {
 		return errors.New("please do not use record and replay together")
 	}
 
+	if o.Source == "" {
+		o.Source = SourcePullRequests
+	}
+	if o.Source != SourcePullRequests && o.Source != SourceConventionalCommits {
+		return errors.Errorf("invalid source: %s", o.Source)
+	}
+
+	if o.Forge == "" {
+		o.Forge = forge.GitHub
+	}
+	if !forge.IsValid(o.Forge) {
+		return errors.Errorf("invalid forge: %s", o.Forge)
+	}
+
 	// Recover for replay if needed
 	if o.ReplayDir != "" {
 		logrus.Info("Using replay mode")
 		return nil
 	}
 
-	// The GitHub Token is required if replay is not specified
-	token, ok := os.LookupEnv(github.TokenEnvKey)
+	// The API token of the forge is required if replay is not specified
+	tokenEnvKey := forge.TokenEnvKey(o.Forge)
+	token, ok := os.LookupEnv(tokenEnvKey)
 	if ok {
 		o.githubToken = token
-	} else if o.ReplayDir == "" {
+	} else if o.Source != SourceConventionalCommits {
 		return errors.Errorf(
 			"neither environment variable `%s` nor `replay` option is set",
-			github.TokenEnvKey,
+			tokenEnvKey,
 		)
 	}
 
@@ -253,11 +311,47 @@ func (o *Options) ValidateAndFinish() (err error) {
 	return nil
 }
 
+// ValidateAndFinishClient checks and completes only the options required
+// for creating the API client, like the forge and its token. It can be used
+// instead of ValidateAndFinish if no revision range is required.
+func (o *Options) ValidateAndFinishClient() error {
+	if o.Debug {
+		logrus.SetLevel(logrus.DebugLevel)
+	}
+
+	if o.Forge == "" {
+		o.Forge = forge.GitHub
+	}
+	if !forge.IsValid(o.Forge) {
+		return errors.Errorf("invalid forge: %s", o.Forge)
+	}
+
+	if o.ReplayDir != "" {
+		return nil
+	}
+
+	tokenEnvKey := forge.TokenEnvKey(o.Forge)
+	token, ok := os.LookupEnv(tokenEnvKey)
+	if !ok {
+		return errors.Errorf(
+			"neither environment variable `%s` nor `replay` option is set",
+			tokenEnvKey,
+		)
+	}
+	o.githubToken = token
+	return nil
+}
+
 // checkFormatOptions verifies that template related options are sane
 func (o *Options) checkFormatOptions() error {
 	// Validate the output format and template
 	logrus.Infof("Using output format: %s", o.Format)
-	if o.Format == FormatMarkdown && o.GoTemplate != GoTemplateDefault {
+	switch o.Format {
+	case FormatJSON, FormatMarkdown, FormatAsciiDoc, FormatRST, FormatHTML, FormatYAML:
+	default:
+		return errors.Errorf("invalid format: %s", o.Format)
+	}
+	if o.Format != FormatJSON && o.GoTemplate != GoTemplateDefault {
 		if !strings.HasPrefix(o.GoTemplate, GoTemplatePrefix) {
 			return errors.Errorf("go template has to be prefixed with %q", GoTemplatePrefix)
 		}
@@ -277,9 +371,6 @@ func (o *Options) checkFormatOptions() error {
 	if o.Format == FormatJSON && o.GoTemplate != GoTemplateDefault {
 		return errors.New("go-template cannot be defined when in JSON mode")
 	}
-	if o.Format != FormatJSON && o.Format != FormatMarkdown {
-		return errors.Errorf("invalid format: %s", o.Format)
-	}
 	return nil
 }
 
@@ -319,13 +410,9 @@ func (o *Options) resolveDiscoverMode() error {
 
 func (o *Options) repo() (repo *git.Repo, err error) {
 	if o.Pull {
-		logrus.Infof("Cloning/updating repository %s/%s", o.GithubOrg, o.GithubRepo)
-		repo, err = o.gitCloneFn(
-			o.RepoPath,
-			o.GithubOrg,
-			o.GithubRepo,
-			false,
-		)
+		repoURL := forge.RepoURL(o.Forge, o.ForgeURL, o.GithubOrg, o.GithubRepo)
+		logrus.Infof("Cloning/updating repository %s", repoURL)
+		repo, err = o.gitCloneFn(o.RepoPath, repoURL, false)
 	} else {
 		logrus.Infof("Re-using local repo %s", o.RepoPath)
 		repo, err = git.OpenRepo(o.RepoPath)
@@ -336,16 +423,45 @@ func (o *Options) repo() (repo *git.Repo, err error) {
 	return repo, nil
 }
 
+// GitHubAccess returns true if the GitHub API can be used, which is the case
+// if a token has been set or a previous recording gets replayed.
+func (o *Options) GitHubAccess() bool {
+	return o.githubToken != "" || o.ReplayDir != ""
+}
+
 // Client returns a Client to be used by the Gatherer. Depending on
-// the provided options this is either a real client talking to the GitHub API,
-// a Client which in addition records the responses from Github and stores them
-// on disk, or a Client that replays those pre-recorded responses and does not
-// talk to the GitHub API at all.
+// the provided options this is either a real client talking to the API of the
+// forge, a Client which in addition records the responses from the forge and
+// stores them on disk, or a Client that replays those pre-recorded responses
+// and does not talk to the forge API at all. Real clients use the response
+// cache if CacheDir is set.
 func (o *Options) Client() (github.Client, error) {
 	if o.ReplayDir != "" {
 		return github.NewReplayer(o.ReplayDir), nil
 	}
 
+	isGitHub := o.Forge == "" || o.Forge == forge.GitHub
+	if !isGitHub || o.CacheDir != "" {
+		var httpClient *http.Client
+		if o.CacheDir != "" {
+			logrus.Infof("Using API response cache in %s", o.CacheDir)
+			httpClient = cache.NewTransport(o.CacheDir, nil).Client()
+		}
+
+		forgeName, baseURL := o.Forge, o.ForgeURL
+		if isGitHub {
+			forgeName, baseURL = forge.GitHub, o.GithubBaseURL
+		}
+		client, err := forge.New(forgeName, baseURL, o.githubToken, httpClient)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to create %s client", forgeName)
+		}
+		if o.RecordDir != "" {
+			return github.NewRecorder(client, o.RecordDir), nil
+		}
+		return client, nil
+	}
+
 	var gh *github.GitHub
 	var err error
 	// Create a real GitHub API client
@@ -364,3 +480,17 @@ func (o *Options) Client() (github.Client, error) {
 
 	return gh.Client(), nil
 }
+
+// BatchClient returns a client for fetching many pull requests at once using
+// the GitHub GraphQL API. It returns nil if the API cannot be used, which is
+// the case for other forges, without a token, or if the API gets recorded or
+// replayed, because the recordings only contain the REST API. It returns nil
+// as well if CacheDir is set, because the GraphQL responses cannot be cached
+// and revalidated like the REST ones.
+func (o *Options) BatchClient() forge.BatchProvider {
+	if (o.Forge != "" && o.Forge != forge.GitHub) || o.githubToken == "" ||
+		o.RecordDir != "" || o.ReplayDir != "" || o.CacheDir != "" {
+		return nil
+	}
+	return forge.NewGraphQL(o.GithubBaseURL, o.githubToken, nil)
+}
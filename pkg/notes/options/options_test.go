@@ -29,6 +29,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 
+	"k8s.io/release/pkg/notes/forge"
 	"sigs.k8s.io/release-sdk/github"
 	"sigs.k8s.io/release-utils/command"
 
@@ -66,7 +67,7 @@ func newTestOptions(t *testing.T) *testOptions {
 			Format:       FormatMarkdown,
 			GoTemplate:   GoTemplateDefault,
 			Pull:         true,
-			gitCloneFn: func(string, string, string, bool) (*kgit.Repo, error) {
+			gitCloneFn: func(string, string, bool) (*kgit.Repo, error) {
 				return testRepo.sut, nil
 			},
 		},
@@ -280,7 +281,7 @@ func TestValidateAndFinishFailureClone(t *testing.T) {
 	defer options.testRepo.cleanup(t)
 
 	options.StartRev = options.testRepo.firstTagName
-	options.gitCloneFn = func(string, string, string, bool) (*kgit.Repo, error) {
+	options.gitCloneFn = func(string, string, bool) (*kgit.Repo, error) {
 		return nil, errors.New("error")
 	}
 	options.StartSHA = ""
@@ -297,6 +298,29 @@ func TestValidateAndFinishSuccessStartRev(t *testing.T) {
 	require.Nil(t, options.ValidateAndFinish())
 }
 
+func TestValidateAndFinishSuccessStartRevGitLab(t *testing.T) {
+	options := newTestOptions(t)
+	defer options.testRepo.cleanup(t)
+	require.Nil(t, os.Setenv(forge.GitLabTokenEnvKey, "token"))
+	defer os.Unsetenv(forge.GitLabTokenEnvKey)
+
+	repoURL := ""
+	options.gitCloneFn = func(_, url string, _ bool) (*kgit.Repo, error) {
+		repoURL = url
+		return options.testRepo.sut, nil
+	}
+	options.Forge = forge.GitLab
+	options.ForgeURL = "https://gitlab.example.com/"
+	options.GithubOrg = "group"
+	options.GithubRepo = "project"
+	options.StartRev = options.testRepo.firstTagName
+	options.StartSHA = ""
+
+	require.Nil(t, options.ValidateAndFinish())
+	require.Equal(t, "https://gitlab.example.com/group/project.git", repoURL)
+	require.Equal(t, options.testRepo.firstCommit, options.StartSHA)
+}
+
 func TestValidateAndFinishFailureStartRevNotExisting(t *testing.T) {
 	options := newTestOptions(t)
 	defer options.testRepo.cleanup(t)
@@ -352,7 +376,7 @@ func TestValidateAndFinishFailureDiscoveryModeMergeBaseToLatestClone(t *testing.
 	defer options.testRepo.cleanup(t)
 
 	options.DiscoverMode = RevisionDiscoveryModeMergeBaseToLatest
-	options.gitCloneFn = func(string, string, string, bool) (*kgit.Repo, error) {
+	options.gitCloneFn = func(string, string, bool) (*kgit.Repo, error) {
 		return nil, errors.New("error")
 	}
 	require.NotNil(t, options.ValidateAndFinish())
@@ -391,6 +415,28 @@ func TestValidateAndFinishFailureFormat(t *testing.T) {
 	require.NotNil(t, options.ValidateAndFinish())
 }
 
+func TestValidateAndFinishFormats(t *testing.T) {
+	for _, format := range []string{
+		FormatAsciiDoc, FormatRST, FormatHTML, FormatYAML,
+	} {
+		options := newTestOptions(t)
+		defer options.testRepo.cleanup(t)
+
+		// Given
+		options.Format = format
+		options.GoTemplate = GoTemplateInline + "{{ .CurrentRevision }}"
+
+		// When
+		require.Nil(t, options.ValidateAndFinish(), format)
+
+		// Given
+		options.GoTemplate = GoTemplatePrefix + "/does/not/exist"
+
+		// When
+		require.NotNil(t, options.ValidateAndFinish(), format)
+	}
+}
+
 func TestValidateAndFinishFailureGoTemplate(t *testing.T) {
 	options := newTestOptions(t)
 	defer options.testRepo.cleanup(t)
@@ -401,3 +447,94 @@ func TestValidateAndFinishFailureGoTemplate(t *testing.T) {
 	// When
 	require.NotNil(t, options.ValidateAndFinish())
 }
+
+func TestValidateAndFinishFailureForge(t *testing.T) {
+	options := newTestOptions(t)
+	defer options.testRepo.cleanup(t)
+
+	// Given
+	options.Forge = "wrong"
+
+	// When
+	require.NotNil(t, options.ValidateAndFinish())
+}
+
+func TestValidateAndFinishForgeToken(t *testing.T) {
+	options := newTestOptions(t)
+	defer options.testRepo.cleanup(t)
+
+	// Given
+	options.Forge = forge.GitLab
+	require.Nil(t, os.Unsetenv(forge.GitLabTokenEnvKey))
+
+	// When
+	require.NotNil(t, options.ValidateAndFinish())
+
+	// Given
+	require.Nil(t, os.Setenv(forge.GitLabTokenEnvKey, "token"))
+	defer os.Unsetenv(forge.GitLabTokenEnvKey)
+
+	// When
+	require.Nil(t, options.ValidateAndFinish())
+	require.Equal(t, "token", options.githubToken)
+}
+
+func TestValidateAndFinishClient(t *testing.T) {
+	options := &Options{Forge: forge.GitLab}
+	require.Nil(t, os.Unsetenv(forge.GitLabTokenEnvKey))
+	require.NotNil(t, options.ValidateAndFinishClient())
+
+	require.Nil(t, os.Setenv(forge.GitLabTokenEnvKey, "token"))
+	defer os.Unsetenv(forge.GitLabTokenEnvKey)
+	require.Nil(t, options.ValidateAndFinishClient())
+	require.Equal(t, "token", options.githubToken)
+
+	require.Nil(t, (&Options{ReplayDir: "dir"}).ValidateAndFinishClient())
+	require.NotNil(t, (&Options{Forge: "wrong"}).ValidateAndFinishClient())
+}
+
+func TestClientCache(t *testing.T) {
+	for _, tc := range []struct {
+		options  *Options
+		expected interface{}
+	}{
+		{options: &Options{Forge: forge.GitHub, CacheDir: t.TempDir()}, expected: &forge.GitHubClient{}},
+		{options: &Options{CacheDir: t.TempDir()}, expected: &forge.GitHubClient{}},
+		{options: &Options{Forge: forge.GitLab, CacheDir: t.TempDir()}, expected: &forge.GitLabClient{}},
+		{options: &Options{Forge: forge.Gitea}, expected: &forge.GiteaClient{}},
+	} {
+		client, err := tc.options.Client()
+		require.Nil(t, err)
+		require.IsType(t, tc.expected, client)
+	}
+}
+
+func TestBatchClient(t *testing.T) {
+	for _, tc := range []struct {
+		options  *Options
+		expected bool
+	}{
+		{options: &Options{githubToken: "token"}, expected: true},
+		{options: &Options{Forge: forge.GitHub, githubToken: "token", CacheDir: "dir"}, expected: false},
+		{options: &Options{Forge: forge.GitHub}, expected: false},
+		{options: &Options{Forge: forge.GitLab, githubToken: "token"}, expected: false},
+		{options: &Options{githubToken: "token", RecordDir: "dir"}, expected: false},
+		{options: &Options{ReplayDir: "dir"}, expected: false},
+	} {
+		require.Equal(t, tc.expected, tc.options.BatchClient() != nil)
+	}
+}
+
+func TestListReleaseNotesV2WithCache(t *testing.T) {
+	opts := &Options{
+		ListReleaseNotesV2: true,
+		CacheDir:           t.TempDir(),
+		githubToken:        "token",
+	}
+
+	// The pull requests get fetched by the cached REST client
+	require.Nil(t, opts.BatchClient())
+	client, err := opts.Client()
+	require.Nil(t, err)
+	require.IsType(t, &forge.GitHubClient{}, client)
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/pkg/errors"
+	"google.golang.org/api/iterator"
+)
+
+// GCS is a Store implementation using the native Google Cloud Storage client
+type GCS struct {
+	client *storage.Client
+}
+
+// NewGCS creates a new GCS store. The storage client gets initialized on
+// first use.
+func NewGCS() *GCS {
+	return &GCS{}
+}
+
+// NewGCSWithClient creates a new GCS store using the provided storage client.
+func NewGCSWithClient(client *storage.Client) *GCS {
+	return &GCS{client: client}
+}
+
+func (g *GCS) storageClient() (*storage.Client, error) {
+	if g.client != nil {
+		return g.client, nil
+	}
+	client, err := storage.NewClient(context.Background())
+	if err != nil {
+		return nil, errors.Wrap(err,
+			"fetching gcloud credentials, try running "+
+				`"gcloud auth application-default login"`,
+		)
+	}
+	g.client = client
+	return client, nil
+}
+
+func (g *GCS) object(objectPath string) (*storage.ObjectHandle, error) {
+	bucket, key, err := SplitPath(objectPath)
+	if err != nil {
+		return nil, err
+	}
+	if key == "" {
+		return nil, errors.Errorf("no object key in path %q", objectPath)
+	}
+	client, err := g.storageClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.Bucket(bucket).Object(key), nil
+}
+
+// NormalizePath joins the path parts and ensures the `gs://` prefix.
+func (g *GCS) NormalizePath(pathParts ...string) (string, error) {
+	return normalizePath(GCSPrefix, pathParts...)
+}
+
+// PathExists returns true if the GCS path is an object or a directory
+// containing at least one object.
+func (g *GCS) PathExists(objectPath string) (bool, error) {
+	bucket, key, err := SplitPath(objectPath)
+	if err != nil {
+		return false, err
+	}
+	client, err := g.storageClient()
+	if err != nil {
+		return false, err
+	}
+	ctx := context.Background()
+
+	if key != "" {
+		_, err := client.Bucket(bucket).Object(key).Attrs(ctx)
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, storage.ErrObjectNotExist) {
+			return false, errors.Wrapf(err, "get attributes of %s", objectPath)
+		}
+		key += "/"
+	}
+
+	it := client.Bucket(bucket).Objects(ctx, &storage.Query{Prefix: key})
+	if _, err := it.Next(); err != nil {
+		if errors.Is(err, iterator.Done) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "list objects in %s", objectPath)
+	}
+	return true, nil
+}
+
+// List returns the paths of all objects directly below the GCS path.
+func (g *GCS) List(objectPath string) ([]string, error) {
+	bucket, key, err := SplitPath(objectPath)
+	if err != nil {
+		return nil, err
+	}
+	client, err := g.storageClient()
+	if err != nil {
+		return nil, err
+	}
+	if key != "" && !strings.HasSuffix(key, "/") {
+		key += "/"
+	}
+
+	res := []string{}
+	it := client.Bucket(bucket).Objects(
+		context.Background(), &storage.Query{Prefix: key, Delimiter: "/"},
+	)
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "list objects in %s", objectPath)
+		}
+		// Prefixes are sub directories
+		if attrs.Name == "" {
+			continue
+		}
+		res = append(res, GCSPrefix+path.Join(bucket, attrs.Name))
+	}
+	return res, nil
+}
+
+// Read returns the content of the GCS object.
+func (g *GCS) Read(objectPath string) ([]byte, error) {
+	reader, err := g.Open(objectPath)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read %s", objectPath)
+	}
+	return content, nil
+}
+
+// Open returns a reader for the content of the GCS object.
+func (g *GCS) Open(objectPath string) (io.ReadCloser, error) {
+	obj, err := g.object(objectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := obj.NewReader(context.Background())
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, errors.Wrapf(ErrNotExist, "read %s", objectPath)
+		}
+		return nil, errors.Wrapf(err, "open %s", objectPath)
+	}
+	return reader, nil
+}
+
+// Write uploads content to the GCS object.
+func (g *GCS) Write(objectPath string, content []byte, attrs *Attributes) error {
+	obj, err := g.object(objectPath)
+	if err != nil {
+		return err
+	}
+
+	writer := obj.NewWriter(context.Background())
+	if attrs != nil {
+		writer.ContentType = attrs.ContentType
+		writer.CacheControl = attrs.CacheControl
+	}
+	if _, err := writer.Write(content); err != nil {
+		writer.Close()
+		return errors.Wrapf(err, "write %s", objectPath)
+	}
+	if err := writer.Close(); err != nil {
+		return errors.Wrapf(err, "finish writing %s", objectPath)
+	}
+	return nil
+}
+
+// MakePublic grants all users read access to the GCS object.
+func (g *GCS) MakePublic(objectPath string) error {
+	obj, err := g.object(objectPath)
+	if err != nil {
+		return err
+	}
+	if err := obj.ACL().Set(
+		context.Background(), storage.AllUsers, storage.RoleReader,
+	); err != nil {
+		return errors.Wrapf(err, "set public ACL on %s", objectPath)
+	}
+	return nil
+}
+
+// PublicURL returns the storage.googleapis.com URL of the GCS object.
+func (g *GCS) PublicURL(objectPath string) string {
+	bucket, key, err := SplitPath(objectPath)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("https://storage.googleapis.com/%s/%s", bucket, key)
+}
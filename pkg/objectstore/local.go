@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstore
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// Local is a Store implementation backed by the local filesystem, which is
+// useful for testing and for publishing to mounted volumes.
+type Local struct{}
+
+// NewLocal creates a new local filesystem store.
+func NewLocal() *Local {
+	return &Local{}
+}
+
+// NormalizePath joins the path parts to an absolute path and ensures the
+// `file://` prefix.
+func (l *Local) NormalizePath(pathParts ...string) (string, error) {
+	if _, err := normalizePath(FilePrefix, pathParts...); err != nil {
+		return "", err
+	}
+
+	parts := append([]string{}, pathParts...)
+	parts[0] = trimScheme(parts[0])
+	abs, err := filepath.Abs(filepath.Join(parts...))
+	if err != nil {
+		return "", errors.Wrap(err, "get absolute path")
+	}
+	return FilePrefix + abs, nil
+}
+
+// PathExists returns true if the file or directory exists.
+func (l *Local) PathExists(objectPath string) (bool, error) {
+	if _, err := os.Stat(localPath(objectPath)); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "stat %s", objectPath)
+	}
+	return true, nil
+}
+
+// List returns the paths of all files in the directory.
+func (l *Local) List(objectPath string) ([]string, error) {
+	dir := localPath(objectPath)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read directory %s", objectPath)
+	}
+
+	res := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		res = append(res, FilePrefix+filepath.Join(dir, entry.Name()))
+	}
+	return res, nil
+}
+
+// Read returns the content of the file.
+func (l *Local) Read(objectPath string) ([]byte, error) {
+	content, err := os.ReadFile(localPath(objectPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Wrapf(ErrNotExist, "read %s", objectPath)
+		}
+		return nil, errors.Wrapf(err, "read %s", objectPath)
+	}
+	return content, nil
+}
+
+// Open returns a reader for the content of the file.
+func (l *Local) Open(objectPath string) (io.ReadCloser, error) {
+	file, err := os.Open(localPath(objectPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Wrapf(ErrNotExist, "read %s", objectPath)
+		}
+		return nil, errors.Wrapf(err, "open %s", objectPath)
+	}
+	return file, nil
+}
+
+// Write creates the file including its parent directories. The attributes
+// are ignored.
+func (l *Local) Write(objectPath string, content []byte, _ *Attributes) error {
+	file := localPath(objectPath)
+	if err := os.MkdirAll(filepath.Dir(file), os.FileMode(0o755)); err != nil {
+		return errors.Wrapf(err, "create parent directory of %s", objectPath)
+	}
+	if err := os.WriteFile(file, content, os.FileMode(0o644)); err != nil {
+		return errors.Wrapf(err, "write %s", objectPath)
+	}
+	return nil
+}
+
+// MakePublic makes the file world readable.
+func (l *Local) MakePublic(objectPath string) error {
+	if err := os.Chmod(localPath(objectPath), os.FileMode(0o644)); err != nil {
+		return errors.Wrapf(err, "change permissions of %s", objectPath)
+	}
+	return nil
+}
+
+// PublicURL always returns an empty string, because local files are not
+// served via HTTP.
+func (l *Local) PublicURL(string) string {
+	return ""
+}
+
+func localPath(objectPath string) string {
+	return filepath.FromSlash(trimScheme(objectPath))
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstore
+
+import (
+	"io"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// GCSPrefix is the URL scheme prefix for Google Cloud Storage paths
+	GCSPrefix = "gs://"
+
+	// S3Prefix is the URL scheme prefix for S3 compatible storage paths
+	S3Prefix = "s3://"
+
+	// FilePrefix is the URL scheme prefix for local filesystem paths
+	FilePrefix = "file://"
+)
+
+// ErrNotExist is returned (wrapped) if a requested object does not exist
+var ErrNotExist = errors.New("object does not exist")
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . Store
+
+// Store is the interface for storing release related objects in a bucket
+// based object store. Paths are URLs in the form `<scheme>://<bucket>/<key>`,
+// where the scheme depends on the implementation.
+type Store interface {
+	// NormalizePath joins the path parts and prefixes them with the scheme
+	// of the store.
+	NormalizePath(pathParts ...string) (string, error)
+
+	// PathExists returns true if the path is either an object or a prefix
+	// of at least one object.
+	PathExists(path string) (bool, error)
+
+	// List returns the paths of all objects directly below the provided
+	// path, without descending into sub directories.
+	List(path string) ([]string, error)
+
+	// Read returns the content of the object at path. It returns a wrapped
+	// ErrNotExist if the object does not exist.
+	Read(path string) ([]byte, error)
+
+	// Open returns a reader for the content of the object at path, which has
+	// to be closed by the caller. It returns a wrapped ErrNotExist if the
+	// object does not exist.
+	Open(path string) (io.ReadCloser, error)
+
+	// Write creates or overwrites the object at path with content. The
+	// attributes are optional.
+	Write(path string, content []byte, attrs *Attributes) error
+
+	// MakePublic grants anonymous read access to the object at path.
+	MakePublic(path string) error
+
+	// PublicURL returns the HTTP(S) URL to access the object at path
+	// anonymously, or an empty string if the store does not serve objects
+	// via HTTP.
+	PublicURL(path string) string
+}
+
+// Attributes are the optional metadata which can be set when writing objects
+type Attributes struct {
+	// ContentType is the MIME type of the object, like `text/plain`
+	ContentType string
+
+	// CacheControl is the Cache-Control header served with the object
+	CacheControl string
+}
+
+// New returns the Store implementation matching the scheme of the provided
+// path. Paths without a known scheme are treated as local filesystem paths.
+func New(storePath string) Store {
+	switch {
+	case strings.HasPrefix(storePath, GCSPrefix):
+		return NewGCS()
+	case strings.HasPrefix(storePath, S3Prefix):
+		return NewS3(DefaultS3Options())
+	default:
+		return NewLocal()
+	}
+}
+
+// ForBucket returns the Store implementation for the provided bucket. Bucket
+// names without a scheme are considered to be GCS buckets.
+func ForBucket(bucket string) Store {
+	if strings.Contains(bucket, "://") {
+		return New(bucket)
+	}
+	return NewGCS()
+}
+
+// SplitPath strips the scheme from path and splits the remainder into its
+// bucket and object key.
+func SplitPath(objectPath string) (bucket, key string, err error) {
+	trimmed := trimScheme(objectPath)
+	trimmed = strings.TrimPrefix(trimmed, "/")
+	if trimmed == "" {
+		return "", "", errors.Errorf("no bucket in path %q", objectPath)
+	}
+
+	parts := strings.SplitN(trimmed, "/", 2)
+	bucket = parts[0]
+	if len(parts) == 2 {
+		key = parts[1]
+	}
+	return bucket, key, nil
+}
+
+// normalizePath joins the path parts and ensures that the result is
+// prefixed with the provided scheme.
+func normalizePath(scheme string, pathParts ...string) (string, error) {
+	if len(pathParts) == 0 {
+		return "", errors.New("must contain at least one path part")
+	}
+
+	emptyParts := 0
+	for i, part := range pathParts {
+		if part == "" {
+			emptyParts++
+		}
+
+		if i > 0 && strings.Contains(part, "://") {
+			return "", errors.Errorf(
+				"path part %q contains a scheme, which may suggest a "+
+					"filepath.Join() error in the caller", part,
+			)
+		}
+	}
+	if emptyParts == len(pathParts) {
+		return "", errors.New("all paths provided were empty")
+	}
+
+	parts := append([]string{}, pathParts...)
+	parts[0] = trimScheme(parts[0])
+	joined := strings.TrimPrefix(path.Join(parts...), "/")
+
+	return scheme + joined, nil
+}
+
+// trimScheme removes any `<scheme>://` prefix from path.
+func trimScheme(objectPath string) string {
+	if i := strings.Index(objectPath, "://"); i >= 0 {
+		return objectPath[i+len("://"):]
+	}
+	return objectPath
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstore_test
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/objectstore"
+)
+
+func TestNew(t *testing.T) {
+	require.IsType(t, &objectstore.GCS{}, objectstore.New("gs://bucket"))
+	require.IsType(t, &objectstore.S3{}, objectstore.New("s3://bucket"))
+	require.IsType(t, &objectstore.Local{}, objectstore.New("file:///tmp"))
+	require.IsType(t, &objectstore.Local{}, objectstore.New("/tmp"))
+}
+
+func TestForBucket(t *testing.T) {
+	require.IsType(t, &objectstore.GCS{}, objectstore.ForBucket("bucket"))
+	require.IsType(t, &objectstore.GCS{}, objectstore.ForBucket("gs://bucket"))
+	require.IsType(t, &objectstore.S3{}, objectstore.ForBucket("s3://bucket"))
+	require.IsType(t, &objectstore.Local{}, objectstore.ForBucket("file:///tmp"))
+}
+
+func TestSplitPath(t *testing.T) {
+	for _, tc := range []struct {
+		path        string
+		bucket      string
+		key         string
+		shouldError bool
+	}{
+		{path: "gs://bucket/a/b.txt", bucket: "bucket", key: "a/b.txt"},
+		{path: "s3://bucket/a", bucket: "bucket", key: "a"},
+		{path: "gs://bucket", bucket: "bucket", key: ""},
+		{path: "bucket/a", bucket: "bucket", key: "a"},
+		{path: "gs://", shouldError: true},
+	} {
+		bucket, key, err := objectstore.SplitPath(tc.path)
+		if tc.shouldError {
+			require.NotNil(t, err)
+			continue
+		}
+		require.Nil(t, err)
+		require.Equal(t, tc.bucket, bucket)
+		require.Equal(t, tc.key, key)
+	}
+}
+
+func TestNormalizePath(t *testing.T) {
+	for _, tc := range []struct {
+		store       objectstore.Store
+		parts       []string
+		expected    string
+		shouldError bool
+	}{
+		{
+			store:    objectstore.NewGCS(),
+			parts:    []string{"bucket", "release", "stable.txt"},
+			expected: "gs://bucket/release/stable.txt",
+		},
+		{
+			store:    objectstore.NewGCS(),
+			parts:    []string{"gs://bucket/release", "stable.txt"},
+			expected: "gs://bucket/release/stable.txt",
+		},
+		{
+			store:    objectstore.NewS3(nil),
+			parts:    []string{"bucket", "release"},
+			expected: "s3://bucket/release",
+		},
+		{
+			store:    objectstore.NewLocal(),
+			parts:    []string{"/tmp/bucket", "release"},
+			expected: "file:///tmp/bucket/release",
+		},
+		{
+			store:    objectstore.NewLocal(),
+			parts:    []string{"file:///tmp/bucket", "release"},
+			expected: "file:///tmp/bucket/release",
+		},
+		{
+			store:       objectstore.NewGCS(),
+			parts:       []string{},
+			shouldError: true,
+		},
+		{
+			store:       objectstore.NewGCS(),
+			parts:       []string{"", ""},
+			shouldError: true,
+		},
+		{
+			store:       objectstore.NewGCS(),
+			parts:       []string{"bucket", "gs://bucket"},
+			shouldError: true,
+		},
+	} {
+		res, err := tc.store.NormalizePath(tc.parts...)
+		if tc.shouldError {
+			require.NotNil(t, err)
+			continue
+		}
+		require.Nil(t, err)
+		require.Equal(t, tc.expected, res)
+	}
+}
+
+func TestPublicURL(t *testing.T) {
+	require.Equal(t,
+		"https://storage.googleapis.com/bucket/release/stable.txt",
+		objectstore.NewGCS().PublicURL("gs://bucket/release/stable.txt"),
+	)
+	require.Equal(t,
+		"https://bucket.s3.amazonaws.com/stable.txt",
+		objectstore.NewS3(&objectstore.S3Options{}).PublicURL("s3://bucket/stable.txt"),
+	)
+	require.Equal(t,
+		"http://localhost:9000/bucket/stable.txt",
+		objectstore.NewS3(
+			&objectstore.S3Options{Endpoint: "http://localhost:9000/"},
+		).PublicURL("s3://bucket/stable.txt"),
+	)
+	require.Empty(t, objectstore.NewLocal().PublicURL("file:///tmp/stable.txt"))
+}
+
+func TestLocal(t *testing.T) {
+	sut := objectstore.NewLocal()
+	dir := t.TempDir()
+
+	file, err := sut.NormalizePath(dir, "release", "stable.txt")
+	require.Nil(t, err)
+
+	exists, err := sut.PathExists(file)
+	require.Nil(t, err)
+	require.False(t, exists)
+
+	_, err = sut.Read(file)
+	require.True(t, errors.Is(err, objectstore.ErrNotExist))
+
+	require.Nil(t, sut.Write(file, []byte("v1.23.0"), nil))
+	require.FileExists(t, filepath.Join(dir, "release", "stable.txt"))
+
+	for _, p := range []string{file, filepath.Join(dir, "release")} {
+		exists, err := sut.PathExists(p)
+		require.Nil(t, err)
+		require.True(t, exists)
+	}
+
+	content, err := sut.Read(file)
+	require.Nil(t, err)
+	require.Equal(t, "v1.23.0", string(content))
+
+	reader, err := sut.Open(file)
+	require.Nil(t, err)
+	content, err = io.ReadAll(reader)
+	require.Nil(t, err)
+	require.Nil(t, reader.Close())
+	require.Equal(t, "v1.23.0", string(content))
+
+	_, err = sut.Open(file + ".missing")
+	require.True(t, errors.Is(err, objectstore.ErrNotExist))
+
+	require.Nil(t, sut.MakePublic(file))
+}
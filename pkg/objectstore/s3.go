@@ -0,0 +1,295 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstore
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+)
+
+const (
+	// S3EndpointEnv is the environment variable to set a custom endpoint for
+	// S3 compatible stores, like MinIO or Ceph.
+	S3EndpointEnv = "S3_ENDPOINT"
+
+	// S3RegionEnv is the environment variable to set the S3 region.
+	S3RegionEnv = "AWS_REGION"
+
+	defaultS3Region = "us-east-1"
+)
+
+// S3Options are the settings for connecting to an S3 compatible store
+type S3Options struct {
+	// Endpoint is the URL of the S3 API. Leave empty for AWS.
+	Endpoint string
+
+	// Region is the region of the buckets.
+	Region string
+
+	// PathStyle forces `<endpoint>/<bucket>/<key>` addressing, which is
+	// required by most S3 compatible stores.
+	PathStyle bool
+}
+
+// DefaultS3Options returns the S3 options derived from the environment.
+func DefaultS3Options() *S3Options {
+	opts := &S3Options{
+		Endpoint: os.Getenv(S3EndpointEnv),
+		Region:   os.Getenv(S3RegionEnv),
+	}
+	if opts.Region == "" {
+		opts.Region = defaultS3Region
+	}
+	opts.PathStyle = opts.Endpoint != ""
+	return opts
+}
+
+// S3 is a Store implementation for AWS S3 and S3 compatible stores
+type S3 struct {
+	opts   *S3Options
+	client *s3.S3
+}
+
+// NewS3 creates a new S3 store. The client gets initialized on first use.
+func NewS3(opts *S3Options) *S3 {
+	if opts == nil {
+		opts = DefaultS3Options()
+	}
+	return &S3{opts: opts}
+}
+
+func (s *S3) s3Client() (*s3.S3, error) {
+	if s.client != nil {
+		return s.client, nil
+	}
+
+	config := aws.NewConfig().
+		WithRegion(s.opts.Region).
+		WithS3ForcePathStyle(s.opts.PathStyle)
+	if s.opts.Endpoint != "" {
+		config = config.WithEndpoint(s.opts.Endpoint)
+	}
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "create S3 session")
+	}
+	s.client = s3.New(sess)
+	return s.client, nil
+}
+
+// NormalizePath joins the path parts and ensures the `s3://` prefix.
+func (s *S3) NormalizePath(pathParts ...string) (string, error) {
+	return normalizePath(S3Prefix, pathParts...)
+}
+
+// PathExists returns true if the S3 path is an object or a prefix containing
+// at least one object.
+func (s *S3) PathExists(objectPath string) (bool, error) {
+	bucket, key, err := SplitPath(objectPath)
+	if err != nil {
+		return false, err
+	}
+	client, err := s.s3Client()
+	if err != nil {
+		return false, err
+	}
+
+	if key != "" {
+		_, err := client.HeadObject(&s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+		if err == nil {
+			return true, nil
+		}
+		if !isS3NotFound(err) {
+			return false, errors.Wrapf(err, "head object %s", objectPath)
+		}
+		key += "/"
+	}
+
+	res, err := client.ListObjectsV2(&s3.ListObjectsV2Input{
+		Bucket:  aws.String(bucket),
+		Prefix:  aws.String(key),
+		MaxKeys: aws.Int64(1),
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "list objects in %s", objectPath)
+	}
+	return len(res.Contents) > 0, nil
+}
+
+// List returns the paths of all objects directly below the S3 path.
+func (s *S3) List(objectPath string) ([]string, error) {
+	bucket, key, err := SplitPath(objectPath)
+	if err != nil {
+		return nil, err
+	}
+	client, err := s.s3Client()
+	if err != nil {
+		return nil, err
+	}
+	if key != "" && !strings.HasSuffix(key, "/") {
+		key += "/"
+	}
+
+	res := []string{}
+	if err := client.ListObjectsV2Pages(
+		&s3.ListObjectsV2Input{
+			Bucket:    aws.String(bucket),
+			Prefix:    aws.String(key),
+			Delimiter: aws.String("/"),
+		},
+		func(page *s3.ListObjectsV2Output, _ bool) bool {
+			for _, object := range page.Contents {
+				res = append(res, S3Prefix+path.Join(bucket, aws.StringValue(object.Key)))
+			}
+			return true
+		},
+	); err != nil {
+		return nil, errors.Wrapf(err, "list objects in %s", objectPath)
+	}
+	return res, nil
+}
+
+// Read returns the content of the S3 object.
+func (s *S3) Read(objectPath string) ([]byte, error) {
+	reader, err := s.Open(objectPath)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read %s", objectPath)
+	}
+	return content, nil
+}
+
+// Open returns a reader for the content of the S3 object.
+func (s *S3) Open(objectPath string) (io.ReadCloser, error) {
+	bucket, key, err := SplitPath(objectPath)
+	if err != nil {
+		return nil, err
+	}
+	client, err := s.s3Client()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isS3NotFound(err) {
+			return nil, errors.Wrapf(ErrNotExist, "read %s", objectPath)
+		}
+		return nil, errors.Wrapf(err, "get object %s", objectPath)
+	}
+	return res.Body, nil
+}
+
+// Write uploads content to the S3 object.
+func (s *S3) Write(objectPath string, content []byte, attrs *Attributes) error {
+	bucket, key, err := SplitPath(objectPath)
+	if err != nil {
+		return err
+	}
+	client, err := s.s3Client()
+	if err != nil {
+		return err
+	}
+
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(content),
+	}
+	if attrs != nil {
+		if attrs.ContentType != "" {
+			input.ContentType = aws.String(attrs.ContentType)
+		}
+		if attrs.CacheControl != "" {
+			input.CacheControl = aws.String(attrs.CacheControl)
+		}
+	}
+
+	if _, err := client.PutObject(input); err != nil {
+		return errors.Wrapf(err, "put object %s", objectPath)
+	}
+	return nil
+}
+
+// MakePublic applies the `public-read` canned ACL to the S3 object.
+func (s *S3) MakePublic(objectPath string) error {
+	bucket, key, err := SplitPath(objectPath)
+	if err != nil {
+		return err
+	}
+	client, err := s.s3Client()
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.PutObjectAcl(&s3.PutObjectAclInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		ACL:    aws.String(s3.ObjectCannedACLPublicRead),
+	}); err != nil {
+		return errors.Wrapf(err, "set public ACL on %s", objectPath)
+	}
+	return nil
+}
+
+// PublicURL returns the HTTP URL of the S3 object, either for the configured
+// endpoint or the AWS virtual hosted style one.
+func (s *S3) PublicURL(objectPath string) string {
+	bucket, key, err := SplitPath(objectPath)
+	if err != nil {
+		return ""
+	}
+	if s.opts.Endpoint != "" {
+		return fmt.Sprintf(
+			"%s/%s/%s", strings.TrimSuffix(s.opts.Endpoint, "/"), bucket, key,
+		)
+	}
+	return fmt.Sprintf("https://%s.s3.amazonaws.com/%s", bucket, key)
+}
+
+func isS3NotFound(err error) bool {
+	var aerr awserr.Error
+	if errors.As(err, &aerr) {
+		switch aerr.Code() {
+		case s3.ErrCodeNoSuchKey, "NotFound":
+			return true
+		}
+	}
+	return false
+}
@@ -18,20 +18,46 @@ package release
 
 import (
 	"path/filepath"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"k8s.io/release/pkg/binary"
 )
 
+// versionVariables are the linker variables which carry the version of the
+// Kubernetes binaries
+var versionVariables = []string{
+	"k8s.io/component-base/version.gitVersion",
+	"k8s.io/client-go/pkg/version.gitVersion",
+}
+
+// treeStateVariables are the linker variables which carry the git tree state
+// of the Kubernetes build
+var treeStateVariables = []string{
+	"k8s.io/component-base/version.gitTreeState",
+	"k8s.io/client-go/pkg/version.gitTreeState",
+}
+
+// dynamicServerBinaries are the server binaries which are not required to be
+// statically linked
+var dynamicServerBinaries = map[string]bool{
+	"kubelet": true,
+}
+
 type ArtifactChecker struct {
 	opts *ArtifactCheckerOptions
 	impl artifactCheckerImplementation
 }
 
 type ArtifactCheckerOptions struct {
-	GitRoot  string   // Directory where the repo was cloned
-	Versions []string // Version tags we are checking
+	GitRoot   string   // Directory where the repo was cloned
+	Versions  []string // Version tags we are checking
+	GoVersion string   // Go version the binaries have to be built with, skipped if empty
+
+	// TarballManifest is the path to the manifest of the expected tarball
+	// contents, the default manifest is used if empty
+	TarballManifest string
 }
 
 func NewArtifactChecker() *ArtifactChecker {
@@ -71,10 +97,34 @@ func (ac *ArtifactChecker) CheckBinaryArchitectures() error {
 	return nil
 }
 
+// CheckBinaryBuildInfo ensures the Go build information of all binaries
+// complies with the release policy
+func (ac *ArtifactChecker) CheckBinaryBuildInfo() error {
+	for _, tag := range ac.opts.Versions {
+		if err := ac.impl.CheckVersionBuildInfo(ac.opts, tag); err != nil {
+			return errors.Wrapf(err, "checking build info of %s binaries", tag)
+		}
+	}
+	return nil
+}
+
+// CheckTarballContents ensures the release tarballs contain exactly the
+// files declared in the tarball manifest
+func (ac *ArtifactChecker) CheckTarballContents() error {
+	for _, tag := range ac.opts.Versions {
+		if err := ac.impl.CheckVersionTarballs(ac.opts, tag); err != nil {
+			return errors.Wrapf(err, "checking contents of %s tarballs", tag)
+		}
+	}
+	return nil
+}
+
 type artifactCheckerImplementation interface {
 	ListReleaseBinaries(opts *ArtifactCheckerOptions, version string) ([]struct{ Path, Platform, Arch string }, error)
 	CheckVersionTags(*ArtifactCheckerOptions, string) error
 	CheckVersionArch(*ArtifactCheckerOptions, string) error
+	CheckVersionBuildInfo(*ArtifactCheckerOptions, string) error
+	CheckVersionTarballs(*ArtifactCheckerOptions, string) error
 }
 
 type defaultArtifactCheckerImpl struct{}
@@ -148,3 +198,223 @@ func (impl *defaultArtifactCheckerImpl) CheckVersionArch(
 	}
 	return nil
 }
+
+// CheckVersionBuildInfo checks the Go build information of the binaries of a
+// certain version against the release policy.
+func (impl *defaultArtifactCheckerImpl) CheckVersionBuildInfo(
+	opts *ArtifactCheckerOptions, version string,
+) error {
+	binaries, err := impl.ListReleaseBinaries(opts, version)
+	if err != nil {
+		return errors.Wrapf(err, "listing binaries for release %s", version)
+	}
+	logrus.Infof("Checking build info of %d binaries for version %s", len(binaries), version)
+
+	serverPath := filepath.Join(opts.GitRoot, BuildDir+"-"+version, ReleaseStagePath, "server")
+	for _, binData := range binaries {
+		bin, err := binary.New(binData.Path)
+		if err != nil {
+			return errors.Wrapf(err, "creating binary object from %s", binData.Path)
+		}
+		info, err := bin.BuildInfo()
+		if err != nil {
+			return errors.Wrapf(err, "reading build info of %s", binData.Path)
+		}
+
+		name := filepath.Base(binData.Path)
+		policy := &BuildInfoPolicy{
+			GoVersion: opts.GoVersion,
+			Static: strings.HasPrefix(binData.Path, serverPath+string(filepath.Separator)) &&
+				!dynamicServerBinaries[strings.TrimSuffix(name, ".exe")],
+		}
+
+		// The mounter binary is not tagged
+		if name != "mounter" {
+			policy.Version = version
+		}
+
+		inspection, err := bin.Inspect()
+		if err != nil {
+			return errors.Wrapf(err, "inspecting binary %s", binData.Path)
+		}
+
+		err = policy.Check(info, inspection)
+		if errors.Is(err, ErrBuildSettingsNotRecorded) {
+			// The exact version string searched below does not match the
+			// `-dirty` versions of modified trees, which leaves only the
+			// untagged binaries unchecked.
+			if policy.Version == "" {
+				logrus.Warnf("Unable to verify the VCS state of %s: %v", binData.Path, err)
+			}
+		} else if err != nil {
+			return errors.Wrapf(err, "binary %s", binData.Path)
+		}
+
+		// The linker flags are not recorded for builds using -trimpath or Go
+		// versions before 1.18, so we fall back to searching for the version
+		// string.
+		if policy.Version != "" && !info.HasLDFlags() {
+			contains, err := bin.ContainsStrings(version)
+			if err != nil {
+				return errors.Wrapf(err, "scanning binary %s", binData.Path)
+			}
+			if !contains {
+				return errors.Errorf(
+					"tag %s not found in produced binary: %s", version, binData.Path,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckVersionTarballs checks the contents of all tarballs of a certain
+// version against the tarball manifest. Tarballs which are not part of the
+// manifest are skipped, while tarballs of the manifest which have not been
+// built are reported.
+func (impl *defaultArtifactCheckerImpl) CheckVersionTarballs(
+	opts *ArtifactCheckerOptions, version string,
+) error {
+	manifest, err := DefaultTarballManifest()
+	if opts.TarballManifest != "" {
+		manifest, err = LoadTarballManifest(opts.TarballManifest)
+	}
+	if err != nil {
+		return errors.Wrap(err, "loading tarball manifest")
+	}
+
+	tarballs, err := ListBuildTarballs(opts.GitRoot, version)
+	if err != nil {
+		return errors.Wrapf(err, "listing tarballs for release %s", version)
+	}
+	logrus.Infof("Checking contents of %d tarballs for version %s", len(tarballs), version)
+
+	findings := []string{}
+	for _, tarball := range tarballs {
+		report, err := manifest.Check(tarball, version)
+		if errors.Is(err, ErrNotInManifest) {
+			logrus.Warnf("Skipping content check of %s: %v", tarball, err)
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "checking contents of %s", tarball)
+		}
+		if !report.Passed() {
+			logrus.Error(report.String())
+			findings = append(findings, report.String())
+		}
+	}
+
+	missing, err := manifest.MissingTarballs(tarballs, version)
+	if err != nil {
+		return errors.Wrap(err, "checking for missing tarballs")
+	}
+	for _, tarball := range missing {
+		logrus.Errorf("Tarball %s has not been built", tarball)
+		findings = append(findings, tarball+": not built")
+	}
+
+	if len(findings) > 0 {
+		return errors.Errorf(
+			"unexpected tarball contents:\n%s", strings.Join(findings, "\n"),
+		)
+	}
+	return nil
+}
+
+// BuildInfoPolicy are the requirements for the Go build information of a
+// release binary
+type BuildInfoPolicy struct {
+	// GoVersion is the expected Go version, skipped if empty
+	GoVersion string
+
+	// Version is the expected value of the version linker variables, skipped
+	// if empty
+	Version string
+
+	// Static requires the binary to be linked statically and built without
+	// cgo
+	Static bool
+}
+
+// ErrBuildSettingsNotRecorded is returned (wrapped) by BuildInfoPolicy.Check
+// if the binary has been built by a Go version before 1.18, which does not
+// record the build settings. The checks relying on them did not run.
+var ErrBuildSettingsNotRecorded = errors.New("build settings not recorded")
+
+// Check verifies the build information and the inspection result of a
+// binary against the policy. The static linking is verified by the
+// inspection, which is therefore required if Static is set. It returns a
+// wrapped ErrBuildSettingsNotRecorded if the VCS and linker flag checks could
+// not be run.
+func (p *BuildInfoPolicy) Check(info *binary.BuildInfo, inspection *binary.Inspection) error {
+	if p.GoVersion != "" {
+		expected := "go" + strings.TrimPrefix(p.GoVersion, "go")
+		if info.GoVersion != expected {
+			return errors.Errorf(
+				"built with Go version %s, expected %s", info.GoVersion, expected,
+			)
+		}
+	}
+
+	if p.Static {
+		if inspection == nil {
+			return errors.New("static linking cannot be verified without inspection")
+		}
+		if inspection.Interpreter != "" {
+			return errors.Errorf(
+				"requires interpreter %s, but has to be static", inspection.Interpreter,
+			)
+		}
+		if len(inspection.Libraries) > 0 {
+			return errors.Errorf(
+				"requires shared libraries %s, but has to be static",
+				strings.Join(inspection.Libraries, ", "),
+			)
+		}
+	}
+
+	if !info.HasSettings() {
+		return errors.Wrapf(ErrBuildSettingsNotRecorded, "by %s", info.GoVersion)
+	}
+
+	if info.VCSModified() {
+		return errors.New("built from a modified VCS tree")
+	}
+
+	if p.Static && info.CGOEnabled() {
+		return errors.New("built with cgo enabled, but has to be static")
+	}
+
+	if !info.HasLDFlags() {
+		return nil
+	}
+
+	variables := info.VersionVariables()
+	for _, variable := range treeStateVariables {
+		if state, ok := variables[variable]; ok && state != "clean" {
+			return errors.Errorf("git tree state %s is %q", variable, state)
+		}
+	}
+
+	if p.Version == "" {
+		return nil
+	}
+	found := false
+	for _, variable := range versionVariables {
+		value, ok := variables[variable]
+		if !ok {
+			continue
+		}
+		found = true
+		if value != p.Version {
+			return errors.Errorf(
+				"version variable %s is %q, expected %q", variable, value, p.Version,
+			)
+		}
+	}
+	if !found {
+		return errors.Errorf("no version variable set to %s", p.Version)
+	}
+	return nil
+}
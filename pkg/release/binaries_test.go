@@ -0,0 +1,124 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"k8s.io/release/pkg/binary"
+	"k8s.io/release/pkg/release"
+)
+
+func TestBuildInfoPolicyCheck(t *testing.T) {
+	const (
+		ldflags = "-s -w -X 'k8s.io/component-base/version.gitVersion=v1.22.3' " +
+			"-X 'k8s.io/component-base/version.gitTreeState=clean'"
+	)
+	newInfo := func(settings map[string]string) *binary.BuildInfo {
+		return &binary.BuildInfo{GoVersion: "go1.18.1", Settings: settings}
+	}
+	static := &binary.Inspection{}
+
+	for _, tc := range []struct {
+		policy      *release.BuildInfoPolicy
+		info        *binary.BuildInfo
+		inspection  *binary.Inspection
+		shouldErr   bool
+		notRecorded bool
+	}{
+		{ // success
+			policy:     &release.BuildInfoPolicy{GoVersion: "1.18.1", Version: "v1.22.3", Static: true},
+			info:       newInfo(map[string]string{"-ldflags": ldflags, "CGO_ENABLED": "0"}),
+			inspection: static,
+		},
+		{ // success without recorded linker flags
+			policy: &release.BuildInfoPolicy{GoVersion: "go1.18.1", Version: "v1.22.3"},
+			info:   newInfo(map[string]string{"-trimpath": "true"}),
+		},
+		{ // build settings not recorded
+			policy:      &release.BuildInfoPolicy{GoVersion: "1.16.6", Version: "v1.22.3", Static: true},
+			info:        &binary.BuildInfo{GoVersion: "go1.16.6"},
+			inspection:  static,
+			shouldErr:   true,
+			notRecorded: true,
+		},
+		{ // failure interpreter without build settings
+			policy:     &release.BuildInfoPolicy{Static: true},
+			info:       &binary.BuildInfo{GoVersion: "go1.16.6"},
+			inspection: &binary.Inspection{Interpreter: "/lib64/ld-linux-x86-64.so.2"},
+			shouldErr:  true,
+		},
+		{ // failure shared libraries without build settings
+			policy:     &release.BuildInfoPolicy{Static: true},
+			info:       &binary.BuildInfo{GoVersion: "go1.16.6"},
+			inspection: &binary.Inspection{Libraries: []string{"libc.so.6"}},
+			shouldErr:  true,
+		},
+		{ // failure static without inspection
+			policy:    &release.BuildInfoPolicy{Static: true},
+			info:      newInfo(map[string]string{"CGO_ENABLED": "0"}),
+			shouldErr: true,
+		},
+		{ // success dynamic binary with cgo
+			policy: &release.BuildInfoPolicy{Version: "v1.22.3"},
+			info:   newInfo(map[string]string{"-ldflags": ldflags, "CGO_ENABLED": "1"}),
+		},
+		{ // failure Go version
+			policy:    &release.BuildInfoPolicy{GoVersion: "1.18.2"},
+			info:      newInfo(map[string]string{}),
+			shouldErr: true,
+		},
+		{ // failure static binary with cgo
+			policy:     &release.BuildInfoPolicy{Static: true},
+			info:       newInfo(map[string]string{"CGO_ENABLED": "1"}),
+			inspection: static,
+			shouldErr:  true,
+		},
+		{ // failure modified VCS tree
+			policy:    &release.BuildInfoPolicy{},
+			info:      newInfo(map[string]string{"vcs.modified": "true"}),
+			shouldErr: true,
+		},
+		{ // failure dirty git tree state
+			policy: &release.BuildInfoPolicy{},
+			info: newInfo(map[string]string{
+				"-ldflags": "-X k8s.io/component-base/version.gitTreeState=dirty",
+			}),
+			shouldErr: true,
+		},
+		{ // failure version mismatch
+			policy:    &release.BuildInfoPolicy{Version: "v1.22.4"},
+			info:      newInfo(map[string]string{"-ldflags": ldflags}),
+			shouldErr: true,
+		},
+		{ // failure version variable not set
+			policy:    &release.BuildInfoPolicy{Version: "v1.22.3"},
+			info:      newInfo(map[string]string{"-ldflags": "-s -w"}),
+			shouldErr: true,
+		},
+	} {
+		err := tc.policy.Check(tc.info, tc.inspection)
+		if tc.shouldErr {
+			require.NotNil(t, err)
+			require.Equal(t, tc.notRecorded, errors.Is(err, release.ErrBuildSettingsNotRecorded))
+		} else {
+			require.Nil(t, err)
+		}
+	}
+}
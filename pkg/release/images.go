@@ -18,64 +18,127 @@ package release
 
 import (
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 
-	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/google"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
-	"sigs.k8s.io/release-utils/command"
+	"k8s.io/release/pkg/sign"
 )
 
 // Images is a wrapper around container image related functionality
 type Images struct {
-	client commandClient
+	client   imagesClient
+	signer   *sign.Signer
+	verifier *sign.Verifier
 }
 
 // NewImages creates a new Images instance
 func NewImages() *Images {
-	return &Images{&defaultCommandClient{}}
+	return &Images{client: &defaultImagesClient{}}
 }
 
-// SetClient can be used to set the internal command client
-func (i *Images) SetClient(client commandClient) {
+// SetClient can be used to set the internal images client
+func (i *Images) SetClient(client imagesClient) {
 	i.client = client
 }
 
-// commandClient is a client for working with Docker
-//counterfeiter:generate . commandClient
-type commandClient interface {
-	Execute(cmd string, args ...string) error
-	ExecuteOutput(cmd string, args ...string) (string, error)
+// SetSigner enables signing of all published images and manifest lists
+func (i *Images) SetSigner(signer *sign.Signer) {
+	i.signer = signer
+}
+
+// SetVerifier enables signature verification during image validation
+func (i *Images) SetVerifier(verifier *sign.Verifier) {
+	i.verifier = verifier
+}
+
+// imagesClient is a client for working with container image tarballs and
+// registries
+//counterfeiter:generate . imagesClient
+type imagesClient interface {
 	RepoTagFromTarball(path string) (string, error)
+	ImageFromTarball(path, tag string) (v1.Image, error)
+	Image(ref string) (v1.Image, error)
+	Index(ref string) (v1.ImageIndex, error)
+	Write(ref string, img v1.Image) error
+	WriteIndex(ref string, idx v1.ImageIndex) error
+}
+
+type defaultImagesClient struct{}
+
+func (*defaultImagesClient) remoteOptions() []remote.Option {
+	return []remote.Option{remote.WithAuthFromKeychain(
+		authn.NewMultiKeychain(authn.DefaultKeychain, google.Keychain),
+	)}
+}
+
+func (*defaultImagesClient) RepoTagFromTarball(path string) (string, error) {
+	manifest, err := tarball.LoadManifest(func() (io.ReadCloser, error) {
+		return os.Open(path)
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "load manifest from %s", path)
+	}
+	if len(manifest) == 0 || len(manifest[0].RepoTags) == 0 {
+		return "", errors.Errorf("no repo tags found in %s", path)
+	}
+	return manifest[0].RepoTags[0], nil
+}
+
+func (*defaultImagesClient) ImageFromTarball(path, tag string) (v1.Image, error) {
+	parsedTag, err := name.NewTag(tag)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse tag %s", tag)
+	}
+	return tarball.ImageFromPath(path, &parsedTag)
 }
 
-type defaultCommandClient struct{}
+func (d *defaultImagesClient) Image(ref string) (v1.Image, error) {
+	parsedRef, err := name.ParseReference(ref)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse reference %s", ref)
+	}
+	return remote.Image(parsedRef, d.remoteOptions()...)
+}
 
-func (*defaultCommandClient) Execute(cmd string, args ...string) error {
-	return command.New(cmd, args...).RunSilentSuccess()
+func (d *defaultImagesClient) Index(ref string) (v1.ImageIndex, error) {
+	parsedRef, err := name.ParseReference(ref)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse reference %s", ref)
+	}
+	return remote.Index(parsedRef, d.remoteOptions()...)
 }
 
-func (*defaultCommandClient) ExecuteOutput(cmd string, args ...string) (string, error) {
-	res, err := command.New(cmd, args...).RunSilentSuccessOutput()
+func (d *defaultImagesClient) Write(ref string, img v1.Image) error {
+	parsedRef, err := name.ParseReference(ref)
 	if err != nil {
-		return "", err
+		return errors.Wrapf(err, "parse reference %s", ref)
 	}
-	return res.OutputTrimNL(), nil
+	return remote.Write(parsedRef, img, d.remoteOptions()...)
 }
 
-func (*defaultCommandClient) RepoTagFromTarball(path string) (string, error) {
-	tagOutput, err := command.
-		New("tar", "xf", path, "manifest.json", "-O").
-		Pipe("jq", "-r", ".[0].RepoTags[0]").
-		RunSilentSuccessOutput()
+func (d *defaultImagesClient) WriteIndex(ref string, idx v1.ImageIndex) error {
+	parsedRef, err := name.ParseReference(ref)
 	if err != nil {
-		return "", err
+		return errors.Wrapf(err, "parse reference %s", ref)
 	}
-	return tagOutput.OutputTrimNL(), nil
+	return remote.WriteIndex(parsedRef, idx, d.remoteOptions()...)
 }
 
 var tagRegex = regexp.MustCompile(`^.+/(.+):.+$`)
@@ -93,32 +156,16 @@ func (i *Images) Publish(registry, version, buildPath string) error {
 	manifestImages, err := i.getManifestImages(
 		registry, version, buildPath,
 		func(path, origTag, newTagWithArch string) error {
-			if err := i.client.Execute(
-				"docker", "load", "-qi", path,
-			); err != nil {
+			img, err := i.client.ImageFromTarball(path, origTag)
+			if err != nil {
 				return errors.Wrap(err, "load container image")
 			}
 
-			if err := i.client.Execute(
-				"docker", "tag", origTag, newTagWithArch,
-			); err != nil {
-				return errors.Wrap(err, "tag container image")
-			}
-
 			logrus.Infof("Pushing %s", newTagWithArch)
-
-			if err := i.client.Execute(
-				"gcloud", "docker", "--", "push", newTagWithArch,
-			); err != nil {
+			if err := i.client.Write(newTagWithArch, img); err != nil {
 				return errors.Wrap(err, "push container image")
 			}
 
-			if err := i.client.Execute(
-				"docker", "rmi", origTag, newTagWithArch,
-			); err != nil {
-				return errors.Wrap(err, "remove local container image")
-			}
-
 			return nil
 		},
 	)
@@ -126,51 +173,172 @@ func (i *Images) Publish(registry, version, buildPath string) error {
 		return errors.Wrap(err, "get manifest images")
 	}
 
-	if err := os.Setenv("DOCKER_CLI_EXPERIMENTAL", "enabled"); err != nil {
-		return errors.Wrap(err, "enable docker experimental CLI")
-	}
-
 	for image, arches := range manifestImages {
 		imageVersion := fmt.Sprintf("%s:%s", image, version)
 		logrus.Infof("Creating manifest image %s", imageVersion)
 
-		manifests := []string{}
-		for _, arch := range arches {
-			manifests = append(manifests,
-				fmt.Sprintf("%s-%s:%s", image, arch, version),
-			)
-		}
-		if err := i.client.Execute("docker", append(
-			[]string{"manifest", "create", "--amend", imageVersion},
-			manifests...,
-		)...); err != nil {
-			return errors.Wrap(err, "create manifest")
-		}
-
-		for _, arch := range arches {
+		var idx v1.ImageIndex = empty.Index
+		for j, arch := range arches {
+			archImageVersion := fmt.Sprintf("%s-%s:%s", image, arch, version)
 			logrus.Infof(
-				"Annotating %s-%s:%s with --arch %s",
-				image, arch, version, arch,
+				"Adding %s to manifest with arch %s", archImageVersion, arch,
 			)
-			if err := i.client.Execute(
-				"docker", "manifest", "annotate", "--arch", arch,
-				imageVersion, fmt.Sprintf("%s-%s:%s", image, arch, version),
-			); err != nil {
-				return errors.Wrap(err, "annotate manifest with arch")
+
+			img, err := i.client.Image(archImageVersion)
+			if err != nil {
+				return errors.Wrapf(err, "get remote image %s", archImageVersion)
+			}
+
+			desc, err := indexDescriptor(img, arch)
+			if err != nil {
+				return errors.Wrapf(err, "get descriptor for %s", archImageVersion)
 			}
+
+			if j == 0 {
+				idx = mutate.IndexMediaType(idx, indexMediaType(desc.MediaType))
+			}
+
+			if err := i.signImage(archImageVersion, img); err != nil {
+				return errors.Wrapf(err, "sign image %s", archImageVersion)
+			}
+			idx = mutate.AppendManifests(idx, mutate.IndexAddendum{
+				Add:        img,
+				Descriptor: *desc,
+			})
 		}
 
 		logrus.Infof("Pushing manifest image %s", imageVersion)
-		if err := i.client.Execute(
-			"docker", "manifest", "push", imageVersion, "--purge",
-		); err != nil {
+		if err := i.client.WriteIndex(imageVersion, idx); err != nil {
 			return errors.Wrap(err, "push manifest")
 		}
+
+		if err := i.signImage(imageVersion, idx); err != nil {
+			return errors.Wrapf(err, "sign manifest %s", imageVersion)
+		}
 	}
 
 	return nil
 }
 
+// signImage signs the digest of the image or index referenced by tag and
+// pushes the signature next to it, if a signer is configured.
+func (i *Images) signImage(tag string, artifact digestable) error {
+	if i.signer == nil {
+		return nil
+	}
+
+	repository, digest, err := repositoryAndDigest(tag, artifact)
+	if err != nil {
+		return err
+	}
+
+	signatureTag, err := sign.SignatureTag(repository, digest)
+	if err != nil {
+		return errors.Wrap(err, "get signature tag")
+	}
+
+	// Existing signatures for the same digest are preserved
+	existing, err := i.client.Image(signatureTag)
+	if err != nil {
+		var terr *transport.Error
+		if !errors.As(err, &terr) || terr.StatusCode != http.StatusNotFound {
+			return errors.Wrapf(err, "get existing signatures %s", signatureTag)
+		}
+	}
+
+	signatures, err := i.signer.SignImage(existing, repository, digest)
+	if err != nil {
+		return errors.Wrap(err, "create signature")
+	}
+
+	logrus.Infof("Pushing signature %s", signatureTag)
+	if err := i.client.Write(signatureTag, signatures); err != nil {
+		return errors.Wrap(err, "push signature")
+	}
+	return nil
+}
+
+// verifyImage verifies the signature of the image digest referenced by tag,
+// if a verifier is configured.
+func (i *Images) verifyImage(tag string, digest v1.Hash) error {
+	if i.verifier == nil {
+		return nil
+	}
+
+	ref, err := name.NewTag(tag)
+	if err != nil {
+		return errors.Wrapf(err, "parse tag %s", tag)
+	}
+	repository := ref.Context().Name()
+
+	signatureTag, err := sign.SignatureTag(repository, digest)
+	if err != nil {
+		return errors.Wrap(err, "get signature tag")
+	}
+
+	signatures, err := i.client.Image(signatureTag)
+	if err != nil {
+		return errors.Wrapf(err, "get signatures %s", signatureTag)
+	}
+
+	if err := i.verifier.VerifyImage(signatures, repository, digest); err != nil {
+		return errors.Wrapf(err, "verify signature of %s", tag)
+	}
+
+	logrus.Infof("Verified signature of %s@%s", repository, digest)
+	return nil
+}
+
+// digestable is anything which has a digest, like images and image indexes
+type digestable interface {
+	Digest() (v1.Hash, error)
+}
+
+func repositoryAndDigest(
+	tag string, artifact digestable,
+) (string, v1.Hash, error) {
+	ref, err := name.NewTag(tag)
+	if err != nil {
+		return "", v1.Hash{}, errors.Wrapf(err, "parse tag %s", tag)
+	}
+	digest, err := artifact.Digest()
+	if err != nil {
+		return "", v1.Hash{}, errors.Wrapf(err, "get digest of %s", tag)
+	}
+	return ref.Context().Name(), digest, nil
+}
+
+// indexDescriptor returns the descriptor of img for adding it to an image
+// index, including its platform for the provided arch.
+func indexDescriptor(img v1.Image, arch string) (*v1.Descriptor, error) {
+	mediaType, err := img.MediaType()
+	if err != nil {
+		return nil, errors.Wrap(err, "get media type")
+	}
+
+	configFile, err := img.ConfigFile()
+	if err != nil {
+		return nil, errors.Wrap(err, "get config file")
+	}
+
+	platform := &v1.Platform{OS: configFile.OS, Architecture: arch}
+	if platform.OS == "" {
+		platform.OS = "linux"
+	}
+
+	return &v1.Descriptor{MediaType: mediaType, Platform: platform}, nil
+}
+
+// indexMediaType returns the index media type matching the manifest media
+// type, which is an OCI image index for OCI manifests and a Docker manifest
+// list otherwise.
+func indexMediaType(manifestMediaType types.MediaType) types.MediaType {
+	if manifestMediaType == types.OCIManifestSchema1 {
+		return types.OCIImageIndex
+	}
+	return types.DockerManifestList
+}
+
 // Validates that image manifests have been pushed to a specified remote
 // registry.
 func (i *Images) Validate(registry, version, buildPath string) error {
@@ -185,52 +353,81 @@ func (i *Images) Validate(registry, version, buildPath string) error {
 	}
 	logrus.Infof("Got manifest images %+v", manifestImages)
 
+	return i.validateManifestImages(version, manifestImages)
+}
+
+// ValidateRemote validates that the image manifests for the provided images
+// and architectures have been pushed to a remote registry. In contrast to
+// Validate, it does not require a local build directory.
+func (i *Images) ValidateRemote(
+	registry, version string, images map[string][]string,
+) error {
+	logrus.Infof("Validating image manifests in %s", registry)
+	version = i.normalizeVersion(version)
+
+	manifestImages := make(map[string][]string)
+	for image, arches := range images {
+		manifestImages[registry+"/"+image] = arches
+	}
+	return i.validateManifestImages(version, manifestImages)
+}
+
+// validateManifestImages checks that the index of every image contains the
+// digests of the per-architecture images.
+func (i *Images) validateManifestImages(
+	version string, manifestImages map[string][]string,
+) error {
 	for image, arches := range manifestImages {
 		imageVersion := fmt.Sprintf("%s:%s", image, version)
 
-		manifestBytes, err := crane.Manifest(imageVersion)
+		indexDigest, digests, err := i.remoteDigests(imageVersion)
 		if err != nil {
-			return errors.Wrapf(
-				err, "get remote manifest from %s", imageVersion,
-			)
+			return err
 		}
 
-		manifest := string(manifestBytes)
-		manifestFile, err := os.CreateTemp("", "manifest-")
-		if err != nil {
-			return errors.Wrap(err, "create temp file for manifest")
+		if err := i.verifyImage(imageVersion, indexDigest); err != nil {
+			return err
 		}
-		if _, err := manifestFile.WriteString(manifest); err != nil {
-			return errors.Wrapf(
-				err, "write manifest to %s", manifestFile.Name(),
-			)
-		}
-		defer os.RemoveAll(manifestFile.Name())
 
 		for _, arch := range arches {
 			logrus.Infof(
 				"Checking image digest for %s on %s architecture", image, arch,
 			)
 
-			digest, err := i.client.ExecuteOutput(
-				"jq", "--arg", "a", arch, "-r",
-				".manifests[] | select(.platform.architecture == $a) | .digest",
-				manifestFile.Name(),
-			)
+			digest, ok := digests[arch]
+			if !ok {
+				return errors.Errorf(
+					"could not find the image digest for %s on %s",
+					imageVersion, arch,
+				)
+			}
+
+			archImageVersion := fmt.Sprintf("%s-%s:%s", image, arch, version)
+			archImage, err := i.client.Image(archImageVersion)
+			if err != nil {
+				return errors.Wrapf(
+					err, "get remote image %s", archImageVersion,
+				)
+			}
+
+			archDigest, err := archImage.Digest()
 			if err != nil {
 				return errors.Wrapf(
-					err, "get digest from manifest file %s for arch %s",
-					manifestFile.Name(), arch,
+					err, "get digest of %s", archImageVersion,
 				)
 			}
 
-			if digest == "" {
+			if digest != archDigest {
 				return errors.Errorf(
-					"could not find the image digest for %s on %s",
-					imageVersion, arch,
+					"digest %s for %s on %s does not match %s of %s",
+					digest, imageVersion, arch, archDigest, archImageVersion,
 				)
 			}
 
+			if err := i.verifyImage(archImageVersion, archDigest); err != nil {
+				return err
+			}
+
 			logrus.Infof("Digest for %s on %s: %s", imageVersion, arch, digest)
 		}
 	}
@@ -256,43 +453,18 @@ func (i *Images) Exists(registry, version string, fast bool) (bool, error) {
 	for _, image := range manifestImages {
 		imageVersion := fmt.Sprintf("%s/%s:%s", registry, image, version)
 
-		manifestBytes, err := crane.Manifest(imageVersion)
-		if err != nil {
-			return false, errors.Wrapf(
-				err, "get remote manifest from %s", imageVersion,
-			)
-		}
-
-		manifest := string(manifestBytes)
-		manifestFile, err := os.CreateTemp("", "manifest-")
+		_, digests, err := i.remoteDigests(imageVersion)
 		if err != nil {
-			return false, errors.Wrap(err, "create temp file for manifest")
-		}
-		if _, err := manifestFile.WriteString(manifest); err != nil {
-			return false, errors.Wrapf(
-				err, "write manifest to %s", manifestFile.Name(),
-			)
+			return false, err
 		}
-		defer os.RemoveAll(manifestFile.Name())
 
 		for _, arch := range arches {
 			logrus.Infof(
 				"Checking image digest for %s on %s architecture", image, arch,
 			)
 
-			digest, err := i.client.ExecuteOutput(
-				"jq", "--arg", "a", arch, "-r",
-				".manifests[] | select(.platform.architecture == $a) | .digest",
-				manifestFile.Name(),
-			)
-			if err != nil {
-				return false, errors.Wrapf(
-					err, "get digest from manifest file %s for arch %s",
-					manifestFile.Name(), arch,
-				)
-			}
-
-			if digest == "" {
+			digest, ok := digests[arch]
+			if !ok {
 				return false, errors.Errorf(
 					"could not find the image digest for %s on %s",
 					imageVersion, arch,
@@ -306,6 +478,42 @@ func (i *Images) Exists(registry, version string, fast bool) (bool, error) {
 	return true, nil
 }
 
+// remoteDigests returns the digest of the remote image index referenced by
+// imageVersion as well as its manifest digests per architecture.
+func (i *Images) remoteDigests(
+	imageVersion string,
+) (indexDigest v1.Hash, digests map[string]v1.Hash, err error) {
+	idx, err := i.client.Index(imageVersion)
+	if err != nil {
+		return indexDigest, nil, errors.Wrapf(
+			err, "get remote manifest from %s", imageVersion,
+		)
+	}
+
+	indexDigest, err = idx.Digest()
+	if err != nil {
+		return indexDigest, nil, errors.Wrapf(
+			err, "get digest of %s", imageVersion,
+		)
+	}
+
+	indexManifest, err := idx.IndexManifest()
+	if err != nil {
+		return indexDigest, nil, errors.Wrapf(
+			err, "parse remote manifest from %s", imageVersion,
+		)
+	}
+
+	digests = make(map[string]v1.Hash)
+	for _, manifest := range indexManifest.Manifests {
+		if manifest.Platform == nil {
+			continue
+		}
+		digests[manifest.Platform.Architecture] = manifest.Digest
+	}
+	return indexDigest, digests, nil
+}
+
 func (i *Images) getManifestImages(
 	registry, version, buildPath string,
 	forTarballFn func(path, origTag, newTagWithArch string) error,
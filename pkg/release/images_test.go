@@ -17,38 +17,50 @@ limitations under the License.
 package release_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"k8s.io/release/pkg/release"
 	"k8s.io/release/pkg/release/releasefakes"
+	"k8s.io/release/pkg/sign"
 )
 
+const testImagesVersion = "v1.18.9"
+
 func TestPublish(t *testing.T) {
 	for _, tc := range []struct {
-		prepare     func(*releasefakes.FakeCommandClient) (buildPath string, cleanup func())
+		prepare     func(t *testing.T) (buildPath string)
 		shouldError bool
 	}{
 		{ // success
-			prepare: func(mock *releasefakes.FakeCommandClient) (string, func()) {
+			prepare: func(t *testing.T) string {
 				tempDir := newImagesPath(t)
-				prepareImages(t, tempDir, mock)
-
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
+				prepareImages(t, tempDir)
+				return tempDir
 			},
 			shouldError: false,
 		},
 		{ // success skipping wrong dirs/files
-			prepare: func(mock *releasefakes.FakeCommandClient) (string, func()) {
+			prepare: func(t *testing.T) string {
 				tempDir := newImagesPath(t)
-				prepareImages(t, tempDir, mock)
+				prepareImages(t, tempDir)
 
 				// arch is not a directory, should be just skipped
 				require.Nil(t, os.WriteFile(
@@ -62,248 +74,228 @@ func TestPublish(t *testing.T) {
 					[]byte{}, os.FileMode(0o644),
 				))
 
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
+				return tempDir
 			},
 			shouldError: false,
 		},
 		{ // success no images
-			prepare: func(*releasefakes.FakeCommandClient) (string, func()) {
-				tempDir := newImagesPath(t)
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
+			prepare: func(t *testing.T) string {
+				return newImagesPath(t)
 			},
 			shouldError: false,
 		},
-		{ // failure on docker load
-			prepare: func(mock *releasefakes.FakeCommandClient) (string, func()) {
+		{ // failure invalid tarball
+			prepare: func(t *testing.T) string {
 				tempDir := newImagesPath(t)
-				prepareImages(t, tempDir, mock)
-
-				mock.ExecuteReturnsOnCall(0, errors.New(""))
-
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
+				prepareImages(t, tempDir)
+				require.Nil(t, os.WriteFile(
+					filepath.Join(tempDir, release.ImagesPath, "arm", "invalid.tar"),
+					[]byte{}, os.FileMode(0o644),
+				))
+				return tempDir
 			},
 			shouldError: true,
 		},
-		{ // failure on docker tag
-			prepare: func(mock *releasefakes.FakeCommandClient) (string, func()) {
-				tempDir := newImagesPath(t)
-				prepareImages(t, tempDir, mock)
-
-				mock.ExecuteReturnsOnCall(1, errors.New(""))
-
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
+		{ // failure no images-path
+			prepare: func(t *testing.T) string {
+				return t.TempDir()
 			},
 			shouldError: true,
 		},
-		{ // failure on docker push
-			prepare: func(mock *releasefakes.FakeCommandClient) (string, func()) {
-				tempDir := newImagesPath(t)
-				prepareImages(t, tempDir, mock)
+	} {
+		registry := newTestRegistry(t)
+		buildPath := tc.prepare(t)
 
-				mock.ExecuteReturnsOnCall(2, errors.New(""))
+		err := release.NewImages().Publish(registry, testImagesVersion, buildPath)
+		if tc.shouldError {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+	}
+}
 
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
-			},
-			shouldError: true,
-		},
-		{ // failure on docker rmi
-			prepare: func(mock *releasefakes.FakeCommandClient) (string, func()) {
-				tempDir := newImagesPath(t)
-				prepareImages(t, tempDir, mock)
+func TestPublishManifestList(t *testing.T) {
+	registry := newTestRegistry(t)
+	buildPath := newImagesPath(t)
+	prepareImages(t, buildPath)
 
-				mock.ExecuteReturnsOnCall(3, errors.New(""))
+	require.Nil(t, release.NewImages().Publish(
+		registry, testImagesVersion, buildPath,
+	))
 
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
-			},
-			shouldError: true,
-		},
-		{ // failure on docker manifest create
-			prepare: func(mock *releasefakes.FakeCommandClient) (string, func()) {
-				tempDir := newImagesPath(t)
-				prepareImages(t, tempDir, mock)
+	for _, image := range []string{"conformance", "kube-apiserver", "kube-proxy"} {
+		ref, err := name.ParseReference(
+			fmt.Sprintf("%s/%s:%s", registry, image, testImagesVersion),
+		)
+		require.Nil(t, err)
 
-				mock.ExecuteReturnsOnCall(36, errors.New(""))
+		idx, err := remote.Index(ref)
+		require.Nil(t, err)
 
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
-			},
-			shouldError: true,
-		},
-		{ // failure on docker manifest annotate
-			prepare: func(mock *releasefakes.FakeCommandClient) (string, func()) {
-				tempDir := newImagesPath(t)
-				prepareImages(t, tempDir, mock)
-
-				mock.ExecuteReturnsOnCall(37, errors.New(""))
+		mediaType, err := idx.MediaType()
+		require.Nil(t, err)
+		require.Equal(t, types.DockerManifestList, mediaType)
 
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
-			},
-			shouldError: true,
-		},
-		{ // failure on docker manifest push
-			prepare: func(mock *releasefakes.FakeCommandClient) (string, func()) {
-				tempDir := newImagesPath(t)
-				prepareImages(t, tempDir, mock)
+		manifest, err := idx.IndexManifest()
+		require.Nil(t, err)
+		require.Len(t, manifest.Manifests, 3)
 
-				mock.ExecuteReturnsOnCall(40, errors.New(""))
+		arches := []string{}
+		for _, m := range manifest.Manifests {
+			require.Equal(t, "linux", m.Platform.OS)
+			arches = append(arches, m.Platform.Architecture)
+		}
+		require.ElementsMatch(t, []string{"amd64", "arm", "arm64"}, arches)
+	}
+}
 
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
-			},
-			shouldError: true,
-		},
+func TestPublishFailure(t *testing.T) {
+	err := errors.New("")
+	for _, tc := range []struct {
+		prepare func(*releasefakes.FakeImagesClient)
+	}{
 		{ // failure get repo tag from tarball
-			prepare: func(mock *releasefakes.FakeCommandClient) (string, func()) {
-				tempDir := newImagesPath(t)
-				prepareImages(t, tempDir, mock)
-
-				mock.RepoTagFromTarballReturnsOnCall(3, "", errors.New(""))
-
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
+			prepare: func(mock *releasefakes.FakeImagesClient) {
+				mock.RepoTagFromTarballReturnsOnCall(3, "", err)
 			},
-			shouldError: true,
 		},
 		{ // failure wrong repo tag from tarball
-			prepare: func(mock *releasefakes.FakeCommandClient) (string, func()) {
-				tempDir := newImagesPath(t)
-				prepareImages(t, tempDir, mock)
-
+			prepare: func(mock *releasefakes.FakeImagesClient) {
 				mock.RepoTagFromTarballReturnsOnCall(3, "wrong-tag", nil)
-
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
 			},
-			shouldError: true,
 		},
-		{ // failure no images-path
-			prepare: func(*releasefakes.FakeCommandClient) (string, func()) {
-				tempDir, err := os.MkdirTemp("", "publish-test-")
-				require.Nil(t, err)
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
+		{ // failure load image from tarball
+			prepare: func(mock *releasefakes.FakeImagesClient) {
+				mock.ImageFromTarballReturnsOnCall(1, nil, err)
+			},
+		},
+		{ // failure push image
+			prepare: func(mock *releasefakes.FakeImagesClient) {
+				mock.WriteReturnsOnCall(2, err)
+			},
+		},
+		{ // failure get remote image
+			prepare: func(mock *releasefakes.FakeImagesClient) {
+				mock.ImageReturnsOnCall(0, nil, err)
+			},
+		},
+		{ // failure push manifest
+			prepare: func(mock *releasefakes.FakeImagesClient) {
+				mock.WriteIndexReturns(err)
 			},
-			shouldError: true,
 		},
 	} {
+		img, imgErr := random.Image(1024, 1)
+		require.Nil(t, imgErr)
+
+		buildPath := newImagesPath(t)
+		prepareImages(t, buildPath)
+
+		clientMock := &releasefakes.FakeImagesClient{}
+		clientMock.RepoTagFromTarballCalls(func(path string) (string, error) {
+			return fmt.Sprintf(
+				"k8s.gcr.io/%s:%s",
+				strings.TrimSuffix(filepath.Base(path), ".tar"),
+				testImagesVersion,
+			), nil
+		})
+		clientMock.ImageFromTarballReturns(img, nil)
+		clientMock.ImageReturns(img, nil)
+		tc.prepare(clientMock)
+
 		sut := release.NewImages()
-		clientMock := &releasefakes.FakeCommandClient{}
 		sut.SetClient(clientMock)
-		buildPath, cleanup := tc.prepare(clientMock)
 
-		err := sut.Publish(release.GCRIOPathProd, "v1.18.9", buildPath)
-		if tc.shouldError {
-			require.NotNil(t, err)
-		} else {
-			require.Nil(t, err)
-		}
-		cleanup()
+		require.NotNil(t, sut.Publish(
+			release.GCRIOPathProd, testImagesVersion, buildPath,
+		))
 	}
 }
 
 func TestValidate(t *testing.T) {
 	for _, tc := range []struct {
-		prepare     func(*releasefakes.FakeCommandClient) (buildPath string, cleanup func())
+		prepare     func(t *testing.T, registry, buildPath string)
 		shouldError bool
 	}{
 		{ // success
-			prepare: func(mock *releasefakes.FakeCommandClient) (string, func()) {
-				tempDir := newImagesPath(t)
-				prepareImages(t, tempDir, mock)
-
-				mock.ExecuteOutputReturns("digest", nil)
-
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
+			prepare: func(t *testing.T, registry, buildPath string) {
+				prepareImages(t, buildPath)
+				require.Nil(t, release.NewImages().Publish(
+					registry, testImagesVersion, buildPath,
+				))
 			},
 			shouldError: false,
 		},
-		{ // failure on crane call
-			prepare: func(mock *releasefakes.FakeCommandClient) (string, func()) {
-				tempDir := newImagesPath(t)
-				prepareImages(t, tempDir, mock)
-
-				mock.ExecuteOutputReturnsOnCall(1, "", errors.New(""))
+		{ // failure digest mismatch
+			prepare: func(t *testing.T, registry, buildPath string) {
+				prepareImages(t, buildPath)
+				require.Nil(t, release.NewImages().Publish(
+					registry, testImagesVersion, buildPath,
+				))
 
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
+				img, err := random.Image(1024, 1)
+				require.Nil(t, err)
+				ref, err := name.ParseReference(fmt.Sprintf(
+					"%s/kube-proxy-arm:%s", registry, testImagesVersion,
+				))
+				require.Nil(t, err)
+				require.Nil(t, remote.Write(ref, img))
 			},
 			shouldError: true,
 		},
-		{ // failure no digest
-			prepare: func(mock *releasefakes.FakeCommandClient) (string, func()) {
-				tempDir := newImagesPath(t)
-				prepareImages(t, tempDir, mock)
-
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
+		{ // failure missing arch in manifest
+			prepare: func(t *testing.T, registry, buildPath string) {
+				prepareImages(t, buildPath)
+				require.Nil(t, release.NewImages().Publish(
+					registry, testImagesVersion, buildPath,
+				))
+				writeImageTarball(t,
+					filepath.Join(buildPath, release.ImagesPath, "s390x", "kube-proxy.tar"),
+					fmt.Sprintf("k8s.gcr.io/kube-proxy:%s", testImagesVersion),
+				)
 			},
 			shouldError: true,
 		},
-		{ // failure on remote digest retrieval
-			prepare: func(mock *releasefakes.FakeCommandClient) (string, func()) {
-				tempDir := newImagesPath(t)
-				prepareImages(t, tempDir, mock)
-
-				mock.ExecuteOutputReturns("", errors.New(""))
-
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
+		{ // failure not published
+			prepare: func(t *testing.T, _, buildPath string) {
+				prepareImages(t, buildPath)
 			},
 			shouldError: true,
 		},
 		{ // failure no images-path
-			prepare: func(*releasefakes.FakeCommandClient) (string, func()) {
-				tempDir, err := os.MkdirTemp("", "publish-test-")
-				require.Nil(t, err)
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
+			prepare: func(t *testing.T, _, buildPath string) {
+				require.Nil(t, os.RemoveAll(
+					filepath.Join(buildPath, release.ImagesPath),
+				))
 			},
 			shouldError: true,
 		},
 	} {
-		sut := release.NewImages()
-		clientMock := &releasefakes.FakeCommandClient{}
-		sut.SetClient(clientMock)
-		buildPath, cleanup := tc.prepare(clientMock)
+		registry := newTestRegistry(t)
+		buildPath := newImagesPath(t)
+		tc.prepare(t, registry, buildPath)
 
-		err := sut.Validate(release.GCRIOPathStaging, "v1.18.9", buildPath)
+		err := release.NewImages().Validate(registry, testImagesVersion, buildPath)
 		if tc.shouldError {
 			require.NotNil(t, err)
 		} else {
 			require.Nil(t, err)
 		}
-		cleanup()
 	}
 }
 
+// newTestRegistry starts an in-memory registry and returns its host
+func newTestRegistry(t *testing.T) string {
+	server := httptest.NewServer(registry.New(
+		registry.Logger(log.New(io.Discard, "", 0)),
+	))
+	t.Cleanup(server.Close)
+	return strings.TrimPrefix(server.URL, "http://")
+}
+
 func newImagesPath(t *testing.T) string {
-	tempDir, err := os.MkdirTemp("", "publish-test-")
-	require.Nil(t, err)
+	tempDir := t.TempDir()
 
 	require.Nil(t, os.MkdirAll(
 		filepath.Join(tempDir, release.ImagesPath),
@@ -313,28 +305,90 @@ func newImagesPath(t *testing.T) string {
 	return tempDir
 }
 
-func prepareImages(t *testing.T, tempDir string, mock *releasefakes.FakeCommandClient) {
-	c := 0
+func prepareImages(t *testing.T, tempDir string) {
 	for _, arch := range []string{"amd64", "arm", "arm64"} {
-		archPath := filepath.Join(tempDir, release.ImagesPath, arch)
-		require.Nil(t, os.MkdirAll(archPath, os.FileMode(0o755)))
-
 		for _, image := range []string{
-			"conformance-amd64.tar", "kube-apiserver.tar", "kube-proxy.tar",
+			"conformance-" + arch + ".tar", "kube-apiserver.tar", "kube-proxy.tar",
 		} {
-			require.Nil(t, os.WriteFile(
-				filepath.Join(archPath, image),
-				[]byte{}, os.FileMode(0o644),
-			))
-			mock.RepoTagFromTarballReturnsOnCall(
-				c,
+			writeImageTarball(t,
+				filepath.Join(tempDir, release.ImagesPath, arch, image),
 				fmt.Sprintf(
-					"k8s.gcr.io/%s:v1.18.9",
-					strings.TrimSuffix(image, ".tar"),
+					"k8s.gcr.io/%s:%s",
+					strings.TrimSuffix(image, ".tar"), testImagesVersion,
 				),
-				nil,
 			)
-			c++
+		}
+	}
+}
+
+func writeImageTarball(t *testing.T, path, tag string) {
+	require.Nil(t, os.MkdirAll(filepath.Dir(path), os.FileMode(0o755)))
+
+	img, err := random.Image(1024, 1)
+	require.Nil(t, err)
+
+	ref, err := name.NewTag(tag)
+	require.Nil(t, err)
+
+	require.Nil(t, tarball.WriteToFile(path, ref, img))
+}
+
+func TestPublishSigned(t *testing.T) {
+	newSigner := func(t *testing.T) *sign.Signer {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.Nil(t, err)
+		return sign.NewSigner(key)
+	}
+
+	for _, tc := range []struct {
+		sign        bool
+		verifier    func(signer *sign.Signer) *sign.Verifier
+		shouldError bool
+	}{
+		{ // success
+			sign:        true,
+			verifier:    (*sign.Signer).Verifier,
+			shouldError: false,
+		},
+		{ // success without verification
+			sign:        true,
+			verifier:    func(*sign.Signer) *sign.Verifier { return nil },
+			shouldError: false,
+		},
+		{ // failure not signed
+			sign:        false,
+			verifier:    (*sign.Signer).Verifier,
+			shouldError: true,
+		},
+		{ // failure wrong key
+			sign: true,
+			verifier: func(*sign.Signer) *sign.Verifier {
+				return newSigner(t).Verifier()
+			},
+			shouldError: true,
+		},
+	} {
+		registry := newTestRegistry(t)
+		buildPath := newImagesPath(t)
+		prepareImages(t, buildPath)
+		signer := newSigner(t)
+
+		publisher := release.NewImages()
+		if tc.sign {
+			publisher.SetSigner(signer)
+		}
+		require.Nil(t, publisher.Publish(registry, testImagesVersion, buildPath))
+
+		validator := release.NewImages()
+		if verifier := tc.verifier(signer); verifier != nil {
+			validator.SetVerifier(verifier)
+		}
+
+		err := validator.Validate(registry, testImagesVersion, buildPath)
+		if tc.shouldError {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
 		}
 	}
 }
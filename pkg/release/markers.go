@@ -0,0 +1,251 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/objectstore"
+	"sigs.k8s.io/release-utils/util"
+)
+
+const versionMarkerSuffix = ".txt"
+
+// VersionMarker is a published version marker file, like `stable.txt`
+type VersionMarker struct {
+	// Name is the file name of the marker, like `latest-1.22.txt`
+	Name string
+
+	// Path is the full object store path of the marker
+	Path string
+
+	// Version is the release version the marker points to
+	Version string
+}
+
+// VersionMarkerProblem is a version marker which does not point to an
+// existing build
+type VersionMarkerProblem struct {
+	VersionMarker
+
+	// Reason describes why the marker is considered broken
+	Reason string
+}
+
+// VersionMarkers is the main structure for inspecting and modifying the
+// version markers of a release bucket
+type VersionMarkers struct {
+	store     objectstore.Store
+	publisher *Publisher
+	dryRun    bool
+}
+
+// NewVersionMarkers creates a new VersionMarkers instance for the provided
+// object store
+func NewVersionMarkers(store objectstore.Store) *VersionMarkers {
+	return &VersionMarkers{
+		store:     store,
+		publisher: NewPublisherWithStore(store),
+	}
+}
+
+// SetDryRun can be used to run all checks of Set without writing the
+// version marker
+func (v *VersionMarkers) SetDryRun(dryRun bool) {
+	v.dryRun = dryRun
+}
+
+// List returns all version markers available in the marker path of the
+// bucket, sorted by name.
+func (v *VersionMarkers) List(bucket, gcsRoot string) ([]VersionMarker, error) {
+	markerPath, err := v.publisher.client.GetMarkerPath(bucket, gcsRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "get version marker path")
+	}
+
+	objects, err := v.store.List(markerPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "list version markers in %s", markerPath)
+	}
+	sort.Strings(objects)
+
+	markers := []VersionMarker{}
+	for _, object := range objects {
+		if !strings.HasSuffix(object, versionMarkerSuffix) {
+			continue
+		}
+		content, err := v.store.Read(object)
+		if err != nil {
+			return nil, errors.Wrapf(err, "read version marker %s", object)
+		}
+		markers = append(markers, VersionMarker{
+			Name:    path.Base(object),
+			Path:    object,
+			Version: strings.TrimSpace(string(content)),
+		})
+	}
+	return markers, nil
+}
+
+// Show returns the version marker with the provided name. The `.txt` suffix
+// of the name is optional.
+func (v *VersionMarkers) Show(bucket, gcsRoot, name string) (*VersionMarker, error) {
+	markerPath, err := v.publisher.client.GetMarkerPath(bucket, gcsRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "get version marker path")
+	}
+
+	name = markerFileName(name)
+	objectPath, err := v.store.NormalizePath(markerPath, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "get version marker destination")
+	}
+
+	content, err := v.store.Read(objectPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read version marker %s", objectPath)
+	}
+	return &VersionMarker{
+		Name:    name,
+		Path:    objectPath,
+		Version: strings.TrimSpace(string(content)),
+	}, nil
+}
+
+// Set updates the version marker with the provided name to version. The
+// marker only gets updated if the build for version exists and the version
+// is newer than the currently published one. Setting `force` allows
+// downgrading the marker. Nothing gets written if dry run is enabled.
+func (v *VersionMarkers) Set(
+	bucket, gcsRoot, name, version string, force, privateBucket bool,
+) error {
+	if _, err := util.TagStringToSemver(version); err != nil {
+		return errors.Wrapf(err, "invalid version %s", version)
+	}
+
+	markerPath, err := v.publisher.client.GetMarkerPath(bucket, gcsRoot)
+	if err != nil {
+		return errors.Wrap(err, "get version marker path")
+	}
+
+	name = markerFileName(name)
+	releasePath, err := v.publisher.client.GetReleasePath(
+		bucket, gcsRoot, version, isFastMarker(name),
+	)
+	if err != nil {
+		return errors.Wrap(err, "get release path")
+	}
+	exists, err := v.store.PathExists(releasePath)
+	if err != nil {
+		return errors.Wrapf(err, "check if release files exist at %s", releasePath)
+	}
+	if !exists {
+		return errors.Errorf("release files don't exist at %s", releasePath)
+	}
+
+	needsUpdate, err := v.publisher.VerifyLatestUpdate(name, markerPath, version)
+	if err != nil {
+		return errors.Wrapf(err, "verify latest update for %s", name)
+	}
+	if !needsUpdate {
+		if !force {
+			return errors.Errorf(
+				"version marker %s is already at %s or newer, "+
+					"force the update to downgrade it", name, version,
+			)
+		}
+		logrus.Warnf("Forcing update of %s to %s", name, version)
+	}
+
+	if v.dryRun {
+		logrus.Infof(
+			"Dry run: not updating version marker %s to %s", name, version,
+		)
+		return nil
+	}
+
+	if err := v.publisher.PublishToGcs(
+		name, markerPath, version, privateBucket,
+	); err != nil {
+		return errors.Wrapf(err, "publish version marker %s", name)
+	}
+	return nil
+}
+
+// Audit checks that every version marker of the bucket points to a valid
+// version of an existing build. It returns all markers which do not.
+func (v *VersionMarkers) Audit(
+	bucket, gcsRoot string,
+) ([]VersionMarkerProblem, error) {
+	markers, err := v.List(bucket, gcsRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "list version markers")
+	}
+
+	problems := []VersionMarkerProblem{}
+	for _, marker := range markers {
+		logrus.Infof("Auditing version marker %s (%s)", marker.Name, marker.Version)
+
+		if _, err := util.TagStringToSemver(marker.Version); err != nil {
+			problems = append(problems, VersionMarkerProblem{
+				VersionMarker: marker,
+				Reason:        "invalid version",
+			})
+			continue
+		}
+
+		releasePath, err := v.publisher.client.GetReleasePath(
+			bucket, gcsRoot, marker.Version, isFastMarker(marker.Name),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "get release path")
+		}
+		exists, err := v.store.PathExists(releasePath)
+		if err != nil {
+			return nil, errors.Wrapf(
+				err, "check if release files exist at %s", releasePath,
+			)
+		}
+		if !exists {
+			problems = append(problems, VersionMarkerProblem{
+				VersionMarker: marker,
+				Reason:        "build does not exist at " + releasePath,
+			})
+		}
+	}
+	return problems, nil
+}
+
+// markerFileName ensures the `.txt` suffix of the version marker name.
+func markerFileName(name string) string {
+	if strings.HasSuffix(name, versionMarkerSuffix) {
+		return name
+	}
+	return name + versionMarkerSuffix
+}
+
+// isFastMarker returns true if the version marker points to a fast build.
+func isFastMarker(name string) bool {
+	return strings.HasSuffix(
+		strings.TrimSuffix(name, versionMarkerSuffix), "-fast",
+	)
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/objectstore"
+	"k8s.io/release/pkg/release"
+)
+
+func newMarkersBucket(t *testing.T, builds []string, markers map[string]string) string {
+	bucketDir := t.TempDir()
+	store := objectstore.NewLocal()
+
+	for _, build := range builds {
+		buildPath, err := store.NormalizePath(bucketDir, "release", build, "bin")
+		require.Nil(t, err)
+		require.Nil(t, store.Write(buildPath, []byte{}, nil))
+	}
+
+	for marker, content := range markers {
+		markerFile, err := store.NormalizePath(bucketDir, "release", marker)
+		require.Nil(t, err)
+		require.Nil(t, store.Write(markerFile, []byte(content), nil))
+	}
+	return bucketDir
+}
+
+func TestVersionMarkersList(t *testing.T) {
+	bucketDir := newMarkersBucket(t, []string{"v1.20.0"}, map[string]string{
+		"stable.txt":     "v1.20.0",
+		"latest-1.txt":   "v1.21.0-rc.0\n",
+		"release-notes":  "not a marker",
+		"stable-1.20.md": "not a marker",
+	})
+
+	markers, err := release.NewVersionMarkers(objectstore.NewLocal()).
+		List(bucketDir, "release")
+	require.Nil(t, err)
+	require.Len(t, markers, 2)
+	require.Equal(t, "latest-1.txt", markers[0].Name)
+	require.Equal(t, "v1.21.0-rc.0", markers[0].Version)
+	require.Equal(t, "stable.txt", markers[1].Name)
+	require.Equal(t, "v1.20.0", markers[1].Version)
+}
+
+func TestVersionMarkersShow(t *testing.T) {
+	bucketDir := newMarkersBucket(t, nil, map[string]string{
+		"stable.txt": "v1.20.0",
+	})
+	sut := release.NewVersionMarkers(objectstore.NewLocal())
+
+	for _, name := range []string{"stable", "stable.txt"} {
+		marker, err := sut.Show(bucketDir, "release", name)
+		require.Nil(t, err)
+		require.Equal(t, "stable.txt", marker.Name)
+		require.Equal(t, "v1.20.0", marker.Version)
+	}
+
+	_, err := sut.Show(bucketDir, "release", "latest")
+	require.NotNil(t, err)
+}
+
+func TestVersionMarkersSet(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		version     string
+		force       bool
+		dryRun      bool
+		expected    string
+		shouldError bool
+	}{
+		{ // newer version
+			name:     "stable",
+			version:  "v1.21.0",
+			expected: "v1.21.0",
+		},
+		{ // new marker
+			name:     "stable-1.21",
+			version:  "v1.21.0",
+			expected: "v1.21.0",
+		},
+		{ // downgrade without force
+			name:        "stable",
+			version:     "v1.19.0",
+			shouldError: true,
+		},
+		{ // downgrade with force
+			name:     "stable",
+			version:  "v1.19.0",
+			force:    true,
+			expected: "v1.19.0",
+		},
+		{ // build does not exist
+			name:        "stable",
+			version:     "v1.22.0",
+			force:       true,
+			shouldError: true,
+		},
+		{ // invalid version
+			name:        "stable",
+			version:     "invalid",
+			shouldError: true,
+		},
+		{ // dry run does not write the marker
+			name:     "stable",
+			version:  "v1.21.0",
+			dryRun:   true,
+			expected: "v1.20.0",
+		},
+		{ // dry run of a new marker
+			name:     "stable-1.21",
+			version:  "v1.21.0",
+			dryRun:   true,
+			expected: "",
+		},
+		{ // dry run still checks for downgrades
+			name:        "stable",
+			version:     "v1.19.0",
+			dryRun:      true,
+			shouldError: true,
+		},
+		{ // dry run still checks the build
+			name:        "stable",
+			version:     "v1.22.0",
+			force:       true,
+			dryRun:      true,
+			shouldError: true,
+		},
+	} {
+		bucketDir := newMarkersBucket(
+			t, []string{"v1.19.0", "v1.20.0", "v1.21.0"},
+			map[string]string{"stable.txt": "v1.20.0"},
+		)
+		sut := release.NewVersionMarkers(objectstore.NewLocal())
+		sut.SetDryRun(tc.dryRun)
+
+		err := sut.Set(bucketDir, "release", tc.name, tc.version, tc.force, true)
+		if tc.shouldError {
+			require.NotNil(t, err)
+			continue
+		}
+		require.Nil(t, err)
+
+		marker, err := sut.Show(bucketDir, "release", tc.name)
+		if tc.expected == "" {
+			require.NotNil(t, err)
+			continue
+		}
+		require.Nil(t, err)
+		require.Equal(t, tc.expected, marker.Version)
+	}
+}
+
+func TestVersionMarkersAudit(t *testing.T) {
+	bucketDir := newMarkersBucket(
+		t, []string{"v1.20.0", "fast/v1.21.0-alpha.1.5+0123456789abcd"},
+		map[string]string{
+			"stable.txt":      "v1.20.0",
+			"stable-1.19.txt": "v1.19.0",
+			"latest-fast.txt": "v1.21.0-alpha.1.5+0123456789abcd",
+			"latest.txt":      "v1.21.0-alpha.1.5+0123456789abcd",
+			"broken.txt":      "invalid",
+		},
+	)
+
+	problems, err := release.NewVersionMarkers(objectstore.NewLocal()).
+		Audit(bucketDir, "release")
+	require.Nil(t, err)
+
+	res := map[string]string{}
+	for _, problem := range problems {
+		res[problem.Name] = problem.Version
+	}
+	require.Equal(t, map[string]string{
+		"broken.txt":      "invalid",
+		"latest.txt":      "v1.21.0-alpha.1.5+0123456789abcd",
+		"stable-1.19.txt": "v1.19.0",
+	}, res)
+}
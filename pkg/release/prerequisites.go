@@ -17,8 +17,17 @@ limitations under the License.
 package release
 
 import (
+	"bytes"
+	"context"
+	"fmt"
 	"strings"
 
+	"cloud.google.com/go/storage"
+	"github.com/blang/semver"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/google"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/pkg/errors"
 	"github.com/shirou/gopsutil/v3/disk"
 	"github.com/sirupsen/logrus"
@@ -26,31 +35,176 @@ import (
 	"sigs.k8s.io/release-sdk/gcli"
 	"sigs.k8s.io/release-sdk/git"
 	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-sdk/object"
 	"sigs.k8s.io/release-utils/command"
 	"sigs.k8s.io/release-utils/env"
 )
 
+// Names of the built-in prerequisite checks
+const (
+	PrerequisiteCommands      = "commands"
+	PrerequisiteDockerVersion = "docker-version"
+	PrerequisiteGoVersion     = "go-version"
+	PrerequisiteGCloud        = "gcloud"
+	PrerequisiteGitHubToken   = "github-token"
+	PrerequisiteDiskSpace     = "disk-space"
+	PrerequisiteKubeCross     = "kube-cross-image"
+	PrerequisiteSigningKey    = "git-signing-key"
+	PrerequisiteBucketAccess  = "bucket-access"
+	PrerequisiteGitConfig     = "git-config"
+)
+
+// bucketWritePermissions are the IAM permissions required to write to a
+// bucket
+var bucketWritePermissions = []string{
+	"storage.objects.create",
+	"storage.objects.delete",
+	"storage.objects.get",
+	"storage.objects.list",
+}
+
 // PrerequisitesChecker is the main type for checking the prerequisites for a
 // release.
 type PrerequisitesChecker struct {
-	impl prerequisitesCheckerImpl
-	opts *PrerequisitesCheckerOptions
+	impl   prerequisitesCheckerImpl
+	opts   *PrerequisitesCheckerOptions
+	checks []PrerequisiteCheck
 }
 
 // Type prerequisites checker
 type PrerequisitesCheckerOptions struct {
 	CheckGitHubToken bool
+
+	// Commands which have to be available in $PATH
+	Commands []string
+
+	// MinDockerVersion is the minimum required Docker client version
+	MinDockerVersion string
+
+	// MinDiskSpaceGiB is the minimum free disk space of the working directory
+	MinDiskSpaceGiB uint64
+
+	// MinGoVersion is the minimum required Go version, unchecked if empty
+	MinGoVersion string
+
+	// KubeCrossImage is an image reference which has to be pullable,
+	// unchecked if empty
+	KubeCrossImage string
+
+	// CheckSigningKey requires a usable git signing key
+	CheckSigningKey bool
+
+	// TagSigning are the options for signing the release tags, which are
+	// used by the signing key check if a key is configured. Otherwise the
+	// check falls back to the git `user.signingkey` and `gpg.format`.
+	TagSigning *TagSignerOptions
+
+	// Buckets are the GCS buckets which have to be writable
+	Buckets []string
+
+	// ConfigureGitUser sets the global git user and email to the release
+	// defaults, which should only be done in the release environment
+	ConfigureGitUser bool
+
+	// Skip are the names of the checks which should not run
+	Skip []string
 }
 
 var DefaultPrerequisitesCheckerOptions = &PrerequisitesCheckerOptions{
 	CheckGitHubToken: true,
+	Commands:         []string{"docker", "jq", "gsutil", "gcloud", "ssh"},
+	MinDockerVersion: "18.06.0",
+	MinDiskSpaceGiB:  100,
+	ConfigureGitUser: true,
+}
+
+// PrerequisiteCheck is a single check of the release prerequisites
+type PrerequisiteCheck struct {
+	// Name identifies the check, for example to skip it
+	Name string
+
+	// Description summarizes what gets checked
+	Description string
+
+	// Remediation is a hint how to fix a failing check
+	Remediation string
+
+	// Run executes the check for the working directory
+	Run func(workdir string) error
+}
+
+// PrerequisiteResult is the outcome of a single check
+type PrerequisiteResult struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Skipped     bool   `json:"skipped,omitempty"`
+	Error       string `json:"error,omitempty"`
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// Passed returns true if the check succeeded or got skipped
+func (r *PrerequisiteResult) Passed() bool {
+	return r.Error == ""
+}
+
+// PrerequisitesReport contains the results of all prerequisite checks
+type PrerequisitesReport struct {
+	Results []PrerequisiteResult `json:"results"`
+}
+
+// Passed returns true if all checks succeeded
+func (r *PrerequisitesReport) Passed() bool {
+	for i := range r.Results {
+		if !r.Results[i].Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Failed returns the names of all failed checks
+func (r *PrerequisitesReport) Failed() []string {
+	res := []string{}
+	for i := range r.Results {
+		if !r.Results[i].Passed() {
+			res = append(res, r.Results[i].Name)
+		}
+	}
+	return res
+}
+
+// String returns a pass/fail summary including the remediation hints
+func (r *PrerequisitesReport) String() string {
+	var buf bytes.Buffer
+	for _, result := range r.Results {
+		switch {
+		case result.Skipped:
+			fmt.Fprintf(&buf, "SKIP %s: %s\n", result.Name, result.Description)
+		case result.Passed():
+			fmt.Fprintf(&buf, "PASS %s: %s\n", result.Name, result.Description)
+		default:
+			fmt.Fprintf(&buf, "FAIL %s: %s\n", result.Name, result.Description)
+			fmt.Fprintf(&buf, "    - %s\n", result.Error)
+			if result.Remediation != "" {
+				fmt.Fprintf(&buf, "    hint: %s\n", result.Remediation)
+			}
+		}
+	}
+
+	outcome := "PASSED"
+	if !r.Passed() {
+		outcome = "FAILED"
+	}
+	fmt.Fprintf(&buf, "Prerequisites check %s\n", outcome)
+	return buf.String()
 }
 
 // NewPrerequisitesChecker creates a new PrerequisitesChecker instance.
 func NewPrerequisitesChecker() *PrerequisitesChecker {
+	opts := *DefaultPrerequisitesCheckerOptions
 	return &PrerequisitesChecker{
-		&defaultPrerequisitesChecker{},
-		DefaultPrerequisitesCheckerOptions,
+		impl: &defaultPrerequisitesChecker{},
+		opts: &opts,
 	}
 }
 
@@ -64,14 +218,50 @@ func (p *PrerequisitesChecker) SetImpl(impl prerequisitesCheckerImpl) {
 	p.impl = impl
 }
 
+// Register adds a custom check which runs after the built-in ones. A check
+// with the same name as an already registered one replaces it.
+func (p *PrerequisitesChecker) Register(check PrerequisiteCheck) {
+	for i := range p.checks {
+		if p.checks[i].Name == check.Name {
+			p.checks[i] = check
+			return
+		}
+	}
+	p.checks = append(p.checks, check)
+}
+
+// Checks returns all checks in the order they run, which are the built-in
+// checks enabled by the options followed by the registered ones.
+func (p *PrerequisitesChecker) Checks() []PrerequisiteCheck {
+	res := []PrerequisiteCheck{}
+	for _, check := range p.builtinChecks() {
+		replaced := false
+		for _, custom := range p.checks {
+			if custom.Name == check.Name {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			res = append(res, check)
+		}
+	}
+	return append(res, p.checks...)
+}
+
 //counterfeiter:generate . prerequisitesCheckerImpl
 type prerequisitesCheckerImpl interface {
 	CommandAvailable(commands ...string) bool
 	DockerVersion() (string, error)
+	GoVersion() (string, error)
 	GCloudOutput(args ...string) (string, error)
 	IsEnvSet(key string) bool
 	Usage(dir string) (*disk.UsageStat, error)
 	ConfigureGlobalDefaultUserAndEmail() error
+	ImageExists(ref string) error
+	GitConfigValue(key string) (string, error)
+	SigningKeyAvailable(opts *TagSignerOptions) error
+	BucketPermissions(bucket string, permissions []string) ([]string, error)
 }
 
 type defaultPrerequisitesChecker struct{}
@@ -106,78 +296,352 @@ func (*defaultPrerequisitesChecker) DockerVersion() (string, error) {
 	return res.OutputTrimNL(), err
 }
 
+func (*defaultPrerequisitesChecker) GoVersion() (string, error) {
+	res, err := command.New("go", "env", "GOVERSION").RunSilentSuccessOutput()
+	if err != nil {
+		return "", err
+	}
+	return res.OutputTrimNL(), nil
+}
+
 func (*defaultPrerequisitesChecker) Usage(dir string) (*disk.UsageStat, error) {
 	return disk.Usage(dir)
 }
 
-func (p *PrerequisitesChecker) Run(workdir string) error {
-	// Command checks
-	commands := []string{
-		"docker", "jq", "gsutil", "gcloud", "ssh",
+func (*defaultPrerequisitesChecker) ImageExists(ref string) error {
+	parsedRef, err := name.ParseReference(ref)
+	if err != nil {
+		return errors.Wrapf(err, "parsing reference %s", ref)
 	}
-	logrus.Infof(
-		"Verifying that the commands %s exist in $PATH.",
-		strings.Join(commands, ", "),
-	)
+	_, err = remote.Head(parsedRef, remote.WithAuthFromKeychain(
+		authn.NewMultiKeychain(authn.DefaultKeychain, google.Keychain),
+	))
+	return err
+}
 
-	if !p.impl.CommandAvailable(commands...) {
-		return errors.Errorf("not all commands available")
+func (*defaultPrerequisitesChecker) GitConfigValue(key string) (string, error) {
+	res, err := command.New("git", "config", "--get", key).RunSilentSuccessOutput()
+	if err != nil {
+		return "", err
 	}
+	return res.OutputTrimNL(), nil
+}
 
-	// Docker version checks
-	const minVersion = "18.06.0"
-	logrus.Infof("Verifying minimum Docker version %s", minVersion)
-	versionOutput, err := p.impl.DockerVersion()
+func (*defaultPrerequisitesChecker) SigningKeyAvailable(
+	opts *TagSignerOptions,
+) error {
+	signer := NewTagSigner(opts)
+	defer signer.Cleanup()
+	return signer.CheckSigningKey()
+}
+
+func (*defaultPrerequisitesChecker) BucketPermissions(
+	bucket string, permissions []string,
+) ([]string, error) {
+	client, err := storage.NewClient(context.Background())
 	if err != nil {
-		return errors.Wrap(err, "validate docker version")
+		return nil, errors.Wrap(err, "creating storage client")
 	}
-	if versionOutput < minVersion {
+	defer client.Close()
+
+	return client.Bucket(bucket).IAM().TestPermissions(
+		context.Background(), permissions,
+	)
+}
+
+// Run executes all prerequisite checks and returns an error if any of them
+// failed.
+func (p *PrerequisitesChecker) Run(workdir string) error {
+	report := p.Check(workdir)
+	if !report.Passed() {
+		for _, result := range report.Results {
+			if !result.Passed() {
+				logrus.Errorf("Prerequisite %s failed: %s", result.Name, result.Error)
+			}
+		}
 		return errors.Errorf(
-			"minimum docker version %s required, got %s",
-			minVersion, versionOutput,
+			"prerequisites not met: %s", strings.Join(report.Failed(), ", "),
 		)
 	}
+	return nil
+}
 
-	// Google Cloud checks
-	logrus.Info("Verifying Google Cloud access")
-	if _, err := p.impl.GCloudOutput(
-		"config", "get-value", "project",
-	); err != nil {
-		return errors.Wrap(err, "no account authorized through gcloud")
+// Check executes all prerequisite checks, regardless of previous failures,
+// and returns their results.
+func (p *PrerequisitesChecker) Check(workdir string) *PrerequisitesReport {
+	skip := map[string]bool{}
+	for _, name := range p.opts.Skip {
+		skip[name] = true
 	}
 
-	// GitHub checks
-	if p.opts.CheckGitHubToken {
-		logrus.Infof(
-			"Verifying that %s environemt variable is set", github.TokenEnvKey,
-		)
-		if !p.impl.IsEnvSet(github.TokenEnvKey) {
-			return errors.Errorf("no %s env variable set", github.TokenEnvKey)
+	report := &PrerequisitesReport{}
+	for _, check := range p.Checks() {
+		result := PrerequisiteResult{
+			Name:        check.Name,
+			Description: check.Description,
 		}
+		if skip[check.Name] {
+			logrus.Infof("Skipping prerequisite check %s", check.Name)
+			result.Skipped = true
+		} else {
+			logrus.Infof("Checking prerequisite: %s", check.Description)
+			if err := check.Run(workdir); err != nil {
+				result.Error = err.Error()
+				result.Remediation = check.Remediation
+			}
+		}
+		report.Results = append(report.Results, result)
 	}
+	return report
+}
 
-	// Disk space check
-	const minDiskSpaceGiB = 100
-	logrus.Infof(
-		"Checking available disk space (%dGB) for %s", minDiskSpaceGiB, workdir,
-	)
-	res, err := p.impl.Usage(workdir)
-	if err != nil {
-		return errors.Wrap(err, "check available disk space")
+// builtinChecks returns the built-in checks enabled by the options
+func (p *PrerequisitesChecker) builtinChecks() []PrerequisiteCheck {
+	checks := []PrerequisiteCheck{}
+
+	if len(p.opts.Commands) > 0 {
+		checks = append(checks, PrerequisiteCheck{
+			Name: PrerequisiteCommands,
+			Description: fmt.Sprintf(
+				"commands %s exist in $PATH", strings.Join(p.opts.Commands, ", "),
+			),
+			Remediation: "install the missing commands and add them to $PATH",
+			Run: func(string) error {
+				missing := []string{}
+				for _, cmd := range p.opts.Commands {
+					if !p.impl.CommandAvailable(cmd) {
+						missing = append(missing, cmd)
+					}
+				}
+				if len(missing) > 0 {
+					return errors.Errorf(
+						"commands not available: %s", strings.Join(missing, ", "),
+					)
+				}
+				return nil
+			},
+		})
 	}
-	diskSpaceGiB := res.Free / 1024 / 1024 / 1024
-	if diskSpaceGiB < minDiskSpaceGiB {
-		return errors.Errorf(
-			"not enough disk space available. Got %dGiB, need at least %dGiB",
-			diskSpaceGiB, minDiskSpaceGiB,
-		)
+
+	if p.opts.MinDockerVersion != "" {
+		checks = append(checks, PrerequisiteCheck{
+			Name:        PrerequisiteDockerVersion,
+			Description: "minimum Docker version " + p.opts.MinDockerVersion,
+			Remediation: "update Docker and make sure the daemon is running",
+			Run: func(string) error {
+				version, err := p.impl.DockerVersion()
+				if err != nil {
+					return errors.Wrap(err, "validate docker version")
+				}
+				if version < p.opts.MinDockerVersion {
+					return errors.Errorf(
+						"minimum docker version %s required, got %s",
+						p.opts.MinDockerVersion, version,
+					)
+				}
+				return nil
+			},
+		})
 	}
 
-	// Git setup check
-	logrus.Info("Configuring git user and email")
-	if err := p.impl.ConfigureGlobalDefaultUserAndEmail(); err != nil {
-		return errors.Wrap(err, "configure git user and email")
+	if p.opts.MinGoVersion != "" {
+		checks = append(checks, PrerequisiteCheck{
+			Name:        PrerequisiteGoVersion,
+			Description: "minimum Go version " + p.opts.MinGoVersion,
+			Remediation: "install a recent Go version from https://go.dev/dl",
+			Run: func(string) error {
+				minVersion, err := semver.ParseTolerant(
+					strings.TrimPrefix(p.opts.MinGoVersion, "go"),
+				)
+				if err != nil {
+					return errors.Wrapf(
+						err, "parsing minimum Go version %s", p.opts.MinGoVersion,
+					)
+				}
+				output, err := p.impl.GoVersion()
+				if err != nil {
+					return errors.Wrap(err, "get go version")
+				}
+				version, err := semver.ParseTolerant(strings.TrimPrefix(output, "go"))
+				if err != nil {
+					return errors.Wrapf(err, "parsing Go version %s", output)
+				}
+				if version.LT(minVersion) {
+					return errors.Errorf(
+						"minimum go version %s required, got %s",
+						p.opts.MinGoVersion, output,
+					)
+				}
+				return nil
+			},
+		})
 	}
 
-	return nil
+	checks = append(checks, PrerequisiteCheck{
+		Name:        PrerequisiteGCloud,
+		Description: "Google Cloud access",
+		Remediation: "run `gcloud auth login` and `gcloud config set project`",
+		Run: func(string) error {
+			if _, err := p.impl.GCloudOutput(
+				"config", "get-value", "project",
+			); err != nil {
+				return errors.Wrap(err, "no account authorized through gcloud")
+			}
+			return nil
+		},
+	})
+
+	if p.opts.CheckGitHubToken {
+		checks = append(checks, PrerequisiteCheck{
+			Name:        PrerequisiteGitHubToken,
+			Description: fmt.Sprintf("%s environment variable is set", github.TokenEnvKey),
+			Remediation: fmt.Sprintf(
+				"export a GitHub personal access token as %s", github.TokenEnvKey,
+			),
+			Run: func(string) error {
+				if !p.impl.IsEnvSet(github.TokenEnvKey) {
+					return errors.Errorf("no %s env variable set", github.TokenEnvKey)
+				}
+				return nil
+			},
+		})
+	}
+
+	if p.opts.MinDiskSpaceGiB > 0 {
+		checks = append(checks, PrerequisiteCheck{
+			Name: PrerequisiteDiskSpace,
+			Description: fmt.Sprintf(
+				"available disk space of %dGiB", p.opts.MinDiskSpaceGiB,
+			),
+			Remediation: "free up disk space or use another working directory",
+			Run: func(workdir string) error {
+				res, err := p.impl.Usage(workdir)
+				if err != nil {
+					return errors.Wrap(err, "check available disk space")
+				}
+				if res == nil {
+					return errors.Errorf("no disk usage available for %s", workdir)
+				}
+				diskSpaceGiB := res.Free / 1024 / 1024 / 1024
+				if diskSpaceGiB < p.opts.MinDiskSpaceGiB {
+					return errors.Errorf(
+						"not enough disk space available. Got %dGiB, need at least %dGiB",
+						diskSpaceGiB, p.opts.MinDiskSpaceGiB,
+					)
+				}
+				return nil
+			},
+		})
+	}
+
+	if p.opts.KubeCrossImage != "" {
+		checks = append(checks, PrerequisiteCheck{
+			Name:        PrerequisiteKubeCross,
+			Description: fmt.Sprintf("image %s is pullable", p.opts.KubeCrossImage),
+			Remediation: "check the kube-cross version and the registry credentials",
+			Run: func(string) error {
+				return errors.Wrapf(
+					p.impl.ImageExists(p.opts.KubeCrossImage),
+					"image %s not available", p.opts.KubeCrossImage,
+				)
+			},
+		})
+	}
+
+	if p.opts.CheckSigningKey {
+		checks = append(checks, PrerequisiteCheck{
+			Name:        PrerequisiteSigningKey,
+			Description: "git signing key is configured",
+			Remediation: "set the TAG_SIGNING_* environment variables or " +
+				"`git config --global user.signingkey <KEY>` and make the " +
+				"secret key available",
+			Run: func(string) error {
+				opts := p.opts.TagSigning
+				if !opts.Enabled() {
+					key, err := p.impl.GitConfigValue("user.signingkey")
+					if err != nil || key == "" {
+						return errors.New(
+							"neither tag signing nor git user.signingkey configured",
+						)
+					}
+					// An unset gpg.format fails and defaults to OpenPGP
+					format, err := p.impl.GitConfigValue("gpg.format")
+					if err != nil || format == "" {
+						format = TagSigningFormatOpenPGP
+					}
+					opts = &TagSignerOptions{Format: format, Key: key}
+				}
+				return errors.Wrap(
+					p.impl.SigningKeyAvailable(opts), "signing key not usable",
+				)
+			},
+		})
+	}
+
+	if len(p.opts.Buckets) > 0 {
+		checks = append(checks, PrerequisiteCheck{
+			Name: PrerequisiteBucketAccess,
+			Description: fmt.Sprintf(
+				"write access to %s", strings.Join(p.opts.Buckets, ", "),
+			),
+			Remediation: "request write access to the buckets or authenticate " +
+				"using `gcloud auth application-default login`",
+			Run: func(string) error {
+				failures := []string{}
+				for _, bucket := range p.opts.Buckets {
+					bucket = strings.TrimSuffix(
+						strings.TrimPrefix(bucket, object.GcsPrefix), "/",
+					)
+					granted, err := p.impl.BucketPermissions(
+						bucket, bucketWritePermissions,
+					)
+					if err != nil {
+						failures = append(failures, fmt.Sprintf("%s: %v", bucket, err))
+						continue
+					}
+					if missing := missingPermissions(granted); len(missing) > 0 {
+						failures = append(failures, fmt.Sprintf(
+							"%s: missing %s", bucket, strings.Join(missing, ", "),
+						))
+					}
+				}
+				if len(failures) > 0 {
+					return errors.Errorf(
+						"no write access to buckets: %s", strings.Join(failures, "; "),
+					)
+				}
+				return nil
+			},
+		})
+	}
+
+	if p.opts.ConfigureGitUser {
+		checks = append(checks, PrerequisiteCheck{
+			Name:        PrerequisiteGitConfig,
+			Description: "configure the global git user and email",
+			Remediation: "make sure the global git config is writable",
+			Run: func(string) error {
+				return errors.Wrap(
+					p.impl.ConfigureGlobalDefaultUserAndEmail(),
+					"configure git user and email",
+				)
+			},
+		})
+	}
+
+	return checks
+}
+
+func missingPermissions(granted []string) []string {
+	grantedSet := map[string]bool{}
+	for _, permission := range granted {
+		grantedSet[permission] = true
+	}
+	missing := []string{}
+	for _, permission := range bucketWritePermissions {
+		if !grantedSet[permission] {
+			missing = append(missing, permission)
+		}
+	}
+	return missing
 }
@@ -122,3 +122,172 @@ func TestCheckPrerequisites(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckPrerequisitesSigningKey(t *testing.T) {
+	err := errors.New("error")
+	tagSigning := &release.TagSignerOptions{
+		Format: release.TagSigningFormatSSH, KeyFile: "key",
+	}
+	for _, tc := range []struct {
+		tagSigning *release.TagSignerOptions
+		prepare    func(*releasefakes.FakePrerequisitesCheckerImpl)
+		expected   *release.TagSignerOptions
+		shouldFail bool
+	}{
+		{ // tag signing options
+			tagSigning: tagSigning,
+			prepare:    func(*releasefakes.FakePrerequisitesCheckerImpl) {},
+			expected:   tagSigning,
+		},
+		{ // tag signing key not usable
+			tagSigning: tagSigning,
+			prepare: func(mock *releasefakes.FakePrerequisitesCheckerImpl) {
+				mock.SigningKeyAvailableReturns(err)
+			},
+			expected:   tagSigning,
+			shouldFail: true,
+		},
+		{ // git OpenPGP signing key
+			prepare: func(mock *releasefakes.FakePrerequisitesCheckerImpl) {
+				mock.GitConfigValueReturnsOnCall(0, "ABCDEF", nil)
+				mock.GitConfigValueReturnsOnCall(1, "", err)
+			},
+			expected: &release.TagSignerOptions{
+				Format: release.TagSigningFormatOpenPGP, Key: "ABCDEF",
+			},
+		},
+		{ // git SSH signing key
+			prepare: func(mock *releasefakes.FakePrerequisitesCheckerImpl) {
+				mock.GitConfigValueReturnsOnCall(0, "/home/user/.ssh/id.pub", nil)
+				mock.GitConfigValueReturnsOnCall(1, release.TagSigningFormatSSH, nil)
+			},
+			expected: &release.TagSignerOptions{
+				Format: release.TagSigningFormatSSH, Key: "/home/user/.ssh/id.pub",
+			},
+		},
+		{ // no signing key
+			tagSigning: &release.TagSignerOptions{},
+			prepare: func(mock *releasefakes.FakePrerequisitesCheckerImpl) {
+				mock.GitConfigValueReturns("", err)
+			},
+			shouldFail: true,
+		},
+	} {
+		mock := &releasefakes.FakePrerequisitesCheckerImpl{}
+		tc.prepare(mock)
+		sut := release.NewPrerequisitesChecker()
+		sut.SetImpl(mock)
+		*sut.Options() = release.PrerequisitesCheckerOptions{
+			CheckSigningKey: true,
+			TagSigning:      tc.tagSigning,
+		}
+
+		report := sut.Check("")
+		require.Equal(t, tc.shouldFail, !report.Passed(), report.String())
+		if tc.expected == nil {
+			require.Zero(t, mock.SigningKeyAvailableCallCount())
+			continue
+		}
+		require.Equal(t, 1, mock.SigningKeyAvailableCallCount())
+		require.Equal(t, tc.expected, mock.SigningKeyAvailableArgsForCall(0))
+	}
+}
+
+func TestCheckPrerequisitesReport(t *testing.T) {
+	err := errors.New("error")
+	for _, tc := range []struct {
+		prepare func(*releasefakes.FakePrerequisitesCheckerImpl, *release.PrerequisitesChecker)
+		failed  []string
+		skipped []string
+	}{
+		{ // all checks run after failures
+			prepare: func(mock *releasefakes.FakePrerequisitesCheckerImpl, _ *release.PrerequisitesChecker) {
+				mock.CommandAvailableReturns(false)
+				mock.DockerVersionReturns("", err)
+				mock.IsEnvSetReturns(true)
+				mock.UsageReturns(&disk.UsageStat{Free: 100}, nil)
+			},
+			failed: []string{
+				release.PrerequisiteCommands,
+				release.PrerequisiteDockerVersion,
+				release.PrerequisiteDiskSpace,
+			},
+		},
+		{ // skipped checks
+			prepare: func(mock *releasefakes.FakePrerequisitesCheckerImpl, sut *release.PrerequisitesChecker) {
+				mock.CommandAvailableReturns(true)
+				mock.DockerVersionReturns("19.03.13", nil)
+				mock.UsageReturns(&disk.UsageStat{Free: 100}, nil)
+				sut.Options().Skip = []string{
+					release.PrerequisiteDiskSpace, release.PrerequisiteGitHubToken,
+				}
+			},
+			skipped: []string{
+				release.PrerequisiteGitHubToken, release.PrerequisiteDiskSpace,
+			},
+		},
+		{ // optional checks
+			prepare: func(mock *releasefakes.FakePrerequisitesCheckerImpl, sut *release.PrerequisitesChecker) {
+				mock.CommandAvailableReturns(true)
+				mock.DockerVersionReturns("19.03.13", nil)
+				mock.GoVersionReturns("go1.16.7", nil)
+				mock.IsEnvSetReturns(true)
+				mock.UsageReturns(
+					&disk.UsageStat{Free: 101 * 1024 * 1024 * 1024}, nil,
+				)
+				mock.ImageExistsReturns(err)
+				mock.GitConfigValueReturns("ABCDEF", nil)
+				mock.SigningKeyAvailableReturns(err)
+				mock.BucketPermissionsReturns([]string{"storage.objects.get"}, nil)
+
+				opts := sut.Options()
+				opts.MinGoVersion = "1.17"
+				opts.KubeCrossImage = "k8s.gcr.io/build-image/kube-cross:v1.17.3-1"
+				opts.CheckSigningKey = true
+				opts.Buckets = []string{"gs://kubernetes-release-gcb"}
+			},
+			failed: []string{
+				release.PrerequisiteGoVersion,
+				release.PrerequisiteKubeCross,
+				release.PrerequisiteSigningKey,
+				release.PrerequisiteBucketAccess,
+			},
+		},
+		{ // registered checks
+			prepare: func(mock *releasefakes.FakePrerequisitesCheckerImpl, sut *release.PrerequisitesChecker) {
+				mock.CommandAvailableReturns(true)
+				mock.DockerVersionReturns("19.03.13", nil)
+				mock.IsEnvSetReturns(true)
+				mock.UsageReturns(
+					&disk.UsageStat{Free: 101 * 1024 * 1024 * 1024}, nil,
+				)
+				sut.Register(release.PrerequisiteCheck{
+					Name: "custom",
+					Run:  func(string) error { return err },
+				})
+				sut.Register(release.PrerequisiteCheck{
+					Name: release.PrerequisiteDockerVersion,
+					Run:  func(string) error { return err },
+				})
+			},
+			failed: []string{release.PrerequisiteDockerVersion, "custom"},
+		},
+	} {
+		mock := &releasefakes.FakePrerequisitesCheckerImpl{}
+		sut := release.NewPrerequisitesChecker()
+		tc.prepare(mock, sut)
+		sut.SetImpl(mock)
+
+		report := sut.Check("")
+		require.Equal(t, len(tc.failed) == 0, report.Passed(), report.String())
+		require.ElementsMatch(t, tc.failed, report.Failed(), report.String())
+
+		skipped := []string{}
+		for _, result := range report.Results {
+			if result.Skipped {
+				skipped = append(skipped, result.Name)
+			}
+		}
+		require.ElementsMatch(t, tc.skipped, skipped)
+	}
+}
@@ -19,14 +19,12 @@ package release
 import (
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
-	"sigs.k8s.io/release-sdk/gcli"
+	"k8s.io/release/pkg/objectstore"
 	"sigs.k8s.io/release-sdk/object"
 	"sigs.k8s.io/release-utils/http"
 	"sigs.k8s.io/release-utils/util"
@@ -37,14 +35,17 @@ type Publisher struct {
 	client publisherClient
 }
 
-// NewPublisher creates a new Publisher instance
+// NewPublisher creates a new Publisher instance which uses Google Cloud
+// Storage
 func NewPublisher() *Publisher {
-	objStore := *object.NewGCS()
-	objStore.SetOptions(
-		objStore.WithNoClobber(false),
-	)
+	return NewPublisherWithStore(objectstore.NewGCS())
+}
+
+// NewPublisherWithStore creates a new Publisher instance which uses the
+// provided object store
+func NewPublisherWithStore(store objectstore.Store) *Publisher {
 	return &Publisher{
-		client: &defaultPublisher{&objStore},
+		client: &defaultPublisher{store},
 	}
 }
 
@@ -53,75 +54,102 @@ func (p *Publisher) SetClient(client publisherClient) {
 	p.client = client
 }
 
-// publisherClient is a client for working with GCS
+// publisherClient is a client for working with the object store
 //counterfeiter:generate . publisherClient
 type publisherClient interface {
-	GSUtil(args ...string) error
-	GSUtilOutput(args ...string) (string, error)
-	GSUtilStatus(args ...string) (bool, error)
+	PathExists(path string) (bool, error)
+	ReadObject(path string) ([]byte, error)
+	WriteObject(path string, content []byte, attrs *objectstore.Attributes) error
+	MakePublic(path string) error
+	PublicURL(path string) string
 	GetURLResponse(url string) (string, error)
 	GetReleasePath(bucket, gcsRoot, version string, fast bool) (string, error)
 	GetMarkerPath(bucket, gcsRoot string) (string, error)
 	NormalizePath(pathParts ...string) (string, error)
-	TempDir(dir, pattern string) (name string, err error)
-	CopyToLocal(remote, local string) error
-	ReadFile(filename string) ([]byte, error)
 	Unmarshal(data []byte, v interface{}) error
 	Marshal(v interface{}) ([]byte, error)
-	TempFile(dir, pattern string) (f *os.File, err error)
-	CopyToRemote(local, remote string) error
 }
 
 type defaultPublisher struct {
-	objStore object.Store
+	objStore objectstore.Store
 }
 
-func (*defaultPublisher) GSUtil(args ...string) error {
-	return gcli.GSUtil(args...)
+func (d *defaultPublisher) PathExists(path string) (bool, error) {
+	return d.objStore.PathExists(path)
 }
 
-func (*defaultPublisher) GSUtilOutput(args ...string) (string, error) {
-	return gcli.GSUtilOutput(args...)
+func (d *defaultPublisher) ReadObject(path string) ([]byte, error) {
+	return d.objStore.Read(path)
 }
 
-func (*defaultPublisher) GSUtilStatus(args ...string) (bool, error) {
-	status, err := gcli.GSUtilStatus(args...)
-	if err != nil {
-		return false, err
-	}
-	return status.Success(), nil
+func (d *defaultPublisher) WriteObject(
+	path string, content []byte, attrs *objectstore.Attributes,
+) error {
+	return d.objStore.Write(path, content, attrs)
+}
+
+func (d *defaultPublisher) MakePublic(path string) error {
+	return d.objStore.MakePublic(path)
+}
+
+func (d *defaultPublisher) PublicURL(path string) string {
+	return d.objStore.PublicURL(path)
 }
 
 func (*defaultPublisher) GetURLResponse(url string) (string, error) {
 	return http.GetURLResponse(url, true)
 }
 
+// GetReleasePath returns the path to retrieve builds from or push builds to
+//
+// Expected destination format:
+//   <scheme>://<bucket>/<gcsRoot>[/fast][/<version>]
 func (d *defaultPublisher) GetReleasePath(
 	bucket, gcsRoot, version string, fast bool,
 ) (string, error) {
-	return d.objStore.GetReleasePath(bucket, gcsRoot, version, fast)
+	if gcsRoot == "" {
+		return "", errors.New("GCS root must be specified")
+	}
+
+	pathParts := []string{bucket, gcsRoot}
+	if fast {
+		pathParts = append(pathParts, "fast")
+	}
+	if version != "" {
+		pathParts = append(pathParts, version)
+	}
+
+	releasePath, err := d.objStore.NormalizePath(pathParts...)
+	if err != nil {
+		return "", errors.Wrap(err, "normalize release path")
+	}
+
+	logrus.Infof("Release path is %s", releasePath)
+	return releasePath, nil
 }
 
+// GetMarkerPath returns the path where version markers should be stored
+//
+// Expected destination format:
+//   <scheme>://<bucket>/<gcsRoot>
 func (d *defaultPublisher) GetMarkerPath(
 	bucket, gcsRoot string,
 ) (string, error) {
-	return d.objStore.GetMarkerPath(bucket, gcsRoot)
-}
-
-func (d *defaultPublisher) NormalizePath(pathParts ...string) (string, error) {
-	return d.objStore.NormalizePath(pathParts...)
-}
+	if gcsRoot == "" {
+		return "", errors.New("GCS root must be specified")
+	}
 
-func (*defaultPublisher) TempDir(dir, pattern string) (name string, err error) {
-	return os.MkdirTemp(dir, pattern)
-}
+	markerPath, err := d.objStore.NormalizePath(bucket, gcsRoot)
+	if err != nil {
+		return "", errors.Wrap(err, "normalize marker path")
+	}
 
-func (d *defaultPublisher) CopyToLocal(remote, local string) error {
-	return d.objStore.CopyToLocal(remote, local)
+	logrus.Infof("Version marker path is %s", markerPath)
+	return markerPath, nil
 }
 
-func (*defaultPublisher) ReadFile(filename string) ([]byte, error) {
-	return os.ReadFile(filename)
+func (d *defaultPublisher) NormalizePath(pathParts ...string) (string, error) {
+	return d.objStore.NormalizePath(pathParts...)
 }
 
 func (*defaultPublisher) Unmarshal(data []byte, v interface{}) error {
@@ -132,14 +160,6 @@ func (*defaultPublisher) Marshal(v interface{}) ([]byte, error) {
 	return json.Marshal(v)
 }
 
-func (*defaultPublisher) TempFile(dir, pattern string) (f *os.File, err error) {
-	return os.CreateTemp(dir, pattern)
-}
-
-func (d *defaultPublisher) CopyToRemote(local, remote string) error {
-	return d.objStore.CopyToRemote(local, remote)
-}
-
 // Publish a new version, (latest or stable) but only if the files actually
 // exist on GCS and the artifacts we're dealing with are newer than the
 // contents in GCS.
@@ -193,9 +213,12 @@ func (p *Publisher) PublishVersion(
 	}
 
 	// TODO: This should probably be a more thorough check of explicit files
-	// TODO: This should explicitly do a `gsutil ls` via gcs.PathExists
-	if err := p.client.GSUtil("ls", releasePath); err != nil {
-		return errors.Wrapf(err, "release files don't exist at %s", releasePath)
+	exists, err := p.client.PathExists(releasePath)
+	if err != nil {
+		return errors.Wrapf(err, "check if release files exist at %s", releasePath)
+	}
+	if !exists {
+		return errors.Errorf("release files don't exist at %s", releasePath)
 	}
 
 	var versionMarkers []string
@@ -240,7 +263,7 @@ func (p *Publisher) PublishVersion(
 		}
 
 		if err := p.PublishToGcs(
-			versionMarker, buildDir, markerPath, version, privateBucket,
+			versionMarker, markerPath, version, privateBucket,
 		); err != nil {
 			return errors.Wrap(err, "publish release to GCS")
 		}
@@ -265,12 +288,15 @@ func (p *Publisher) VerifyLatestUpdate(
 		return false, errors.Wrap(publishFileDstErr, "get marker file destination")
 	}
 
-	// TODO: Should we add a object.`GCS` method for `gsutil cat`?
-	gcsVersion, err := p.client.GSUtilOutput("cat", publishFileDst)
+	content, err := p.client.ReadObject(publishFileDst)
 	if err != nil {
-		logrus.Infof("%s does not exist but will be created", publishFileDst)
-		return true, nil
+		if errors.Is(err, objectstore.ErrNotExist) {
+			logrus.Infof("%s does not exist but will be created", publishFileDst)
+			return true, nil
+		}
+		return false, errors.Wrapf(err, "read %s", publishFileDst)
 	}
+	gcsVersion := strings.TrimSpace(string(content))
 
 	sv, err := util.TagStringToSemver(version)
 	if err != nil {
@@ -295,49 +321,35 @@ func (p *Publisher) VerifyLatestUpdate(
 
 // PublishToGcs publishes a release to GCS
 // publishFile - the GCS location to look in
-// buildDir - build output directory
 // markerPath - the GCS path to publish a version marker to
 // version - release version
 func (p *Publisher) PublishToGcs(
-	publishFile, buildDir, markerPath, version string,
+	publishFile, markerPath, version string,
 	privateBucket bool,
 ) error {
-	releaseStage := filepath.Join(buildDir, ReleaseStagePath)
 	publishFileDst, publishFileDstErr := p.client.NormalizePath(markerPath, publishFile)
 	if publishFileDstErr != nil {
 		return errors.Wrap(publishFileDstErr, "get marker file destination")
 	}
 
-	publicLink := fmt.Sprintf("%s/%s", URLPrefixForBucket(markerPath), publishFile)
+	publicLink := p.client.PublicURL(publishFileDst)
 	if strings.HasPrefix(markerPath, ProductionBucket) {
 		publicLink = fmt.Sprintf("%s/%s", ProductionBucketURL, publishFile)
 	}
 
-	uploadDir := filepath.Join(releaseStage, "upload")
-	if err := os.MkdirAll(uploadDir, os.FileMode(0o755)); err != nil {
-		return errors.Wrapf(err, "create upload dir %s", uploadDir)
-	}
-
-	latestFile := filepath.Join(uploadDir, "latest")
-	if err := os.WriteFile(
-		latestFile, []byte(version), os.FileMode(0o644),
-	); err != nil {
-		return errors.Wrap(err, "write latest version file")
-	}
-
-	if err := p.client.GSUtil(
-		"-m",
-		"-h", "Content-Type:text/plain",
-		"-h", "Cache-Control:private, max-age=0, no-transform",
-		"cp",
-		latestFile,
+	if err := p.client.WriteObject(
 		publishFileDst,
+		[]byte(version),
+		&objectstore.Attributes{
+			ContentType:  "text/plain",
+			CacheControl: "private, max-age=0, no-transform",
+		},
 	); err != nil {
-		return errors.Wrapf(err, "copy %s to %s", latestFile, publishFileDst)
+		return errors.Wrapf(err, "write version marker %s", publishFileDst)
 	}
 
 	var content string
-	if !privateBucket {
+	if !privateBucket && publicLink != "" {
 		// New Kubernetes infra buckets, like k8s-staging-kubernetes, have a
 		// bucket-only ACL policy set, which means attempting to set the ACL on
 		// an object will fail. We should skip this ACL change in those
@@ -348,13 +360,10 @@ func (p *Publisher) PublishToGcs(
 		// - https://cloud.google.com/storage/docs/bucket-policy-only
 		// - https://github.com/kubernetes/release/issues/904
 		if !strings.HasPrefix(markerPath, object.GcsPrefix+"k8s-") {
-			aclOutput, err := p.client.GSUtilOutput(
-				"acl", "ch", "-R", "-g", "all:R", publishFileDst,
-			)
-			if err != nil {
+			logrus.Infof("Making uploaded version file public: %s", publishFileDst)
+			if err := p.client.MakePublic(publishFileDst); err != nil {
 				return errors.Wrapf(err, "change %s permissions", publishFileDst)
 			}
-			logrus.Infof("Making uploaded version file public: %s", aclOutput)
 		}
 
 		// If public, validate public link
@@ -364,11 +373,12 @@ func (p *Publisher) PublishToGcs(
 		}
 		content = response
 	} else {
-		response, err := p.client.GSUtilOutput("cat", publicLink)
+		response, err := p.client.ReadObject(publishFileDst)
 		if err != nil {
-			return errors.Wrapf(err, "get content of %s", publicLink)
+			return errors.Wrapf(err, "get content of %s", publishFileDst)
 		}
-		content = response
+		content = string(response)
+		publicLink = publishFileDst
 	}
 
 	logrus.Infof("Validating uploaded version file at %s", publicLink)
@@ -403,32 +413,19 @@ func (p *Publisher) PublishReleaseNotesIndex(
 		return errors.Wrap(err, "normalize release notes file")
 	}
 
-	success, err := p.client.GSUtilStatus("-q", "stat", indexFilePath)
+	exists, err := p.client.PathExists(indexFilePath)
 	if err != nil {
-		return errors.Wrap(err, "run gcsutil stat")
+		return errors.Wrapf(err, "check if %s exists", indexFilePath)
 	}
 
 	logrus.Info("Building release notes index")
 	versions := make(map[string]string)
-	if success {
+	if exists {
 		logrus.Info("Modifying existing release notes index file")
 
-		tempDir, err := p.client.TempDir("", "release-notes-index-")
+		indexBytes, err := p.client.ReadObject(indexFilePath)
 		if err != nil {
-			return errors.Wrap(err, "create temp dir")
-		}
-		defer os.RemoveAll(tempDir)
-		tempIndexFile := filepath.Join(tempDir, releaseNotesIndex)
-
-		if err := p.client.CopyToLocal(
-			indexFilePath, tempIndexFile,
-		); err != nil {
-			return errors.Wrap(err, "copy index file to local")
-		}
-
-		indexBytes, err := p.client.ReadFile(tempIndexFile)
-		if err != nil {
-			return errors.Wrap(err, "read local index file")
+			return errors.Wrap(err, "read index file")
 		}
 
 		if err := p.client.Unmarshal(indexBytes, &versions); err != nil {
@@ -445,18 +442,10 @@ func (p *Publisher) PublishReleaseNotesIndex(
 	}
 
 	logrus.Infof("Writing new release notes index: %s", string(versionJSON))
-	tempFile, err := p.client.TempFile("", "release-notes-index-")
-	if err != nil {
-		return errors.Wrap(err, "create temp file")
-	}
-	defer os.Remove(tempFile.Name())
-	if _, err := tempFile.Write(versionJSON); err != nil {
-		return errors.Wrap(err, "write temp index")
-	}
-
-	logrus.Info("Uploading release notes index")
-	if err := p.client.CopyToRemote(
-		tempFile.Name(), indexFilePath,
+	if err := p.client.WriteObject(
+		indexFilePath,
+		versionJSON,
+		&objectstore.Attributes{ContentType: "application/json"},
 	); err != nil {
 		return errors.Wrap(err, "upload index file")
 	}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"k8s.io/release/pkg/objectstore"
 	"k8s.io/release/pkg/release"
 	"k8s.io/release/pkg/release/releasefakes"
 )
@@ -33,11 +34,11 @@ func TestPublishVersion(t *testing.T) {
 	)
 
 	mockVersionMarkers := func(mock *releasefakes.FakePublisherClient) {
-		mock.GSUtilOutputReturnsOnCall(0, olderTestVersion, nil)
-		mock.GSUtilOutputReturnsOnCall(1, testVersion, nil)
-		mock.GSUtilOutputReturnsOnCall(2, olderTestVersion, nil)
-		mock.GSUtilOutputReturnsOnCall(3, testVersion, nil)
-		mock.GSUtilOutputReturnsOnCall(4, olderTestVersion, nil)
+		mock.ReadObjectReturnsOnCall(0, []byte(olderTestVersion), nil)
+		mock.ReadObjectReturnsOnCall(1, []byte(testVersion), nil)
+		mock.ReadObjectReturnsOnCall(2, []byte(olderTestVersion), nil)
+		mock.ReadObjectReturnsOnCall(3, []byte(testVersion), nil)
+		mock.ReadObjectReturnsOnCall(4, []byte(olderTestVersion), nil)
 	}
 
 	for _, tc := range []struct {
@@ -60,8 +61,7 @@ func TestPublishVersion(t *testing.T) {
 				tempDir, err := os.MkdirTemp("", "publish-version-test-")
 				require.Nil(t, err)
 
-				mock.GSUtilOutputReturnsOnCall(0, olderTestVersion, nil)
-				mock.GSUtilOutputReturnsOnCall(1, testVersion, nil)
+				mock.ReadObjectReturnsOnCall(0, []byte(olderTestVersion), nil)
 				mock.GetURLResponseReturns(testVersion, nil)
 
 				return tempDir, func() {
@@ -96,7 +96,7 @@ func TestPublishVersion(t *testing.T) {
 				require.Nil(t, err)
 
 				mockVersionMarkers(mock)
-				mock.GSUtilOutputReturnsOnCall(5, testVersion, nil)
+				mock.ReadObjectReturnsOnCall(5, []byte(testVersion), nil)
 
 				return tempDir, func() {
 					require.Nil(t, os.RemoveAll(tempDir))
@@ -114,7 +114,7 @@ func TestPublishVersion(t *testing.T) {
 				require.Nil(t, err)
 
 				mockVersionMarkers(mock)
-				mock.GSUtilOutputReturnsOnCall(5, "", errors.New(""))
+				mock.ReadObjectReturnsOnCall(5, nil, errors.New(""))
 
 				return tempDir, func() {
 					require.Nil(t, os.RemoveAll(tempDir))
@@ -132,7 +132,7 @@ func TestPublishVersion(t *testing.T) {
 				require.Nil(t, err)
 
 				mockVersionMarkers(mock)
-				mock.GSUtilOutputReturnsOnCall(5, "wrong", nil)
+				mock.ReadObjectReturnsOnCall(5, []byte("wrong"), nil)
 
 				return tempDir, func() {
 					require.Nil(t, os.RemoveAll(tempDir))
@@ -184,7 +184,7 @@ func TestPublishVersion(t *testing.T) {
 				tempDir, err := os.MkdirTemp("", "publish-version-test-")
 				require.Nil(t, err)
 
-				mock.GSUtilReturnsOnCall(0, errors.New(""))
+				mock.PathExistsReturns(false, nil)
 
 				return tempDir, func() {
 					require.Nil(t, os.RemoveAll(tempDir))
@@ -209,6 +209,10 @@ func TestPublishVersion(t *testing.T) {
 	} {
 		sut := release.NewPublisher()
 		clientMock := &releasefakes.FakePublisherClient{}
+		clientMock.PathExistsReturns(true, nil)
+		clientMock.PublicURLStub = func(path string) string {
+			return "https://storage.googleapis.com/" + path
+		}
 		sut.SetClient(clientMock)
 		buildDir, cleanup := tc.prepare(clientMock)
 
@@ -232,28 +236,18 @@ func TestPublishReleaseNotesIndex(t *testing.T) {
 		shouldError bool
 	}{
 		{ // success not existing
-			prepare: func(mock *releasefakes.FakePublisherClient) {
-				mock.TempFileCalls(os.CreateTemp)
-			},
+			prepare:     func(*releasefakes.FakePublisherClient) {},
 			shouldError: false,
 		},
 		{ // success existing
 			prepare: func(mock *releasefakes.FakePublisherClient) {
-				mock.TempFileCalls(os.CreateTemp)
-				mock.GSUtilStatusReturns(true, nil)
+				mock.PathExistsReturns(true, nil)
 			},
 			shouldError: false,
 		},
-		{ // failure CopyToRemote
-			prepare: func(mock *releasefakes.FakePublisherClient) {
-				mock.TempFileCalls(os.CreateTemp)
-				mock.CopyToRemoteReturns(err)
-			},
-			shouldError: true,
-		},
-		{ // failure TempFile
+		{ // failure WriteObject
 			prepare: func(mock *releasefakes.FakePublisherClient) {
-				mock.TempFileReturns(nil, err)
+				mock.WriteObjectReturns(err)
 			},
 			shouldError: true,
 		},
@@ -265,35 +259,21 @@ func TestPublishReleaseNotesIndex(t *testing.T) {
 		},
 		{ // failure Unmarshal
 			prepare: func(mock *releasefakes.FakePublisherClient) {
-				mock.GSUtilStatusReturns(true, nil)
+				mock.PathExistsReturns(true, nil)
 				mock.UnmarshalReturns(err)
 			},
 			shouldError: true,
 		},
-		{ // failure ReadFile
-			prepare: func(mock *releasefakes.FakePublisherClient) {
-				mock.GSUtilStatusReturns(true, nil)
-				mock.ReadFileReturns(nil, err)
-			},
-			shouldError: true,
-		},
-		{ // failure CopyToLocal
-			prepare: func(mock *releasefakes.FakePublisherClient) {
-				mock.GSUtilStatusReturns(true, nil)
-				mock.CopyToLocalReturns(err)
-			},
-			shouldError: true,
-		},
-		{ // failure TempDir
+		{ // failure ReadObject
 			prepare: func(mock *releasefakes.FakePublisherClient) {
-				mock.GSUtilStatusReturns(true, nil)
-				mock.TempDirReturns("", err)
+				mock.PathExistsReturns(true, nil)
+				mock.ReadObjectReturns(nil, err)
 			},
 			shouldError: true,
 		},
-		{ // failure GSUtilStatus
+		{ // failure PathExists
 			prepare: func(mock *releasefakes.FakePublisherClient) {
-				mock.GSUtilStatusReturns(false, err)
+				mock.PathExistsReturns(false, err)
 			},
 			shouldError: true,
 		},
@@ -325,3 +305,48 @@ func TestPublishReleaseNotesIndex(t *testing.T) {
 		}
 	}
 }
+
+func TestPublishVersionLocalStore(t *testing.T) {
+	const (
+		testVersion      = "v1.20.0"
+		olderTestVersion = "v1.19.3"
+		newerTestVersion = "v1.21.0"
+	)
+
+	bucketDir := t.TempDir()
+	buildDir := t.TempDir()
+	store := objectstore.NewLocal()
+
+	releasePath, err := store.NormalizePath(bucketDir, "release", testVersion, "bin")
+	require.Nil(t, err)
+	require.Nil(t, store.Write(releasePath, []byte{}, nil))
+
+	markerPath, err := store.NormalizePath(bucketDir, "release")
+	require.Nil(t, err)
+	for marker, content := range map[string]string{
+		"stable.txt":      olderTestVersion,
+		"stable-1.20.txt": newerTestVersion,
+	} {
+		markerFile, err := store.NormalizePath(markerPath, marker)
+		require.Nil(t, err)
+		require.Nil(t, store.Write(markerFile, []byte(content), nil))
+	}
+
+	sut := release.NewPublisherWithStore(store)
+	require.Nil(t, sut.PublishVersion(
+		"release", testVersion, buildDir, bucketDir, "release",
+		nil, false, false,
+	))
+
+	for marker, expected := range map[string]string{
+		"stable.txt":      testVersion,
+		"stable-1.txt":    testVersion,
+		"stable-1.20.txt": newerTestVersion,
+	} {
+		markerFile, err := store.NormalizePath(markerPath, marker)
+		require.Nil(t, err)
+		content, err := store.Read(markerFile)
+		require.Nil(t, err)
+		require.Equal(t, expected, string(content))
+	}
+}
@@ -17,15 +17,25 @@ limitations under the License.
 package release
 
 import (
+	"bufio"
+	"fmt"
+	"math"
 	"strings"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-utils/command"
 	"sigs.k8s.io/release-utils/util"
 )
 
+const (
+	gitTagRefPrefix    = "refs/tags/"
+	gitBranchRefPrefix = "refs/heads/"
+)
+
 // GitObjectPusher is an object that pushes things to a gitrepo
 type GitObjectPusher struct {
 	repo git.Repo
@@ -178,6 +188,249 @@ func (gp *GitObjectPusher) PushTag(newTag string) (err error) {
 	return nil
 }
 
+// PushRefs pushes the tags and branches to the remote in a single atomic
+// push transaction, either all refs get updated or none of them. Afterwards
+// the remote refs are verified to point to the local objects.
+//
+// Refs which already point to the expected objects in the remote are not
+// pushed again, so a failed push can be resumed by running PushRefs again
+// with the same tags and branches. Besides release branches, the branch list
+// can contain the main branch.
+func (gp *GitObjectPusher) PushRefs(tagList, branchList []string) error {
+	refs := []string{}
+	expected := map[string]string{}
+
+	for _, tag := range tagList {
+		if err := gp.checkTagName(tag); err != nil {
+			return errors.Wrapf(err, "parsing version tag %s", tag)
+		}
+		ref := gitTagRefPrefix + tag
+		object, err := gp.localObject(ref)
+		if err != nil {
+			return errors.Wrapf(
+				err, "unable to push tag %s, it does not exist in the repo yet", tag,
+			)
+		}
+		refs = append(refs, ref)
+		expected[ref] = object
+	}
+
+	for _, branch := range branchList {
+		if branch != git.DefaultBranch {
+			if err := gp.checkBranchName(branch); err != nil {
+				return errors.Wrapf(err, "checking branch name %s", branch)
+			}
+		}
+		branchExists, err := gp.repo.HasBranch(branch)
+		if err != nil {
+			return errors.Wrap(err, "checking if branch already exists locally")
+		}
+		if !branchExists {
+			return errors.Errorf(
+				"unable to push branch %s, it does not exist in the local repo", branch,
+			)
+		}
+		if err := gp.repo.Checkout(branch); err != nil {
+			return errors.Wrapf(err, "checking out branch %s", branch)
+		}
+		if err := gp.mergeRemoteIfRequired(branch); err != nil {
+			return errors.Wrap(err, "merge remote if required")
+		}
+		ref := gitBranchRefPrefix + branch
+		object, err := gp.localObject(ref)
+		if err != nil {
+			return errors.Wrapf(err, "resolving branch %s", branch)
+		}
+		refs = append(refs, ref)
+		expected[ref] = object
+	}
+
+	if len(refs) == 0 {
+		return nil
+	}
+
+	remoteRefs, err := gp.RemoteRefs(refs...)
+	if err != nil {
+		return errors.Wrap(err, "listing remote refs")
+	}
+
+	pending := []string{}
+	for _, ref := range refs {
+		remoteObject, ok := remoteRefs[ref]
+		switch {
+		case !ok:
+			pending = append(pending, ref)
+		case remoteObject == expected[ref]:
+			logrus.Infof("Remote %s already points to %s, skipping", ref, remoteObject)
+		case strings.HasPrefix(ref, gitTagRefPrefix):
+			return errors.Errorf(
+				"remote tag %s points to %s instead of %s",
+				ref, remoteObject, expected[ref],
+			)
+		default:
+			pending = append(pending, ref)
+		}
+	}
+
+	if len(pending) == 0 {
+		logrus.Infof("All %d refs are already pushed", len(refs))
+		return nil
+	}
+
+	logrus.Infof(
+		"Pushing%s %d refs atomically: %s",
+		dryRunLabel[gp.opts.DryRun], len(pending), strings.Join(pending, ", "),
+	)
+	if err := gp.pushAtomic(pending); err != nil {
+		return errors.Wrapf(
+			err, "atomic push failed, no refs were updated and the push can "+
+				"be resumed by running it again",
+		)
+	}
+
+	if gp.opts.DryRun {
+		return nil
+	}
+
+	if err := gp.VerifyRemoteRefs(expected); err != nil {
+		return errors.Wrap(err, "verifying pushed refs")
+	}
+	logrus.Infof("Successfully pushed and verified %d refs", len(pending))
+	return nil
+}
+
+// VerifyRemoteRefs checks that every ref in the remote points to the
+// expected object. Refs are fully qualified, for example refs/tags/v1.22.0.
+func (gp *GitObjectPusher) VerifyRemoteRefs(expected map[string]string) error {
+	refs := []string{}
+	for ref := range expected {
+		refs = append(refs, ref)
+	}
+	remoteRefs, err := gp.RemoteRefs(refs...)
+	if err != nil {
+		return errors.Wrap(err, "listing remote refs")
+	}
+
+	mismatches := []string{}
+	for _, ref := range refs {
+		remoteObject, ok := remoteRefs[ref]
+		if !ok {
+			mismatches = append(mismatches, fmt.Sprintf("%s is missing", ref))
+			continue
+		}
+		if remoteObject != expected[ref] {
+			mismatches = append(mismatches, fmt.Sprintf(
+				"%s points to %s instead of %s", ref, remoteObject, expected[ref],
+			))
+		}
+	}
+	if len(mismatches) > 0 {
+		return errors.Errorf(
+			"remote refs do not match: %s", strings.Join(mismatches, "; "),
+		)
+	}
+	return nil
+}
+
+// RemoteRefs returns the objects the refs point to in the remote, keyed by
+// the fully qualified ref name. Refs which do not exist in the remote are
+// not part of the result.
+func (gp *GitObjectPusher) RemoteRefs(refs ...string) (map[string]string, error) {
+	output, err := gp.repo.LsRemote(append([]string{git.DefaultRemote}, refs...)...)
+	if err != nil {
+		return nil, errors.Wrap(err, "running ls-remote")
+	}
+
+	wanted := map[string]bool{}
+	for _, ref := range refs {
+		wanted[ref] = true
+	}
+
+	res := map[string]string{}
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// Peeled tags (ending with ^{}) are not of interest, because we
+		// compare the tag objects
+		if len(fields) != 2 || !wanted[fields[1]] {
+			continue
+		}
+		res[fields[1]] = fields[0]
+	}
+	return res, nil
+}
+
+// localObject returns the object the ref points to in the local repository,
+// which is the tag object for annotated tags.
+func (gp *GitObjectPusher) localObject(ref string) (string, error) {
+	res, err := command.NewWithWorkDir(
+		gp.repo.Dir(), "git", "rev-parse", "--verify", "--quiet", ref,
+	).RunSilentSuccessOutput()
+	if err != nil {
+		return "", errors.Wrapf(err, "resolving %s", ref)
+	}
+	return res.OutputTrimNL(), nil
+}
+
+// pushAtomic pushes the refs in one transaction, retrying on network errors
+func (gp *GitObjectPusher) pushAtomic(refs []string) (err error) {
+	args := []string{"push", "--atomic"}
+	if gp.opts.DryRun {
+		args = append(args, "--dry-run")
+	}
+	args = append(args, git.DefaultRemote)
+	for _, ref := range refs {
+		args = append(args, ref+":"+ref)
+	}
+
+	for i := gp.opts.MaxRetries + 1; i > 0; i-- {
+		cmd, cmdErr := command.NewWithWorkDir(gp.repo.Dir(), "git", args...).
+			Filter(`(?m)git:[0-9a-zA-Z]{35,40}`, "[REDACTED]")
+		if cmdErr != nil {
+			return errors.Wrap(cmdErr, "creating git push command")
+		}
+		if err = cmd.RunSilentSuccess(); err == nil {
+			return nil
+		}
+		err = git.NewNetworkError(err)
+		if !err.(git.NetworkError).CanRetry() || gp.opts.MaxRetries == 0 || i == 1 {
+			return err
+		}
+		waitTime := math.Pow(2, float64(gp.opts.MaxRetries-i+1))
+		logrus.Errorf(
+			"Error pushing refs (will retry %d more times in %.0f secs): %v",
+			i-1, waitTime, err,
+		)
+		time.Sleep(time.Duration(waitTime) * time.Second)
+	}
+	return err
+}
+
+// DeleteRemoteTag removes a tag from the remote repository. Deleting a tag
+// which does not exist in the remote is a noop.
+func (gp *GitObjectPusher) DeleteRemoteTag(tag string) error {
+	if err := gp.checkTagName(tag); err != nil {
+		return errors.Wrap(err, "parsing version tag")
+	}
+
+	tagExists, err := gp.repo.HasRemoteTag(tag)
+	if err != nil {
+		return errors.Wrapf(err, "checking if tag %s exists", tag)
+	}
+	if !tagExists {
+		logrus.Infof("Tag %s does not exist in remote. Noop.", tag)
+		return nil
+	}
+
+	logrus.Infof("Deleting%s remote tag %s", dryRunLabel[gp.opts.DryRun], tag)
+	if err := gp.repo.Push(":refs/tags/" + tag); err != nil {
+		return errors.Wrapf(err, "deleting remote tag %s", tag)
+	}
+
+	logrus.Infof("Successfully deleted remote tag %s", tag)
+	return nil
+}
+
 // checkTagName verifies that the specified tag name is valid
 func (gp *GitObjectPusher) checkTagName(tagName string) error {
 	_, err := util.TagStringToSemver(tagName)
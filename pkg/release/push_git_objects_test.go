@@ -18,6 +18,7 @@ package release
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -116,3 +117,79 @@ func TestCheckTagName(t *testing.T) {
 		}
 	}
 }
+
+func TestPushRefs(t *testing.T) {
+	for _, key := range []string{
+		"GIT_AUTHOR_NAME", "GIT_AUTHOR_EMAIL", "GIT_COMMITTER_NAME", "GIT_COMMITTER_EMAIL",
+	} {
+		t.Setenv(key, "test@example.com")
+	}
+
+	ghp, repoPath, err := getTestGitObjectPusher()
+	if repoPath != "" {
+		defer os.RemoveAll(repoPath)
+	}
+	require.Nil(t, err)
+
+	runGit := func(dir string, args ...string) {
+		require.Nil(t, command.NewWithWorkDir(dir, "git", args...).RunSilentSuccess())
+	}
+	remotePath := t.TempDir()
+	runGit(remotePath, "init", "--bare")
+	runGit(repoPath, "remote", "add", "origin", remotePath)
+	runGit(repoPath, "push", "origin", "HEAD:refs/heads/"+git.DefaultBranch)
+
+	// Reject a single branch in the remote to test the atomicity
+	hook := filepath.Join(remotePath, "hooks", "update")
+	require.Nil(t, os.WriteFile(hook, []byte(
+		"#!/bin/sh\n[ \"$1\" = refs/heads/release-1.1 ] && exit 1\nexit 0\n",
+	), os.FileMode(0o755)))
+
+	runGit(repoPath, "branch", "release-1.0")
+	runGit(repoPath, "branch", "release-1.1")
+	runGit(repoPath, "tag", "-a", "-m", "v1.0.0", "v1.0.0")
+	runGit(repoPath, "tag", "v1.1.0-alpha.0")
+	runGit(repoPath, "tag", "-a", "-m", "v1.0.1", "v1.0.1")
+
+	// Successful push
+	tags := []string{"v1.0.0", "v1.1.0-alpha.0"}
+	branches := []string{"release-1.0"}
+	require.Nil(t, ghp.PushRefs(tags, branches))
+
+	expected := map[string]string{}
+	for _, ref := range []string{
+		"refs/tags/v1.0.0", "refs/tags/v1.1.0-alpha.0", "refs/heads/release-1.0",
+	} {
+		object, err := ghp.localObject(ref)
+		require.Nil(t, err)
+		expected[ref] = object
+	}
+	require.Nil(t, ghp.VerifyRemoteRefs(expected))
+
+	// Resuming an already finished push is a noop
+	require.Nil(t, ghp.PushRefs(tags, branches))
+
+	// A rejected ref fails the whole push
+	require.NotNil(t, ghp.PushRefs([]string{"v1.0.1"}, []string{"release-1.1"}))
+	remoteRefs, err := ghp.RemoteRefs("refs/tags/v1.0.1", "refs/heads/release-1.1")
+	require.Nil(t, err)
+	require.Empty(t, remoteRefs)
+
+	// A remote tag pointing to another object is not overwritten
+	runGit(repoPath, "commit", "--allow-empty", "-m", "Second commit")
+	runGit(repoPath, "tag", "-f", "-a", "-m", "v1.0.0", "v1.0.0")
+	require.NotNil(t, ghp.PushRefs([]string{"v1.0.0"}, nil))
+	require.NotNil(t, ghp.VerifyRemoteRefs(map[string]string{
+		"refs/tags/v1.0.0": "0000000000000000000000000000000000000000",
+	}))
+
+	// The main branch is part of the atomic push
+	require.Nil(t, ghp.PushRefs(nil, []string{"release-1.0", git.DefaultBranch}))
+	mainRef := "refs/heads/" + git.DefaultBranch
+	mainObject, err := ghp.localObject(mainRef)
+	require.Nil(t, err)
+	require.Nil(t, ghp.VerifyRemoteRefs(map[string]string{mainRef: mainObject}))
+
+	// Missing local tag
+	require.NotNil(t, ghp.PushRefs([]string{"v1.2.0"}, nil))
+}
@@ -0,0 +1,521 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package releasefakes
+
+import (
+	"sync"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
+type FakeImagesClient struct {
+	ImageStub        func(string) (v1.Image, error)
+	imageMutex       sync.RWMutex
+	imageArgsForCall []struct {
+		arg1 string
+	}
+	imageReturns struct {
+		result1 v1.Image
+		result2 error
+	}
+	imageReturnsOnCall map[int]struct {
+		result1 v1.Image
+		result2 error
+	}
+	ImageFromTarballStub        func(string, string) (v1.Image, error)
+	imageFromTarballMutex       sync.RWMutex
+	imageFromTarballArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	imageFromTarballReturns struct {
+		result1 v1.Image
+		result2 error
+	}
+	imageFromTarballReturnsOnCall map[int]struct {
+		result1 v1.Image
+		result2 error
+	}
+	IndexStub        func(string) (v1.ImageIndex, error)
+	indexMutex       sync.RWMutex
+	indexArgsForCall []struct {
+		arg1 string
+	}
+	indexReturns struct {
+		result1 v1.ImageIndex
+		result2 error
+	}
+	indexReturnsOnCall map[int]struct {
+		result1 v1.ImageIndex
+		result2 error
+	}
+	RepoTagFromTarballStub        func(string) (string, error)
+	repoTagFromTarballMutex       sync.RWMutex
+	repoTagFromTarballArgsForCall []struct {
+		arg1 string
+	}
+	repoTagFromTarballReturns struct {
+		result1 string
+		result2 error
+	}
+	repoTagFromTarballReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	WriteStub        func(string, v1.Image) error
+	writeMutex       sync.RWMutex
+	writeArgsForCall []struct {
+		arg1 string
+		arg2 v1.Image
+	}
+	writeReturns struct {
+		result1 error
+	}
+	writeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WriteIndexStub        func(string, v1.ImageIndex) error
+	writeIndexMutex       sync.RWMutex
+	writeIndexArgsForCall []struct {
+		arg1 string
+		arg2 v1.ImageIndex
+	}
+	writeIndexReturns struct {
+		result1 error
+	}
+	writeIndexReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImagesClient) Image(arg1 string) (v1.Image, error) {
+	fake.imageMutex.Lock()
+	ret, specificReturn := fake.imageReturnsOnCall[len(fake.imageArgsForCall)]
+	fake.imageArgsForCall = append(fake.imageArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ImageStub
+	fakeReturns := fake.imageReturns
+	fake.recordInvocation("Image", []interface{}{arg1})
+	fake.imageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImagesClient) ImageCallCount() int {
+	fake.imageMutex.RLock()
+	defer fake.imageMutex.RUnlock()
+	return len(fake.imageArgsForCall)
+}
+
+func (fake *FakeImagesClient) ImageCalls(stub func(string) (v1.Image, error)) {
+	fake.imageMutex.Lock()
+	defer fake.imageMutex.Unlock()
+	fake.ImageStub = stub
+}
+
+func (fake *FakeImagesClient) ImageArgsForCall(i int) string {
+	fake.imageMutex.RLock()
+	defer fake.imageMutex.RUnlock()
+	argsForCall := fake.imageArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImagesClient) ImageReturns(result1 v1.Image, result2 error) {
+	fake.imageMutex.Lock()
+	defer fake.imageMutex.Unlock()
+	fake.ImageStub = nil
+	fake.imageReturns = struct {
+		result1 v1.Image
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImagesClient) ImageReturnsOnCall(i int, result1 v1.Image, result2 error) {
+	fake.imageMutex.Lock()
+	defer fake.imageMutex.Unlock()
+	fake.ImageStub = nil
+	if fake.imageReturnsOnCall == nil {
+		fake.imageReturnsOnCall = make(map[int]struct {
+			result1 v1.Image
+			result2 error
+		})
+	}
+	fake.imageReturnsOnCall[i] = struct {
+		result1 v1.Image
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImagesClient) ImageFromTarball(arg1 string, arg2 string) (v1.Image, error) {
+	fake.imageFromTarballMutex.Lock()
+	ret, specificReturn := fake.imageFromTarballReturnsOnCall[len(fake.imageFromTarballArgsForCall)]
+	fake.imageFromTarballArgsForCall = append(fake.imageFromTarballArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ImageFromTarballStub
+	fakeReturns := fake.imageFromTarballReturns
+	fake.recordInvocation("ImageFromTarball", []interface{}{arg1, arg2})
+	fake.imageFromTarballMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImagesClient) ImageFromTarballCallCount() int {
+	fake.imageFromTarballMutex.RLock()
+	defer fake.imageFromTarballMutex.RUnlock()
+	return len(fake.imageFromTarballArgsForCall)
+}
+
+func (fake *FakeImagesClient) ImageFromTarballCalls(stub func(string, string) (v1.Image, error)) {
+	fake.imageFromTarballMutex.Lock()
+	defer fake.imageFromTarballMutex.Unlock()
+	fake.ImageFromTarballStub = stub
+}
+
+func (fake *FakeImagesClient) ImageFromTarballArgsForCall(i int) (string, string) {
+	fake.imageFromTarballMutex.RLock()
+	defer fake.imageFromTarballMutex.RUnlock()
+	argsForCall := fake.imageFromTarballArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImagesClient) ImageFromTarballReturns(result1 v1.Image, result2 error) {
+	fake.imageFromTarballMutex.Lock()
+	defer fake.imageFromTarballMutex.Unlock()
+	fake.ImageFromTarballStub = nil
+	fake.imageFromTarballReturns = struct {
+		result1 v1.Image
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImagesClient) ImageFromTarballReturnsOnCall(i int, result1 v1.Image, result2 error) {
+	fake.imageFromTarballMutex.Lock()
+	defer fake.imageFromTarballMutex.Unlock()
+	fake.ImageFromTarballStub = nil
+	if fake.imageFromTarballReturnsOnCall == nil {
+		fake.imageFromTarballReturnsOnCall = make(map[int]struct {
+			result1 v1.Image
+			result2 error
+		})
+	}
+	fake.imageFromTarballReturnsOnCall[i] = struct {
+		result1 v1.Image
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImagesClient) Index(arg1 string) (v1.ImageIndex, error) {
+	fake.indexMutex.Lock()
+	ret, specificReturn := fake.indexReturnsOnCall[len(fake.indexArgsForCall)]
+	fake.indexArgsForCall = append(fake.indexArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.IndexStub
+	fakeReturns := fake.indexReturns
+	fake.recordInvocation("Index", []interface{}{arg1})
+	fake.indexMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImagesClient) IndexCallCount() int {
+	fake.indexMutex.RLock()
+	defer fake.indexMutex.RUnlock()
+	return len(fake.indexArgsForCall)
+}
+
+func (fake *FakeImagesClient) IndexCalls(stub func(string) (v1.ImageIndex, error)) {
+	fake.indexMutex.Lock()
+	defer fake.indexMutex.Unlock()
+	fake.IndexStub = stub
+}
+
+func (fake *FakeImagesClient) IndexArgsForCall(i int) string {
+	fake.indexMutex.RLock()
+	defer fake.indexMutex.RUnlock()
+	argsForCall := fake.indexArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImagesClient) IndexReturns(result1 v1.ImageIndex, result2 error) {
+	fake.indexMutex.Lock()
+	defer fake.indexMutex.Unlock()
+	fake.IndexStub = nil
+	fake.indexReturns = struct {
+		result1 v1.ImageIndex
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImagesClient) IndexReturnsOnCall(i int, result1 v1.ImageIndex, result2 error) {
+	fake.indexMutex.Lock()
+	defer fake.indexMutex.Unlock()
+	fake.IndexStub = nil
+	if fake.indexReturnsOnCall == nil {
+		fake.indexReturnsOnCall = make(map[int]struct {
+			result1 v1.ImageIndex
+			result2 error
+		})
+	}
+	fake.indexReturnsOnCall[i] = struct {
+		result1 v1.ImageIndex
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImagesClient) RepoTagFromTarball(arg1 string) (string, error) {
+	fake.repoTagFromTarballMutex.Lock()
+	ret, specificReturn := fake.repoTagFromTarballReturnsOnCall[len(fake.repoTagFromTarballArgsForCall)]
+	fake.repoTagFromTarballArgsForCall = append(fake.repoTagFromTarballArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RepoTagFromTarballStub
+	fakeReturns := fake.repoTagFromTarballReturns
+	fake.recordInvocation("RepoTagFromTarball", []interface{}{arg1})
+	fake.repoTagFromTarballMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImagesClient) RepoTagFromTarballCallCount() int {
+	fake.repoTagFromTarballMutex.RLock()
+	defer fake.repoTagFromTarballMutex.RUnlock()
+	return len(fake.repoTagFromTarballArgsForCall)
+}
+
+func (fake *FakeImagesClient) RepoTagFromTarballCalls(stub func(string) (string, error)) {
+	fake.repoTagFromTarballMutex.Lock()
+	defer fake.repoTagFromTarballMutex.Unlock()
+	fake.RepoTagFromTarballStub = stub
+}
+
+func (fake *FakeImagesClient) RepoTagFromTarballArgsForCall(i int) string {
+	fake.repoTagFromTarballMutex.RLock()
+	defer fake.repoTagFromTarballMutex.RUnlock()
+	argsForCall := fake.repoTagFromTarballArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImagesClient) RepoTagFromTarballReturns(result1 string, result2 error) {
+	fake.repoTagFromTarballMutex.Lock()
+	defer fake.repoTagFromTarballMutex.Unlock()
+	fake.RepoTagFromTarballStub = nil
+	fake.repoTagFromTarballReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImagesClient) RepoTagFromTarballReturnsOnCall(i int, result1 string, result2 error) {
+	fake.repoTagFromTarballMutex.Lock()
+	defer fake.repoTagFromTarballMutex.Unlock()
+	fake.RepoTagFromTarballStub = nil
+	if fake.repoTagFromTarballReturnsOnCall == nil {
+		fake.repoTagFromTarballReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.repoTagFromTarballReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImagesClient) Write(arg1 string, arg2 v1.Image) error {
+	fake.writeMutex.Lock()
+	ret, specificReturn := fake.writeReturnsOnCall[len(fake.writeArgsForCall)]
+	fake.writeArgsForCall = append(fake.writeArgsForCall, struct {
+		arg1 string
+		arg2 v1.Image
+	}{arg1, arg2})
+	stub := fake.WriteStub
+	fakeReturns := fake.writeReturns
+	fake.recordInvocation("Write", []interface{}{arg1, arg2})
+	fake.writeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImagesClient) WriteCallCount() int {
+	fake.writeMutex.RLock()
+	defer fake.writeMutex.RUnlock()
+	return len(fake.writeArgsForCall)
+}
+
+func (fake *FakeImagesClient) WriteCalls(stub func(string, v1.Image) error) {
+	fake.writeMutex.Lock()
+	defer fake.writeMutex.Unlock()
+	fake.WriteStub = stub
+}
+
+func (fake *FakeImagesClient) WriteArgsForCall(i int) (string, v1.Image) {
+	fake.writeMutex.RLock()
+	defer fake.writeMutex.RUnlock()
+	argsForCall := fake.writeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImagesClient) WriteReturns(result1 error) {
+	fake.writeMutex.Lock()
+	defer fake.writeMutex.Unlock()
+	fake.WriteStub = nil
+	fake.writeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImagesClient) WriteReturnsOnCall(i int, result1 error) {
+	fake.writeMutex.Lock()
+	defer fake.writeMutex.Unlock()
+	fake.WriteStub = nil
+	if fake.writeReturnsOnCall == nil {
+		fake.writeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImagesClient) WriteIndex(arg1 string, arg2 v1.ImageIndex) error {
+	fake.writeIndexMutex.Lock()
+	ret, specificReturn := fake.writeIndexReturnsOnCall[len(fake.writeIndexArgsForCall)]
+	fake.writeIndexArgsForCall = append(fake.writeIndexArgsForCall, struct {
+		arg1 string
+		arg2 v1.ImageIndex
+	}{arg1, arg2})
+	stub := fake.WriteIndexStub
+	fakeReturns := fake.writeIndexReturns
+	fake.recordInvocation("WriteIndex", []interface{}{arg1, arg2})
+	fake.writeIndexMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImagesClient) WriteIndexCallCount() int {
+	fake.writeIndexMutex.RLock()
+	defer fake.writeIndexMutex.RUnlock()
+	return len(fake.writeIndexArgsForCall)
+}
+
+func (fake *FakeImagesClient) WriteIndexCalls(stub func(string, v1.ImageIndex) error) {
+	fake.writeIndexMutex.Lock()
+	defer fake.writeIndexMutex.Unlock()
+	fake.WriteIndexStub = stub
+}
+
+func (fake *FakeImagesClient) WriteIndexArgsForCall(i int) (string, v1.ImageIndex) {
+	fake.writeIndexMutex.RLock()
+	defer fake.writeIndexMutex.RUnlock()
+	argsForCall := fake.writeIndexArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImagesClient) WriteIndexReturns(result1 error) {
+	fake.writeIndexMutex.Lock()
+	defer fake.writeIndexMutex.Unlock()
+	fake.WriteIndexStub = nil
+	fake.writeIndexReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImagesClient) WriteIndexReturnsOnCall(i int, result1 error) {
+	fake.writeIndexMutex.Lock()
+	defer fake.writeIndexMutex.Unlock()
+	fake.WriteIndexStub = nil
+	if fake.writeIndexReturnsOnCall == nil {
+		fake.writeIndexReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeIndexReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImagesClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.imageMutex.RLock()
+	defer fake.imageMutex.RUnlock()
+	fake.imageFromTarballMutex.RLock()
+	defer fake.imageFromTarballMutex.RUnlock()
+	fake.indexMutex.RLock()
+	defer fake.indexMutex.RUnlock()
+	fake.repoTagFromTarballMutex.RLock()
+	defer fake.repoTagFromTarballMutex.RUnlock()
+	fake.writeMutex.RLock()
+	defer fake.writeMutex.RUnlock()
+	fake.writeIndexMutex.RLock()
+	defer fake.writeIndexMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImagesClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -21,9 +21,24 @@ import (
 	"sync"
 
 	"github.com/shirou/gopsutil/v3/disk"
+	"k8s.io/release/pkg/release"
 )
 
 type FakePrerequisitesCheckerImpl struct {
+	BucketPermissionsStub        func(string, []string) ([]string, error)
+	bucketPermissionsMutex       sync.RWMutex
+	bucketPermissionsArgsForCall []struct {
+		arg1 string
+		arg2 []string
+	}
+	bucketPermissionsReturns struct {
+		result1 []string
+		result2 error
+	}
+	bucketPermissionsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	CommandAvailableStub        func(...string) bool
 	commandAvailableMutex       sync.RWMutex
 	commandAvailableArgsForCall []struct {
@@ -70,6 +85,42 @@ type FakePrerequisitesCheckerImpl struct {
 		result1 string
 		result2 error
 	}
+	GitConfigValueStub        func(string) (string, error)
+	gitConfigValueMutex       sync.RWMutex
+	gitConfigValueArgsForCall []struct {
+		arg1 string
+	}
+	gitConfigValueReturns struct {
+		result1 string
+		result2 error
+	}
+	gitConfigValueReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GoVersionStub        func() (string, error)
+	goVersionMutex       sync.RWMutex
+	goVersionArgsForCall []struct {
+	}
+	goVersionReturns struct {
+		result1 string
+		result2 error
+	}
+	goVersionReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	ImageExistsStub        func(string) error
+	imageExistsMutex       sync.RWMutex
+	imageExistsArgsForCall []struct {
+		arg1 string
+	}
+	imageExistsReturns struct {
+		result1 error
+	}
+	imageExistsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	IsEnvSetStub        func(string) bool
 	isEnvSetMutex       sync.RWMutex
 	isEnvSetArgsForCall []struct {
@@ -81,6 +132,17 @@ type FakePrerequisitesCheckerImpl struct {
 	isEnvSetReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	SigningKeyAvailableStub        func(*release.TagSignerOptions) error
+	signingKeyAvailableMutex       sync.RWMutex
+	signingKeyAvailableArgsForCall []struct {
+		arg1 *release.TagSignerOptions
+	}
+	signingKeyAvailableReturns struct {
+		result1 error
+	}
+	signingKeyAvailableReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UsageStub        func(string) (*disk.UsageStat, error)
 	usageMutex       sync.RWMutex
 	usageArgsForCall []struct {
@@ -98,6 +160,76 @@ type FakePrerequisitesCheckerImpl struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakePrerequisitesCheckerImpl) BucketPermissions(arg1 string, arg2 []string) ([]string, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.bucketPermissionsMutex.Lock()
+	ret, specificReturn := fake.bucketPermissionsReturnsOnCall[len(fake.bucketPermissionsArgsForCall)]
+	fake.bucketPermissionsArgsForCall = append(fake.bucketPermissionsArgsForCall, struct {
+		arg1 string
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.BucketPermissionsStub
+	fakeReturns := fake.bucketPermissionsReturns
+	fake.recordInvocation("BucketPermissions", []interface{}{arg1, arg2Copy})
+	fake.bucketPermissionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePrerequisitesCheckerImpl) BucketPermissionsCallCount() int {
+	fake.bucketPermissionsMutex.RLock()
+	defer fake.bucketPermissionsMutex.RUnlock()
+	return len(fake.bucketPermissionsArgsForCall)
+}
+
+func (fake *FakePrerequisitesCheckerImpl) BucketPermissionsCalls(stub func(string, []string) ([]string, error)) {
+	fake.bucketPermissionsMutex.Lock()
+	defer fake.bucketPermissionsMutex.Unlock()
+	fake.BucketPermissionsStub = stub
+}
+
+func (fake *FakePrerequisitesCheckerImpl) BucketPermissionsArgsForCall(i int) (string, []string) {
+	fake.bucketPermissionsMutex.RLock()
+	defer fake.bucketPermissionsMutex.RUnlock()
+	argsForCall := fake.bucketPermissionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePrerequisitesCheckerImpl) BucketPermissionsReturns(result1 []string, result2 error) {
+	fake.bucketPermissionsMutex.Lock()
+	defer fake.bucketPermissionsMutex.Unlock()
+	fake.BucketPermissionsStub = nil
+	fake.bucketPermissionsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePrerequisitesCheckerImpl) BucketPermissionsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.bucketPermissionsMutex.Lock()
+	defer fake.bucketPermissionsMutex.Unlock()
+	fake.BucketPermissionsStub = nil
+	if fake.bucketPermissionsReturnsOnCall == nil {
+		fake.bucketPermissionsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.bucketPermissionsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePrerequisitesCheckerImpl) CommandAvailable(arg1 ...string) bool {
 	fake.commandAvailableMutex.Lock()
 	ret, specificReturn := fake.commandAvailableReturnsOnCall[len(fake.commandAvailableArgsForCall)]
@@ -332,6 +464,187 @@ func (fake *FakePrerequisitesCheckerImpl) GCloudOutputReturnsOnCall(i int, resul
 	}{result1, result2}
 }
 
+func (fake *FakePrerequisitesCheckerImpl) GitConfigValue(arg1 string) (string, error) {
+	fake.gitConfigValueMutex.Lock()
+	ret, specificReturn := fake.gitConfigValueReturnsOnCall[len(fake.gitConfigValueArgsForCall)]
+	fake.gitConfigValueArgsForCall = append(fake.gitConfigValueArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GitConfigValueStub
+	fakeReturns := fake.gitConfigValueReturns
+	fake.recordInvocation("GitConfigValue", []interface{}{arg1})
+	fake.gitConfigValueMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePrerequisitesCheckerImpl) GitConfigValueCallCount() int {
+	fake.gitConfigValueMutex.RLock()
+	defer fake.gitConfigValueMutex.RUnlock()
+	return len(fake.gitConfigValueArgsForCall)
+}
+
+func (fake *FakePrerequisitesCheckerImpl) GitConfigValueCalls(stub func(string) (string, error)) {
+	fake.gitConfigValueMutex.Lock()
+	defer fake.gitConfigValueMutex.Unlock()
+	fake.GitConfigValueStub = stub
+}
+
+func (fake *FakePrerequisitesCheckerImpl) GitConfigValueArgsForCall(i int) string {
+	fake.gitConfigValueMutex.RLock()
+	defer fake.gitConfigValueMutex.RUnlock()
+	argsForCall := fake.gitConfigValueArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePrerequisitesCheckerImpl) GitConfigValueReturns(result1 string, result2 error) {
+	fake.gitConfigValueMutex.Lock()
+	defer fake.gitConfigValueMutex.Unlock()
+	fake.GitConfigValueStub = nil
+	fake.gitConfigValueReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePrerequisitesCheckerImpl) GitConfigValueReturnsOnCall(i int, result1 string, result2 error) {
+	fake.gitConfigValueMutex.Lock()
+	defer fake.gitConfigValueMutex.Unlock()
+	fake.GitConfigValueStub = nil
+	if fake.gitConfigValueReturnsOnCall == nil {
+		fake.gitConfigValueReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.gitConfigValueReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePrerequisitesCheckerImpl) GoVersion() (string, error) {
+	fake.goVersionMutex.Lock()
+	ret, specificReturn := fake.goVersionReturnsOnCall[len(fake.goVersionArgsForCall)]
+	fake.goVersionArgsForCall = append(fake.goVersionArgsForCall, struct {
+	}{})
+	stub := fake.GoVersionStub
+	fakeReturns := fake.goVersionReturns
+	fake.recordInvocation("GoVersion", []interface{}{})
+	fake.goVersionMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePrerequisitesCheckerImpl) GoVersionCallCount() int {
+	fake.goVersionMutex.RLock()
+	defer fake.goVersionMutex.RUnlock()
+	return len(fake.goVersionArgsForCall)
+}
+
+func (fake *FakePrerequisitesCheckerImpl) GoVersionCalls(stub func() (string, error)) {
+	fake.goVersionMutex.Lock()
+	defer fake.goVersionMutex.Unlock()
+	fake.GoVersionStub = stub
+}
+
+func (fake *FakePrerequisitesCheckerImpl) GoVersionReturns(result1 string, result2 error) {
+	fake.goVersionMutex.Lock()
+	defer fake.goVersionMutex.Unlock()
+	fake.GoVersionStub = nil
+	fake.goVersionReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePrerequisitesCheckerImpl) GoVersionReturnsOnCall(i int, result1 string, result2 error) {
+	fake.goVersionMutex.Lock()
+	defer fake.goVersionMutex.Unlock()
+	fake.GoVersionStub = nil
+	if fake.goVersionReturnsOnCall == nil {
+		fake.goVersionReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.goVersionReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePrerequisitesCheckerImpl) ImageExists(arg1 string) error {
+	fake.imageExistsMutex.Lock()
+	ret, specificReturn := fake.imageExistsReturnsOnCall[len(fake.imageExistsArgsForCall)]
+	fake.imageExistsArgsForCall = append(fake.imageExistsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ImageExistsStub
+	fakeReturns := fake.imageExistsReturns
+	fake.recordInvocation("ImageExists", []interface{}{arg1})
+	fake.imageExistsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePrerequisitesCheckerImpl) ImageExistsCallCount() int {
+	fake.imageExistsMutex.RLock()
+	defer fake.imageExistsMutex.RUnlock()
+	return len(fake.imageExistsArgsForCall)
+}
+
+func (fake *FakePrerequisitesCheckerImpl) ImageExistsCalls(stub func(string) error) {
+	fake.imageExistsMutex.Lock()
+	defer fake.imageExistsMutex.Unlock()
+	fake.ImageExistsStub = stub
+}
+
+func (fake *FakePrerequisitesCheckerImpl) ImageExistsArgsForCall(i int) string {
+	fake.imageExistsMutex.RLock()
+	defer fake.imageExistsMutex.RUnlock()
+	argsForCall := fake.imageExistsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePrerequisitesCheckerImpl) ImageExistsReturns(result1 error) {
+	fake.imageExistsMutex.Lock()
+	defer fake.imageExistsMutex.Unlock()
+	fake.ImageExistsStub = nil
+	fake.imageExistsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePrerequisitesCheckerImpl) ImageExistsReturnsOnCall(i int, result1 error) {
+	fake.imageExistsMutex.Lock()
+	defer fake.imageExistsMutex.Unlock()
+	fake.ImageExistsStub = nil
+	if fake.imageExistsReturnsOnCall == nil {
+		fake.imageExistsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageExistsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePrerequisitesCheckerImpl) IsEnvSet(arg1 string) bool {
 	fake.isEnvSetMutex.Lock()
 	ret, specificReturn := fake.isEnvSetReturnsOnCall[len(fake.isEnvSetArgsForCall)]
@@ -393,6 +706,67 @@ func (fake *FakePrerequisitesCheckerImpl) IsEnvSetReturnsOnCall(i int, result1 b
 	}{result1}
 }
 
+func (fake *FakePrerequisitesCheckerImpl) SigningKeyAvailable(arg1 *release.TagSignerOptions) error {
+	fake.signingKeyAvailableMutex.Lock()
+	ret, specificReturn := fake.signingKeyAvailableReturnsOnCall[len(fake.signingKeyAvailableArgsForCall)]
+	fake.signingKeyAvailableArgsForCall = append(fake.signingKeyAvailableArgsForCall, struct {
+		arg1 *release.TagSignerOptions
+	}{arg1})
+	stub := fake.SigningKeyAvailableStub
+	fakeReturns := fake.signingKeyAvailableReturns
+	fake.recordInvocation("SigningKeyAvailable", []interface{}{arg1})
+	fake.signingKeyAvailableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePrerequisitesCheckerImpl) SigningKeyAvailableCallCount() int {
+	fake.signingKeyAvailableMutex.RLock()
+	defer fake.signingKeyAvailableMutex.RUnlock()
+	return len(fake.signingKeyAvailableArgsForCall)
+}
+
+func (fake *FakePrerequisitesCheckerImpl) SigningKeyAvailableCalls(stub func(*release.TagSignerOptions) error) {
+	fake.signingKeyAvailableMutex.Lock()
+	defer fake.signingKeyAvailableMutex.Unlock()
+	fake.SigningKeyAvailableStub = stub
+}
+
+func (fake *FakePrerequisitesCheckerImpl) SigningKeyAvailableArgsForCall(i int) *release.TagSignerOptions {
+	fake.signingKeyAvailableMutex.RLock()
+	defer fake.signingKeyAvailableMutex.RUnlock()
+	argsForCall := fake.signingKeyAvailableArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePrerequisitesCheckerImpl) SigningKeyAvailableReturns(result1 error) {
+	fake.signingKeyAvailableMutex.Lock()
+	defer fake.signingKeyAvailableMutex.Unlock()
+	fake.SigningKeyAvailableStub = nil
+	fake.signingKeyAvailableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePrerequisitesCheckerImpl) SigningKeyAvailableReturnsOnCall(i int, result1 error) {
+	fake.signingKeyAvailableMutex.Lock()
+	defer fake.signingKeyAvailableMutex.Unlock()
+	fake.SigningKeyAvailableStub = nil
+	if fake.signingKeyAvailableReturnsOnCall == nil {
+		fake.signingKeyAvailableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.signingKeyAvailableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePrerequisitesCheckerImpl) Usage(arg1 string) (*disk.UsageStat, error) {
 	fake.usageMutex.Lock()
 	ret, specificReturn := fake.usageReturnsOnCall[len(fake.usageArgsForCall)]
@@ -460,6 +834,8 @@ func (fake *FakePrerequisitesCheckerImpl) UsageReturnsOnCall(i int, result1 *dis
 func (fake *FakePrerequisitesCheckerImpl) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.bucketPermissionsMutex.RLock()
+	defer fake.bucketPermissionsMutex.RUnlock()
 	fake.commandAvailableMutex.RLock()
 	defer fake.commandAvailableMutex.RUnlock()
 	fake.configureGlobalDefaultUserAndEmailMutex.RLock()
@@ -468,8 +844,16 @@ func (fake *FakePrerequisitesCheckerImpl) Invocations() map[string][][]interface
 	defer fake.dockerVersionMutex.RUnlock()
 	fake.gCloudOutputMutex.RLock()
 	defer fake.gCloudOutputMutex.RUnlock()
+	fake.gitConfigValueMutex.RLock()
+	defer fake.gitConfigValueMutex.RUnlock()
+	fake.goVersionMutex.RLock()
+	defer fake.goVersionMutex.RUnlock()
+	fake.imageExistsMutex.RLock()
+	defer fake.imageExistsMutex.RUnlock()
 	fake.isEnvSetMutex.RLock()
 	defer fake.isEnvSetMutex.RUnlock()
+	fake.signingKeyAvailableMutex.RLock()
+	defer fake.signingKeyAvailableMutex.RUnlock()
 	fake.usageMutex.RLock()
 	defer fake.usageMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -18,72 +18,12 @@ limitations under the License.
 package releasefakes
 
 import (
-	"os"
 	"sync"
+
+	"k8s.io/release/pkg/objectstore"
 )
 
 type FakePublisherClient struct {
-	CopyToLocalStub        func(string, string) error
-	copyToLocalMutex       sync.RWMutex
-	copyToLocalArgsForCall []struct {
-		arg1 string
-		arg2 string
-	}
-	copyToLocalReturns struct {
-		result1 error
-	}
-	copyToLocalReturnsOnCall map[int]struct {
-		result1 error
-	}
-	CopyToRemoteStub        func(string, string) error
-	copyToRemoteMutex       sync.RWMutex
-	copyToRemoteArgsForCall []struct {
-		arg1 string
-		arg2 string
-	}
-	copyToRemoteReturns struct {
-		result1 error
-	}
-	copyToRemoteReturnsOnCall map[int]struct {
-		result1 error
-	}
-	GSUtilStub        func(...string) error
-	gSUtilMutex       sync.RWMutex
-	gSUtilArgsForCall []struct {
-		arg1 []string
-	}
-	gSUtilReturns struct {
-		result1 error
-	}
-	gSUtilReturnsOnCall map[int]struct {
-		result1 error
-	}
-	GSUtilOutputStub        func(...string) (string, error)
-	gSUtilOutputMutex       sync.RWMutex
-	gSUtilOutputArgsForCall []struct {
-		arg1 []string
-	}
-	gSUtilOutputReturns struct {
-		result1 string
-		result2 error
-	}
-	gSUtilOutputReturnsOnCall map[int]struct {
-		result1 string
-		result2 error
-	}
-	GSUtilStatusStub        func(...string) (bool, error)
-	gSUtilStatusMutex       sync.RWMutex
-	gSUtilStatusArgsForCall []struct {
-		arg1 []string
-	}
-	gSUtilStatusReturns struct {
-		result1 bool
-		result2 error
-	}
-	gSUtilStatusReturnsOnCall map[int]struct {
-		result1 bool
-		result2 error
-	}
 	GetMarkerPathStub        func(string, string) (string, error)
 	getMarkerPathMutex       sync.RWMutex
 	getMarkerPathArgsForCall []struct {
@@ -127,6 +67,17 @@ type FakePublisherClient struct {
 		result1 string
 		result2 error
 	}
+	MakePublicStub        func(string) error
+	makePublicMutex       sync.RWMutex
+	makePublicArgsForCall []struct {
+		arg1 string
+	}
+	makePublicReturns struct {
+		result1 error
+	}
+	makePublicReturnsOnCall map[int]struct {
+		result1 error
+	}
 	MarshalStub        func(interface{}) ([]byte, error)
 	marshalMutex       sync.RWMutex
 	marshalArgsForCall []struct {
@@ -153,45 +104,41 @@ type FakePublisherClient struct {
 		result1 string
 		result2 error
 	}
-	ReadFileStub        func(string) ([]byte, error)
-	readFileMutex       sync.RWMutex
-	readFileArgsForCall []struct {
+	PathExistsStub        func(string) (bool, error)
+	pathExistsMutex       sync.RWMutex
+	pathExistsArgsForCall []struct {
 		arg1 string
 	}
-	readFileReturns struct {
-		result1 []byte
+	pathExistsReturns struct {
+		result1 bool
 		result2 error
 	}
-	readFileReturnsOnCall map[int]struct {
-		result1 []byte
+	pathExistsReturnsOnCall map[int]struct {
+		result1 bool
 		result2 error
 	}
-	TempDirStub        func(string, string) (string, error)
-	tempDirMutex       sync.RWMutex
-	tempDirArgsForCall []struct {
+	PublicURLStub        func(string) string
+	publicURLMutex       sync.RWMutex
+	publicURLArgsForCall []struct {
 		arg1 string
-		arg2 string
 	}
-	tempDirReturns struct {
+	publicURLReturns struct {
 		result1 string
-		result2 error
 	}
-	tempDirReturnsOnCall map[int]struct {
+	publicURLReturnsOnCall map[int]struct {
 		result1 string
-		result2 error
 	}
-	TempFileStub        func(string, string) (*os.File, error)
-	tempFileMutex       sync.RWMutex
-	tempFileArgsForCall []struct {
+	ReadObjectStub        func(string) ([]byte, error)
+	readObjectMutex       sync.RWMutex
+	readObjectArgsForCall []struct {
 		arg1 string
-		arg2 string
 	}
-	tempFileReturns struct {
-		result1 *os.File
+	readObjectReturns struct {
+		result1 []byte
 		result2 error
 	}
-	tempFileReturnsOnCall map[int]struct {
-		result1 *os.File
+	readObjectReturnsOnCall map[int]struct {
+		result1 []byte
 		result2 error
 	}
 	UnmarshalStub        func([]byte, interface{}) error
@@ -206,321 +153,21 @@ type FakePublisherClient struct {
 	unmarshalReturnsOnCall map[int]struct {
 		result1 error
 	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
-}
-
-func (fake *FakePublisherClient) CopyToLocal(arg1 string, arg2 string) error {
-	fake.copyToLocalMutex.Lock()
-	ret, specificReturn := fake.copyToLocalReturnsOnCall[len(fake.copyToLocalArgsForCall)]
-	fake.copyToLocalArgsForCall = append(fake.copyToLocalArgsForCall, struct {
+	WriteObjectStub        func(string, []byte, *objectstore.Attributes) error
+	writeObjectMutex       sync.RWMutex
+	writeObjectArgsForCall []struct {
 		arg1 string
-		arg2 string
-	}{arg1, arg2})
-	stub := fake.CopyToLocalStub
-	fakeReturns := fake.copyToLocalReturns
-	fake.recordInvocation("CopyToLocal", []interface{}{arg1, arg2})
-	fake.copyToLocalMutex.Unlock()
-	if stub != nil {
-		return stub(arg1, arg2)
+		arg2 []byte
+		arg3 *objectstore.Attributes
 	}
-	if specificReturn {
-		return ret.result1
-	}
-	return fakeReturns.result1
-}
-
-func (fake *FakePublisherClient) CopyToLocalCallCount() int {
-	fake.copyToLocalMutex.RLock()
-	defer fake.copyToLocalMutex.RUnlock()
-	return len(fake.copyToLocalArgsForCall)
-}
-
-func (fake *FakePublisherClient) CopyToLocalCalls(stub func(string, string) error) {
-	fake.copyToLocalMutex.Lock()
-	defer fake.copyToLocalMutex.Unlock()
-	fake.CopyToLocalStub = stub
-}
-
-func (fake *FakePublisherClient) CopyToLocalArgsForCall(i int) (string, string) {
-	fake.copyToLocalMutex.RLock()
-	defer fake.copyToLocalMutex.RUnlock()
-	argsForCall := fake.copyToLocalArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
-}
-
-func (fake *FakePublisherClient) CopyToLocalReturns(result1 error) {
-	fake.copyToLocalMutex.Lock()
-	defer fake.copyToLocalMutex.Unlock()
-	fake.CopyToLocalStub = nil
-	fake.copyToLocalReturns = struct {
+	writeObjectReturns struct {
 		result1 error
-	}{result1}
-}
-
-func (fake *FakePublisherClient) CopyToLocalReturnsOnCall(i int, result1 error) {
-	fake.copyToLocalMutex.Lock()
-	defer fake.copyToLocalMutex.Unlock()
-	fake.CopyToLocalStub = nil
-	if fake.copyToLocalReturnsOnCall == nil {
-		fake.copyToLocalReturnsOnCall = make(map[int]struct {
-			result1 error
-		})
 	}
-	fake.copyToLocalReturnsOnCall[i] = struct {
+	writeObjectReturnsOnCall map[int]struct {
 		result1 error
-	}{result1}
-}
-
-func (fake *FakePublisherClient) CopyToRemote(arg1 string, arg2 string) error {
-	fake.copyToRemoteMutex.Lock()
-	ret, specificReturn := fake.copyToRemoteReturnsOnCall[len(fake.copyToRemoteArgsForCall)]
-	fake.copyToRemoteArgsForCall = append(fake.copyToRemoteArgsForCall, struct {
-		arg1 string
-		arg2 string
-	}{arg1, arg2})
-	stub := fake.CopyToRemoteStub
-	fakeReturns := fake.copyToRemoteReturns
-	fake.recordInvocation("CopyToRemote", []interface{}{arg1, arg2})
-	fake.copyToRemoteMutex.Unlock()
-	if stub != nil {
-		return stub(arg1, arg2)
-	}
-	if specificReturn {
-		return ret.result1
-	}
-	return fakeReturns.result1
-}
-
-func (fake *FakePublisherClient) CopyToRemoteCallCount() int {
-	fake.copyToRemoteMutex.RLock()
-	defer fake.copyToRemoteMutex.RUnlock()
-	return len(fake.copyToRemoteArgsForCall)
-}
-
-func (fake *FakePublisherClient) CopyToRemoteCalls(stub func(string, string) error) {
-	fake.copyToRemoteMutex.Lock()
-	defer fake.copyToRemoteMutex.Unlock()
-	fake.CopyToRemoteStub = stub
-}
-
-func (fake *FakePublisherClient) CopyToRemoteArgsForCall(i int) (string, string) {
-	fake.copyToRemoteMutex.RLock()
-	defer fake.copyToRemoteMutex.RUnlock()
-	argsForCall := fake.copyToRemoteArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
-}
-
-func (fake *FakePublisherClient) CopyToRemoteReturns(result1 error) {
-	fake.copyToRemoteMutex.Lock()
-	defer fake.copyToRemoteMutex.Unlock()
-	fake.CopyToRemoteStub = nil
-	fake.copyToRemoteReturns = struct {
-		result1 error
-	}{result1}
-}
-
-func (fake *FakePublisherClient) CopyToRemoteReturnsOnCall(i int, result1 error) {
-	fake.copyToRemoteMutex.Lock()
-	defer fake.copyToRemoteMutex.Unlock()
-	fake.CopyToRemoteStub = nil
-	if fake.copyToRemoteReturnsOnCall == nil {
-		fake.copyToRemoteReturnsOnCall = make(map[int]struct {
-			result1 error
-		})
-	}
-	fake.copyToRemoteReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
-}
-
-func (fake *FakePublisherClient) GSUtil(arg1 ...string) error {
-	fake.gSUtilMutex.Lock()
-	ret, specificReturn := fake.gSUtilReturnsOnCall[len(fake.gSUtilArgsForCall)]
-	fake.gSUtilArgsForCall = append(fake.gSUtilArgsForCall, struct {
-		arg1 []string
-	}{arg1})
-	stub := fake.GSUtilStub
-	fakeReturns := fake.gSUtilReturns
-	fake.recordInvocation("GSUtil", []interface{}{arg1})
-	fake.gSUtilMutex.Unlock()
-	if stub != nil {
-		return stub(arg1...)
-	}
-	if specificReturn {
-		return ret.result1
-	}
-	return fakeReturns.result1
-}
-
-func (fake *FakePublisherClient) GSUtilCallCount() int {
-	fake.gSUtilMutex.RLock()
-	defer fake.gSUtilMutex.RUnlock()
-	return len(fake.gSUtilArgsForCall)
-}
-
-func (fake *FakePublisherClient) GSUtilCalls(stub func(...string) error) {
-	fake.gSUtilMutex.Lock()
-	defer fake.gSUtilMutex.Unlock()
-	fake.GSUtilStub = stub
-}
-
-func (fake *FakePublisherClient) GSUtilArgsForCall(i int) []string {
-	fake.gSUtilMutex.RLock()
-	defer fake.gSUtilMutex.RUnlock()
-	argsForCall := fake.gSUtilArgsForCall[i]
-	return argsForCall.arg1
-}
-
-func (fake *FakePublisherClient) GSUtilReturns(result1 error) {
-	fake.gSUtilMutex.Lock()
-	defer fake.gSUtilMutex.Unlock()
-	fake.GSUtilStub = nil
-	fake.gSUtilReturns = struct {
-		result1 error
-	}{result1}
-}
-
-func (fake *FakePublisherClient) GSUtilReturnsOnCall(i int, result1 error) {
-	fake.gSUtilMutex.Lock()
-	defer fake.gSUtilMutex.Unlock()
-	fake.GSUtilStub = nil
-	if fake.gSUtilReturnsOnCall == nil {
-		fake.gSUtilReturnsOnCall = make(map[int]struct {
-			result1 error
-		})
-	}
-	fake.gSUtilReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
-}
-
-func (fake *FakePublisherClient) GSUtilOutput(arg1 ...string) (string, error) {
-	fake.gSUtilOutputMutex.Lock()
-	ret, specificReturn := fake.gSUtilOutputReturnsOnCall[len(fake.gSUtilOutputArgsForCall)]
-	fake.gSUtilOutputArgsForCall = append(fake.gSUtilOutputArgsForCall, struct {
-		arg1 []string
-	}{arg1})
-	stub := fake.GSUtilOutputStub
-	fakeReturns := fake.gSUtilOutputReturns
-	fake.recordInvocation("GSUtilOutput", []interface{}{arg1})
-	fake.gSUtilOutputMutex.Unlock()
-	if stub != nil {
-		return stub(arg1...)
-	}
-	if specificReturn {
-		return ret.result1, ret.result2
-	}
-	return fakeReturns.result1, fakeReturns.result2
-}
-
-func (fake *FakePublisherClient) GSUtilOutputCallCount() int {
-	fake.gSUtilOutputMutex.RLock()
-	defer fake.gSUtilOutputMutex.RUnlock()
-	return len(fake.gSUtilOutputArgsForCall)
-}
-
-func (fake *FakePublisherClient) GSUtilOutputCalls(stub func(...string) (string, error)) {
-	fake.gSUtilOutputMutex.Lock()
-	defer fake.gSUtilOutputMutex.Unlock()
-	fake.GSUtilOutputStub = stub
-}
-
-func (fake *FakePublisherClient) GSUtilOutputArgsForCall(i int) []string {
-	fake.gSUtilOutputMutex.RLock()
-	defer fake.gSUtilOutputMutex.RUnlock()
-	argsForCall := fake.gSUtilOutputArgsForCall[i]
-	return argsForCall.arg1
-}
-
-func (fake *FakePublisherClient) GSUtilOutputReturns(result1 string, result2 error) {
-	fake.gSUtilOutputMutex.Lock()
-	defer fake.gSUtilOutputMutex.Unlock()
-	fake.GSUtilOutputStub = nil
-	fake.gSUtilOutputReturns = struct {
-		result1 string
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakePublisherClient) GSUtilOutputReturnsOnCall(i int, result1 string, result2 error) {
-	fake.gSUtilOutputMutex.Lock()
-	defer fake.gSUtilOutputMutex.Unlock()
-	fake.GSUtilOutputStub = nil
-	if fake.gSUtilOutputReturnsOnCall == nil {
-		fake.gSUtilOutputReturnsOnCall = make(map[int]struct {
-			result1 string
-			result2 error
-		})
-	}
-	fake.gSUtilOutputReturnsOnCall[i] = struct {
-		result1 string
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakePublisherClient) GSUtilStatus(arg1 ...string) (bool, error) {
-	fake.gSUtilStatusMutex.Lock()
-	ret, specificReturn := fake.gSUtilStatusReturnsOnCall[len(fake.gSUtilStatusArgsForCall)]
-	fake.gSUtilStatusArgsForCall = append(fake.gSUtilStatusArgsForCall, struct {
-		arg1 []string
-	}{arg1})
-	stub := fake.GSUtilStatusStub
-	fakeReturns := fake.gSUtilStatusReturns
-	fake.recordInvocation("GSUtilStatus", []interface{}{arg1})
-	fake.gSUtilStatusMutex.Unlock()
-	if stub != nil {
-		return stub(arg1...)
-	}
-	if specificReturn {
-		return ret.result1, ret.result2
-	}
-	return fakeReturns.result1, fakeReturns.result2
-}
-
-func (fake *FakePublisherClient) GSUtilStatusCallCount() int {
-	fake.gSUtilStatusMutex.RLock()
-	defer fake.gSUtilStatusMutex.RUnlock()
-	return len(fake.gSUtilStatusArgsForCall)
-}
-
-func (fake *FakePublisherClient) GSUtilStatusCalls(stub func(...string) (bool, error)) {
-	fake.gSUtilStatusMutex.Lock()
-	defer fake.gSUtilStatusMutex.Unlock()
-	fake.GSUtilStatusStub = stub
-}
-
-func (fake *FakePublisherClient) GSUtilStatusArgsForCall(i int) []string {
-	fake.gSUtilStatusMutex.RLock()
-	defer fake.gSUtilStatusMutex.RUnlock()
-	argsForCall := fake.gSUtilStatusArgsForCall[i]
-	return argsForCall.arg1
-}
-
-func (fake *FakePublisherClient) GSUtilStatusReturns(result1 bool, result2 error) {
-	fake.gSUtilStatusMutex.Lock()
-	defer fake.gSUtilStatusMutex.Unlock()
-	fake.GSUtilStatusStub = nil
-	fake.gSUtilStatusReturns = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakePublisherClient) GSUtilStatusReturnsOnCall(i int, result1 bool, result2 error) {
-	fake.gSUtilStatusMutex.Lock()
-	defer fake.gSUtilStatusMutex.Unlock()
-	fake.GSUtilStatusStub = nil
-	if fake.gSUtilStatusReturnsOnCall == nil {
-		fake.gSUtilStatusReturnsOnCall = make(map[int]struct {
-			result1 bool
-			result2 error
-		})
 	}
-	fake.gSUtilStatusReturnsOnCall[i] = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
 }
 
 func (fake *FakePublisherClient) GetMarkerPath(arg1 string, arg2 string) (string, error) {
@@ -719,6 +366,67 @@ func (fake *FakePublisherClient) GetURLResponseReturnsOnCall(i int, result1 stri
 	}{result1, result2}
 }
 
+func (fake *FakePublisherClient) MakePublic(arg1 string) error {
+	fake.makePublicMutex.Lock()
+	ret, specificReturn := fake.makePublicReturnsOnCall[len(fake.makePublicArgsForCall)]
+	fake.makePublicArgsForCall = append(fake.makePublicArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.MakePublicStub
+	fakeReturns := fake.makePublicReturns
+	fake.recordInvocation("MakePublic", []interface{}{arg1})
+	fake.makePublicMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePublisherClient) MakePublicCallCount() int {
+	fake.makePublicMutex.RLock()
+	defer fake.makePublicMutex.RUnlock()
+	return len(fake.makePublicArgsForCall)
+}
+
+func (fake *FakePublisherClient) MakePublicCalls(stub func(string) error) {
+	fake.makePublicMutex.Lock()
+	defer fake.makePublicMutex.Unlock()
+	fake.MakePublicStub = stub
+}
+
+func (fake *FakePublisherClient) MakePublicArgsForCall(i int) string {
+	fake.makePublicMutex.RLock()
+	defer fake.makePublicMutex.RUnlock()
+	argsForCall := fake.makePublicArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePublisherClient) MakePublicReturns(result1 error) {
+	fake.makePublicMutex.Lock()
+	defer fake.makePublicMutex.Unlock()
+	fake.MakePublicStub = nil
+	fake.makePublicReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePublisherClient) MakePublicReturnsOnCall(i int, result1 error) {
+	fake.makePublicMutex.Lock()
+	defer fake.makePublicMutex.Unlock()
+	fake.MakePublicStub = nil
+	if fake.makePublicReturnsOnCall == nil {
+		fake.makePublicReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.makePublicReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePublisherClient) Marshal(arg1 interface{}) ([]byte, error) {
 	fake.marshalMutex.Lock()
 	ret, specificReturn := fake.marshalReturnsOnCall[len(fake.marshalArgsForCall)]
@@ -847,16 +555,16 @@ func (fake *FakePublisherClient) NormalizePathReturnsOnCall(i int, result1 strin
 	}{result1, result2}
 }
 
-func (fake *FakePublisherClient) ReadFile(arg1 string) ([]byte, error) {
-	fake.readFileMutex.Lock()
-	ret, specificReturn := fake.readFileReturnsOnCall[len(fake.readFileArgsForCall)]
-	fake.readFileArgsForCall = append(fake.readFileArgsForCall, struct {
+func (fake *FakePublisherClient) PathExists(arg1 string) (bool, error) {
+	fake.pathExistsMutex.Lock()
+	ret, specificReturn := fake.pathExistsReturnsOnCall[len(fake.pathExistsArgsForCall)]
+	fake.pathExistsArgsForCall = append(fake.pathExistsArgsForCall, struct {
 		arg1 string
 	}{arg1})
-	stub := fake.ReadFileStub
-	fakeReturns := fake.readFileReturns
-	fake.recordInvocation("ReadFile", []interface{}{arg1})
-	fake.readFileMutex.Unlock()
+	stub := fake.PathExistsStub
+	fakeReturns := fake.pathExistsReturns
+	fake.recordInvocation("PathExists", []interface{}{arg1})
+	fake.pathExistsMutex.Unlock()
 	if stub != nil {
 		return stub(arg1)
 	}
@@ -866,129 +574,124 @@ func (fake *FakePublisherClient) ReadFile(arg1 string) ([]byte, error) {
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakePublisherClient) ReadFileCallCount() int {
-	fake.readFileMutex.RLock()
-	defer fake.readFileMutex.RUnlock()
-	return len(fake.readFileArgsForCall)
+func (fake *FakePublisherClient) PathExistsCallCount() int {
+	fake.pathExistsMutex.RLock()
+	defer fake.pathExistsMutex.RUnlock()
+	return len(fake.pathExistsArgsForCall)
 }
 
-func (fake *FakePublisherClient) ReadFileCalls(stub func(string) ([]byte, error)) {
-	fake.readFileMutex.Lock()
-	defer fake.readFileMutex.Unlock()
-	fake.ReadFileStub = stub
+func (fake *FakePublisherClient) PathExistsCalls(stub func(string) (bool, error)) {
+	fake.pathExistsMutex.Lock()
+	defer fake.pathExistsMutex.Unlock()
+	fake.PathExistsStub = stub
 }
 
-func (fake *FakePublisherClient) ReadFileArgsForCall(i int) string {
-	fake.readFileMutex.RLock()
-	defer fake.readFileMutex.RUnlock()
-	argsForCall := fake.readFileArgsForCall[i]
+func (fake *FakePublisherClient) PathExistsArgsForCall(i int) string {
+	fake.pathExistsMutex.RLock()
+	defer fake.pathExistsMutex.RUnlock()
+	argsForCall := fake.pathExistsArgsForCall[i]
 	return argsForCall.arg1
 }
 
-func (fake *FakePublisherClient) ReadFileReturns(result1 []byte, result2 error) {
-	fake.readFileMutex.Lock()
-	defer fake.readFileMutex.Unlock()
-	fake.ReadFileStub = nil
-	fake.readFileReturns = struct {
-		result1 []byte
+func (fake *FakePublisherClient) PathExistsReturns(result1 bool, result2 error) {
+	fake.pathExistsMutex.Lock()
+	defer fake.pathExistsMutex.Unlock()
+	fake.PathExistsStub = nil
+	fake.pathExistsReturns = struct {
+		result1 bool
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakePublisherClient) ReadFileReturnsOnCall(i int, result1 []byte, result2 error) {
-	fake.readFileMutex.Lock()
-	defer fake.readFileMutex.Unlock()
-	fake.ReadFileStub = nil
-	if fake.readFileReturnsOnCall == nil {
-		fake.readFileReturnsOnCall = make(map[int]struct {
-			result1 []byte
+func (fake *FakePublisherClient) PathExistsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.pathExistsMutex.Lock()
+	defer fake.pathExistsMutex.Unlock()
+	fake.PathExistsStub = nil
+	if fake.pathExistsReturnsOnCall == nil {
+		fake.pathExistsReturnsOnCall = make(map[int]struct {
+			result1 bool
 			result2 error
 		})
 	}
-	fake.readFileReturnsOnCall[i] = struct {
-		result1 []byte
+	fake.pathExistsReturnsOnCall[i] = struct {
+		result1 bool
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakePublisherClient) TempDir(arg1 string, arg2 string) (string, error) {
-	fake.tempDirMutex.Lock()
-	ret, specificReturn := fake.tempDirReturnsOnCall[len(fake.tempDirArgsForCall)]
-	fake.tempDirArgsForCall = append(fake.tempDirArgsForCall, struct {
+func (fake *FakePublisherClient) PublicURL(arg1 string) string {
+	fake.publicURLMutex.Lock()
+	ret, specificReturn := fake.publicURLReturnsOnCall[len(fake.publicURLArgsForCall)]
+	fake.publicURLArgsForCall = append(fake.publicURLArgsForCall, struct {
 		arg1 string
-		arg2 string
-	}{arg1, arg2})
-	stub := fake.TempDirStub
-	fakeReturns := fake.tempDirReturns
-	fake.recordInvocation("TempDir", []interface{}{arg1, arg2})
-	fake.tempDirMutex.Unlock()
+	}{arg1})
+	stub := fake.PublicURLStub
+	fakeReturns := fake.publicURLReturns
+	fake.recordInvocation("PublicURL", []interface{}{arg1})
+	fake.publicURLMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fakeReturns.result1, fakeReturns.result2
+	return fakeReturns.result1
 }
 
-func (fake *FakePublisherClient) TempDirCallCount() int {
-	fake.tempDirMutex.RLock()
-	defer fake.tempDirMutex.RUnlock()
-	return len(fake.tempDirArgsForCall)
+func (fake *FakePublisherClient) PublicURLCallCount() int {
+	fake.publicURLMutex.RLock()
+	defer fake.publicURLMutex.RUnlock()
+	return len(fake.publicURLArgsForCall)
 }
 
-func (fake *FakePublisherClient) TempDirCalls(stub func(string, string) (string, error)) {
-	fake.tempDirMutex.Lock()
-	defer fake.tempDirMutex.Unlock()
-	fake.TempDirStub = stub
+func (fake *FakePublisherClient) PublicURLCalls(stub func(string) string) {
+	fake.publicURLMutex.Lock()
+	defer fake.publicURLMutex.Unlock()
+	fake.PublicURLStub = stub
 }
 
-func (fake *FakePublisherClient) TempDirArgsForCall(i int) (string, string) {
-	fake.tempDirMutex.RLock()
-	defer fake.tempDirMutex.RUnlock()
-	argsForCall := fake.tempDirArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+func (fake *FakePublisherClient) PublicURLArgsForCall(i int) string {
+	fake.publicURLMutex.RLock()
+	defer fake.publicURLMutex.RUnlock()
+	argsForCall := fake.publicURLArgsForCall[i]
+	return argsForCall.arg1
 }
 
-func (fake *FakePublisherClient) TempDirReturns(result1 string, result2 error) {
-	fake.tempDirMutex.Lock()
-	defer fake.tempDirMutex.Unlock()
-	fake.TempDirStub = nil
-	fake.tempDirReturns = struct {
+func (fake *FakePublisherClient) PublicURLReturns(result1 string) {
+	fake.publicURLMutex.Lock()
+	defer fake.publicURLMutex.Unlock()
+	fake.PublicURLStub = nil
+	fake.publicURLReturns = struct {
 		result1 string
-		result2 error
-	}{result1, result2}
+	}{result1}
 }
 
-func (fake *FakePublisherClient) TempDirReturnsOnCall(i int, result1 string, result2 error) {
-	fake.tempDirMutex.Lock()
-	defer fake.tempDirMutex.Unlock()
-	fake.TempDirStub = nil
-	if fake.tempDirReturnsOnCall == nil {
-		fake.tempDirReturnsOnCall = make(map[int]struct {
+func (fake *FakePublisherClient) PublicURLReturnsOnCall(i int, result1 string) {
+	fake.publicURLMutex.Lock()
+	defer fake.publicURLMutex.Unlock()
+	fake.PublicURLStub = nil
+	if fake.publicURLReturnsOnCall == nil {
+		fake.publicURLReturnsOnCall = make(map[int]struct {
 			result1 string
-			result2 error
 		})
 	}
-	fake.tempDirReturnsOnCall[i] = struct {
+	fake.publicURLReturnsOnCall[i] = struct {
 		result1 string
-		result2 error
-	}{result1, result2}
+	}{result1}
 }
 
-func (fake *FakePublisherClient) TempFile(arg1 string, arg2 string) (*os.File, error) {
-	fake.tempFileMutex.Lock()
-	ret, specificReturn := fake.tempFileReturnsOnCall[len(fake.tempFileArgsForCall)]
-	fake.tempFileArgsForCall = append(fake.tempFileArgsForCall, struct {
+func (fake *FakePublisherClient) ReadObject(arg1 string) ([]byte, error) {
+	fake.readObjectMutex.Lock()
+	ret, specificReturn := fake.readObjectReturnsOnCall[len(fake.readObjectArgsForCall)]
+	fake.readObjectArgsForCall = append(fake.readObjectArgsForCall, struct {
 		arg1 string
-		arg2 string
-	}{arg1, arg2})
-	stub := fake.TempFileStub
-	fakeReturns := fake.tempFileReturns
-	fake.recordInvocation("TempFile", []interface{}{arg1, arg2})
-	fake.tempFileMutex.Unlock()
+	}{arg1})
+	stub := fake.ReadObjectStub
+	fakeReturns := fake.readObjectReturns
+	fake.recordInvocation("ReadObject", []interface{}{arg1})
+	fake.readObjectMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -996,47 +699,47 @@ func (fake *FakePublisherClient) TempFile(arg1 string, arg2 string) (*os.File, e
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakePublisherClient) TempFileCallCount() int {
-	fake.tempFileMutex.RLock()
-	defer fake.tempFileMutex.RUnlock()
-	return len(fake.tempFileArgsForCall)
+func (fake *FakePublisherClient) ReadObjectCallCount() int {
+	fake.readObjectMutex.RLock()
+	defer fake.readObjectMutex.RUnlock()
+	return len(fake.readObjectArgsForCall)
 }
 
-func (fake *FakePublisherClient) TempFileCalls(stub func(string, string) (*os.File, error)) {
-	fake.tempFileMutex.Lock()
-	defer fake.tempFileMutex.Unlock()
-	fake.TempFileStub = stub
+func (fake *FakePublisherClient) ReadObjectCalls(stub func(string) ([]byte, error)) {
+	fake.readObjectMutex.Lock()
+	defer fake.readObjectMutex.Unlock()
+	fake.ReadObjectStub = stub
 }
 
-func (fake *FakePublisherClient) TempFileArgsForCall(i int) (string, string) {
-	fake.tempFileMutex.RLock()
-	defer fake.tempFileMutex.RUnlock()
-	argsForCall := fake.tempFileArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+func (fake *FakePublisherClient) ReadObjectArgsForCall(i int) string {
+	fake.readObjectMutex.RLock()
+	defer fake.readObjectMutex.RUnlock()
+	argsForCall := fake.readObjectArgsForCall[i]
+	return argsForCall.arg1
 }
 
-func (fake *FakePublisherClient) TempFileReturns(result1 *os.File, result2 error) {
-	fake.tempFileMutex.Lock()
-	defer fake.tempFileMutex.Unlock()
-	fake.TempFileStub = nil
-	fake.tempFileReturns = struct {
-		result1 *os.File
+func (fake *FakePublisherClient) ReadObjectReturns(result1 []byte, result2 error) {
+	fake.readObjectMutex.Lock()
+	defer fake.readObjectMutex.Unlock()
+	fake.ReadObjectStub = nil
+	fake.readObjectReturns = struct {
+		result1 []byte
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakePublisherClient) TempFileReturnsOnCall(i int, result1 *os.File, result2 error) {
-	fake.tempFileMutex.Lock()
-	defer fake.tempFileMutex.Unlock()
-	fake.TempFileStub = nil
-	if fake.tempFileReturnsOnCall == nil {
-		fake.tempFileReturnsOnCall = make(map[int]struct {
-			result1 *os.File
+func (fake *FakePublisherClient) ReadObjectReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.readObjectMutex.Lock()
+	defer fake.readObjectMutex.Unlock()
+	fake.ReadObjectStub = nil
+	if fake.readObjectReturnsOnCall == nil {
+		fake.readObjectReturnsOnCall = make(map[int]struct {
+			result1 []byte
 			result2 error
 		})
 	}
-	fake.tempFileReturnsOnCall[i] = struct {
-		result1 *os.File
+	fake.readObjectReturnsOnCall[i] = struct {
+		result1 []byte
 		result2 error
 	}{result1, result2}
 }
@@ -1108,37 +811,99 @@ func (fake *FakePublisherClient) UnmarshalReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakePublisherClient) WriteObject(arg1 string, arg2 []byte, arg3 *objectstore.Attributes) error {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.writeObjectMutex.Lock()
+	ret, specificReturn := fake.writeObjectReturnsOnCall[len(fake.writeObjectArgsForCall)]
+	fake.writeObjectArgsForCall = append(fake.writeObjectArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+		arg3 *objectstore.Attributes
+	}{arg1, arg2Copy, arg3})
+	stub := fake.WriteObjectStub
+	fakeReturns := fake.writeObjectReturns
+	fake.recordInvocation("WriteObject", []interface{}{arg1, arg2Copy, arg3})
+	fake.writeObjectMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePublisherClient) WriteObjectCallCount() int {
+	fake.writeObjectMutex.RLock()
+	defer fake.writeObjectMutex.RUnlock()
+	return len(fake.writeObjectArgsForCall)
+}
+
+func (fake *FakePublisherClient) WriteObjectCalls(stub func(string, []byte, *objectstore.Attributes) error) {
+	fake.writeObjectMutex.Lock()
+	defer fake.writeObjectMutex.Unlock()
+	fake.WriteObjectStub = stub
+}
+
+func (fake *FakePublisherClient) WriteObjectArgsForCall(i int) (string, []byte, *objectstore.Attributes) {
+	fake.writeObjectMutex.RLock()
+	defer fake.writeObjectMutex.RUnlock()
+	argsForCall := fake.writeObjectArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePublisherClient) WriteObjectReturns(result1 error) {
+	fake.writeObjectMutex.Lock()
+	defer fake.writeObjectMutex.Unlock()
+	fake.WriteObjectStub = nil
+	fake.writeObjectReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePublisherClient) WriteObjectReturnsOnCall(i int, result1 error) {
+	fake.writeObjectMutex.Lock()
+	defer fake.writeObjectMutex.Unlock()
+	fake.WriteObjectStub = nil
+	if fake.writeObjectReturnsOnCall == nil {
+		fake.writeObjectReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeObjectReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePublisherClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.copyToLocalMutex.RLock()
-	defer fake.copyToLocalMutex.RUnlock()
-	fake.copyToRemoteMutex.RLock()
-	defer fake.copyToRemoteMutex.RUnlock()
-	fake.gSUtilMutex.RLock()
-	defer fake.gSUtilMutex.RUnlock()
-	fake.gSUtilOutputMutex.RLock()
-	defer fake.gSUtilOutputMutex.RUnlock()
-	fake.gSUtilStatusMutex.RLock()
-	defer fake.gSUtilStatusMutex.RUnlock()
 	fake.getMarkerPathMutex.RLock()
 	defer fake.getMarkerPathMutex.RUnlock()
 	fake.getReleasePathMutex.RLock()
 	defer fake.getReleasePathMutex.RUnlock()
 	fake.getURLResponseMutex.RLock()
 	defer fake.getURLResponseMutex.RUnlock()
+	fake.makePublicMutex.RLock()
+	defer fake.makePublicMutex.RUnlock()
 	fake.marshalMutex.RLock()
 	defer fake.marshalMutex.RUnlock()
 	fake.normalizePathMutex.RLock()
 	defer fake.normalizePathMutex.RUnlock()
-	fake.readFileMutex.RLock()
-	defer fake.readFileMutex.RUnlock()
-	fake.tempDirMutex.RLock()
-	defer fake.tempDirMutex.RUnlock()
-	fake.tempFileMutex.RLock()
-	defer fake.tempFileMutex.RUnlock()
+	fake.pathExistsMutex.RLock()
+	defer fake.pathExistsMutex.RUnlock()
+	fake.publicURLMutex.RLock()
+	defer fake.publicURLMutex.RUnlock()
+	fake.readObjectMutex.RLock()
+	defer fake.readObjectMutex.RUnlock()
 	fake.unmarshalMutex.RLock()
 	defer fake.unmarshalMutex.RUnlock()
+	fake.writeObjectMutex.RLock()
+	defer fake.writeObjectMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,538 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package releasefakes
+
+import (
+	"sync"
+
+	"k8s.io/release/pkg/release"
+)
+
+type FakeYankerImpl struct {
+	BuildExistsStub        func(string, string, string) (bool, error)
+	buildExistsMutex       sync.RWMutex
+	buildExistsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	buildExistsReturns struct {
+		result1 bool
+		result2 error
+	}
+	buildExistsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	DeleteRemoteTagStub        func(string, string, bool) error
+	deleteRemoteTagMutex       sync.RWMutex
+	deleteRemoteTagArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}
+	deleteRemoteTagReturns struct {
+		result1 error
+	}
+	deleteRemoteTagReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ListVersionMarkersStub        func(string, string) ([]release.VersionMarker, error)
+	listVersionMarkersMutex       sync.RWMutex
+	listVersionMarkersArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	listVersionMarkersReturns struct {
+		result1 []release.VersionMarker
+		result2 error
+	}
+	listVersionMarkersReturnsOnCall map[int]struct {
+		result1 []release.VersionMarker
+		result2 error
+	}
+	SetVersionMarkerStub        func(string, string, string, string, bool) error
+	setVersionMarkerMutex       sync.RWMutex
+	setVersionMarkerArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 bool
+	}
+	setVersionMarkerReturns struct {
+		result1 error
+	}
+	setVersionMarkerReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WriteRecordStub        func(string, string, []byte) error
+	writeRecordMutex       sync.RWMutex
+	writeRecordArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 []byte
+	}
+	writeRecordReturns struct {
+		result1 error
+	}
+	writeRecordReturnsOnCall map[int]struct {
+		result1 error
+	}
+	YankGitHubReleaseStub        func(string, string, string, bool) error
+	yankGitHubReleaseMutex       sync.RWMutex
+	yankGitHubReleaseArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 bool
+	}
+	yankGitHubReleaseReturns struct {
+		result1 error
+	}
+	yankGitHubReleaseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeYankerImpl) BuildExists(arg1 string, arg2 string, arg3 string) (bool, error) {
+	fake.buildExistsMutex.Lock()
+	ret, specificReturn := fake.buildExistsReturnsOnCall[len(fake.buildExistsArgsForCall)]
+	fake.buildExistsArgsForCall = append(fake.buildExistsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.BuildExistsStub
+	fakeReturns := fake.buildExistsReturns
+	fake.recordInvocation("BuildExists", []interface{}{arg1, arg2, arg3})
+	fake.buildExistsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeYankerImpl) BuildExistsCallCount() int {
+	fake.buildExistsMutex.RLock()
+	defer fake.buildExistsMutex.RUnlock()
+	return len(fake.buildExistsArgsForCall)
+}
+
+func (fake *FakeYankerImpl) BuildExistsCalls(stub func(string, string, string) (bool, error)) {
+	fake.buildExistsMutex.Lock()
+	defer fake.buildExistsMutex.Unlock()
+	fake.BuildExistsStub = stub
+}
+
+func (fake *FakeYankerImpl) BuildExistsArgsForCall(i int) (string, string, string) {
+	fake.buildExistsMutex.RLock()
+	defer fake.buildExistsMutex.RUnlock()
+	argsForCall := fake.buildExistsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeYankerImpl) BuildExistsReturns(result1 bool, result2 error) {
+	fake.buildExistsMutex.Lock()
+	defer fake.buildExistsMutex.Unlock()
+	fake.BuildExistsStub = nil
+	fake.buildExistsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeYankerImpl) BuildExistsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.buildExistsMutex.Lock()
+	defer fake.buildExistsMutex.Unlock()
+	fake.BuildExistsStub = nil
+	if fake.buildExistsReturnsOnCall == nil {
+		fake.buildExistsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.buildExistsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeYankerImpl) DeleteRemoteTag(arg1 string, arg2 string, arg3 bool) error {
+	fake.deleteRemoteTagMutex.Lock()
+	ret, specificReturn := fake.deleteRemoteTagReturnsOnCall[len(fake.deleteRemoteTagArgsForCall)]
+	fake.deleteRemoteTagArgsForCall = append(fake.deleteRemoteTagArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteRemoteTagStub
+	fakeReturns := fake.deleteRemoteTagReturns
+	fake.recordInvocation("DeleteRemoteTag", []interface{}{arg1, arg2, arg3})
+	fake.deleteRemoteTagMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeYankerImpl) DeleteRemoteTagCallCount() int {
+	fake.deleteRemoteTagMutex.RLock()
+	defer fake.deleteRemoteTagMutex.RUnlock()
+	return len(fake.deleteRemoteTagArgsForCall)
+}
+
+func (fake *FakeYankerImpl) DeleteRemoteTagCalls(stub func(string, string, bool) error) {
+	fake.deleteRemoteTagMutex.Lock()
+	defer fake.deleteRemoteTagMutex.Unlock()
+	fake.DeleteRemoteTagStub = stub
+}
+
+func (fake *FakeYankerImpl) DeleteRemoteTagArgsForCall(i int) (string, string, bool) {
+	fake.deleteRemoteTagMutex.RLock()
+	defer fake.deleteRemoteTagMutex.RUnlock()
+	argsForCall := fake.deleteRemoteTagArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeYankerImpl) DeleteRemoteTagReturns(result1 error) {
+	fake.deleteRemoteTagMutex.Lock()
+	defer fake.deleteRemoteTagMutex.Unlock()
+	fake.DeleteRemoteTagStub = nil
+	fake.deleteRemoteTagReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeYankerImpl) DeleteRemoteTagReturnsOnCall(i int, result1 error) {
+	fake.deleteRemoteTagMutex.Lock()
+	defer fake.deleteRemoteTagMutex.Unlock()
+	fake.DeleteRemoteTagStub = nil
+	if fake.deleteRemoteTagReturnsOnCall == nil {
+		fake.deleteRemoteTagReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteRemoteTagReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeYankerImpl) ListVersionMarkers(arg1 string, arg2 string) ([]release.VersionMarker, error) {
+	fake.listVersionMarkersMutex.Lock()
+	ret, specificReturn := fake.listVersionMarkersReturnsOnCall[len(fake.listVersionMarkersArgsForCall)]
+	fake.listVersionMarkersArgsForCall = append(fake.listVersionMarkersArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ListVersionMarkersStub
+	fakeReturns := fake.listVersionMarkersReturns
+	fake.recordInvocation("ListVersionMarkers", []interface{}{arg1, arg2})
+	fake.listVersionMarkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeYankerImpl) ListVersionMarkersCallCount() int {
+	fake.listVersionMarkersMutex.RLock()
+	defer fake.listVersionMarkersMutex.RUnlock()
+	return len(fake.listVersionMarkersArgsForCall)
+}
+
+func (fake *FakeYankerImpl) ListVersionMarkersCalls(stub func(string, string) ([]release.VersionMarker, error)) {
+	fake.listVersionMarkersMutex.Lock()
+	defer fake.listVersionMarkersMutex.Unlock()
+	fake.ListVersionMarkersStub = stub
+}
+
+func (fake *FakeYankerImpl) ListVersionMarkersArgsForCall(i int) (string, string) {
+	fake.listVersionMarkersMutex.RLock()
+	defer fake.listVersionMarkersMutex.RUnlock()
+	argsForCall := fake.listVersionMarkersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeYankerImpl) ListVersionMarkersReturns(result1 []release.VersionMarker, result2 error) {
+	fake.listVersionMarkersMutex.Lock()
+	defer fake.listVersionMarkersMutex.Unlock()
+	fake.ListVersionMarkersStub = nil
+	fake.listVersionMarkersReturns = struct {
+		result1 []release.VersionMarker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeYankerImpl) ListVersionMarkersReturnsOnCall(i int, result1 []release.VersionMarker, result2 error) {
+	fake.listVersionMarkersMutex.Lock()
+	defer fake.listVersionMarkersMutex.Unlock()
+	fake.ListVersionMarkersStub = nil
+	if fake.listVersionMarkersReturnsOnCall == nil {
+		fake.listVersionMarkersReturnsOnCall = make(map[int]struct {
+			result1 []release.VersionMarker
+			result2 error
+		})
+	}
+	fake.listVersionMarkersReturnsOnCall[i] = struct {
+		result1 []release.VersionMarker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeYankerImpl) SetVersionMarker(arg1 string, arg2 string, arg3 string, arg4 string, arg5 bool) error {
+	fake.setVersionMarkerMutex.Lock()
+	ret, specificReturn := fake.setVersionMarkerReturnsOnCall[len(fake.setVersionMarkerArgsForCall)]
+	fake.setVersionMarkerArgsForCall = append(fake.setVersionMarkerArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 bool
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.SetVersionMarkerStub
+	fakeReturns := fake.setVersionMarkerReturns
+	fake.recordInvocation("SetVersionMarker", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.setVersionMarkerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeYankerImpl) SetVersionMarkerCallCount() int {
+	fake.setVersionMarkerMutex.RLock()
+	defer fake.setVersionMarkerMutex.RUnlock()
+	return len(fake.setVersionMarkerArgsForCall)
+}
+
+func (fake *FakeYankerImpl) SetVersionMarkerCalls(stub func(string, string, string, string, bool) error) {
+	fake.setVersionMarkerMutex.Lock()
+	defer fake.setVersionMarkerMutex.Unlock()
+	fake.SetVersionMarkerStub = stub
+}
+
+func (fake *FakeYankerImpl) SetVersionMarkerArgsForCall(i int) (string, string, string, string, bool) {
+	fake.setVersionMarkerMutex.RLock()
+	defer fake.setVersionMarkerMutex.RUnlock()
+	argsForCall := fake.setVersionMarkerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeYankerImpl) SetVersionMarkerReturns(result1 error) {
+	fake.setVersionMarkerMutex.Lock()
+	defer fake.setVersionMarkerMutex.Unlock()
+	fake.SetVersionMarkerStub = nil
+	fake.setVersionMarkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeYankerImpl) SetVersionMarkerReturnsOnCall(i int, result1 error) {
+	fake.setVersionMarkerMutex.Lock()
+	defer fake.setVersionMarkerMutex.Unlock()
+	fake.SetVersionMarkerStub = nil
+	if fake.setVersionMarkerReturnsOnCall == nil {
+		fake.setVersionMarkerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setVersionMarkerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeYankerImpl) WriteRecord(arg1 string, arg2 string, arg3 []byte) error {
+	var arg3Copy []byte
+	if arg3 != nil {
+		arg3Copy = make([]byte, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.writeRecordMutex.Lock()
+	ret, specificReturn := fake.writeRecordReturnsOnCall[len(fake.writeRecordArgsForCall)]
+	fake.writeRecordArgsForCall = append(fake.writeRecordArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []byte
+	}{arg1, arg2, arg3Copy})
+	stub := fake.WriteRecordStub
+	fakeReturns := fake.writeRecordReturns
+	fake.recordInvocation("WriteRecord", []interface{}{arg1, arg2, arg3Copy})
+	fake.writeRecordMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeYankerImpl) WriteRecordCallCount() int {
+	fake.writeRecordMutex.RLock()
+	defer fake.writeRecordMutex.RUnlock()
+	return len(fake.writeRecordArgsForCall)
+}
+
+func (fake *FakeYankerImpl) WriteRecordCalls(stub func(string, string, []byte) error) {
+	fake.writeRecordMutex.Lock()
+	defer fake.writeRecordMutex.Unlock()
+	fake.WriteRecordStub = stub
+}
+
+func (fake *FakeYankerImpl) WriteRecordArgsForCall(i int) (string, string, []byte) {
+	fake.writeRecordMutex.RLock()
+	defer fake.writeRecordMutex.RUnlock()
+	argsForCall := fake.writeRecordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeYankerImpl) WriteRecordReturns(result1 error) {
+	fake.writeRecordMutex.Lock()
+	defer fake.writeRecordMutex.Unlock()
+	fake.WriteRecordStub = nil
+	fake.writeRecordReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeYankerImpl) WriteRecordReturnsOnCall(i int, result1 error) {
+	fake.writeRecordMutex.Lock()
+	defer fake.writeRecordMutex.Unlock()
+	fake.WriteRecordStub = nil
+	if fake.writeRecordReturnsOnCall == nil {
+		fake.writeRecordReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeRecordReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeYankerImpl) YankGitHubRelease(arg1 string, arg2 string, arg3 string, arg4 bool) error {
+	fake.yankGitHubReleaseMutex.Lock()
+	ret, specificReturn := fake.yankGitHubReleaseReturnsOnCall[len(fake.yankGitHubReleaseArgsForCall)]
+	fake.yankGitHubReleaseArgsForCall = append(fake.yankGitHubReleaseArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 bool
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.YankGitHubReleaseStub
+	fakeReturns := fake.yankGitHubReleaseReturns
+	fake.recordInvocation("YankGitHubRelease", []interface{}{arg1, arg2, arg3, arg4})
+	fake.yankGitHubReleaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeYankerImpl) YankGitHubReleaseCallCount() int {
+	fake.yankGitHubReleaseMutex.RLock()
+	defer fake.yankGitHubReleaseMutex.RUnlock()
+	return len(fake.yankGitHubReleaseArgsForCall)
+}
+
+func (fake *FakeYankerImpl) YankGitHubReleaseCalls(stub func(string, string, string, bool) error) {
+	fake.yankGitHubReleaseMutex.Lock()
+	defer fake.yankGitHubReleaseMutex.Unlock()
+	fake.YankGitHubReleaseStub = stub
+}
+
+func (fake *FakeYankerImpl) YankGitHubReleaseArgsForCall(i int) (string, string, string, bool) {
+	fake.yankGitHubReleaseMutex.RLock()
+	defer fake.yankGitHubReleaseMutex.RUnlock()
+	argsForCall := fake.yankGitHubReleaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeYankerImpl) YankGitHubReleaseReturns(result1 error) {
+	fake.yankGitHubReleaseMutex.Lock()
+	defer fake.yankGitHubReleaseMutex.Unlock()
+	fake.YankGitHubReleaseStub = nil
+	fake.yankGitHubReleaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeYankerImpl) YankGitHubReleaseReturnsOnCall(i int, result1 error) {
+	fake.yankGitHubReleaseMutex.Lock()
+	defer fake.yankGitHubReleaseMutex.Unlock()
+	fake.YankGitHubReleaseStub = nil
+	if fake.yankGitHubReleaseReturnsOnCall == nil {
+		fake.yankGitHubReleaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.yankGitHubReleaseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeYankerImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.buildExistsMutex.RLock()
+	defer fake.buildExistsMutex.RUnlock()
+	fake.deleteRemoteTagMutex.RLock()
+	defer fake.deleteRemoteTagMutex.RUnlock()
+	fake.listVersionMarkersMutex.RLock()
+	defer fake.listVersionMarkersMutex.RUnlock()
+	fake.setVersionMarkerMutex.RLock()
+	defer fake.setVersionMarkerMutex.RUnlock()
+	fake.writeRecordMutex.RLock()
+	defer fake.writeRecordMutex.RUnlock()
+	fake.yankGitHubReleaseMutex.RLock()
+	defer fake.yankGitHubReleaseMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeYankerImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,790 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/binary"
+	"k8s.io/release/pkg/spdx"
+	"sigs.k8s.io/release-sdk/object"
+)
+
+// Likely causes of a difference between two builds, ordered from the least
+// to the most severe one
+const (
+	ReproCauseDigests    = "derived digests"
+	ReproCauseMetadata   = "archive metadata"
+	ReproCauseTimestamps = "timestamps"
+	ReproCauseVersions   = "embedded versions"
+	ReproCauseBuildPaths = "build paths"
+	ReproCauseContent    = "content"
+)
+
+var reproCauses = []string{
+	ReproCauseContent,
+	ReproCauseBuildPaths,
+	ReproCauseVersions,
+	ReproCauseTimestamps,
+	ReproCauseMetadata,
+	ReproCauseDigests,
+}
+
+const (
+	// reproArchiveSeparator separates the path of an archive from the path
+	// of a file inside of it
+	reproArchiveSeparator = "!/"
+
+	// reproMaxDepth limits the recursion into nested archives
+	reproMaxDepth = 5
+
+	// reproMaxDetails limits the details recorded per difference
+	reproMaxDetails = 5
+
+	// Minimum length of the strings compared in text files and binaries
+	reproMinStringLength       = 4
+	reproMinBinaryStringLength = 8
+)
+
+var (
+	reproTimestampRegex = regexp.MustCompile(
+		`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}|` +
+			`(Mon|Tue|Wed|Thu|Fri|Sat|Sun),? \d{1,2} (Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec) \d{4}`,
+	)
+	reproBuildPathRegex = regexp.MustCompile(
+		`(^|[^A-Za-z0-9._:/-])/[A-Za-z0-9._-]+/[A-Za-z0-9._/-]+|[A-Za-z]:\\[^\\]+\\`,
+	)
+	reproVersionRegex = regexp.MustCompile(
+		`v?\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?|\b[0-9a-f]{40}\b`,
+	)
+	reproDigestRegex = regexp.MustCompile(`[0-9a-f]{64}|[0-9a-f]{128}`)
+)
+
+// ReproDiffOptions are the settings for comparing two builds
+type ReproDiffOptions struct {
+	// A and B are the roots of the builds to be compared. They can be local
+	// directories or GCS paths prefixed with gs://, for example
+	// gs://kubernetes-release-gcb/stage/v1.23.0-alpha.1.123+0123456789abcd
+	A string
+	B string
+}
+
+// ReproDifference is a file which differs between two builds
+type ReproDifference struct {
+	// Path is the relative path of the file. Files inside of archives are
+	// separated from the archive by "!/", for example
+	// kubernetes-server-linux-amd64.tar.gz!/kubernetes/server/bin/kubelet
+	Path string `json:"path"`
+
+	// Cause is the likely cause of the difference
+	Cause string `json:"cause"`
+
+	// Details are samples of the differing content
+	Details []string `json:"details,omitempty"`
+}
+
+// ReproDiffReport is the result of comparing two builds
+type ReproDiffReport struct {
+	A           string            `json:"a"`
+	B           string            `json:"b"`
+	Compared    int               `json:"compared"`
+	Differences []ReproDifference `json:"differences"`
+}
+
+// Passed returns true if both builds are identical
+func (r *ReproDiffReport) Passed() bool {
+	return len(r.Differences) == 0
+}
+
+// ByCause returns the differences grouped by their cause
+func (r *ReproDiffReport) ByCause() map[string][]ReproDifference {
+	res := map[string][]ReproDifference{}
+	for _, diff := range r.Differences {
+		res[diff.Cause] = append(res[diff.Cause], diff)
+	}
+	return res
+}
+
+// String returns a summary of all differences grouped by cause
+func (r *ReproDiffReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Compared %d files of %s and %s\n", r.Compared, r.A, r.B)
+	if r.Passed() {
+		sb.WriteString("The builds are identical\n")
+		return sb.String()
+	}
+
+	byCause := r.ByCause()
+	for _, cause := range reproCauses {
+		diffs := byCause[cause]
+		if len(diffs) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n%s (%d):\n", cause, len(diffs))
+		for _, diff := range diffs {
+			fmt.Fprintf(&sb, "  %s\n", diff.Path)
+			for _, detail := range diff.Details {
+				fmt.Fprintf(&sb, "      %s\n", detail)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// ReproDiffer compares the artifacts of two builds
+type ReproDiffer struct {
+	options *ReproDiffOptions
+	spdx    *spdx.SPDX
+}
+
+// NewReproDiffer creates a new ReproDiffer
+func NewReproDiffer(opts *ReproDiffOptions) *ReproDiffer {
+	return &ReproDiffer{
+		options: opts,
+		spdx:    spdx.NewSPDX(),
+	}
+}
+
+// Diff compares both builds file by file. Tarballs and image layers are
+// compared recursively and every differing file is reported with the likely
+// cause of the difference.
+func (d *ReproDiffer) Diff() (*ReproDiffReport, error) {
+	tempDir, err := os.MkdirTemp("", "repro-diff-")
+	if err != nil {
+		return nil, errors.Wrap(err, "creating temporary directory")
+	}
+	defer os.RemoveAll(tempDir)
+
+	dirA, err := localBuildDir(d.options.A, filepath.Join(tempDir, "a"))
+	if err != nil {
+		return nil, err
+	}
+	dirB, err := localBuildDir(d.options.B, filepath.Join(tempDir, "b"))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ReproDiffReport{A: d.options.A, B: d.options.B}
+	if err := d.diffDirs(report, "", dirA, dirB, 0); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// localBuildDir returns the local directory of a build, downloading it to
+// dst if it is stored on GCS
+func localBuildDir(build, dst string) (string, error) {
+	if !strings.HasPrefix(build, object.GcsPrefix) {
+		info, err := os.Stat(build)
+		if err != nil {
+			return "", errors.Wrapf(err, "checking build directory %s", build)
+		}
+		if !info.IsDir() {
+			return "", errors.Errorf("build %s is not a directory", build)
+		}
+		return build, nil
+	}
+
+	gcs := object.NewGCS()
+	gcs.WithConcurrent(true)
+	gcs.WithRecursive(true)
+	gcs.WithAllowMissing(false)
+	if err := gcs.CopyToLocal(build, dst); err != nil {
+		return "", errors.Wrapf(err, "downloading build %s", build)
+	}
+	return dst, nil
+}
+
+// diffDirs pairs the files of both directories by their relative path and
+// compares them
+func (d *ReproDiffer) diffDirs(
+	report *ReproDiffReport, prefix, dirA, dirB string, depth int,
+) error {
+	filesA, err := listReproFiles(dirA)
+	if err != nil {
+		return err
+	}
+	filesB, err := listReproFiles(dirB)
+	if err != nil {
+		return err
+	}
+
+	names := []string{}
+	for name := range filesA {
+		names = append(names, name)
+	}
+	for name := range filesB {
+		if _, ok := filesA[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		_, inA := filesA[name]
+		_, inB := filesB[name]
+		switch {
+		case !inA:
+			report.add(prefix+name, ReproCauseContent, []string{"only in B"})
+		case !inB:
+			report.add(prefix+name, ReproCauseContent, []string{"only in A"})
+		default:
+			report.Compared++
+			if err := d.diffFiles(
+				report, prefix+name,
+				filepath.Join(dirA, name), filepath.Join(dirB, name), depth,
+			); err != nil {
+				return errors.Wrapf(err, "comparing %s", prefix+name)
+			}
+		}
+	}
+	return nil
+}
+
+// listReproFiles returns the slash separated paths of all regular files
+// below the directory
+func listReproFiles(dir string) (map[string]struct{}, error) {
+	res := map[string]struct{}{}
+	if err := filepath.Walk(dir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		res[filepath.ToSlash(rel)] = struct{}{}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "listing files of %s", dir)
+	}
+	return res, nil
+}
+
+func (r *ReproDiffReport) add(filePath, cause string, details []string) {
+	if len(details) > reproMaxDetails {
+		details = append(
+			details[:reproMaxDetails],
+			fmt.Sprintf("... and %d more", len(details)-reproMaxDetails),
+		)
+	}
+	r.Differences = append(r.Differences, ReproDifference{
+		Path: filePath, Cause: cause, Details: details,
+	})
+}
+
+// diffFiles compares two files, recursing into archives
+func (d *ReproDiffer) diffFiles(
+	report *ReproDiffReport, name, fileA, fileB string, depth int,
+) error {
+	equal, err := sameFileContent(fileA, fileB)
+	if err != nil {
+		return err
+	}
+	if equal {
+		return nil
+	}
+
+	if depth < reproMaxDepth {
+		isArchive, err := d.diffArchives(report, name, fileA, fileB, depth)
+		if err != nil {
+			return err
+		}
+		if isArchive {
+			return nil
+		}
+	}
+
+	cause, details, err := classifyFileDiff(fileA, fileB)
+	if err != nil {
+		return err
+	}
+	report.add(name, cause, details)
+	return nil
+}
+
+// diffArchives compares two (gzipped) tarballs by their headers and their
+// extracted contents. It returns false if the files are no tarballs.
+func (d *ReproDiffer) diffArchives(
+	report *ReproDiffReport, name, fileA, fileB string, depth int,
+) (bool, error) {
+	kindA, err := sniffArchive(fileA)
+	if err != nil {
+		return false, err
+	}
+	kindB, err := sniffArchive(fileB)
+	if err != nil {
+		return false, err
+	}
+	if kindA == archiveNone || kindA != kindB {
+		return false, nil
+	}
+
+	tempDir, err := os.MkdirTemp("", "repro-diff-archive-")
+	if err != nil {
+		return false, errors.Wrap(err, "creating temporary directory")
+	}
+	defer os.RemoveAll(tempDir)
+
+	tarA, tarB := fileA, fileB
+	if kindA == archiveGzip {
+		var mtimeA, mtimeB string
+		if tarA, mtimeA, err = gunzipTemp(fileA, tempDir); err != nil {
+			return false, err
+		}
+		if tarB, mtimeB, err = gunzipTemp(fileB, tempDir); err != nil {
+			return false, err
+		}
+		if ok, err := isTarball(tarA); err != nil || !ok {
+			return false, err
+		}
+		if ok, err := isTarball(tarB); err != nil || !ok {
+			return false, err
+		}
+
+		equal, err := sameFileContent(tarA, tarB)
+		if err != nil {
+			return false, err
+		}
+		if equal {
+			// Only the gzip header differs
+			if mtimeA != mtimeB {
+				report.add(name, ReproCauseTimestamps, []string{
+					fmt.Sprintf("gzip timestamp %s != %s", mtimeA, mtimeB),
+				})
+			} else {
+				report.add(name, ReproCauseMetadata, []string{"gzip compression differs"})
+			}
+			return true, nil
+		}
+	}
+
+	if err := diffTarHeaders(report, name+reproArchiveSeparator, tarA, tarB); err != nil {
+		return false, err
+	}
+
+	extractedA, err := d.spdx.ExtractTarballTmp(tarA)
+	if extractedA != "" {
+		defer os.RemoveAll(extractedA)
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "extracting %s", fileA)
+	}
+	extractedB, err := d.spdx.ExtractTarballTmp(tarB)
+	if extractedB != "" {
+		defer os.RemoveAll(extractedB)
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "extracting %s", fileB)
+	}
+
+	logrus.Debugf("Comparing contents of archive %s", name)
+	return true, d.diffDirs(
+		report, name+reproArchiveSeparator, extractedA, extractedB, depth+1,
+	)
+}
+
+type archiveKind int
+
+const (
+	archiveNone archiveKind = iota
+	archiveTar
+	archiveGzip
+)
+
+// sniffArchive detects tarballs and gzipped files by their content, which
+// covers image layers stored without file extension as well
+func sniffArchive(filePath string) (archiveKind, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return archiveNone, errors.Wrapf(err, "opening %s", filePath)
+	}
+	defer f.Close()
+
+	header := make([]byte, 512)
+	n, err := io.ReadFull(f, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return archiveNone, errors.Wrapf(err, "reading %s", filePath)
+	}
+	header = header[:n]
+
+	switch {
+	case bytes.HasPrefix(header, []byte{0x1f, 0x8b}):
+		return archiveGzip, nil
+	case len(header) >= 262 && bytes.Equal(header[257:262], []byte("ustar")):
+		return archiveTar, nil
+	}
+	return archiveNone, nil
+}
+
+func isTarball(filePath string) (bool, error) {
+	kind, err := sniffArchive(filePath)
+	return kind == archiveTar, err
+}
+
+// gunzipTemp decompresses the file into the directory and returns the path
+// of the result as well as the modification time of the gzip header
+func gunzipTemp(filePath, dir string) (tarPath, mtime string, err error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "opening %s", filePath)
+	}
+	defer f.Close()
+
+	gzipReader, err := gzip.NewReader(f)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "creating gzip reader for %s", filePath)
+	}
+	defer gzipReader.Close()
+
+	out, err := os.CreateTemp(dir, "gunzip-*.tar")
+	if err != nil {
+		return "", "", errors.Wrap(err, "creating temporary file")
+	}
+	defer out.Close()
+
+	//nolint:gosec // the size is bound by the artifacts we compare
+	if _, err := io.Copy(out, gzipReader); err != nil {
+		return "", "", errors.Wrapf(err, "decompressing %s", filePath)
+	}
+	return out.Name(), gzipReader.ModTime.UTC().String(), nil
+}
+
+// diffTarHeaders reports the entries of both tarballs which differ in their
+// metadata
+func diffTarHeaders(report *ReproDiffReport, prefix, tarA, tarB string) error {
+	headersA, orderA, err := readTarHeaders(tarA)
+	if err != nil {
+		return err
+	}
+	headersB, orderB, err := readTarHeaders(tarB)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range orderA {
+		hdrA := headersA[name]
+		hdrB, ok := headersB[name]
+		if !ok {
+			// Reported when comparing the extracted files
+			continue
+		}
+
+		if !hdrA.ModTime.Equal(hdrB.ModTime) {
+			report.add(prefix+name, ReproCauseTimestamps, []string{
+				fmt.Sprintf("mtime %s != %s", hdrA.ModTime.UTC(), hdrB.ModTime.UTC()),
+			})
+		}
+
+		details := []string{}
+		if hdrA.Typeflag != hdrB.Typeflag {
+			details = append(details, fmt.Sprintf("type %q != %q", hdrA.Typeflag, hdrB.Typeflag))
+		}
+		if hdrA.Mode != hdrB.Mode {
+			details = append(details, fmt.Sprintf(
+				"mode %s != %s", os.FileMode(hdrA.Mode), os.FileMode(hdrB.Mode),
+			))
+		}
+		if hdrA.Uid != hdrB.Uid || hdrA.Gid != hdrB.Gid ||
+			hdrA.Uname != hdrB.Uname || hdrA.Gname != hdrB.Gname {
+			details = append(details, fmt.Sprintf(
+				"owner %d:%d (%s:%s) != %d:%d (%s:%s)",
+				hdrA.Uid, hdrA.Gid, hdrA.Uname, hdrA.Gname,
+				hdrB.Uid, hdrB.Gid, hdrB.Uname, hdrB.Gname,
+			))
+		}
+		if len(details) > 0 {
+			report.add(prefix+name, ReproCauseMetadata, details)
+		}
+
+		if hdrA.Linkname != hdrB.Linkname {
+			report.add(prefix+name, ReproCauseContent, []string{
+				fmt.Sprintf("link target %s != %s", hdrA.Linkname, hdrB.Linkname),
+			})
+		}
+	}
+
+	// The order only matters if both archives contain the same entries
+	if len(orderA) == len(orderB) {
+		for i := range orderA {
+			if orderA[i] != orderB[i] {
+				report.add(strings.TrimSuffix(prefix, reproArchiveSeparator),
+					ReproCauseMetadata, []string{
+						fmt.Sprintf("entry order differs at %s != %s", orderA[i], orderB[i]),
+					},
+				)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// readTarHeaders returns the headers of the tarball by their cleaned name
+// and the order of the entries
+func readTarHeaders(tarPath string) (map[string]*tar.Header, []string, error) {
+	f, err := os.Open(tarPath)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "opening %s", tarPath)
+	}
+	defer f.Close()
+
+	headers := map[string]*tar.Header{}
+	order := []string{}
+	tarReader := tar.NewReader(f)
+	for {
+		hdr, err := tarReader.Next()
+		if err == io.EOF {
+			return headers, order, nil
+		}
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "reading tar header of %s", tarPath)
+		}
+		name := strings.TrimPrefix(path.Clean(hdr.Name), "./")
+		headers[name] = hdr
+		order = append(order, name)
+	}
+}
+
+// sameFileContent returns true if both files have the same SHA256 digest
+func sameFileContent(fileA, fileB string) (bool, error) {
+	digestA, err := fileSHA256(fileA)
+	if err != nil {
+		return false, err
+	}
+	digestB, err := fileSHA256(fileB)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(digestA, digestB), nil
+}
+
+func fileSHA256(filePath string) ([]byte, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening %s", filePath)
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return nil, errors.Wrapf(err, "hashing %s", filePath)
+	}
+	return hasher.Sum(nil), nil
+}
+
+// classifyFileDiff returns the likely cause of the difference between two
+// files which are no archives
+func classifyFileDiff(fileA, fileB string) (cause string, details []string, err error) {
+	infoA, errA := binary.ReadBuildInfo(fileA)
+	infoB, errB := binary.ReadBuildInfo(fileB)
+	minLength := reproMinStringLength
+	if errA == nil && errB == nil {
+		if cause, details := classifyBuildInfoDiff(infoA, infoB); cause != "" {
+			return cause, details, nil
+		}
+		minLength = reproMinBinaryStringLength
+	}
+
+	stringsA, err := readPrintableStrings(fileA, minLength)
+	if err != nil {
+		return "", nil, err
+	}
+	stringsB, err := readPrintableStrings(fileB, minLength)
+	if err != nil {
+		return "", nil, err
+	}
+
+	causeDetails := map[string][]string{}
+	collect := func(from, to map[string]struct{}, side string) {
+		for s := range from {
+			if _, ok := to[s]; ok {
+				continue
+			}
+			stringCause := classifyString(s)
+			causeDetails[stringCause] = append(
+				causeDetails[stringCause], fmt.Sprintf("%s: %q", side, s),
+			)
+			if reproCauseSeverity(stringCause) > reproCauseSeverity(cause) {
+				cause = stringCause
+			}
+		}
+	}
+	collect(stringsA, stringsB, "A")
+	collect(stringsB, stringsA, "B")
+
+	if cause == "" {
+		// The strings are equal, so the difference is in the raw bytes
+		return ReproCauseContent, nil, nil
+	}
+	details = causeDetails[cause]
+	sort.Strings(details)
+	return cause, details, nil
+}
+
+// classifyBuildInfoDiff compares the build information of two Go binaries.
+// An empty cause is returned if both are equal.
+func classifyBuildInfoDiff(a, b *binary.BuildInfo) (cause string, details []string) {
+	if a.GoVersion != b.GoVersion {
+		return ReproCauseContent, []string{
+			fmt.Sprintf("built with %s != %s", a.GoVersion, b.GoVersion),
+		}
+	}
+
+	keys := map[string]struct{}{}
+	for key := range a.Settings {
+		keys[key] = struct{}{}
+	}
+	for key := range b.Settings {
+		keys[key] = struct{}{}
+	}
+	sortedKeys := []string{}
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	causeDetails := map[string][]string{}
+	for _, key := range sortedKeys {
+		if a.Settings[key] == b.Settings[key] {
+			continue
+		}
+		settingCause := ReproCauseContent
+		switch key {
+		case "vcs.time":
+			settingCause = ReproCauseTimestamps
+		case "vcs.revision":
+			settingCause = ReproCauseVersions
+		case binary.BuildSettingLDFlags:
+			settingCause = classifyLDFlagsDiff(a, b)
+		}
+		causeDetails[settingCause] = append(causeDetails[settingCause], fmt.Sprintf(
+			"%s: %q != %q", key, a.Settings[key], b.Settings[key],
+		))
+		if reproCauseSeverity(settingCause) > reproCauseSeverity(cause) {
+			cause = settingCause
+		}
+	}
+	return cause, causeDetails[cause]
+}
+
+// classifyLDFlagsDiff returns the likely cause of differing linker flags,
+// where only differing `-X` variables count as embedded versions
+func classifyLDFlagsDiff(a, b *binary.BuildInfo) string {
+	varsA, varsB := a.VersionVariables(), b.VersionVariables()
+	withoutVars := func(info *binary.BuildInfo) string {
+		flags := info.LDFlags()
+		for key, value := range info.VersionVariables() {
+			flags = strings.ReplaceAll(flags, key+"="+value, "")
+		}
+		return flags
+	}
+	if withoutVars(a) != withoutVars(b) || len(varsA) != len(varsB) {
+		return ReproCauseContent
+	}
+	cause := ReproCauseTimestamps
+	for key, value := range varsA {
+		valueB, ok := varsB[key]
+		if !ok {
+			return ReproCauseContent
+		}
+		if value != valueB && classifyString(value) != ReproCauseTimestamps {
+			cause = ReproCauseVersions
+		}
+	}
+	return cause
+}
+
+// classifyString returns the likely cause of a string being present in only
+// one of two files
+func classifyString(s string) string {
+	switch {
+	case reproTimestampRegex.MatchString(s):
+		return ReproCauseTimestamps
+	case reproBuildPathRegex.MatchString(s):
+		return ReproCauseBuildPaths
+	case reproDigestRegex.MatchString(s):
+		return ReproCauseDigests
+	case reproVersionRegex.MatchString(s):
+		return ReproCauseVersions
+	}
+	return ReproCauseContent
+}
+
+func reproCauseSeverity(cause string) int {
+	for i, c := range reproCauses {
+		if c == cause {
+			return len(reproCauses) - i
+		}
+	}
+	return 0
+}
+
+// readPrintableStrings returns the set of printable ASCII sequences of the
+// file with at least the minimum length, like strings(1) does
+func readPrintableStrings(filePath string, minLength int) (map[string]struct{}, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening %s", filePath)
+	}
+	defer f.Close()
+
+	res := map[string]struct{}{}
+	var current []byte
+	flush := func() {
+		if len(current) >= minLength {
+			res[string(current)] = struct{}{}
+		}
+		current = current[:0]
+	}
+
+	reader := bufio.NewReader(f)
+	for {
+		c, err := reader.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s", filePath)
+		}
+		if (c >= 0x20 && c < 0x7f) || c == '\t' {
+			current = append(current, c)
+			continue
+		}
+		flush()
+	}
+	flush()
+	return res, nil
+}
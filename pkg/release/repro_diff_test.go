@@ -0,0 +1,182 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/release"
+)
+
+type testReproFile struct {
+	name    string
+	content []byte
+	mtime   time.Time
+}
+
+// reproTarball returns a gzipped tarball of the files
+func reproTarball(t *testing.T, gzipped bool, files ...testReproFile) []byte {
+	var buf bytes.Buffer
+	var tarWriter *tar.Writer
+	var gzipWriter *gzip.Writer
+	if gzipped {
+		gzipWriter = gzip.NewWriter(&buf)
+		tarWriter = tar.NewWriter(gzipWriter)
+	} else {
+		tarWriter = tar.NewWriter(&buf)
+	}
+	for _, file := range files {
+		require.Nil(t, tarWriter.WriteHeader(&tar.Header{
+			Name:     file.name,
+			Mode:     0o644,
+			Size:     int64(len(file.content)),
+			ModTime:  file.mtime,
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tarWriter.Write(file.content)
+		require.Nil(t, err)
+	}
+	require.Nil(t, tarWriter.Close())
+	if gzipped {
+		require.Nil(t, gzipWriter.Close())
+	}
+	return buf.Bytes()
+}
+
+func writeReproTree(t *testing.T, files map[string][]byte) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		filePath := filepath.Join(dir, name)
+		require.Nil(t, os.MkdirAll(filepath.Dir(filePath), os.FileMode(0o755)))
+		require.Nil(t, os.WriteFile(filePath, content, os.FileMode(0o644)))
+	}
+	return dir
+}
+
+func TestReproDiff(t *testing.T) {
+	const tarball = "kubernetes-client-linux-amd64.tar.gz"
+	epoch := time.Unix(1634567890, 0)
+
+	for _, tc := range []struct {
+		a, b     map[string][]byte
+		expected []release.ReproDifference
+	}{
+		{ // identical
+			a: map[string][]byte{
+				tarball: reproTarball(t, true, testReproFile{name: "kubernetes/version", content: []byte("v1.23.0")}),
+			},
+			b: map[string][]byte{
+				tarball: reproTarball(t, true, testReproFile{name: "kubernetes/version", content: []byte("v1.23.0")}),
+			},
+		},
+		{ // modification time of a tarball entry
+			a: map[string][]byte{
+				tarball: reproTarball(t, true, testReproFile{
+					name: "kubernetes/version", content: []byte("v1.23.0"), mtime: epoch,
+				}),
+			},
+			b: map[string][]byte{
+				tarball: reproTarball(t, true, testReproFile{
+					name: "kubernetes/version", content: []byte("v1.23.0"), mtime: epoch.Add(time.Hour),
+				}),
+			},
+			expected: []release.ReproDifference{{
+				Path:  tarball + "!/kubernetes/version",
+				Cause: release.ReproCauseTimestamps,
+			}},
+		},
+		{ // embedded build path
+			a: map[string][]byte{
+				"bin/linux/amd64/kubectl": []byte("\x00\x01compiled in /home/alice/go/src/k8s.io/kubernetes\x00"),
+			},
+			b: map[string][]byte{
+				"bin/linux/amd64/kubectl": []byte("\x00\x01compiled in /workspace/src/k8s.io/kubernetes\x00"),
+			},
+			expected: []release.ReproDifference{{
+				Path:  "bin/linux/amd64/kubectl",
+				Cause: release.ReproCauseBuildPaths,
+			}},
+		},
+		{ // embedded version inside of an image layer
+			a: map[string][]byte{
+				"bin/linux/amd64/kube-proxy.tar": reproTarball(t, false, testReproFile{
+					name: "0123abcd/layer.tar",
+					content: reproTarball(t, false, testReproFile{
+						name: "usr/local/bin/kube-proxy", content: []byte("\x00gitVersion=v1.23.0\x00"),
+					}),
+				}),
+			},
+			b: map[string][]byte{
+				"bin/linux/amd64/kube-proxy.tar": reproTarball(t, false, testReproFile{
+					name: "0123abcd/layer.tar",
+					content: reproTarball(t, false, testReproFile{
+						name: "usr/local/bin/kube-proxy", content: []byte("\x00gitVersion=v1.23.1\x00"),
+					}),
+				}),
+			},
+			expected: []release.ReproDifference{{
+				Path:  "bin/linux/amd64/kube-proxy.tar!/0123abcd/layer.tar!/usr/local/bin/kube-proxy",
+				Cause: release.ReproCauseVersions,
+			}},
+		},
+		{ // real content difference and missing files
+			a: map[string][]byte{
+				"kubernetes.tar.gz": reproTarball(t, true, testReproFile{
+					name: "kubernetes/README.md", content: []byte("hello world"),
+				}),
+				"SHA256SUMS": []byte("sums"),
+			},
+			b: map[string][]byte{
+				"kubernetes.tar.gz": reproTarball(t, true, testReproFile{
+					name: "kubernetes/README.md", content: []byte("goodbye moon"),
+				}),
+			},
+			expected: []release.ReproDifference{
+				{Path: "SHA256SUMS", Cause: release.ReproCauseContent},
+				{Path: "kubernetes.tar.gz!/kubernetes/README.md", Cause: release.ReproCauseContent},
+			},
+		},
+	} {
+		report, err := release.NewReproDiffer(&release.ReproDiffOptions{
+			A: writeReproTree(t, tc.a),
+			B: writeReproTree(t, tc.b),
+		}).Diff()
+		require.Nil(t, err)
+		require.Equal(t, len(tc.expected) == 0, report.Passed(), report.String())
+		require.Len(t, report.Differences, len(tc.expected), report.String())
+		for i, expected := range tc.expected {
+			require.Equal(t, expected.Path, report.Differences[i].Path)
+			require.Equal(t, expected.Cause, report.Differences[i].Cause)
+		}
+	}
+}
+
+func TestReproDiffFailure(t *testing.T) {
+	_, err := release.NewReproDiffer(&release.ReproDiffOptions{
+		A: t.TempDir(),
+		B: filepath.Join(t.TempDir(), "missing"),
+	}).Diff()
+	require.NotNil(t, err)
+}
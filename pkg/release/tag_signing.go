@@ -0,0 +1,446 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/command"
+	"sigs.k8s.io/release-utils/env"
+)
+
+// Supported formats of tag signatures
+const (
+	TagSigningFormatOpenPGP = "openpgp"
+	TagSigningFormatSSH     = "ssh"
+)
+
+// Environment variables used to configure the tag signing, for example via
+// secrets in the Google Cloud Build environment
+const (
+	TagSigningFormatEnvKey      = "TAG_SIGNING_FORMAT"
+	TagSigningKeyEnvKey         = "TAG_SIGNING_KEY"
+	TagSigningKeyFileEnvKey     = "TAG_SIGNING_KEY_FILE"
+	TagSigningAgentSocketEnvKey = "TAG_SIGNING_AGENT_SOCKET"
+	TagSigningKeyringEnvKey     = "TAG_SIGNING_KEYRING"
+)
+
+const (
+	pgpSignatureHeader = "-----BEGIN PGP SIGNATURE-----"
+	sshSignatureHeader = "-----BEGIN SSH SIGNATURE-----"
+)
+
+// TagSignerOptions are the settings for signing and verifying git tags
+type TagSignerOptions struct {
+	// Format is the signature format, either "openpgp" or "ssh". Verifying
+	// detects the format from the signature if empty.
+	Format string
+
+	// Key is the OpenPGP key ID or the SSH public key (file) used for
+	// signing
+	Key string
+
+	// KeyFile is an OpenPGP key to be imported or the SSH private key. When
+	// using an agent, this can be the public key as well.
+	KeyFile string
+
+	// AgentSocket is the socket of the gpg-agent or ssh-agent holding the
+	// secret key
+	AgentSocket string
+
+	// Keyring contains the trusted keys for verifying signatures. This is an
+	// armored OpenPGP keyring or an SSH allowed signers file. The signing
+	// key is trusted if empty.
+	Keyring string
+}
+
+// TagSignerOptionsFromEnv returns the tag signing options configured via the
+// TAG_SIGNING_* environment variables
+func TagSignerOptionsFromEnv() *TagSignerOptions {
+	return &TagSignerOptions{
+		Format:      env.Default(TagSigningFormatEnvKey, TagSigningFormatOpenPGP),
+		Key:         env.Default(TagSigningKeyEnvKey, ""),
+		KeyFile:     env.Default(TagSigningKeyFileEnvKey, ""),
+		AgentSocket: env.Default(TagSigningAgentSocketEnvKey, ""),
+		Keyring:     env.Default(TagSigningKeyringEnvKey, ""),
+	}
+}
+
+// Enabled returns true if a signing key is configured
+func (o *TagSignerOptions) Enabled() bool {
+	return o != nil && (o.Key != "" || o.KeyFile != "")
+}
+
+// Validate checks the options for signing tags
+func (o *TagSignerOptions) Validate() error {
+	if o.Format != TagSigningFormatOpenPGP && o.Format != TagSigningFormatSSH {
+		return errors.Errorf(
+			"unsupported tag signing format %q, expected %s or %s",
+			o.Format, TagSigningFormatOpenPGP, TagSigningFormatSSH,
+		)
+	}
+	if !o.Enabled() {
+		return errors.New("no tag signing key configured")
+	}
+	return nil
+}
+
+// TagSigner signs and verifies git tags
+type TagSigner struct {
+	opts     *TagSignerOptions
+	tempDirs []string
+	gpgHome  string
+	gpgKey   string
+}
+
+// NewTagSigner creates a new TagSigner. Cleanup has to be called to remove
+// the temporary key material.
+func NewTagSigner(opts *TagSignerOptions) *TagSigner {
+	return &TagSigner{opts: opts}
+}
+
+// Cleanup removes all temporary files of the signer
+func (s *TagSigner) Cleanup() {
+	// Stop the agent started for the imported key, but never an agent we
+	// are only linked to
+	if s.gpgHome != "" && s.opts.AgentSocket == "" {
+		if err := command.New(
+			"gpgconf", "--homedir", s.gpgHome, "--kill", "gpg-agent",
+		).RunSilentSuccess(); err != nil {
+			logrus.Warnf("Unable to stop gpg-agent: %v", err)
+		}
+	}
+	for _, dir := range s.tempDirs {
+		os.RemoveAll(dir)
+	}
+	s.tempDirs = nil
+	s.gpgHome = ""
+	s.gpgKey = ""
+}
+
+// Sign creates the signed annotated tag in the repository at HEAD
+func (s *TagSigner) Sign(repoPath, tag, message string) error {
+	if err := s.opts.Validate(); err != nil {
+		return errors.Wrap(err, "validating tag signing options")
+	}
+
+	config, environ, err := s.signingConfig()
+	if err != nil {
+		return errors.Wrap(err, "preparing signing key")
+	}
+
+	args := append(config, "tag", "--sign", "--message", message, tag)
+	logrus.Infof("Signing tag %s using %s", tag, s.opts.Format)
+	if err := command.NewWithWorkDir(repoPath, "git", args...).
+		Env(environ...).RunSilentSuccess(); err != nil {
+		return errors.Wrapf(err, "creating signed tag %s", tag)
+	}
+	return nil
+}
+
+// CheckSigningKey verifies that the configured signing key can be used,
+// without creating a signature
+func (s *TagSigner) CheckSigningKey() error {
+	if err := s.opts.Validate(); err != nil {
+		return errors.Wrap(err, "validating tag signing options")
+	}
+
+	if s.opts.Format == TagSigningFormatSSH {
+		if _, err := s.sshPublicKey(); err != nil {
+			return errors.Wrap(err, "reading SSH signing key")
+		}
+		if s.opts.AgentSocket != "" {
+			if _, err := os.Stat(s.opts.AgentSocket); err != nil {
+				return errors.Wrap(err, "checking ssh-agent socket")
+			}
+		} else if s.opts.KeyFile == "" && strings.HasPrefix(s.opts.Key, "key::") {
+			return errors.New("SSH signing with a literal public key requires an agent")
+		}
+		return nil
+	}
+
+	_, environ, err := s.signingConfig()
+	if err != nil {
+		return errors.Wrap(err, "preparing signing key")
+	}
+	key := s.opts.Key
+	if key == "" {
+		key = s.gpgKey
+	}
+	if key == "" {
+		return errors.Errorf("no OpenPGP key found in %s", s.opts.KeyFile)
+	}
+	if err := command.New("gpg", "--batch", "--list-secret-keys", key).
+		Env(environ...).RunSilentSuccess(); err != nil {
+		return errors.Wrapf(err, "secret key %s not found in GnuPG", key)
+	}
+	return nil
+}
+
+// Verify checks that the tag carries a valid signature of a trusted key
+func (s *TagSigner) Verify(repoPath, tag string) error {
+	format := s.opts.Format
+	if format == "" {
+		detected, err := signatureFormat(repoPath, tag)
+		if err != nil {
+			return err
+		}
+		format = detected
+	}
+
+	config, environ, err := s.verificationConfig(format)
+	if err != nil {
+		return errors.Wrap(err, "preparing trusted keys")
+	}
+
+	args := append(config, "verify-tag", tag)
+	res, err := command.NewWithWorkDir(repoPath, "git", args...).
+		Env(environ...).RunSilent()
+	if err != nil {
+		return errors.Wrapf(err, "running git verify-tag for %s", tag)
+	}
+	if !res.Success() {
+		return errors.Errorf(
+			"verifying signature of tag %s: %s",
+			tag, strings.TrimSpace(res.Error()),
+		)
+	}
+	logrus.Infof("Tag %s has a valid %s signature", tag, format)
+	return nil
+}
+
+// signatureFormat detects the format of the tag signature
+func signatureFormat(repoPath, tag string) (string, error) {
+	res, err := command.NewWithWorkDir(
+		repoPath, "git", "cat-file", "tag", tag,
+	).RunSilentSuccessOutput()
+	if err != nil {
+		return "", errors.Wrapf(err, "reading tag object %s", tag)
+	}
+	switch {
+	case strings.Contains(res.Output(), pgpSignatureHeader):
+		return TagSigningFormatOpenPGP, nil
+	case strings.Contains(res.Output(), sshSignatureHeader):
+		return TagSigningFormatSSH, nil
+	}
+	return "", errors.Errorf("tag %s is not signed", tag)
+}
+
+// signingConfig returns the git config arguments and the environment for
+// signing
+func (s *TagSigner) signingConfig() (config, environ []string, err error) {
+	config = []string{"-c", "gpg.format=" + s.opts.Format}
+
+	if s.opts.Format == TagSigningFormatSSH {
+		key := s.opts.Key
+		if key == "" {
+			key = s.opts.KeyFile
+		}
+		config = append(config, "-c", "user.signingkey="+key)
+		if s.opts.AgentSocket != "" {
+			environ = append(environ, "SSH_AUTH_SOCK="+s.opts.AgentSocket)
+		}
+		return config, environ, nil
+	}
+
+	if s.opts.KeyFile == "" && s.opts.AgentSocket == "" {
+		// Use the keys of the default GnuPG home directory
+		return append(config, "-c", "user.signingkey="+s.opts.Key), nil, nil
+	}
+	home, err := s.signingGPGHome()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Default to the imported key
+	key := s.opts.Key
+	if key == "" {
+		key = s.gpgKey
+	}
+	if key != "" {
+		config = append(config, "-c", "user.signingkey="+key)
+	}
+	return config, []string{"GNUPGHOME=" + home}, nil
+}
+
+// verificationConfig returns the git config arguments and the environment
+// for verifying signatures of the format
+func (s *TagSigner) verificationConfig(format string) (config, environ []string, err error) {
+	config = []string{"-c", "gpg.format=" + format}
+
+	if format == TagSigningFormatSSH {
+		allowedSigners := s.opts.Keyring
+		if allowedSigners == "" {
+			if allowedSigners, err = s.signerAllowedSigners(); err != nil {
+				return nil, nil, err
+			}
+		}
+		return append(config, "-c", "gpg.ssh.allowedSignersFile="+allowedSigners), nil, nil
+	}
+
+	if s.opts.Keyring == "" {
+		if s.opts.KeyFile == "" && s.opts.AgentSocket == "" {
+			return config, nil, nil
+		}
+		home, err := s.signingGPGHome()
+		if err != nil {
+			return nil, nil, err
+		}
+		return config, []string{"GNUPGHOME=" + home}, nil
+	}
+
+	home, err := s.tempDir("tag-verify-gnupg-")
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := gpgImport(home, s.opts.Keyring); err != nil {
+		return nil, nil, errors.Wrap(err, "importing keyring")
+	}
+	return config, []string{"GNUPGHOME=" + home}, nil
+}
+
+// signingGPGHome returns a temporary GnuPG home directory which contains the
+// imported key file and is linked to the agent socket
+func (s *TagSigner) signingGPGHome() (string, error) {
+	if s.gpgHome != "" {
+		return s.gpgHome, nil
+	}
+
+	home, err := s.tempDir("tag-sign-gnupg-")
+	if err != nil {
+		return "", err
+	}
+	if s.opts.AgentSocket != "" {
+		if err := os.Symlink(
+			s.opts.AgentSocket, filepath.Join(home, "S.gpg-agent"),
+		); err != nil {
+			return "", errors.Wrap(err, "linking gpg-agent socket")
+		}
+	}
+	if s.opts.KeyFile != "" {
+		fingerprints, err := gpgImport(home, s.opts.KeyFile)
+		if err != nil {
+			return "", errors.Wrap(err, "importing signing key")
+		}
+		if len(fingerprints) > 0 {
+			s.gpgKey = fingerprints[0]
+		}
+	}
+	s.gpgHome = home
+	return home, nil
+}
+
+// signerAllowedSigners writes an SSH allowed signers file trusting the
+// signing key for all principals
+func (s *TagSigner) signerAllowedSigners() (string, error) {
+	publicKey, err := s.sshPublicKey()
+	if err != nil {
+		return "", err
+	}
+	dir, err := s.tempDir("tag-verify-ssh-")
+	if err != nil {
+		return "", err
+	}
+	allowedSigners := filepath.Join(dir, "allowed_signers")
+	if err := os.WriteFile(
+		allowedSigners, []byte(fmt.Sprintf("* %s\n", publicKey)), os.FileMode(0o600),
+	); err != nil {
+		return "", errors.Wrap(err, "writing allowed signers file")
+	}
+	return allowedSigners, nil
+}
+
+// sshPublicKey returns the public key of the SSH signing key
+func (s *TagSigner) sshPublicKey() (string, error) {
+	key := s.opts.Key
+	if strings.HasPrefix(key, "key::") {
+		return strings.TrimPrefix(key, "key::"), nil
+	}
+	if strings.HasPrefix(key, "ssh-") || strings.HasPrefix(key, "ecdsa-") {
+		return key, nil
+	}
+	if key != "" {
+		content, err := os.ReadFile(key)
+		if err != nil {
+			return "", errors.Wrapf(err, "reading public key %s", key)
+		}
+		return strings.TrimSpace(string(content)), nil
+	}
+
+	res, err := command.New("ssh-keygen", "-y", "-f", s.opts.KeyFile).
+		RunSilentSuccessOutput()
+	if err != nil {
+		return "", errors.Wrapf(err, "deriving public key of %s", s.opts.KeyFile)
+	}
+	return res.OutputTrimNL(), nil
+}
+
+func (s *TagSigner) tempDir(pattern string) (string, error) {
+	dir, err := os.MkdirTemp("", pattern)
+	if err != nil {
+		return "", errors.Wrap(err, "creating temporary directory")
+	}
+	s.tempDirs = append(s.tempDirs, dir)
+	return dir, nil
+}
+
+// gpgImport imports the keys of the file into the GnuPG home directory,
+// trusts them ultimately and returns the fingerprints of the primary keys
+func gpgImport(home, keyFile string) ([]string, error) {
+	if err := command.New(
+		"gpg", "--homedir", home, "--batch", "--import", keyFile,
+	).RunSilentSuccess(); err != nil {
+		return nil, errors.Wrapf(err, "importing %s", keyFile)
+	}
+
+	res, err := command.New(
+		"gpg", "--homedir", home, "--batch", "--with-colons", "--list-keys",
+	).RunSilentSuccessOutput()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing imported keys")
+	}
+	// Only the fingerprints following a primary key are of interest
+	fingerprints := []string{}
+	ownerTrust := &strings.Builder{}
+	previous := ""
+	for _, line := range strings.Split(res.Output(), "\n") {
+		fields := strings.Split(line, ":")
+		if len(fields) > 9 && fields[0] == "fpr" && previous == "pub" {
+			fingerprints = append(fingerprints, fields[9])
+			fmt.Fprintf(ownerTrust, "%s:6:\n", fields[9])
+		}
+		previous = fields[0]
+	}
+	trustFile := filepath.Join(home, "ownertrust.txt")
+	if err := os.WriteFile(
+		trustFile, []byte(ownerTrust.String()), os.FileMode(0o600),
+	); err != nil {
+		return nil, errors.Wrap(err, "writing owner trust")
+	}
+	if err := command.New(
+		"gpg", "--homedir", home, "--batch", "--import-ownertrust", trustFile,
+	).RunSilentSuccess(); err != nil {
+		return nil, errors.Wrap(err, "importing owner trust")
+	}
+	return fingerprints, nil
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/release"
+	"sigs.k8s.io/release-utils/command"
+)
+
+func newSigningTestRepo(t *testing.T) string {
+	for _, key := range []string{
+		"GIT_AUTHOR_NAME", "GIT_AUTHOR_EMAIL", "GIT_COMMITTER_NAME", "GIT_COMMITTER_EMAIL",
+	} {
+		t.Setenv(key, "test@example.com")
+	}
+	repoPath := t.TempDir()
+	require.Nil(t, command.NewWithWorkDir(repoPath, "git", "init").RunSilentSuccess())
+	require.Nil(t, command.NewWithWorkDir(
+		repoPath, "git", "commit", "--allow-empty", "-m", "Root commit",
+	).RunSilentSuccess())
+	require.Nil(t, command.NewWithWorkDir(
+		repoPath, "git", "tag", "-a", "-m", "unsigned", "v1.0.0",
+	).RunSilentSuccess())
+	return repoPath
+}
+
+func newSSHTestKey(t *testing.T, dir, name string) string {
+	keyPath := filepath.Join(dir, name)
+	require.Nil(t, command.New(
+		"ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", keyPath,
+	).RunSilentSuccess())
+	return keyPath
+}
+
+// newGPGTestKey generates a key and returns the paths of the armored secret
+// and public key
+func newGPGTestKey(t *testing.T, dir, name string) (secretKey, publicKey string) {
+	home, err := os.MkdirTemp("", "gpg-test-")
+	require.Nil(t, err)
+	defer func() {
+		require.Nil(t, command.New(
+			"gpgconf", "--homedir", home, "--kill", "gpg-agent",
+		).RunSilentSuccess())
+		os.RemoveAll(home)
+	}()
+
+	require.Nil(t, command.New(
+		"gpg", "--homedir", home, "--batch", "--passphrase", "",
+		"--quick-gen-key", name+" <"+name+"@example.com>", "ed25519", "sign", "never",
+	).RunSilentSuccess())
+
+	secretKey = filepath.Join(dir, name+".secret.asc")
+	publicKey = filepath.Join(dir, name+".asc")
+	for path, exportArg := range map[string]string{
+		secretKey: "--export-secret-keys", publicKey: "--export",
+	} {
+		res, err := command.New(
+			"gpg", "--homedir", home, "--batch", "--armor", exportArg,
+		).RunSilentSuccessOutput()
+		require.Nil(t, err)
+		require.Nil(t, os.WriteFile(path, []byte(res.Output()), os.FileMode(0o600)))
+	}
+	return secretKey, publicKey
+}
+
+func TestTagSignerSSH(t *testing.T) {
+	if !command.Available("ssh-keygen") {
+		t.Skip("ssh-keygen not available")
+	}
+	repoPath := newSigningTestRepo(t)
+	keyDir := t.TempDir()
+	signingKey := newSSHTestKey(t, keyDir, "signing")
+	otherKey := newSSHTestKey(t, keyDir, "other")
+
+	signer := release.NewTagSigner(&release.TagSignerOptions{
+		Format: release.TagSigningFormatSSH, KeyFile: signingKey,
+	})
+	defer signer.Cleanup()
+	require.Nil(t, signer.CheckSigningKey())
+	require.Nil(t, signer.Sign(repoPath, "v1.1.0", "Kubernetes official release v1.1.0"))
+	require.Nil(t, signer.Verify(repoPath, "v1.1.0"))
+	require.NotNil(t, signer.Verify(repoPath, "v1.0.0"))
+
+	// Verify against allowed signers files
+	otherPublicKey, err := os.ReadFile(otherKey + ".pub")
+	require.Nil(t, err)
+	allowedSigners := filepath.Join(keyDir, "allowed_signers")
+	require.Nil(t, os.WriteFile(
+		allowedSigners, append([]byte("* "), otherPublicKey...), os.FileMode(0o600),
+	))
+	verifier := release.NewTagSigner(&release.TagSignerOptions{Keyring: allowedSigners})
+	defer verifier.Cleanup()
+	require.NotNil(t, verifier.Verify(repoPath, "v1.1.0"))
+	require.NotNil(t, verifier.Verify(repoPath, "v1.0.0"))
+
+	// Missing signing key
+	missing := release.NewTagSigner(&release.TagSignerOptions{
+		Format: release.TagSigningFormatSSH, KeyFile: filepath.Join(keyDir, "missing"),
+	})
+	defer missing.Cleanup()
+	require.NotNil(t, missing.CheckSigningKey())
+}
+
+func TestTagSignerOpenPGP(t *testing.T) {
+	if !command.Available("gpg", "gpgconf") {
+		t.Skip("gpg not available")
+	}
+	repoPath := newSigningTestRepo(t)
+	keyDir := t.TempDir()
+	secretKey, _ := newGPGTestKey(t, keyDir, "signing")
+	_, otherPublicKey := newGPGTestKey(t, keyDir, "other")
+
+	signer := release.NewTagSigner(&release.TagSignerOptions{
+		Format: release.TagSigningFormatOpenPGP, KeyFile: secretKey,
+	})
+	defer signer.Cleanup()
+	require.Nil(t, signer.CheckSigningKey())
+	require.Nil(t, signer.Sign(repoPath, "v1.1.0", "Kubernetes official release v1.1.0"))
+	require.Nil(t, signer.Verify(repoPath, "v1.1.0"))
+	require.NotNil(t, signer.Verify(repoPath, "v1.0.0"))
+
+	// Verify against a keyring without the signing key
+	verifier := release.NewTagSigner(&release.TagSignerOptions{Keyring: otherPublicKey})
+	defer verifier.Cleanup()
+	require.NotNil(t, verifier.Verify(repoPath, "v1.1.0"))
+
+	// A public key file does not contain the secret key
+	_, publicKey := newGPGTestKey(t, keyDir, "public")
+	public := release.NewTagSigner(&release.TagSignerOptions{
+		Format: release.TagSigningFormatOpenPGP, KeyFile: publicKey,
+	})
+	defer public.Cleanup()
+	require.NotNil(t, public.CheckSigningKey())
+}
+
+func TestTagSignerOptionsValidate(t *testing.T) {
+	for _, tc := range []struct {
+		opts      *release.TagSignerOptions
+		shouldErr bool
+	}{
+		{ // success
+			opts: &release.TagSignerOptions{
+				Format: release.TagSigningFormatSSH, KeyFile: "key",
+			},
+		},
+		{ // unsupported format
+			opts:      &release.TagSignerOptions{Format: "x509", KeyFile: "key"},
+			shouldErr: true,
+		},
+		{ // no key
+			opts:      &release.TagSignerOptions{Format: release.TagSigningFormatOpenPGP},
+			shouldErr: true,
+		},
+	} {
+		err := tc.opts.Validate()
+		if tc.shouldErr {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+	}
+}
@@ -0,0 +1,370 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	_ "embed" // used for the default tarball manifest
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+//go:embed tarball_manifest.yaml
+var defaultTarballManifest []byte
+
+// maxVersionFileSize limits the size of files checked for the version
+const maxVersionFileSize = 1024
+
+// ErrNotInManifest is returned by TarballManifest.Check if the tarball is not
+// part of the manifest
+var ErrNotInManifest = errors.New("tarball not found in manifest")
+
+// TarballManifest declares the expected contents of release tarballs
+type TarballManifest struct {
+	Tarballs []TarballSpec `yaml:"tarballs"`
+}
+
+// TarballSpec declares the expected contents of a tarball
+type TarballSpec struct {
+	// Name is the templated file name of the tarball
+	Name string `yaml:"name"`
+
+	// Platforms are the OS/Arch combinations the tarball is built for. The
+	// tarball is platform independent if empty.
+	Platforms []string `yaml:"platforms,omitempty"`
+
+	// Files are the expected files of the tarball
+	Files []TarballFileSpec `yaml:"files"`
+}
+
+// TarballFileSpec declares a file or a set of files in a tarball
+type TarballFileSpec struct {
+	// Pattern is the templated path pattern of the file
+	Pattern string `yaml:"pattern"`
+
+	// Optional files are not required to exist
+	Optional bool `yaml:"optional,omitempty"`
+
+	// Mode are the expected permission bits of the file, unchecked if zero
+	Mode os.FileMode `yaml:"mode,omitempty"`
+
+	// Version requires the file to contain the release version
+	Version bool `yaml:"version,omitempty"`
+}
+
+// TarballContentReport is the result of checking a tarball against its
+// manifest
+type TarballContentReport struct {
+	Tarball    string
+	Missing    []string
+	Unexpected []string
+	Problems   []string
+}
+
+// Passed returns true if the tarball matches its manifest
+func (r *TarballContentReport) Passed() bool {
+	return len(r.Missing) == 0 && len(r.Unexpected) == 0 && len(r.Problems) == 0
+}
+
+// String returns a summary of all findings of the report
+func (r *TarballContentReport) String() string {
+	res := []string{}
+	for _, file := range r.Missing {
+		res = append(res, fmt.Sprintf("%s: missing %s", r.Tarball, file))
+	}
+	for _, file := range r.Unexpected {
+		res = append(res, fmt.Sprintf("%s: unexpected %s", r.Tarball, file))
+	}
+	for _, problem := range r.Problems {
+		res = append(res, fmt.Sprintf("%s: %s", r.Tarball, problem))
+	}
+	return strings.Join(res, "\n")
+}
+
+// tarballTemplateData are the fields available in the manifest templates
+type tarballTemplateData struct {
+	Version string
+	OS      string
+	Arch    string
+	Ext     string
+}
+
+// DefaultTarballManifest returns the manifest of the Kubernetes release
+// tarballs
+func DefaultTarballManifest() (*TarballManifest, error) {
+	return parseTarballManifest(defaultTarballManifest)
+}
+
+// LoadTarballManifest reads a tarball manifest from a YAML file
+func LoadTarballManifest(manifestPath string) (*TarballManifest, error) {
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading tarball manifest %s", manifestPath)
+	}
+	return parseTarballManifest(content)
+}
+
+func parseTarballManifest(content []byte) (*TarballManifest, error) {
+	manifest := &TarballManifest{}
+	if err := yaml.UnmarshalStrict(content, manifest); err != nil {
+		return nil, errors.Wrap(err, "parsing tarball manifest")
+	}
+	for _, tarball := range manifest.Tarballs {
+		if tarball.Name == "" {
+			return nil, errors.New("tarball without name in manifest")
+		}
+		for _, platform := range tarball.Platforms {
+			if len(strings.Split(platform, "/")) != 2 {
+				return nil, errors.Errorf(
+					"expected `os/arch` format for platform %s of %s",
+					platform, tarball.Name,
+				)
+			}
+		}
+	}
+	return manifest, nil
+}
+
+// Check verifies the contents of the tarball against its specification in
+// the manifest. An error wrapping ErrNotInManifest is returned if the tarball
+// is not part of the manifest, any other error if it cannot be read.
+func (m *TarballManifest) Check(tarballPath, version string) (*TarballContentReport, error) {
+	spec, data, err := m.specFor(filepath.Base(tarballPath), version)
+	if err != nil {
+		return nil, err
+	}
+	if spec == nil {
+		return nil, errors.Wrap(ErrNotInManifest, filepath.Base(tarballPath))
+	}
+
+	files, err := renderFileSpecs(spec.Files, data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "rendering file patterns of %s", spec.Name)
+	}
+
+	report := &TarballContentReport{Tarball: filepath.Base(tarballPath)}
+	matched := make([]bool, len(files))
+	dockerTag := strings.ReplaceAll(version, "+", "_")
+
+	if err := walkTarball(tarballPath, func(hdr *tar.Header, r io.Reader) error {
+		if hdr.Typeflag == tar.TypeDir {
+			return nil
+		}
+		name := strings.TrimPrefix(path.Clean(hdr.Name), "./")
+
+		found := false
+		for i := range files {
+			if !matchTarballPattern(files[i].Pattern, name) {
+				continue
+			}
+			found = true
+			matched[i] = true
+
+			if mode := files[i].Mode.Perm(); mode != 0 &&
+				os.FileMode(hdr.Mode).Perm() != mode {
+				report.Problems = append(report.Problems, fmt.Sprintf(
+					"%s has mode %s, expected %s",
+					name, os.FileMode(hdr.Mode).Perm(), mode,
+				))
+			}
+
+			if files[i].Version {
+				content, err := io.ReadAll(io.LimitReader(r, maxVersionFileSize))
+				if err != nil {
+					return errors.Wrapf(err, "reading %s", name)
+				}
+				fileVersion := string(bytes.TrimSpace(content))
+				if fileVersion != version && fileVersion != dockerTag {
+					report.Problems = append(report.Problems, fmt.Sprintf(
+						"%s contains version %q, expected %q",
+						name, fileVersion, version,
+					))
+				}
+			}
+			break
+		}
+
+		if !found {
+			report.Unexpected = append(report.Unexpected, name)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "reading tarball %s", tarballPath)
+	}
+
+	for i, file := range files {
+		if !matched[i] && !file.Optional {
+			report.Missing = append(report.Missing, file.Pattern)
+		}
+	}
+	sort.Strings(report.Unexpected)
+	return report, nil
+}
+
+// MissingTarballs returns the names of all tarballs of the manifest which are
+// not part of the provided tarball paths.
+func (m *TarballManifest) MissingTarballs(
+	tarballPaths []string, version string,
+) ([]string, error) {
+	built := map[string]bool{}
+	for _, tarballPath := range tarballPaths {
+		built[filepath.Base(tarballPath)] = true
+	}
+
+	missing := []string{}
+	for i := range m.Tarballs {
+		for _, data := range m.Tarballs[i].templateData(version) {
+			name, err := renderTarballTemplate(m.Tarballs[i].Name, data)
+			if err != nil {
+				return nil, errors.Wrapf(
+					err, "rendering tarball name %s", m.Tarballs[i].Name,
+				)
+			}
+			if !built[name] {
+				missing = append(missing, name)
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+// specFor returns the specification and the template data of a tarball, nil
+// if the manifest does not contain the tarball.
+func (m *TarballManifest) specFor(
+	tarballName, version string,
+) (*TarballSpec, *tarballTemplateData, error) {
+	for i := range m.Tarballs {
+		spec := &m.Tarballs[i]
+		for _, data := range spec.templateData(version) {
+			name, err := renderTarballTemplate(spec.Name, data)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "rendering tarball name %s", spec.Name)
+			}
+			if name == tarballName {
+				return spec, data, nil
+			}
+		}
+	}
+	return nil, nil, nil
+}
+
+// templateData returns the template data of the tarball for every platform
+// it is built for.
+func (s *TarballSpec) templateData(version string) []*tarballTemplateData {
+	if len(s.Platforms) == 0 {
+		return []*tarballTemplateData{{Version: version}}
+	}
+	res := []*tarballTemplateData{}
+	for _, platform := range s.Platforms {
+		split := strings.Split(platform, "/")
+		data := &tarballTemplateData{
+			Version: version, OS: split[0], Arch: split[1],
+		}
+		if data.OS == "windows" {
+			data.Ext = ".exe"
+		}
+		res = append(res, data)
+	}
+	return res
+}
+
+func renderFileSpecs(
+	specs []TarballFileSpec, data *tarballTemplateData,
+) ([]TarballFileSpec, error) {
+	res := []TarballFileSpec{}
+	for _, spec := range specs {
+		pattern, err := renderTarballTemplate(spec.Pattern, data)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := path.Match(strings.TrimSuffix(pattern, "/**"), ""); err != nil {
+			return nil, errors.Wrapf(err, "invalid pattern %s", pattern)
+		}
+		spec.Pattern = pattern
+		res = append(res, spec)
+	}
+	return res, nil
+}
+
+func renderTarballTemplate(text string, data *tarballTemplateData) (string, error) {
+	tmpl, err := template.New("").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// matchTarballPattern matches the name against the pattern, where a trailing
+// "/**" matches everything below the directory.
+func matchTarballPattern(pattern, name string) bool {
+	if strings.HasSuffix(pattern, "/**") {
+		dir := strings.TrimSuffix(pattern, "/**")
+		for parent := path.Dir(name); parent != "." && parent != "/"; parent = path.Dir(parent) {
+			if match, _ := path.Match(dir, parent); match {
+				return true
+			}
+		}
+		return false
+	}
+	match, _ := path.Match(pattern, name)
+	return match
+}
+
+// walkTarball calls fn for every entry of the gzipped tarball
+func walkTarball(tarballPath string, fn func(*tar.Header, io.Reader) error) error {
+	file, err := os.Open(tarballPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return errors.Wrap(err, "creating gzip reader")
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		hdr, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "reading tar header")
+		}
+		if err := fn(hdr, tarReader); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,278 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/release"
+)
+
+type testTarEntry struct {
+	name    string
+	mode    int64
+	content string
+}
+
+func writeTestTarball(t *testing.T, tarballPath string, entries []testTarEntry) {
+	file, err := os.Create(tarballPath)
+	require.Nil(t, err)
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, entry := range entries {
+		hdr := &tar.Header{
+			Name:     entry.name,
+			Mode:     entry.mode,
+			Size:     int64(len(entry.content)),
+			Typeflag: tar.TypeReg,
+		}
+		if entry.name[len(entry.name)-1] == '/' {
+			hdr.Typeflag = tar.TypeDir
+		}
+		require.Nil(t, tarWriter.WriteHeader(hdr))
+		_, err := tarWriter.Write([]byte(entry.content))
+		require.Nil(t, err)
+	}
+	require.Nil(t, tarWriter.Close())
+	require.Nil(t, gzipWriter.Close())
+}
+
+func serverTarballEntries() []testTarEntry {
+	entries := []testTarEntry{
+		{name: "kubernetes/", mode: 0o755},
+		{name: "kubernetes/LICENSES", mode: 0o644, content: "licenses"},
+		{name: "kubernetes/kubernetes-src.tar.gz", mode: 0o644},
+		{name: "kubernetes/addons/", mode: 0o755},
+		{name: "kubernetes/server/bin/kubeadm", mode: 0o755},
+		{name: "kubernetes/server/bin/kubectl", mode: 0o755},
+		{name: "kubernetes/server/bin/kubelet", mode: 0o755},
+	}
+	for _, image := range []string{
+		"kube-apiserver", "kube-controller-manager", "kube-scheduler", "kube-proxy",
+	} {
+		entries = append(entries,
+			testTarEntry{name: "kubernetes/server/bin/" + image, mode: 0o755},
+			testTarEntry{name: "kubernetes/server/bin/" + image + ".tar", mode: 0o644},
+			testTarEntry{
+				name: "kubernetes/server/bin/" + image + ".docker_tag",
+				mode: 0o644, content: "v1.22.3_abc\n",
+			},
+		)
+	}
+	return entries
+}
+
+func TestTarballManifestCheck(t *testing.T) {
+	const version = "v1.22.3+abc"
+	manifest, err := release.DefaultTarballManifest()
+	require.Nil(t, err)
+
+	for _, tc := range []struct {
+		tarball    string
+		entries    func() []testTarEntry
+		missing    []string
+		unexpected []string
+		problems   int
+		corrupt    bool
+		shouldErr  bool
+		notFound   bool
+	}{
+		{ // success
+			tarball: "kubernetes-server-linux-arm64.tar.gz",
+			entries: serverTarballEntries,
+		},
+		{ // missing binary
+			tarball: "kubernetes-server-linux-arm64.tar.gz",
+			entries: func() []testTarEntry {
+				return serverTarballEntries()[1:5]
+			},
+			missing: []string{
+				"kubernetes/server/bin/kube-apiserver",
+				"kubernetes/server/bin/kube-controller-manager",
+				"kubernetes/server/bin/kube-scheduler",
+				"kubernetes/server/bin/kube-proxy",
+				"kubernetes/server/bin/kube-*.tar",
+				"kubernetes/server/bin/kube-*.docker_tag",
+				"kubernetes/server/bin/kubectl",
+				"kubernetes/server/bin/kubelet",
+			},
+		},
+		{ // unexpected file
+			tarball: "kubernetes-server-linux-arm64.tar.gz",
+			entries: func() []testTarEntry {
+				return append(serverTarballEntries(),
+					testTarEntry{name: "kubernetes/server/bin/debug.log", mode: 0o644},
+				)
+			},
+			unexpected: []string{"kubernetes/server/bin/debug.log"},
+		},
+		{ // wrong mode and version
+			tarball: "kubernetes-server-linux-arm64.tar.gz",
+			entries: func() []testTarEntry {
+				entries := serverTarballEntries()
+				entries[4].mode = 0o644
+				entries[len(entries)-1].content = "v1.22.2"
+				return entries
+			},
+			problems: 2,
+		},
+		{ // windows node binaries
+			tarball: "kubernetes-node-windows-amd64.tar.gz",
+			entries: func() []testTarEntry {
+				return []testTarEntry{
+					{name: "kubernetes/LICENSES", mode: 0o644},
+					{name: "kubernetes/kubernetes-src.tar.gz", mode: 0o644},
+					{name: "kubernetes/node/bin/kubeadm.exe", mode: 0o755},
+					{name: "kubernetes/node/bin/kubectl.exe", mode: 0o755},
+					{name: "kubernetes/node/bin/kubelet.exe", mode: 0o755},
+					{name: "kubernetes/node/bin/kube-proxy.exe", mode: 0o755},
+				}
+			},
+		},
+		{ // recursive patterns
+			tarball: "kubernetes.tar.gz",
+			entries: func() []testTarEntry {
+				return []testTarEntry{
+					{name: "kubernetes/version", mode: 0o644, content: version},
+					{name: "kubernetes/LICENSES", mode: 0o644},
+					{name: "kubernetes/README.md", mode: 0o644},
+					{name: "kubernetes/cluster/gce/util.sh", mode: 0o755},
+				}
+			},
+		},
+		{ // tarball not in manifest
+			tarball:   "kubernetes-unknown.tar.gz",
+			entries:   serverTarballEntries,
+			shouldErr: true,
+			notFound:  true,
+		},
+		{ // tarball cannot be read
+			tarball:   "kubernetes-server-linux-arm64.tar.gz",
+			entries:   serverTarballEntries,
+			corrupt:   true,
+			shouldErr: true,
+		},
+	} {
+		tarballPath := filepath.Join(t.TempDir(), tc.tarball)
+		if tc.corrupt {
+			require.Nil(t, os.WriteFile(tarballPath, []byte("invalid"), os.FileMode(0o644)))
+		} else {
+			writeTestTarball(t, tarballPath, tc.entries())
+		}
+
+		report, err := manifest.Check(tarballPath, version)
+		if tc.shouldErr {
+			require.NotNil(t, err)
+			require.Equal(t, tc.notFound, errors.Is(err, release.ErrNotInManifest))
+			continue
+		}
+		require.Nil(t, err)
+		require.Equal(t, tc.missing, report.Missing)
+		require.Equal(t, tc.unexpected, report.Unexpected)
+		require.Len(t, report.Problems, tc.problems)
+		require.Equal(t, tc.missing == nil && tc.unexpected == nil && tc.problems == 0, report.Passed())
+	}
+}
+
+func TestTarballManifestMissingTarballs(t *testing.T) {
+	manifest, err := release.LoadTarballManifest(writeTestManifest(t,
+		"tarballs:\n"+
+			"- name: kubernetes.tar.gz\n"+
+			"- name: kubernetes-node-{{ .OS }}-{{ .Arch }}.tar.gz\n"+
+			"  platforms: [linux/amd64, windows/amd64]\n",
+	))
+	require.Nil(t, err)
+
+	for _, tc := range []struct {
+		tarballs []string
+		expected []string
+	}{
+		{ // all built
+			tarballs: []string{
+				"/tmp/kubernetes.tar.gz",
+				"/tmp/kubernetes-node-linux-amd64.tar.gz",
+				"/tmp/kubernetes-node-windows-amd64.tar.gz",
+				"/tmp/kubernetes-unknown.tar.gz",
+			},
+			expected: []string{},
+		},
+		{ // platform not built
+			tarballs: []string{
+				"/tmp/kubernetes.tar.gz",
+				"/tmp/kubernetes-node-linux-amd64.tar.gz",
+			},
+			expected: []string{"kubernetes-node-windows-amd64.tar.gz"},
+		},
+		{ // nothing built
+			tarballs: []string{},
+			expected: []string{
+				"kubernetes-node-linux-amd64.tar.gz",
+				"kubernetes-node-windows-amd64.tar.gz",
+				"kubernetes.tar.gz",
+			},
+		},
+	} {
+		missing, err := manifest.MissingTarballs(tc.tarballs, "v1.22.3")
+		require.Nil(t, err)
+		require.Equal(t, tc.expected, missing)
+	}
+}
+
+func writeTestManifest(t *testing.T, content string) string {
+	manifestPath := filepath.Join(t.TempDir(), "manifest.yaml")
+	require.Nil(t, os.WriteFile(manifestPath, []byte(content), os.FileMode(0o644)))
+	return manifestPath
+}
+
+func TestLoadTarballManifest(t *testing.T) {
+	for _, tc := range []struct {
+		content   string
+		shouldErr bool
+	}{
+		{ // success
+			content: "tarballs:\n- name: test.tar.gz\n  files:\n  - pattern: bin/*\n    mode: 0755\n",
+		},
+		{ // unknown field
+			content:   "tarballs:\n- name: test.tar.gz\n  unknown: true\n",
+			shouldErr: true,
+		},
+		{ // invalid platform
+			content:   "tarballs:\n- name: test.tar.gz\n  platforms: [linux]\n",
+			shouldErr: true,
+		},
+	} {
+		manifestPath := filepath.Join(t.TempDir(), "manifest.yaml")
+		require.Nil(t, os.WriteFile(manifestPath, []byte(tc.content), os.FileMode(0o644)))
+
+		manifest, err := release.LoadTarballManifest(manifestPath)
+		if tc.shouldErr {
+			require.NotNil(t, err)
+			continue
+		}
+		require.Nil(t, err)
+		require.Equal(t, os.FileMode(0o755), manifest.Tarballs[0].Files[0].Mode)
+	}
+}
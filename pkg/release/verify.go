@@ -0,0 +1,672 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/objectstore"
+	"k8s.io/release/pkg/provenance"
+	"k8s.io/release/pkg/spdx"
+	"sigs.k8s.io/release-sdk/github"
+)
+
+const (
+	// SHA256SumsFile is the file containing the SHA256 checksums of all
+	// release artifacts
+	SHA256SumsFile = "SHA256SUMS"
+
+	// SHA512SumsFile is the file containing the SHA512 checksums of all
+	// release artifacts
+	SHA512SumsFile = "SHA512SUMS"
+
+	// ReleaseSBOMFile is the SPDX bill of materials of the release artifacts
+	ReleaseSBOMFile = "kubernetes-release.spdx"
+)
+
+// Names of the checks done by the ReleaseVerifier
+const (
+	VerifyCheckChecksums  = "checksums"
+	VerifyCheckProvenance = "provenance"
+	VerifyCheckSBOM       = "sbom"
+	VerifyCheckImages     = "images"
+	VerifyCheckGitHub     = "github"
+)
+
+// ghPageAssetRegex matches the assets table rendered by announce.ghPageBody
+var ghPageAssetRegex = regexp.MustCompile(
+	`<td colspan="2">(?:<b>[^<]*: </b> ([^<]+)|<b>([^<]+)</b>)</td><tr>\s*` +
+		`<tr><td>SHA256</td><td>([0-9a-fA-F]*)</td></tr>\s*` +
+		`<tr><td>SHA512</td><td>([0-9a-fA-F]*)</td></tr>`,
+)
+
+// imageTarballRegex matches the server image tarballs of the release
+var imageTarballRegex = regexp.MustCompile(`^bin/linux/([^/]+)/([^/]+)\.tar$`)
+
+// ReleaseVerifierOptions are the settings for verifying a published release
+type ReleaseVerifierOptions struct {
+	// Version is the release version to be verified
+	Version string
+
+	// Bucket and GCSRoot define the location of the release artifacts
+	Bucket  string
+	GCSRoot string
+
+	// Mirror is a local directory containing the artifacts of the version.
+	// It takes precedence over Bucket if set.
+	Mirror string
+
+	// Registry is the container image registry to check the image manifests
+	// in. Images are not checked if empty.
+	Registry string
+
+	// GitHubOrg and GitHubRepo are the location of the release page. The
+	// release page is not checked if empty.
+	GitHubOrg  string
+	GitHubRepo string
+}
+
+// VerificationResult is the outcome of a single check
+type VerificationResult struct {
+	// Check is the name of the check
+	Check string
+
+	// Checked is the number of verified items
+	Checked int
+
+	// Skipped is true if the check was not run
+	Skipped bool
+
+	// Failures contains a message for each failed item
+	Failures []string
+}
+
+// Passed returns true if the check did not fail
+func (r *VerificationResult) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+func (r *VerificationResult) failf(format string, args ...interface{}) {
+	r.Failures = append(r.Failures, fmt.Sprintf(format, args...))
+}
+
+// VerificationReport contains the results of all checks of a release
+type VerificationReport struct {
+	Version string
+	Results []*VerificationResult
+}
+
+// Passed returns true if all checks passed
+func (r *VerificationReport) Passed() bool {
+	for _, result := range r.Results {
+		if !result.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// String renders the report in a human readable format
+func (r *VerificationReport) String() string {
+	var buf bytes.Buffer
+	for _, result := range r.Results {
+		if result.Skipped {
+			fmt.Fprintf(&buf, "SKIP %s\n", result.Check)
+			continue
+		}
+		status := "PASS"
+		if !result.Passed() {
+			status = "FAIL"
+		}
+		fmt.Fprintf(&buf, "%s %s (%d checked)\n", status, result.Check, result.Checked)
+		for _, failure := range result.Failures {
+			fmt.Fprintf(&buf, "    - %s\n", failure)
+		}
+	}
+
+	outcome := "PASSED"
+	if !r.Passed() {
+		outcome = "FAILED"
+	}
+	fmt.Fprintf(&buf, "Verification of %s %s\n", r.Version, outcome)
+	return buf.String()
+}
+
+// artifactHashes are the checksums of a single release artifact
+type artifactHashes struct {
+	sha256 string
+	sha512 string
+}
+
+// ReleaseVerifier checks that a published release is complete and intact
+type ReleaseVerifier struct {
+	opts   *ReleaseVerifierOptions
+	store  objectstore.Store
+	images *Images
+	github *github.GitHub
+}
+
+// NewReleaseVerifier creates a new ReleaseVerifier. The artifacts are read
+// from the local mirror if set, otherwise from the bucket.
+func NewReleaseVerifier(opts *ReleaseVerifierOptions) *ReleaseVerifier {
+	store := objectstore.ForBucket(opts.Bucket)
+	if opts.Mirror != "" {
+		store = objectstore.NewLocal()
+	}
+	return &ReleaseVerifier{
+		opts:   opts,
+		store:  store,
+		images: NewImages(),
+		github: github.New(),
+	}
+}
+
+// SetStore sets the object store to read the release artifacts from
+func (v *ReleaseVerifier) SetStore(store objectstore.Store) {
+	v.store = store
+}
+
+// SetImages sets the images instance used to check the image manifests
+func (v *ReleaseVerifier) SetImages(images *Images) {
+	v.images = images
+}
+
+// SetGitHub sets the GitHub client used to check the release page
+func (v *ReleaseVerifier) SetGitHub(gh *github.GitHub) {
+	v.github = gh
+}
+
+// Verify runs all checks against the published release. An error is only
+// returned if the verification could not be run at all, failed checks are
+// part of the report.
+func (v *ReleaseVerifier) Verify() (*VerificationReport, error) {
+	rootPath, err := v.rootPath()
+	if err != nil {
+		return nil, errors.Wrap(err, "get release root path")
+	}
+	logrus.Infof("Verifying release %s in %s", v.opts.Version, rootPath)
+
+	report := &VerificationReport{Version: v.opts.Version}
+	checksums, hashes := v.verifyChecksums(rootPath)
+	report.Results = append(report.Results,
+		checksums,
+		v.verifyProvenance(rootPath, hashes),
+		v.verifySBOM(rootPath, hashes),
+		v.verifyImages(hashes),
+		v.verifyGitHubPage(hashes),
+	)
+	return report, nil
+}
+
+func (v *ReleaseVerifier) rootPath() (string, error) {
+	if v.opts.Mirror != "" {
+		return v.store.NormalizePath(v.opts.Mirror)
+	}
+	if v.opts.GCSRoot == "" {
+		return "", errors.New("GCS root must be specified")
+	}
+	return v.store.NormalizePath(v.opts.Bucket, v.opts.GCSRoot, v.opts.Version)
+}
+
+// verifyChecksums hashes every artifact listed in the checksum files and
+// returns the computed hashes of all artifacts which match.
+func (v *ReleaseVerifier) verifyChecksums(
+	rootPath string,
+) (*VerificationResult, map[string]*artifactHashes) {
+	result := &VerificationResult{Check: VerifyCheckChecksums}
+	hashes := map[string]*artifactHashes{}
+
+	sha256Sums, err := v.readChecksums(rootPath, SHA256SumsFile)
+	if err != nil {
+		result.failf("%v", err)
+		return result, hashes
+	}
+	sha512Sums, err := v.readChecksums(rootPath, SHA512SumsFile)
+	if err != nil {
+		result.failf("%v", err)
+		return result, hashes
+	}
+
+	for _, name := range sortedKeys(sha512Sums) {
+		if _, ok := sha256Sums[name]; !ok {
+			result.failf("%s is missing in %s", name, SHA256SumsFile)
+		}
+	}
+
+	for _, name := range sortedKeys(sha256Sums) {
+		result.Checked++
+		artifactPath, err := v.store.NormalizePath(rootPath, name)
+		if err != nil {
+			result.failf("%s: %v", name, err)
+			continue
+		}
+
+		computed, err := v.hashArtifact(artifactPath)
+		if err != nil {
+			result.failf("%s: %v", name, err)
+			continue
+		}
+
+		if computed.sha256 != sha256Sums[name] {
+			result.failf(
+				"%s: SHA256 %s does not match %s",
+				name, computed.sha256, sha256Sums[name],
+			)
+			continue
+		}
+		if expected, ok := sha512Sums[name]; ok && computed.sha512 != expected {
+			result.failf(
+				"%s: SHA512 %s does not match %s", name, computed.sha512, expected,
+			)
+			continue
+		}
+		hashes[name] = computed
+	}
+	return result, hashes
+}
+
+// readChecksums parses a checksum file written by WriteChecksums. The
+// returned map is keyed by the artifact path relative to the release root.
+func (v *ReleaseVerifier) readChecksums(
+	rootPath, fileName string,
+) (map[string]string, error) {
+	sumsPath, err := v.store.NormalizePath(rootPath, fileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get path of %s", fileName)
+	}
+	content, err := v.store.Read(sumsPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read %s", fileName)
+	}
+
+	entries := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		parts := strings.SplitN(line, "  ", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("malformed line in %s: %q", fileName, line)
+		}
+		entries[parts[1]] = strings.ToLower(parts[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "scan %s", fileName)
+	}
+
+	// WriteChecksums records the paths of the local staging directory, which
+	// is the common prefix of all entries.
+	prefix := v.stagePrefix(sortedKeys(entries))
+	res := map[string]string{}
+	for name, sum := range entries {
+		res[strings.TrimPrefix(name, prefix)] = sum
+	}
+	return res, nil
+}
+
+// stagePrefix returns the local staging directory prefix of the checksum
+// file entries.
+func (v *ReleaseVerifier) stagePrefix(names []string) string {
+	stageDir := path.Join(GCSStagePath, v.opts.Version) + "/"
+	for _, name := range names {
+		if i := strings.Index(name, stageDir); i >= 0 {
+			return name[:i+len(stageDir)]
+		}
+	}
+
+	if len(names) == 0 {
+		return ""
+	}
+	prefix := path.Dir(names[0])
+	for _, name := range names[1:] {
+		for prefix != "." && prefix != "/" &&
+			!strings.HasPrefix(name, prefix+"/") {
+			prefix = path.Dir(prefix)
+		}
+	}
+	if prefix == "." {
+		return ""
+	}
+	return strings.TrimSuffix(prefix, "/") + "/"
+}
+
+func (v *ReleaseVerifier) hashArtifact(artifactPath string) (*artifactHashes, error) {
+	reader, err := v.store.Open(artifactPath)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	sha256Hash := sha256.New()
+	sha512Hash := sha512.New()
+	if _, err := io.Copy(
+		io.MultiWriter(sha256Hash, sha512Hash), reader,
+	); err != nil {
+		return nil, errors.Wrapf(err, "hash %s", artifactPath)
+	}
+	return &artifactHashes{
+		sha256: hex.EncodeToString(sha256Hash.Sum(nil)),
+		sha512: hex.EncodeToString(sha512Hash.Sum(nil)),
+	}, nil
+}
+
+// verifyProvenance checks that the subjects of the provenance attestation
+// match the verified artifacts. Releases without provenance are skipped.
+func (v *ReleaseVerifier) verifyProvenance(
+	rootPath string, hashes map[string]*artifactHashes,
+) *VerificationResult {
+	result := &VerificationResult{Check: VerifyCheckProvenance}
+
+	provenancePath, err := v.store.NormalizePath(rootPath, ProvenanceFilename)
+	if err != nil {
+		result.failf("%v", err)
+		return result
+	}
+	content, err := v.store.Read(provenancePath)
+	if errors.Is(err, objectstore.ErrNotExist) {
+		logrus.Warnf("Skipping %s check: %v", result.Check, err)
+		result.Skipped = true
+		return result
+	}
+	if err != nil {
+		result.failf("read %s: %v", ProvenanceFilename, err)
+		return result
+	}
+
+	statement := provenance.NewSLSAStatement()
+	if err := json.Unmarshal(content, statement); err != nil {
+		result.failf("decode %s: %v", ProvenanceFilename, err)
+		return result
+	}
+
+	versionDir := "/" + v.opts.Version + "/"
+	for _, subject := range statement.Subject {
+		i := strings.Index(subject.Name, versionDir)
+		if i < 0 {
+			logrus.Debugf("Skipping provenance subject %s", subject.Name)
+			continue
+		}
+		name := subject.Name[i+len(versionDir):]
+		result.Checked++
+
+		computed, ok := hashes[name]
+		if !ok {
+			result.failf("subject %s is not a verified artifact", name)
+			continue
+		}
+		if err := compareDigests(computed, subject.Digest); err != nil {
+			result.failf("subject %s: %v", name, err)
+		}
+	}
+
+	if result.Checked == 0 {
+		result.failf("no release artifacts found in %s", ProvenanceFilename)
+	}
+	return result
+}
+
+// verifySBOM checks that the files referenced in the release SBOM match the
+// verified artifacts. Releases without an SBOM are skipped.
+func (v *ReleaseVerifier) verifySBOM(
+	rootPath string, hashes map[string]*artifactHashes,
+) *VerificationResult {
+	result := &VerificationResult{Check: VerifyCheckSBOM}
+
+	sbomPath, err := v.store.NormalizePath(rootPath, ReleaseSBOMFile)
+	if err != nil {
+		result.failf("%v", err)
+		return result
+	}
+	content, err := v.store.Read(sbomPath)
+	if errors.Is(err, objectstore.ErrNotExist) {
+		logrus.Warnf("Skipping %s check: %v", result.Check, err)
+		result.Skipped = true
+		return result
+	}
+	if err != nil {
+		result.failf("read %s: %v", ReleaseSBOMFile, err)
+		return result
+	}
+
+	// The SPDX parser works on local files only
+	tmpFile, err := os.CreateTemp("", "release-sbom-")
+	if err != nil {
+		result.failf("create temporary file: %v", err)
+		return result
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(content); err != nil {
+		tmpFile.Close()
+		result.failf("write temporary file: %v", err)
+		return result
+	}
+	tmpFile.Close()
+
+	doc, err := spdx.OpenDoc(tmpFile.Name())
+	if err != nil {
+		result.failf("parse %s: %v", ReleaseSBOMFile, err)
+		return result
+	}
+
+	for _, file := range doc.Files {
+		name := file.FileName
+		if name == "" {
+			name = file.Name
+		}
+		result.Checked++
+
+		computed, ok := hashes[name]
+		if !ok {
+			result.failf("file %s is not a verified artifact", name)
+			continue
+		}
+		if err := compareDigests(computed, file.Checksum); err != nil {
+			result.failf("file %s: %v", name, err)
+		}
+	}
+	return result
+}
+
+// verifyImages checks the image manifest lists of all server images in the
+// release artifacts for every architecture.
+func (v *ReleaseVerifier) verifyImages(
+	hashes map[string]*artifactHashes,
+) *VerificationResult {
+	result := &VerificationResult{Check: VerifyCheckImages}
+	if v.opts.Registry == "" {
+		result.Skipped = true
+		return result
+	}
+
+	images := map[string][]string{}
+	for _, name := range sortedHashKeys(hashes) {
+		match := imageTarballRegex.FindStringSubmatch(name)
+		if len(match) != 3 {
+			continue
+		}
+		arch := match[1]
+		image := strings.TrimSuffix(match[2], "-"+arch)
+		images[image] = append(images[image], arch)
+	}
+
+	for _, image := range sortedImageKeys(images) {
+		result.Checked++
+		if err := v.images.ValidateRemote(
+			v.opts.Registry, v.opts.Version,
+			map[string][]string{image: images[image]},
+		); err != nil {
+			result.failf("%s: %v", image, err)
+		}
+	}
+	return result
+}
+
+// verifyGitHubPage checks that the release page exists and that the assets
+// listed on it match the verified artifacts.
+func (v *ReleaseVerifier) verifyGitHubPage(
+	hashes map[string]*artifactHashes,
+) *VerificationResult {
+	result := &VerificationResult{Check: VerifyCheckGitHub}
+	if v.opts.GitHubOrg == "" || v.opts.GitHubRepo == "" {
+		result.Skipped = true
+		return result
+	}
+
+	releases, err := v.github.Releases(v.opts.GitHubOrg, v.opts.GitHubRepo, true)
+	if err != nil {
+		result.failf("list releases: %v", err)
+		return result
+	}
+
+	found := false
+	for _, release := range releases {
+		if release.GetTagName() != v.opts.Version {
+			continue
+		}
+		found = true
+
+		if release.GetDraft() {
+			result.failf("release page %s is a draft", v.opts.Version)
+		}
+
+		listed := map[string]bool{}
+		for _, match := range ghPageAssetRegex.FindAllStringSubmatch(
+			release.GetBody(), -1,
+		) {
+			fileName := match[1]
+			if fileName == "" {
+				fileName = match[2]
+			}
+			fileName = strings.TrimSpace(fileName)
+			listed[fileName] = true
+			result.Checked++
+
+			if err := matchArtifact(hashes, fileName, match[3], match[4]); err != nil {
+				result.failf("page asset %s: %v", fileName, err)
+			}
+		}
+
+		for _, asset := range release.Assets {
+			if !listed[asset.GetName()] {
+				result.failf(
+					"uploaded asset %s is not listed on the page", asset.GetName(),
+				)
+			}
+		}
+	}
+
+	if !found {
+		result.failf("release page for %s not found", v.opts.Version)
+	}
+	return result
+}
+
+// matchArtifact checks that an artifact with the file name and hashes exists.
+func matchArtifact(
+	hashes map[string]*artifactHashes, fileName, sha256Sum, sha512Sum string,
+) error {
+	candidates := 0
+	for name, computed := range hashes {
+		if path.Base(name) != fileName {
+			continue
+		}
+		candidates++
+		if strings.EqualFold(computed.sha256, sha256Sum) &&
+			strings.EqualFold(computed.sha512, sha512Sum) {
+			return nil
+		}
+	}
+	if candidates == 0 {
+		return errors.New("not a verified artifact")
+	}
+	return errors.New("hashes do not match any verified artifact")
+}
+
+// compareDigests checks the SHA256 and SHA512 digests of a digest set. The
+// algorithm names are matched case insensitively. At least one of both
+// digests has to be present.
+func compareDigests(computed *artifactHashes, digests map[string]string) error {
+	checked := 0
+	for algorithm, digest := range digests {
+		var expected string
+		switch strings.ToLower(algorithm) {
+		case "sha256":
+			expected = computed.sha256
+		case "sha512":
+			expected = computed.sha512
+		default:
+			continue
+		}
+		checked++
+		if !strings.EqualFold(expected, digest) {
+			return errors.Errorf(
+				"%s digest %s does not match %s", algorithm, digest, expected,
+			)
+		}
+	}
+	if checked == 0 {
+		return errors.New("no SHA256 or SHA512 digest found")
+	}
+	return nil
+}
+
+// sortedKeys returns the sorted keys of the checksums map.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedHashKeys returns the sorted artifact names of the hashes map.
+func sortedHashKeys(m map[string]*artifactHashes) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedImageKeys returns the sorted image names of the images map.
+func sortedImageKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
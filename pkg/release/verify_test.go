@@ -0,0 +1,349 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gogithub "github.com/google/go-github/v39/github"
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/provenance"
+	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/spdx"
+	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-sdk/github/githubfakes"
+)
+
+const testVerifyVersion = testImagesVersion
+
+var testVerifyArtifacts = map[string]string{
+	"kubernetes.tar.gz":          "kubernetes",
+	"kubernetes-src.tar.gz":      "source",
+	"bin/linux/amd64/kubectl":    "kubectl amd64",
+	"bin/linux/arm64/kubectl":    "kubectl arm64",
+	"bin/windows/amd64/kube.exe": "kubectl windows",
+}
+
+// newReleaseMirror creates a local mirror of a release bucket layout as
+// produced by PushArtifacts and returns its path.
+func newReleaseMirror(t *testing.T) string {
+	buildDir := t.TempDir()
+	stageDir := filepath.Join(buildDir, release.GCSStagePath, testVerifyVersion)
+
+	for name, content := range testVerifyArtifacts {
+		writeMirrorFile(t, filepath.Join(stageDir, name), content)
+	}
+
+	writeMirrorChecksums(t, buildDir, stageDir)
+
+	statement := provenance.NewSLSAStatement()
+	sbom := spdx.NewDocument()
+	sbom.Name = "Kubernetes Release " + testVerifyVersion
+	for name := range testVerifyArtifacts {
+		sha256Sum, sha512Sum := mirrorFileHashes(t, filepath.Join(stageDir, name))
+		statement.Subject = append(statement.Subject, intoto.Subject{
+			Name: fmt.Sprintf(
+				"gs://bucket/release/%s/%s", testVerifyVersion, name,
+			),
+			Digest: map[string]string{"sha256": sha256Sum, "sha512": sha512Sum},
+		})
+
+		file := spdx.NewFile()
+		require.Nil(t, file.ReadSourceFile(filepath.Join(stageDir, name)))
+		file.Name = name
+		file.FileName = name
+		require.Nil(t, sbom.AddFile(file))
+	}
+
+	content, err := json.Marshal(statement)
+	require.Nil(t, err)
+	writeMirrorFile(t,
+		filepath.Join(stageDir, release.ProvenanceFilename), string(content),
+	)
+	require.Nil(t, sbom.Write(filepath.Join(stageDir, release.ReleaseSBOMFile)))
+
+	return stageDir
+}
+
+// writeMirrorChecksums runs WriteChecksums from within workDir, because it
+// creates the sums files in the current directory first.
+func writeMirrorChecksums(t *testing.T, workDir, stageDir string) {
+	require.Nil(t, os.Chdir(workDir))
+	defer func() { require.Nil(t, os.Chdir(os.TempDir())) }()
+	require.Nil(t, release.WriteChecksums(stageDir))
+}
+
+func writeMirrorFile(t *testing.T, path, content string) {
+	require.Nil(t, os.MkdirAll(filepath.Dir(path), os.FileMode(0o755)))
+	require.Nil(t, os.WriteFile(path, []byte(content), os.FileMode(0o644)))
+}
+
+func mirrorFileHashes(t *testing.T, path string) (sha256Sum, sha512Sum string) {
+	content, err := os.ReadFile(path)
+	require.Nil(t, err)
+	s256 := sha256.Sum256(content)
+	s512 := sha512.Sum512(content)
+	return hex.EncodeToString(s256[:]), hex.EncodeToString(s512[:])
+}
+
+func newTestVerifier(mirror string) *release.ReleaseVerifier {
+	return release.NewReleaseVerifier(&release.ReleaseVerifierOptions{
+		Version: testVerifyVersion,
+		Mirror:  mirror,
+	})
+}
+
+func failedChecks(report *release.VerificationReport) []string {
+	res := []string{}
+	for _, result := range report.Results {
+		if !result.Passed() {
+			res = append(res, result.Check)
+		}
+	}
+	return res
+}
+
+func TestVerify(t *testing.T) {
+	for _, tc := range []struct {
+		prepare  func(t *testing.T, mirror string)
+		expected []string
+	}{
+		{ // success
+			prepare:  func(*testing.T, string) {},
+			expected: []string{},
+		},
+		{ // success without provenance and SBOM
+			prepare: func(t *testing.T, mirror string) {
+				require.Nil(t, os.Remove(filepath.Join(mirror, release.ProvenanceFilename)))
+				require.Nil(t, os.Remove(filepath.Join(mirror, release.ReleaseSBOMFile)))
+			},
+			expected: []string{},
+		},
+		{ // failure modified artifact
+			prepare: func(t *testing.T, mirror string) {
+				writeMirrorFile(t, filepath.Join(mirror, "kubernetes.tar.gz"), "changed")
+			},
+			expected: []string{release.VerifyCheckChecksums, release.VerifyCheckProvenance, release.VerifyCheckSBOM},
+		},
+		{ // failure missing artifact
+			prepare: func(t *testing.T, mirror string) {
+				require.Nil(t, os.Remove(filepath.Join(mirror, "bin/linux/arm64/kubectl")))
+			},
+			expected: []string{release.VerifyCheckChecksums, release.VerifyCheckProvenance, release.VerifyCheckSBOM},
+		},
+		{ // failure missing checksums
+			prepare: func(t *testing.T, mirror string) {
+				require.Nil(t, os.Remove(filepath.Join(mirror, release.SHA512SumsFile)))
+			},
+			expected: []string{release.VerifyCheckChecksums, release.VerifyCheckProvenance, release.VerifyCheckSBOM},
+		},
+		{ // failure provenance subject digest mismatch
+			prepare: func(t *testing.T, mirror string) {
+				provenancePath := filepath.Join(mirror, release.ProvenanceFilename)
+				statement, err := provenance.LoadStatement(provenancePath)
+				require.Nil(t, err)
+				statement.Subject[0].Digest["sha256"] = strings.Repeat("0", 64)
+				content, err := json.Marshal(statement)
+				require.Nil(t, err)
+				writeMirrorFile(t, provenancePath, string(content))
+			},
+			expected: []string{release.VerifyCheckProvenance},
+		},
+	} {
+		mirror := newReleaseMirror(t)
+		tc.prepare(t, mirror)
+
+		report, err := newTestVerifier(mirror).Verify()
+		require.Nil(t, err)
+		require.Equal(t, tc.expected, failedChecks(report))
+		require.Equal(t, len(tc.expected) == 0, report.Passed())
+	}
+}
+
+func TestVerifyImages(t *testing.T) {
+	for _, tc := range []struct {
+		publish     bool
+		shouldError bool
+	}{
+		{publish: true, shouldError: false},
+		{publish: false, shouldError: true},
+	} {
+		registry := newTestRegistry(t)
+		buildPath := newImagesPath(t)
+		prepareImages(t, buildPath)
+		if tc.publish {
+			require.Nil(t, release.NewImages().Publish(
+				registry, testImagesVersion, buildPath,
+			))
+		}
+
+		// Add the image tarballs to the checksums of the mirror
+		mirror := newReleaseMirror(t)
+		require.Nil(t, os.Remove(filepath.Join(mirror, release.SHA256SumsFile)))
+		require.Nil(t, os.Remove(filepath.Join(mirror, release.SHA512SumsFile)))
+		tarballs, err := filepath.Glob(
+			filepath.Join(buildPath, release.ImagesPath, "*", "*.tar"),
+		)
+		require.Nil(t, err)
+		for _, tarball := range tarballs {
+			arch := filepath.Base(filepath.Dir(tarball))
+			dst := filepath.Join(mirror, "bin", "linux", arch, filepath.Base(tarball))
+			require.Nil(t, os.MkdirAll(filepath.Dir(dst), os.FileMode(0o755)))
+			require.Nil(t, os.Rename(tarball, dst))
+		}
+		writeMirrorChecksums(t, buildPath, mirror)
+
+		sut := release.NewReleaseVerifier(&release.ReleaseVerifierOptions{
+			Version:  testVerifyVersion,
+			Mirror:   mirror,
+			Registry: registry,
+		})
+		report, err := sut.Verify()
+		require.Nil(t, err)
+
+		failed := failedChecks(report)
+		if tc.shouldError {
+			require.Contains(t, failed, release.VerifyCheckImages)
+		} else {
+			require.NotContains(t, failed, release.VerifyCheckImages)
+		}
+	}
+}
+
+func ghPageAsset(t *testing.T, mirror, name string) string {
+	sha256Sum, sha512Sum := mirrorFileHashes(t, filepath.Join(mirror, name))
+	return fmt.Sprintf(
+		"<tr><td colspan=\"2\"><b>%s</b></td><tr>\n"+
+			"<tr><td>SHA256</td><td>%s</td></tr>\n"+
+			"<tr><td>SHA512</td><td>%s</td></tr>\n",
+		filepath.Base(name), sha256Sum, sha512Sum,
+	)
+}
+
+func TestVerifyGitHubPage(t *testing.T) {
+	mirror := newReleaseMirror(t)
+	body := ghPageAsset(t, mirror, "kubernetes.tar.gz") +
+		ghPageAsset(t, mirror, "kubernetes-src.tar.gz")
+
+	for _, tc := range []struct {
+		prepare     func(*githubfakes.FakeClient)
+		shouldError bool
+	}{
+		{ // success
+			prepare: func(mock *githubfakes.FakeClient) {
+				mock.ListReleasesReturns([]*gogithub.RepositoryRelease{
+					{TagName: gogithub.String("v1.18.8")},
+					{
+						TagName: gogithub.String(testVerifyVersion),
+						Body:    gogithub.String(body),
+						Assets: []*gogithub.ReleaseAsset{
+							{Name: gogithub.String("kubernetes.tar.gz")},
+						},
+					},
+				}, nil, nil)
+			},
+			shouldError: false,
+		},
+		{ // failure hash mismatch
+			prepare: func(mock *githubfakes.FakeClient) {
+				mock.ListReleasesReturns([]*gogithub.RepositoryRelease{
+					{
+						TagName: gogithub.String(testVerifyVersion),
+						Body: gogithub.String(strings.Replace(
+							body, "<td>SHA256</td><td>", "<td>SHA256</td><td>00", 1,
+						)),
+					},
+				}, nil, nil)
+			},
+			shouldError: true,
+		},
+		{ // failure asset not listed on the page
+			prepare: func(mock *githubfakes.FakeClient) {
+				mock.ListReleasesReturns([]*gogithub.RepositoryRelease{
+					{
+						TagName: gogithub.String(testVerifyVersion),
+						Body:    gogithub.String(body),
+						Assets: []*gogithub.ReleaseAsset{
+							{Name: gogithub.String("unknown.tar.gz")},
+						},
+					},
+				}, nil, nil)
+			},
+			shouldError: true,
+		},
+		{ // failure draft release
+			prepare: func(mock *githubfakes.FakeClient) {
+				mock.ListReleasesReturns([]*gogithub.RepositoryRelease{
+					{
+						TagName: gogithub.String(testVerifyVersion),
+						Body:    gogithub.String(body),
+						Draft:   gogithub.Bool(true),
+					},
+				}, nil, nil)
+			},
+			shouldError: true,
+		},
+		{ // failure release not found
+			prepare: func(mock *githubfakes.FakeClient) {
+				mock.ListReleasesReturns([]*gogithub.RepositoryRelease{
+					{TagName: gogithub.String("v1.18.8")},
+				}, nil, nil)
+			},
+			shouldError: true,
+		},
+		{ // failure listing releases
+			prepare: func(mock *githubfakes.FakeClient) {
+				mock.ListReleasesReturns(nil, nil, errors.New("Synthetic error"))
+			},
+			shouldError: true,
+		},
+	} {
+		mock := &githubfakes.FakeClient{}
+		tc.prepare(mock)
+		gh := github.New()
+		gh.SetClient(mock)
+
+		sut := release.NewReleaseVerifier(&release.ReleaseVerifierOptions{
+			Version:    testVerifyVersion,
+			Mirror:     mirror,
+			GitHubOrg:  "kubernetes",
+			GitHubRepo: "kubernetes",
+		})
+		sut.SetGitHub(gh)
+
+		report, err := sut.Verify()
+		require.Nil(t, err)
+
+		failed := failedChecks(report)
+		if tc.shouldError {
+			require.Equal(t, []string{release.VerifyCheckGitHub}, failed)
+		} else {
+			require.Empty(t, failed)
+		}
+	}
+}
@@ -0,0 +1,411 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/objectstore"
+	"sigs.k8s.io/release-utils/util"
+)
+
+// yankRecordFile is the file name of the yank record in the release archive
+const yankRecordFile = "yank.json"
+
+// YankActionType is the kind of change done when yanking a release
+type YankActionType string
+
+const (
+	// YankActionMarker reverts a version marker to the previous version
+	YankActionMarker YankActionType = "revert-marker"
+
+	// YankActionMarkGitHubRelease marks the GitHub release page as yanked
+	YankActionMarkGitHubRelease YankActionType = "mark-github-release"
+
+	// YankActionDeleteGitHubRelease deletes the GitHub release page
+	YankActionDeleteGitHubRelease YankActionType = "delete-github-release"
+
+	// YankActionDeleteTag deletes the release tag from the remote repository
+	YankActionDeleteTag YankActionType = "delete-tag"
+)
+
+// YankAction is a single planned change of a release yank
+type YankAction struct {
+	// Type is the kind of the action
+	Type YankActionType `json:"type"`
+
+	// Target is the modified object, like the marker name or the tag
+	Target string `json:"target"`
+
+	// Value is the new value of the target, if any
+	Value string `json:"value,omitempty"`
+}
+
+// String returns a human readable description of the action
+func (a YankAction) String() string {
+	switch a.Type {
+	case YankActionMarker:
+		return fmt.Sprintf("Revert version marker %s to %s", a.Target, a.Value)
+	case YankActionMarkGitHubRelease:
+		return fmt.Sprintf("Mark GitHub release page %s as yanked", a.Target)
+	case YankActionDeleteGitHubRelease:
+		return fmt.Sprintf("Delete GitHub release page %s", a.Target)
+	case YankActionDeleteTag:
+		return fmt.Sprintf("Delete remote git tag %s", a.Target)
+	}
+	return fmt.Sprintf("%s %s %s", a.Type, a.Target, a.Value)
+}
+
+// YankRecord is the record of a yank stored in the release archive
+type YankRecord struct {
+	Version         string       `json:"version"`
+	PreviousVersion string       `json:"previousVersion"`
+	Date            string       `json:"date"`
+	Actions         []YankAction `json:"actions"`
+	Error           string       `json:"error,omitempty"`
+}
+
+// YankOptions are the settings for yanking a release
+type YankOptions struct {
+	// Version is the release version to be yanked
+	Version string
+
+	// PreviousVersion is the last good version the markers get reverted to.
+	// It gets derived from Version if empty.
+	PreviousVersion string
+
+	// Bucket is the release bucket containing the version markers
+	Bucket string
+
+	// GCSRoot is the root directory of builds and markers in the bucket
+	GCSRoot string
+
+	// PrivateBucket does not make reverted version markers public
+	PrivateBucket bool
+
+	// GitHubOrg and GitHubRepo are the location of the release page
+	GitHubOrg  string
+	GitHubRepo string
+
+	// DeleteGitHubRelease deletes the release page instead of marking it
+	DeleteGitHubRelease bool
+
+	// DeleteTags removes the release tag from the remote of the repository
+	// at RepoPath
+	DeleteTags bool
+	RepoPath   string
+
+	// NoMock runs the yank against production
+	NoMock bool
+}
+
+// Validate checks that the yank options are complete
+func (o *YankOptions) Validate() error {
+	if _, err := util.TagStringToSemver(o.Version); err != nil {
+		return errors.Wrapf(err, "invalid version %s", o.Version)
+	}
+	if o.PreviousVersion != "" {
+		if _, err := util.TagStringToSemver(o.PreviousVersion); err != nil {
+			return errors.Wrapf(err, "invalid previous version %s", o.PreviousVersion)
+		}
+	}
+	if o.Bucket == "" || o.GCSRoot == "" {
+		return errors.New("bucket and GCS root must be specified")
+	}
+	if o.DeleteTags && o.RepoPath == "" {
+		return errors.New("repository path must be specified to delete tags")
+	}
+	return nil
+}
+
+// Yanker reverts a partially or fully published release
+type Yanker struct {
+	impl yankerImpl
+	opts *YankOptions
+}
+
+// NewYanker creates a new Yanker with the default implementation
+func NewYanker(opts *YankOptions) *Yanker {
+	store := objectstore.ForBucket(opts.Bucket)
+	return &Yanker{
+		impl: &defaultYankerImpl{
+			markers: NewVersionMarkers(store),
+			store:   store,
+		},
+		opts: opts,
+	}
+}
+
+// SetImpl changes the yanker implementation
+func (y *Yanker) SetImpl(impl yankerImpl) {
+	y.impl = impl
+}
+
+//counterfeiter:generate . yankerImpl
+type yankerImpl interface {
+	ListVersionMarkers(bucket, gcsRoot string) ([]VersionMarker, error)
+	BuildExists(bucket, gcsRoot, version string) (bool, error)
+	SetVersionMarker(bucket, gcsRoot, name, version string, privateBucket bool) error
+	YankGitHubRelease(owner, repo, tag string, remove bool) error
+	DeleteRemoteTag(repoPath, tag string, dryRun bool) error
+	WriteRecord(bucket, version string, content []byte) error
+}
+
+type defaultYankerImpl struct {
+	markers *VersionMarkers
+	store   objectstore.Store
+}
+
+func (d *defaultYankerImpl) ListVersionMarkers(
+	bucket, gcsRoot string,
+) ([]VersionMarker, error) {
+	return d.markers.List(bucket, gcsRoot)
+}
+
+func (d *defaultYankerImpl) BuildExists(
+	bucket, gcsRoot, version string,
+) (bool, error) {
+	releasePath, err := d.markers.publisher.client.GetReleasePath(
+		bucket, gcsRoot, version, false,
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "get release path")
+	}
+	return d.store.PathExists(releasePath)
+}
+
+func (d *defaultYankerImpl) SetVersionMarker(
+	bucket, gcsRoot, name, version string, privateBucket bool,
+) error {
+	return d.markers.Set(bucket, gcsRoot, name, version, true, privateBucket)
+}
+
+func (d *defaultYankerImpl) YankGitHubRelease(
+	owner, repo, tag string, remove bool,
+) error {
+	return announce.YankGitHubPage(owner, repo, tag, remove)
+}
+
+func (d *defaultYankerImpl) DeleteRemoteTag(
+	repoPath, tag string, dryRun bool,
+) error {
+	pusher, err := NewGitPusher(&GitObjectPusherOptions{
+		DryRun:     dryRun,
+		MaxRetries: 10,
+		RepoPath:   repoPath,
+	})
+	if err != nil {
+		return errors.Wrap(err, "create git pusher")
+	}
+	return pusher.DeleteRemoteTag(tag)
+}
+
+func (d *defaultYankerImpl) WriteRecord(
+	bucket, version string, content []byte,
+) error {
+	recordPath, err := d.store.NormalizePath(
+		bucket, ArchivePath, archiveDirPrefix+version, yankRecordFile,
+	)
+	if err != nil {
+		return errors.Wrap(err, "get yank record path")
+	}
+	logrus.Infof("Writing yank record to %s", recordPath)
+	return d.store.Write(
+		recordPath, content, &objectstore.Attributes{ContentType: "application/json"},
+	)
+}
+
+// Plan returns the list of actions required to yank the release. Nothing
+// gets modified.
+func (y *Yanker) Plan() ([]YankAction, error) {
+	if err := y.opts.Validate(); err != nil {
+		return nil, errors.Wrap(err, "validating yank options")
+	}
+
+	actions := []YankAction{}
+	markers, err := y.impl.ListVersionMarkers(y.opts.Bucket, y.opts.GCSRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "list version markers")
+	}
+
+	yankedMarkers := []VersionMarker{}
+	for _, marker := range markers {
+		if marker.Version == y.opts.Version {
+			yankedMarkers = append(yankedMarkers, marker)
+		}
+	}
+
+	if len(yankedMarkers) > 0 {
+		if y.opts.PreviousVersion == "" {
+			previous, err := previousVersion(y.opts.Version)
+			if err != nil {
+				return nil, errors.Wrap(err, "determine previous version")
+			}
+			y.opts.PreviousVersion = previous
+		}
+
+		exists, err := y.impl.BuildExists(
+			y.opts.Bucket, y.opts.GCSRoot, y.opts.PreviousVersion,
+		)
+		if err != nil {
+			return nil, errors.Wrapf(
+				err, "check if build %s exists", y.opts.PreviousVersion,
+			)
+		}
+		if !exists {
+			return nil, errors.Errorf(
+				"previous version %s has no build to revert the markers to",
+				y.opts.PreviousVersion,
+			)
+		}
+
+		for _, marker := range yankedMarkers {
+			actions = append(actions, YankAction{
+				Type:   YankActionMarker,
+				Target: marker.Name,
+				Value:  y.opts.PreviousVersion,
+			})
+		}
+	}
+
+	if y.opts.GitHubOrg != "" && y.opts.GitHubRepo != "" {
+		action := YankActionMarkGitHubRelease
+		if y.opts.DeleteGitHubRelease {
+			action = YankActionDeleteGitHubRelease
+		}
+		actions = append(actions, YankAction{
+			Type:   action,
+			Target: y.opts.Version,
+		})
+	}
+
+	if y.opts.DeleteTags {
+		actions = append(actions, YankAction{
+			Type:   YankActionDeleteTag,
+			Target: y.opts.Version,
+		})
+	}
+
+	return actions, nil
+}
+
+// Run executes the planned actions. Without NoMock only the remote git
+// operations get simulated, all other actions are skipped. The executed
+// actions get recorded in the release archive.
+func (y *Yanker) Run(actions []YankAction) error {
+	if !y.opts.NoMock {
+		for _, action := range actions {
+			if action.Type == YankActionDeleteTag {
+				if err := y.impl.DeleteRemoteTag(
+					y.opts.RepoPath, action.Target, true,
+				); err != nil {
+					return errors.Wrapf(err, "simulating: %s", action)
+				}
+				continue
+			}
+			logrus.Infof("Mock mode, skipping: %s", action)
+		}
+		return nil
+	}
+
+	record := &YankRecord{
+		Version:         y.opts.Version,
+		PreviousVersion: y.opts.PreviousVersion,
+		Date:            time.Now().UTC().Format(time.RFC3339),
+		Actions:         []YankAction{},
+	}
+
+	var runErr error
+	for _, action := range actions {
+		logrus.Info(action)
+		if err := y.runAction(action); err != nil {
+			runErr = errors.Wrapf(err, "running: %s", action)
+			record.Error = runErr.Error()
+			break
+		}
+		record.Actions = append(record.Actions, action)
+	}
+
+	content, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal yank record")
+	}
+	if err := y.impl.WriteRecord(y.opts.Bucket, y.opts.Version, content); err != nil {
+		if runErr != nil {
+			logrus.Errorf("Unable to write yank record: %v", err)
+			return runErr
+		}
+		return errors.Wrap(err, "write yank record")
+	}
+	return runErr
+}
+
+func (y *Yanker) runAction(action YankAction) error {
+	switch action.Type {
+	case YankActionMarker:
+		return y.impl.SetVersionMarker(
+			y.opts.Bucket, y.opts.GCSRoot,
+			action.Target, action.Value, y.opts.PrivateBucket,
+		)
+	case YankActionMarkGitHubRelease, YankActionDeleteGitHubRelease:
+		return y.impl.YankGitHubRelease(
+			y.opts.GitHubOrg, y.opts.GitHubRepo, action.Target,
+			action.Type == YankActionDeleteGitHubRelease,
+		)
+	case YankActionDeleteTag:
+		return y.impl.DeleteRemoteTag(y.opts.RepoPath, action.Target, false)
+	}
+	return errors.Errorf("unknown yank action type %s", action.Type)
+}
+
+// previousVersion returns the version released before the provided one by
+// decrementing its patch or last numeric pre-release component. Versions
+// which cannot be decremented (like `v1.22.0`) return an error.
+func previousVersion(version string) (string, error) {
+	sv, err := util.TagStringToSemver(version)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid version %s", version)
+	}
+	sv.Build = nil
+
+	if len(sv.Pre) == 0 {
+		if sv.Patch == 0 {
+			return "", errors.Errorf(
+				"cannot derive the version before %s, "+
+					"the previous version has to be specified", version,
+			)
+		}
+		sv.Patch--
+		return util.SemverToTagString(sv), nil
+	}
+
+	last := &sv.Pre[len(sv.Pre)-1]
+	if !last.IsNum || last.VersionNum == 0 {
+		return "", errors.Errorf(
+			"cannot derive the version before %s, "+
+				"the previous version has to be specified", version,
+		)
+	}
+	last.VersionNum--
+	return util.SemverToTagString(sv), nil
+}
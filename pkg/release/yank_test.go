@@ -0,0 +1,241 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/release/releasefakes"
+)
+
+func newYankOptions(version string) *release.YankOptions {
+	return &release.YankOptions{
+		Version:    version,
+		Bucket:     "bucket",
+		GCSRoot:    "release",
+		GitHubOrg:  "kubernetes",
+		GitHubRepo: "kubernetes",
+		DeleteTags: true,
+		RepoPath:   "/repo",
+	}
+}
+
+func TestYankPlan(t *testing.T) {
+	err := errors.New("Synthetic error")
+	markers := []release.VersionMarker{
+		{Name: "stable.txt", Version: "v1.22.3"},
+		{Name: "stable-1.22.txt", Version: "v1.22.3"},
+		{Name: "stable-1.21.txt", Version: "v1.21.6"},
+		{Name: "latest-1.23.txt", Version: "v1.23.0-rc.1"},
+	}
+
+	for _, tc := range []struct {
+		version   string
+		previous  string
+		prepare   func(*releasefakes.FakeYankerImpl)
+		expected  []release.YankAction
+		shouldErr bool
+	}{
+		{ // Derived previous patch version
+			version: "v1.22.3",
+			prepare: func(mock *releasefakes.FakeYankerImpl) {},
+			expected: []release.YankAction{
+				{Type: release.YankActionMarker, Target: "stable.txt", Value: "v1.22.2"},
+				{Type: release.YankActionMarker, Target: "stable-1.22.txt", Value: "v1.22.2"},
+				{Type: release.YankActionMarkGitHubRelease, Target: "v1.22.3"},
+				{Type: release.YankActionDeleteTag, Target: "v1.22.3"},
+			},
+		},
+		{ // Derived previous pre-release version
+			version: "v1.23.0-rc.1",
+			prepare: func(mock *releasefakes.FakeYankerImpl) {},
+			expected: []release.YankAction{
+				{Type: release.YankActionMarker, Target: "latest-1.23.txt", Value: "v1.23.0-rc.0"},
+				{Type: release.YankActionMarkGitHubRelease, Target: "v1.23.0-rc.1"},
+				{Type: release.YankActionDeleteTag, Target: "v1.23.0-rc.1"},
+			},
+		},
+		{ // Explicit previous version
+			version:  "v1.22.3",
+			previous: "v1.22.1",
+			prepare:  func(mock *releasefakes.FakeYankerImpl) {},
+			expected: []release.YankAction{
+				{Type: release.YankActionMarker, Target: "stable.txt", Value: "v1.22.1"},
+				{Type: release.YankActionMarker, Target: "stable-1.22.txt", Value: "v1.22.1"},
+				{Type: release.YankActionMarkGitHubRelease, Target: "v1.22.3"},
+				{Type: release.YankActionDeleteTag, Target: "v1.22.3"},
+			},
+		},
+		{ // No markers point to the version
+			version: "v1.20.0",
+			prepare: func(mock *releasefakes.FakeYankerImpl) {},
+			expected: []release.YankAction{
+				{Type: release.YankActionMarkGitHubRelease, Target: "v1.20.0"},
+				{Type: release.YankActionDeleteTag, Target: "v1.20.0"},
+			},
+		},
+		{ // Previous build does not exist
+			version: "v1.22.3",
+			prepare: func(mock *releasefakes.FakeYankerImpl) {
+				mock.BuildExistsReturns(false, nil)
+			},
+			shouldErr: true,
+		},
+		{ // Listing markers fails
+			version: "v1.22.3",
+			prepare: func(mock *releasefakes.FakeYankerImpl) {
+				mock.ListVersionMarkersReturns(nil, err)
+			},
+			shouldErr: true,
+		},
+		{ // Invalid version
+			version:   "invalid",
+			prepare:   func(mock *releasefakes.FakeYankerImpl) {},
+			shouldErr: true,
+		},
+	} {
+		mock := &releasefakes.FakeYankerImpl{}
+		mock.ListVersionMarkersReturns(markers, nil)
+		mock.BuildExistsReturns(true, nil)
+		tc.prepare(mock)
+
+		opts := newYankOptions(tc.version)
+		opts.PreviousVersion = tc.previous
+		sut := release.NewYanker(opts)
+		sut.SetImpl(mock)
+
+		actions, err := sut.Plan()
+		if tc.shouldErr {
+			require.NotNil(t, err)
+			continue
+		}
+		require.Nil(t, err)
+		require.Equal(t, tc.expected, actions)
+	}
+}
+
+func TestYankPlanUnderivablePreviousVersion(t *testing.T) {
+	mock := &releasefakes.FakeYankerImpl{}
+	mock.ListVersionMarkersReturns([]release.VersionMarker{
+		{Name: "stable.txt", Version: "v1.22.0"},
+	}, nil)
+	mock.BuildExistsReturns(true, nil)
+
+	sut := release.NewYanker(newYankOptions("v1.22.0"))
+	sut.SetImpl(mock)
+	_, err := sut.Plan()
+	require.NotNil(t, err)
+}
+
+func TestYankRun(t *testing.T) {
+	err := errors.New("Synthetic error")
+	actions := []release.YankAction{
+		{Type: release.YankActionMarker, Target: "stable.txt", Value: "v1.22.2"},
+		{Type: release.YankActionDeleteGitHubRelease, Target: "v1.22.3"},
+		{Type: release.YankActionDeleteTag, Target: "v1.22.3"},
+	}
+
+	for _, tc := range []struct {
+		noMock    bool
+		prepare   func(*releasefakes.FakeYankerImpl)
+		assert    func(*releasefakes.FakeYankerImpl)
+		shouldErr bool
+	}{
+		{ // Mock mode only simulates the tag deletion
+			prepare: func(mock *releasefakes.FakeYankerImpl) {},
+			assert: func(mock *releasefakes.FakeYankerImpl) {
+				require.Zero(t, mock.SetVersionMarkerCallCount())
+				require.Zero(t, mock.YankGitHubReleaseCallCount())
+				require.Zero(t, mock.WriteRecordCallCount())
+				require.Equal(t, 1, mock.DeleteRemoteTagCallCount())
+				_, _, dryRun := mock.DeleteRemoteTagArgsForCall(0)
+				require.True(t, dryRun)
+			},
+		},
+		{ // Success in nomock mode
+			noMock:  true,
+			prepare: func(mock *releasefakes.FakeYankerImpl) {},
+			assert: func(mock *releasefakes.FakeYankerImpl) {
+				require.Equal(t, 1, mock.SetVersionMarkerCallCount())
+				_, _, name, version, _ := mock.SetVersionMarkerArgsForCall(0)
+				require.Equal(t, "stable.txt", name)
+				require.Equal(t, "v1.22.2", version)
+
+				require.Equal(t, 1, mock.YankGitHubReleaseCallCount())
+				_, _, tag, remove := mock.YankGitHubReleaseArgsForCall(0)
+				require.Equal(t, "v1.22.3", tag)
+				require.True(t, remove)
+
+				require.Equal(t, 1, mock.DeleteRemoteTagCallCount())
+				_, _, dryRun := mock.DeleteRemoteTagArgsForCall(0)
+				require.False(t, dryRun)
+
+				require.Equal(t, 1, mock.WriteRecordCallCount())
+				_, version, content := mock.WriteRecordArgsForCall(0)
+				require.Equal(t, "v1.22.3", version)
+				record := &release.YankRecord{}
+				require.Nil(t, json.Unmarshal(content, record))
+				require.Equal(t, actions, record.Actions)
+				require.Empty(t, record.Error)
+			},
+		},
+		{ // Failing action stops the yank and gets recorded
+			noMock: true,
+			prepare: func(mock *releasefakes.FakeYankerImpl) {
+				mock.YankGitHubReleaseReturns(err)
+			},
+			assert: func(mock *releasefakes.FakeYankerImpl) {
+				require.Zero(t, mock.DeleteRemoteTagCallCount())
+				require.Equal(t, 1, mock.WriteRecordCallCount())
+				_, _, content := mock.WriteRecordArgsForCall(0)
+				record := &release.YankRecord{}
+				require.Nil(t, json.Unmarshal(content, record))
+				require.Equal(t, actions[:1], record.Actions)
+				require.NotEmpty(t, record.Error)
+			},
+			shouldErr: true,
+		},
+		{ // Writing the record fails
+			noMock: true,
+			prepare: func(mock *releasefakes.FakeYankerImpl) {
+				mock.WriteRecordReturns(err)
+			},
+			assert:    func(mock *releasefakes.FakeYankerImpl) {},
+			shouldErr: true,
+		},
+	} {
+		mock := &releasefakes.FakeYankerImpl{}
+		tc.prepare(mock)
+
+		opts := newYankOptions("v1.22.3")
+		opts.NoMock = tc.noMock
+		sut := release.NewYanker(opts)
+		sut.SetImpl(mock)
+
+		err := sut.Run(actions)
+		if tc.shouldErr {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+		tc.assert(mock)
+	}
+}
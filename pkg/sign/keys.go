@@ -0,0 +1,177 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sign
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// PasswordEnvKey is the environment variable containing the password of
+	// encrypted cosign private keys.
+	PasswordEnvKey = "COSIGN_PASSWORD"
+
+	// SigningKeyEnvKey is the environment variable containing the path of
+	// the private key for signing release images.
+	SigningKeyEnvKey = "IMAGE_SIGNING_KEY"
+
+	// VerificationKeyEnvKey is the environment variable containing the path
+	// of the public key for verifying release image signatures.
+	VerificationKeyEnvKey = "IMAGE_VERIFICATION_KEY"
+
+	pemTypeEncryptedCosign = "ENCRYPTED COSIGN PRIVATE KEY"
+	pemTypePrivateKey      = "PRIVATE KEY"
+	pemTypeECPrivateKey    = "EC PRIVATE KEY"
+	pemTypePublicKey       = "PUBLIC KEY"
+)
+
+// LoadPrivateKey reads an ECDSA private key from the PEM encoded file at
+// path. Supported are PKCS8 and SEC1 keys, as well as encrypted keys created
+// by `cosign generate-key-pair`, which get decrypted using password.
+func LoadPrivateKey(path string, password []byte) (*ecdsa.PrivateKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	der := block.Bytes
+	switch block.Type {
+	case pemTypeEncryptedCosign:
+		der, err = decrypt(block.Bytes, password)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decrypt private key %s", path)
+		}
+	case pemTypeECPrivateKey:
+		key, err := x509.ParseECPrivateKey(der)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse private key %s", path)
+		}
+		return key, nil
+	case pemTypePrivateKey:
+	default:
+		return nil, errors.Errorf(
+			"unsupported PEM type %q in %s", block.Type, path,
+		)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse private key %s", path)
+	}
+	ecdsaKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.Errorf("private key %s is not an ECDSA key", path)
+	}
+	return ecdsaKey, nil
+}
+
+// LoadPublicKey reads an ECDSA public key from the PEM encoded file at path,
+// like the `cosign.pub` files created by `cosign generate-key-pair`.
+func LoadPublicKey(path string) (*ecdsa.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type != pemTypePublicKey {
+		return nil, errors.Errorf(
+			"unsupported PEM type %q in %s", block.Type, path,
+		)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse public key %s", path)
+	}
+	ecdsaKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.Errorf("public key %s is not an ECDSA key", path)
+	}
+	return ecdsaKey, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read key file %s", path)
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errors.Errorf("no PEM data found in %s", path)
+	}
+	return block, nil
+}
+
+// encryptedKey is the JSON structure of encrypted cosign private keys
+type encryptedKey struct {
+	KDF struct {
+		Name   string `json:"name"`
+		Params struct {
+			N int `json:"N"`
+			R int `json:"r"`
+			P int `json:"p"`
+		} `json:"params"`
+		Salt []byte `json:"salt"`
+	} `json:"kdf"`
+	Cipher struct {
+		Name  string `json:"name"`
+		Nonce []byte `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func decrypt(content, password []byte) ([]byte, error) {
+	data := encryptedKey{}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, errors.Wrap(err, "unmarshal encrypted key")
+	}
+	if data.KDF.Name != "scrypt" {
+		return nil, errors.Errorf("unsupported KDF %q", data.KDF.Name)
+	}
+	if data.Cipher.Name != "nacl/secretbox" {
+		return nil, errors.Errorf("unsupported cipher %q", data.Cipher.Name)
+	}
+
+	const keyLen = 32
+	derived, err := scrypt.Key(
+		password, data.KDF.Salt,
+		data.KDF.Params.N, data.KDF.Params.R, data.KDF.Params.P, keyLen,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "derive key")
+	}
+
+	var (
+		key   [keyLen]byte
+		nonce [24]byte
+	)
+	copy(key[:], derived)
+	copy(nonce[:], data.Cipher.Nonce)
+
+	plain, ok := secretbox.Open(nil, data.Ciphertext, &nonce, &key)
+	if !ok {
+		return nil, errors.New("invalid password")
+	}
+	return plain, nil
+}
@@ -0,0 +1,278 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sign
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SignatureTagSuffix is the suffix of the tag which references the
+	// signatures of an image digest.
+	SignatureTagSuffix = ".sig"
+
+	// SignatureAnnotation is the layer annotation containing the base64
+	// encoded signature of the layer payload.
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	// SimpleSigningMediaType is the media type of the signature payload layers.
+	SimpleSigningMediaType types.MediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+
+	// SignatureType is the critical type of the simple signing payload.
+	SignatureType = "cosign container image signature"
+)
+
+// Signer signs container images with an ECDSA private key
+type Signer struct {
+	key *ecdsa.PrivateKey
+}
+
+// NewSigner creates a new Signer for the provided private key
+func NewSigner(key *ecdsa.PrivateKey) *Signer {
+	return &Signer{key: key}
+}
+
+// NewSignerFromFile creates a new Signer by loading the private key from
+// path. Encrypted cosign keys are decrypted using password.
+func NewSignerFromFile(path string, password []byte) (*Signer, error) {
+	key, err := LoadPrivateKey(path, password)
+	if err != nil {
+		return nil, err
+	}
+	return NewSigner(key), nil
+}
+
+// Verifier returns a Verifier for the public key of the signer.
+func (s *Signer) Verifier() *Verifier {
+	return NewVerifier(&s.key.PublicKey)
+}
+
+// Sign returns the ASN.1 encoded ECDSA signature of the SHA256 hashed payload.
+func (s *Signer) Sign(payload []byte) ([]byte, error) {
+	digest := sha256.Sum256(payload)
+	signature, err := ecdsa.SignASN1(rand.Reader, s.key, digest[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "sign payload")
+	}
+	return signature, nil
+}
+
+// SignImage creates the simple signing payload for the image digest in
+// repository, signs it and appends it as new layer to the signature image
+// base. A nil base creates a new signature image.
+func (s *Signer) SignImage(
+	base v1.Image, repository string, digest v1.Hash,
+) (v1.Image, error) {
+	payload, err := Payload(repository, digest)
+	if err != nil {
+		return nil, errors.Wrap(err, "create payload")
+	}
+
+	signature, err := s.Sign(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if base == nil {
+		base = mutate.ConfigMediaType(
+			mutate.MediaType(empty.Image, types.OCIManifestSchema1),
+			types.OCIConfigJSON,
+		)
+	}
+
+	img, err := mutate.Append(base, mutate.Addendum{
+		Layer: static.NewLayer(payload, SimpleSigningMediaType),
+		Annotations: map[string]string{
+			SignatureAnnotation: base64.StdEncoding.EncodeToString(signature),
+		},
+		MediaType: SimpleSigningMediaType,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "append signature layer")
+	}
+	return img, nil
+}
+
+// Verifier verifies container image signatures with an ECDSA public key
+type Verifier struct {
+	key *ecdsa.PublicKey
+}
+
+// NewVerifier creates a new Verifier for the provided public key
+func NewVerifier(key *ecdsa.PublicKey) *Verifier {
+	return &Verifier{key: key}
+}
+
+// NewVerifierFromFile creates a new Verifier by loading the public key from
+// path.
+func NewVerifierFromFile(path string) (*Verifier, error) {
+	key, err := LoadPublicKey(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewVerifier(key), nil
+}
+
+// NewVerifierFromKeys creates a new Verifier by loading the public key from
+// verificationKey or, if empty, by deriving it from the private key at
+// signingKey. It returns nil if both paths are empty.
+func NewVerifierFromKeys(signingKey, verificationKey string, password []byte) (*Verifier, error) {
+	if verificationKey != "" {
+		return NewVerifierFromFile(verificationKey)
+	}
+	if signingKey == "" {
+		return nil, nil
+	}
+	signer, err := NewSignerFromFile(signingKey, password)
+	if err != nil {
+		return nil, err
+	}
+	return signer.Verifier(), nil
+}
+
+// Verify checks that signature is a valid signature of payload.
+func (v *Verifier) Verify(payload, signature []byte) error {
+	digest := sha256.Sum256(payload)
+	if !ecdsa.VerifyASN1(v.key, digest[:], signature) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// VerifyImage checks that the signature image contains at least one valid
+// signature for the image digest in repository.
+func (v *Verifier) VerifyImage(
+	signatures v1.Image, repository string, digest v1.Hash,
+) error {
+	manifest, err := signatures.Manifest()
+	if err != nil {
+		return errors.Wrap(err, "get signature manifest")
+	}
+
+	for _, desc := range manifest.Layers {
+		encoded, ok := desc.Annotations[SignatureAnnotation]
+		if !ok {
+			continue
+		}
+		signature, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+
+		layer, err := signatures.LayerByDigest(desc.Digest)
+		if err != nil {
+			return errors.Wrapf(err, "get signature layer %s", desc.Digest)
+		}
+		payload, err := readLayer(layer)
+		if err != nil {
+			return errors.Wrapf(err, "read signature layer %s", desc.Digest)
+		}
+
+		if err := v.Verify(payload, signature); err != nil {
+			continue
+		}
+		if err := checkPayload(payload, repository, digest); err != nil {
+			continue
+		}
+		return nil
+	}
+
+	return errors.Errorf(
+		"no valid signature found for %s@%s", repository, digest,
+	)
+}
+
+// SignatureTag returns the tag in repository which references the signatures
+// of the image digest, like `<repository>:sha256-<hex>.sig`.
+func SignatureTag(repository string, digest v1.Hash) (string, error) {
+	repo, err := name.NewRepository(repository)
+	if err != nil {
+		return "", errors.Wrapf(err, "parse repository %s", repository)
+	}
+	return fmt.Sprintf(
+		"%s:%s-%s%s", repo.Name(), digest.Algorithm, digest.Hex, SignatureTagSuffix,
+	), nil
+}
+
+type payload struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+	Optional map[string]interface{} `json:"optional"`
+}
+
+// Payload returns the simple signing payload for the image digest in
+// repository.
+func Payload(repository string, digest v1.Hash) ([]byte, error) {
+	p := payload{}
+	p.Critical.Identity.DockerReference = repository
+	p.Critical.Image.DockerManifestDigest = digest.String()
+	p.Critical.Type = SignatureType
+	return json.Marshal(&p)
+}
+
+func checkPayload(content []byte, repository string, digest v1.Hash) error {
+	p := payload{}
+	if err := json.Unmarshal(content, &p); err != nil {
+		return errors.Wrap(err, "unmarshal payload")
+	}
+	if p.Critical.Type != SignatureType {
+		return errors.Errorf("unknown payload type %q", p.Critical.Type)
+	}
+	if p.Critical.Image.DockerManifestDigest != digest.String() {
+		return errors.Errorf(
+			"payload digest %s does not match %s",
+			p.Critical.Image.DockerManifestDigest, digest,
+		)
+	}
+	if p.Critical.Identity.DockerReference != repository {
+		return errors.Errorf(
+			"payload reference %s does not match %s",
+			p.Critical.Identity.DockerReference, repository,
+		)
+	}
+	return nil
+}
+
+func readLayer(layer v1.Layer) ([]byte, error) {
+	reader, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sign_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+
+	"k8s.io/release/pkg/sign"
+)
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	return key
+}
+
+func writePEM(t *testing.T, pemType string, content []byte) string {
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.Nil(t, os.WriteFile(
+		path,
+		pem.EncodeToMemory(&pem.Block{Type: pemType, Bytes: content}),
+		os.FileMode(0o600),
+	))
+	return path
+}
+
+func TestSignImage(t *testing.T) {
+	const repository = "gcr.io/k8s-staging/kube-proxy"
+
+	img, err := random.Image(1024, 1)
+	require.Nil(t, err)
+	digest, err := img.Digest()
+	require.Nil(t, err)
+
+	signer := sign.NewSigner(newKey(t))
+	signatures, err := signer.SignImage(nil, repository, digest)
+	require.Nil(t, err)
+
+	manifest, err := signatures.Manifest()
+	require.Nil(t, err)
+	require.Len(t, manifest.Layers, 1)
+	require.Equal(t, sign.SimpleSigningMediaType, manifest.Layers[0].MediaType)
+	require.NotEmpty(t, manifest.Layers[0].Annotations[sign.SignatureAnnotation])
+
+	// Valid signature
+	require.Nil(t, signer.Verifier().VerifyImage(signatures, repository, digest))
+
+	// Wrong repository
+	require.NotNil(t, signer.Verifier().VerifyImage(
+		signatures, "gcr.io/k8s-staging/kube-apiserver", digest,
+	))
+
+	// Wrong digest
+	otherDigest, err := v1.NewHash(
+		"sha256:0000000000000000000000000000000000000000000000000000000000000000",
+	)
+	require.Nil(t, err)
+	require.NotNil(t, signer.Verifier().VerifyImage(
+		signatures, repository, otherDigest,
+	))
+
+	// Wrong key
+	otherSigner := sign.NewSigner(newKey(t))
+	require.NotNil(t, otherSigner.Verifier().VerifyImage(
+		signatures, repository, digest,
+	))
+
+	// Appending keeps existing signatures
+	signatures, err = otherSigner.SignImage(signatures, repository, digest)
+	require.Nil(t, err)
+	manifest, err = signatures.Manifest()
+	require.Nil(t, err)
+	require.Len(t, manifest.Layers, 2)
+	require.Nil(t, signer.Verifier().VerifyImage(signatures, repository, digest))
+	require.Nil(t, otherSigner.Verifier().VerifyImage(signatures, repository, digest))
+}
+
+func TestSignatureTag(t *testing.T) {
+	digest, err := v1.NewHash(
+		"sha256:0123456789012345678901234567890123456789012345678901234567890123",
+	)
+	require.Nil(t, err)
+
+	tag, err := sign.SignatureTag("gcr.io/k8s-staging/kube-proxy", digest)
+	require.Nil(t, err)
+	require.Equal(t,
+		"gcr.io/k8s-staging/kube-proxy:sha256-0123456789012345678901234567890123456789012345678901234567890123.sig",
+		tag,
+	)
+
+	_, err = sign.SignatureTag("INVALID", digest)
+	require.NotNil(t, err)
+}
+
+func TestLoadPrivateKey(t *testing.T) {
+	key := newKey(t)
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.Nil(t, err)
+	sec1, err := x509.MarshalECPrivateKey(key)
+	require.Nil(t, err)
+
+	password := []byte("password")
+	salt := []byte("0123456789abcdef0123456789abcdef")
+	derived, err := scrypt.Key(password, salt, 16, 8, 1, 32)
+	require.Nil(t, err)
+	var (
+		secret [32]byte
+		nonce  [24]byte
+	)
+	copy(secret[:], derived)
+	copy(nonce[:], "0123456789abcdef01234567")
+	encrypted, err := json.Marshal(map[string]interface{}{
+		"kdf": map[string]interface{}{
+			"name":   "scrypt",
+			"params": map[string]int{"N": 16, "r": 8, "p": 1},
+			"salt":   salt,
+		},
+		"cipher": map[string]interface{}{
+			"name":  "nacl/secretbox",
+			"nonce": nonce[:],
+		},
+		"ciphertext": secretbox.Seal(nil, pkcs8, &nonce, &secret),
+	})
+	require.Nil(t, err)
+
+	for _, tc := range []struct {
+		pemType     string
+		content     []byte
+		password    []byte
+		shouldError bool
+	}{
+		{pemType: "PRIVATE KEY", content: pkcs8},
+		{pemType: "EC PRIVATE KEY", content: sec1},
+		{pemType: "ENCRYPTED COSIGN PRIVATE KEY", content: encrypted, password: password},
+		{
+			pemType:     "ENCRYPTED COSIGN PRIVATE KEY",
+			content:     encrypted,
+			password:    []byte("wrong"),
+			shouldError: true,
+		},
+		{pemType: "PRIVATE KEY", content: []byte("invalid"), shouldError: true},
+		{pemType: "CERTIFICATE", content: pkcs8, shouldError: true},
+	} {
+		res, err := sign.LoadPrivateKey(
+			writePEM(t, tc.pemType, tc.content), tc.password,
+		)
+		if tc.shouldError {
+			require.NotNil(t, err)
+			continue
+		}
+		require.Nil(t, err)
+		require.True(t, key.Equal(res))
+	}
+
+	_, err = sign.LoadPrivateKey(filepath.Join(t.TempDir(), "missing"), nil)
+	require.NotNil(t, err)
+}
+
+func TestLoadPublicKey(t *testing.T) {
+	// The key used for testing go-runner image signatures
+	_, err := sign.LoadPublicKey(filepath.Join("..", "..", "cosign-test.pub"))
+	require.Nil(t, err)
+
+	key := newKey(t)
+	pkix, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.Nil(t, err)
+
+	verifier, err := sign.NewVerifierFromFile(writePEM(t, "PUBLIC KEY", pkix))
+	require.Nil(t, err)
+
+	payload := []byte("payload")
+	signature, err := sign.NewSigner(key).Sign(payload)
+	require.Nil(t, err)
+	require.Nil(t, verifier.Verify(payload, signature))
+	require.NotNil(t, verifier.Verify([]byte("other"), signature))
+
+	_, err = sign.LoadPublicKey(writePEM(t, "PRIVATE KEY", pkix))
+	require.NotNil(t, err)
+}
+
+func TestNewVerifierFromKeys(t *testing.T) {
+	key := newKey(t)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.Nil(t, err)
+	privateKey := writePEM(t, "PRIVATE KEY", der)
+	pkix, err := x509.MarshalPKIXPublicKey(&newKey(t).PublicKey)
+	require.Nil(t, err)
+	publicKey := writePEM(t, "PUBLIC KEY", pkix)
+
+	payload := []byte("payload")
+	signature, err := sign.NewSigner(key).Sign(payload)
+	require.Nil(t, err)
+
+	// No keys
+	verifier, err := sign.NewVerifierFromKeys("", "", nil)
+	require.Nil(t, err)
+	require.Nil(t, verifier)
+
+	// Derived from the signing key
+	verifier, err = sign.NewVerifierFromKeys(privateKey, "", nil)
+	require.Nil(t, err)
+	require.Nil(t, verifier.Verify(payload, signature))
+
+	// The verification key takes precedence
+	verifier, err = sign.NewVerifierFromKeys(privateKey, publicKey, nil)
+	require.Nil(t, err)
+	require.NotNil(t, verifier.Verify(payload, signature))
+
+	_, err = sign.NewVerifierFromKeys(publicKey, "", nil)
+	require.NotNil(t, err)
+}